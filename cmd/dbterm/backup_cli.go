@@ -20,6 +20,7 @@ import (
 	"github.com/shreyam1008/dbterm/internal/config"
 	"github.com/shreyam1008/dbterm/internal/osservice"
 	"github.com/shreyam1008/dbterm/internal/processinfo"
+	"github.com/shreyam1008/dbterm/internal/secrets"
 )
 
 func runBackupCommand(args []string) error {
@@ -27,7 +28,16 @@ func runBackupCommand(args []string) error {
 		printBackupHelp()
 		return nil
 	}
-	switch strings.ToLower(strings.TrimSpace(args[0])) {
+	command := strings.ToLower(strings.TrimSpace(args[0]))
+	switch command {
+	case "create", "run", "prune", "run-due", "restore", "notify-test":
+		// These read saved credentials; the agent unlocks after applying its
+		// directory overrides.
+		if err := unlockVaultForCommand(os.Stderr); err != nil {
+			return err
+		}
+	}
+	switch command {
 	case "list", "jobs":
 		return backupListCommand(args[1:])
 	case "create":
@@ -240,6 +250,12 @@ func backupAgentCommand(args []string) error {
 		return err
 	}
 	defer closeLog()
+	if unlocked, err := secrets.UnlockNonInteractive(); err != nil {
+		emit("backup agent startup failed: unlock secrets vault: " + err.Error())
+		return err
+	} else if !unlocked && secrets.Locked() {
+		emit(fmt.Sprintf("secrets vault is locked; jobs that need saved passwords will fail until %s or %s is set for the agent", secrets.EnvKeyFile, secrets.EnvPassphraseFile))
+	}
 	store, err := backupcore.OpenDefaultStore()
 	if err != nil {
 		emit("backup agent startup failed: " + err.Error())
@@ -571,8 +587,8 @@ func restoreTargetSummary(target *config.ConnectionConfig) string {
 	if name == "" {
 		name = "saved connection"
 	}
-	if target.Type == config.SQLite {
-		return fmt.Sprintf("%q (SQLite %q)", name, filepath.Clean(target.FilePath))
+	if target.Type == config.SQLite || target.Type == config.DuckDB {
+		return fmt.Sprintf("%q (%s %q)", name, target.TypeLabel(), filepath.Clean(target.FilePath))
 	}
 	return fmt.Sprintf("%q (%s %s@%s:%s/%s)", name, target.TypeLabel(), target.User, target.Host, target.Port, target.Database)
 }
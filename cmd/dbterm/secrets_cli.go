@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	backupcore "github.com/shreyam1008/dbterm/internal/backup"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/secrets"
+	"golang.org/x/term"
+)
+
+const migrateSecretsUsage = "usage: dbterm connections migrate-secrets [--key-file PATH]"
+
+// unlockVaultForCommand applies the non-interactive unlock sources before a
+// CLI command touches saved profiles. A vault that stays locked is reported
+// once; commands that only read non-secret fields still work.
+func unlockVaultForCommand(output io.Writer) error {
+	unlocked, err := secrets.UnlockNonInteractive()
+	if err != nil {
+		return fmt.Errorf("unlock secrets vault: %w", err)
+	}
+	if !unlocked && secrets.Locked() {
+		fmt.Fprintf(output, "  Secrets vault is locked; saved passwords are unavailable. Set %s or %s to unlock it.\n", secrets.EnvKeyFile, secrets.EnvPassphraseFile)
+	}
+	return nil
+}
+
+func connectionsMigrateSecretsCommand(args []string) error {
+	fs := flag.NewFlagSet("connections migrate-secrets", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "age identity file to seal the vault with instead of a passphrase")
+	if err := fs.Parse(args); err != nil {
+		return ignoreFlagHelp(err)
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf(migrateSecretsUsage)
+	}
+
+	if err := openOrCreateVault(strings.TrimSpace(*keyFile)); err != nil {
+		return err
+	}
+
+	store, err := config.LoadStore()
+	if err != nil {
+		return fmt.Errorf("load saved connections: %w", err)
+	}
+	moved := 0
+	for _, connection := range store.Connections {
+		if connection.Password != "" || connection.AuthToken != "" || connection.SSHPassword != "" {
+			moved++
+		}
+	}
+	// The second save rotates the last plaintext generation out of the
+	// .bak.previous recovery mirrors.
+	for pass := 0; pass < 2; pass++ {
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("seal saved connections: %w", err)
+		}
+	}
+
+	backupStore, err := backupcore.OpenDefaultStore()
+	if err != nil {
+		return fmt.Errorf("open backup catalog: %w", err)
+	}
+	defer backupStore.Close()
+	sealedJobs, err := backupStore.SealSecrets(context.Background())
+	if err != nil {
+		return fmt.Errorf("seal backup notification passwords: %w", err)
+	}
+
+	vaultPath, _ := secrets.DefaultPath()
+	fmt.Printf("\n  Secrets vault: %s\n", vaultPath)
+	fmt.Printf("  Connections sealed: %d of %d\n", moved, len(store.Connections))
+	fmt.Printf("  Backup jobs with SMTP passwords sealed: %d\n\n", sealedJobs)
+	fmt.Println("  Older copies made before migration (for example *.corrupt-* files or")
+	fmt.Println("  external backups of the config directory) may still hold plaintext.")
+	fmt.Println()
+	return nil
+}
+
+func openOrCreateVault(keyFile string) error {
+	if secrets.Enabled() {
+		if unlocked, err := secrets.UnlockNonInteractive(); err != nil {
+			return fmt.Errorf("unlock secrets vault: %w", err)
+		} else if unlocked {
+			return nil
+		}
+		key := secrets.Key{IdentityFile: keyFile}
+		if keyFile == "" {
+			passphrase, err := promptPassphrase("Vault passphrase: ")
+			if err != nil {
+				return err
+			}
+			key.Passphrase = passphrase
+		}
+		if _, err := secrets.Unlock(key); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	key := secrets.Key{IdentityFile: keyFile}
+	if keyFile == "" {
+		passphrase, err := promptPassphrase("New vault passphrase: ")
+		if err != nil {
+			return err
+		}
+		if len(passphrase) < 8 {
+			return fmt.Errorf("vault passphrase must be at least 8 characters")
+		}
+		confirm, err := promptPassphrase("Repeat passphrase: ")
+		if err != nil {
+			return err
+		}
+		if confirm != passphrase {
+			return fmt.Errorf("passphrases do not match")
+		}
+		key.Passphrase = passphrase
+	}
+	if _, err := secrets.Initialize(key); err != nil {
+		return fmt.Errorf("create secrets vault: %w", err)
+	}
+	return nil
+}
+
+func promptPassphrase(label string) (string, error) {
+	descriptor := int(os.Stdin.Fd())
+	if !term.IsTerminal(descriptor) {
+		return "", fmt.Errorf("a terminal is required to enter the vault passphrase; use --key-file or set %s", secrets.EnvPassphraseFile)
+	}
+	fmt.Fprint(os.Stderr, "  "+label)
+	value, err := term.ReadPassword(descriptor)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return string(value), nil
+}
@@ -126,14 +126,16 @@ func printInfo() {
 	fmt.Println("  SQLite        modernc.org/sqlite")
 	fmt.Println("  Turso         libsql-client-go")
 	fmt.Println("  Cloudflare D1 dbterm ordered-raw adapter (cfd1 API client)")
+	fmt.Println("  DuckDB        dbterm adapter over the duckdb command-line client")
 	fmt.Println()
-	fmt.Println("  \033[33mCLIENT TOOLS\033[0m  PostgreSQL/MySQL backup/restore; SQLite SQL restore")
+	fmt.Println("  \033[33mCLIENT TOOLS\033[0m  PostgreSQL/MySQL backup/restore; SQLite SQL restore; DuckDB")
 	fmt.Printf("  psql          %s\n", cliToolStatus("psql"))
 	fmt.Printf("  pg_restore    %s\n", cliToolStatus("pg_restore"))
 	fmt.Printf("  mysql         %s\n", cliToolStatus("mysql"))
 	fmt.Printf("  pg_dump       %s\n", cliToolStatus("pg_dump"))
 	fmt.Printf("  mysqldump     %s\n", cliToolStatus("mysqldump"))
 	fmt.Printf("  sqlite3       %s\n", cliToolStatus("sqlite3"))
+	fmt.Printf("  duckdb        %s\n", cliToolStatus("duckdb"))
 	fmt.Println()
 	fmt.Println("  \033[33mINSTALL\033[0m       No Go required")
 	fmt.Println("  macOS/Linux   curl -fsSL https://raw.githubusercontent.com/shreyam1008/dbterm/main/install.sh | bash")
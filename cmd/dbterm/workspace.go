@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// parseWorkspaceArgs accepts --workspace PATH or --workspace=PATH, where PATH
+// is a workspace file or a directory containing .dbterm.json.
+func parseWorkspaceArgs(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("--workspace requires a path")
+	}
+	path, inline := strings.CutPrefix(args[0], "--workspace=")
+	rest := args[1:]
+	if !inline {
+		if len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
+			return "", fmt.Errorf("--workspace requires a path")
+		}
+		path, rest = rest[0], rest[1:]
+	}
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("--workspace requires a path")
+	}
+	if len(rest) > 0 {
+		return "", fmt.Errorf("unexpected argument %q after --workspace", rest[0])
+	}
+	return path, nil
+}
+
+// loadStartupWorkspace loads an explicit workspace, or the nearest one above
+// the working directory. Only an explicit path is trusted. A discovered
+// workspace that fails to load is reported and ignored so a broken project
+// file never blocks the client.
+func loadStartupWorkspace(path string) (*config.Workspace, error) {
+	if path != "" {
+		return config.LoadWorkspace(path, true)
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	found, err := config.FindWorkspace(dir)
+	if err != nil || found == "" {
+		return nil, nil
+	}
+	workspace, err := config.LoadWorkspace(found, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  \033[33m⚠ Ignoring workspace:\033[0m %s\n", err)
+		return nil, nil
+	}
+	return workspace, nil
+}
+
+func printWorkspaceBanner(workspace *config.Workspace) {
+	if workspace == nil {
+		return
+	}
+	fmt.Printf("  \033[38;2;108;112;134mWorkspace: %s (%d connection(s), %d shared queries)\033[0m\n",
+		workspace.Path, len(workspace.Connections), len(workspace.Queries))
+	for _, warning := range workspace.Warnings {
+		fmt.Printf("  \033[33m⚠ Workspace %s\033[0m\n", warning)
+	}
+}
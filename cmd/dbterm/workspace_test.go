@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestParseWorkspaceArgs(t *testing.T) {
+	for _, test := range []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{args: []string{"--workspace", "../api"}, want: "../api"},
+		{args: []string{"--workspace=./.dbterm.json"}, want: "./.dbterm.json"},
+		{args: []string{"--workspace"}, wantErr: true},
+		{args: []string{"--workspace="}, wantErr: true},
+		{args: []string{"--workspace", "--help"}, wantErr: true},
+		{args: []string{"--workspace", "a", "b"}, wantErr: true},
+	} {
+		got, err := parseWorkspaceArgs(test.args)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("parseWorkspaceArgs(%q) = %q, %v", test.args, got, err)
+		}
+	}
+}
@@ -9,6 +9,23 @@ import (
 	"github.com/shreyam1008/dbterm/internal/config"
 )
 
+// libpqTarget returns a copy of cfg addressed the way native PostgreSQL tools
+// should dial it, along with the TLS and session environment they need. The
+// copy's Host is the name libpq verifies, so it also keys the pgpass entry.
+func libpqTarget(cfg *config.ConnectionConfig) (*config.ConnectionConfig, []string, error) {
+	host, tlsEnv, err := cfg.TLSEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionEnv, err := cfg.LibpqSessionEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	target := *cfg
+	target.Host = host
+	return &target, append(tlsEnv, sessionEnv...), nil
+}
+
 func writePGPassFile(dir string, cfg *config.ConnectionConfig) (string, func(), error) {
 	if cfg == nil || cfg.Password == "" {
 		return "", func() {}, nil
@@ -0,0 +1,232 @@
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
+)
+
+// DuckDB backups are an EXPORT DATABASE directory packed into a tar archive.
+// The export is written to a relative directory name so load.sql refers to
+// its data files relatively and IMPORT DATABASE works wherever it is unpacked.
+const (
+	duckDBExportDir     = "export"
+	duckDBSchemaEntry   = "schema.sql"
+	duckDBLoadEntry     = "load.sql"
+	duckDBMaxTarEntries = 10000
+)
+
+func runDuckDBExport(ctx context.Context, cfg *config.ConnectionConfig, outputPath string) error {
+	sourcePath := strings.TrimSpace(cfg.FilePath)
+	if sourcePath == "" {
+		return fmt.Errorf("DuckDB backup requires a database file")
+	}
+	if duckdbsql.IsDataFile(sourcePath) {
+		return fmt.Errorf("DuckDB backup needs a database file; %s is a data file that can be copied directly", filepath.Base(sourcePath))
+	}
+	sourcePath, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return fmt.Errorf("resolve DuckDB source path: %w", err)
+	}
+	if _, err := os.Stat(sourcePath); err != nil {
+		return fmt.Errorf("open DuckDB source: %w", err)
+	}
+	tool, err := requireClientTool("duckdb")
+	if err != nil {
+		return fmt.Errorf("DuckDB backup requires the duckdb command-line client: %w", err)
+	}
+	workDir, err := os.MkdirTemp(filepath.Dir(outputPath), "duckdb-export-")
+	if err != nil {
+		return fmt.Errorf("create DuckDB export directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	cmd := exec.CommandContext(ctx, tool, "-bail", "-init", os.DevNull, "-readonly", sourcePath,
+		"-c", fmt.Sprintf("EXPORT DATABASE '%s' (FORMAT parquet)", duckDBExportDir))
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return externalCommandError(ctx, "duckdb", output, err)
+	}
+	return writeDuckDBExportTar(filepath.Join(workDir, duckDBExportDir), outputPath)
+}
+
+// writeDuckDBExportTar packs an export directory with schema.sql first so
+// inspection can recognize the archive from its first entry.
+func writeDuckDBExportTar(exportDir, outputPath string) (err error) {
+	entries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return fmt.Errorf("read DuckDB export: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("DuckDB export contains unexpected entry %s", entry.Name())
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return duckDBEntryRank(names[i]) < duckDBEntryRank(names[j]) ||
+			(duckDBEntryRank(names[i]) == duckDBEntryRank(names[j]) && names[i] < names[j])
+	})
+	if len(names) < 2 || names[0] != duckDBSchemaEntry || names[1] != duckDBLoadEntry {
+		return fmt.Errorf("DuckDB export is missing %s or %s", duckDBSchemaEntry, duckDBLoadEntry)
+	}
+
+	output, err := os.OpenFile(outputPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create private DuckDB archive: %w", err)
+	}
+	defer func() {
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(outputPath)
+		}
+	}()
+	writer := tar.NewWriter(output)
+	for _, name := range names {
+		if err := appendTarFile(writer, filepath.Join(exportDir, name), name); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("finish DuckDB archive: %w", err)
+	}
+	return nil
+}
+
+func duckDBEntryRank(name string) int {
+	switch name {
+	case duckDBSchemaEntry:
+		return 0
+	case duckDBLoadEntry:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func appendTarFile(writer *tar.Writer, path, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open DuckDB export file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{Name: name, Mode: 0o600, Size: info.Size(), ModTime: info.ModTime(), Typeflag: tar.TypeReg}
+	if err := writer.WriteHeader(header); err != nil {
+		return fmt.Errorf("write DuckDB archive header: %w", err)
+	}
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("write DuckDB archive: %w", err)
+	}
+	return nil
+}
+
+// detectDuckDBExport recognizes a tar whose first two entries are the
+// schema.sql and load.sql written by EXPORT DATABASE.
+func detectDuckDBExport(ctx context.Context, source *payloadSource) (bool, error) {
+	if _, err := source.file.Seek(0, io.SeekStart); err != nil {
+		return false, fmt.Errorf("rewind possible DuckDB export archive: %w", err)
+	}
+	reader := tar.NewReader(&contextReader{ctx: ctx, reader: source.file})
+	for _, want := range []string{duckDBSchemaEntry, duckDBLoadEntry} {
+		header, err := reader.Next()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return false, ctxErr
+			}
+			return false, nil
+		}
+		if header.Name != want || header.Typeflag != tar.TypeReg {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func executeDuckDBImportRestore(ctx context.Context, plan *RestorePlan, payloadPath string, emit func(string)) error {
+	tool, err := requireClientTool("duckdb")
+	if err != nil {
+		return fmt.Errorf("DuckDB restore requires the duckdb command-line client: %w", err)
+	}
+	workDir, err := os.MkdirTemp(filepath.Dir(payloadPath), "duckdb-import-")
+	if err != nil {
+		return fmt.Errorf("create DuckDB import directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+	emitRestore(emit, "Unpacking DuckDB export")
+	if err := extractDuckDBExport(ctx, payloadPath, filepath.Join(workDir, duckDBExportDir)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(plan.Target.FilePath), 0o700); err != nil {
+		return fmt.Errorf("create DuckDB restore target directory: %w", err)
+	}
+
+	emitRestore(emit, "Running IMPORT DATABASE with duckdb")
+	cmd := exec.CommandContext(ctx, tool, "-bail", "-init", os.DevNull, plan.Target.FilePath,
+		"-c", fmt.Sprintf("IMPORT DATABASE '%s'", duckDBExportDir))
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return externalCommandError(ctx, "duckdb", output, err)
+	}
+	return nil
+}
+
+// extractDuckDBExport unpacks only flat regular files, so a crafted archive
+// cannot write outside the import directory.
+func extractDuckDBExport(ctx context.Context, archivePath, destination string) error {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("open DuckDB export archive: %w", err)
+	}
+	defer archive.Close()
+	if err := os.Mkdir(destination, 0o700); err != nil {
+		return fmt.Errorf("create DuckDB import directory: %w", err)
+	}
+	reader := tar.NewReader(&contextReader{ctx: ctx, reader: archive})
+	for count := 0; ; count++ {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read DuckDB export archive: %w", err)
+		}
+		if count >= duckDBMaxTarEntries {
+			return fmt.Errorf("DuckDB export archive has more than %d entries", duckDBMaxTarEntries)
+		}
+		name := header.Name
+		if header.Typeflag != tar.TypeReg || name == "" || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			return fmt.Errorf("DuckDB export archive contains unsafe entry %q", name)
+		}
+		file, err := os.OpenFile(filepath.Join(destination, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("unpack DuckDB export: %w", err)
+		}
+		_, copyErr := io.Copy(file, reader)
+		closeErr := file.Close()
+		if copyErr != nil {
+			return fmt.Errorf("unpack DuckDB export: %w", copyErr)
+		}
+		if closeErr != nil {
+			return fmt.Errorf("unpack DuckDB export: %w", closeErr)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestDuckDBExportArchiveIsInspectedAndUnpacked(t *testing.T) {
+	dir := t.TempDir()
+	exportDir := filepath.Join(dir, duckDBExportDir)
+	if err := os.Mkdir(exportDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"load.sql":       "COPY events FROM 'export/events.parquet' (FORMAT 'parquet');\n",
+		"events.parquet": "PAR1",
+		"schema.sql":     "CREATE TABLE events(id INTEGER);\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(exportDir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archive := filepath.Join(dir, "warehouse.tar")
+	if err := writeDuckDBExportTar(exportDir, archive); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ConnectionConfig{Type: config.DuckDB}
+	if err := verifyNativeBackup(cfg, archive); err != nil {
+		t.Fatalf("verifyNativeBackup: %v", err)
+	}
+
+	inspection, err := Inspect(context.Background(), archive, InspectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inspection.Format != FormatDuckDBExport || inspection.Engine != config.DuckDB || len(inspection.Warnings) != 0 {
+		t.Fatalf("inspection = %s/%s warnings=%v", inspection.Format, inspection.Engine, inspection.Warnings)
+	}
+
+	unpacked := filepath.Join(dir, "unpacked")
+	if err := extractDuckDBExport(context.Background(), archive, unpacked); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(unpacked, name))
+		if err != nil || string(got) != content {
+			t.Fatalf("unpacked %s = %q, %v", name, got, err)
+		}
+	}
+}
+
+func TestExtractDuckDBExportRejectsNestedEntries(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "crafted.tar")
+	file, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := tar.NewWriter(file)
+	_ = writer.WriteHeader(&tar.Header{Name: "../escape.sql", Mode: 0o600, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = writer.Write([]byte("x"))
+	_ = writer.Close()
+	_ = file.Close()
+
+	err = extractDuckDBExport(context.Background(), archive, filepath.Join(dir, "out"))
+	if err == nil || !strings.Contains(err.Error(), "unsafe entry") {
+		t.Fatalf("extractDuckDBExport error = %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "escape.sql")); !os.IsNotExist(statErr) {
+		t.Fatal("crafted entry was written outside the import directory")
+	}
+}
+
+func TestDuckDBRestorePlanRequiresMergeIntoDatabaseFile(t *testing.T) {
+	inspection := &Inspection{Path: "/tmp/warehouse.tar", Size: 10, SHA256: strings.Repeat("ab", 32), Format: FormatDuckDBExport, Engine: config.DuckDB, Confidence: ConfidenceExact}
+	target := &config.ConnectionConfig{Name: "copy", Type: config.DuckDB, FilePath: filepath.Join(t.TempDir(), "copy.duckdb")}
+	if _, err := BuildRestorePlan(inspection, target, RestoreOptions{Mode: RestoreModeClean, StopOnError: true}); err == nil {
+		t.Fatal("clean DuckDB restore was accepted")
+	}
+	if _, err := BuildRestorePlan(inspection, target, RestoreOptions{Mode: RestoreModeMerge, StopOnError: true}); err != nil {
+		t.Fatalf("merge DuckDB restore: %v", err)
+	}
+	dataTarget := *target
+	dataTarget.FilePath = "events.parquet"
+	if _, err := BuildRestorePlan(inspection, &dataTarget, RestoreOptions{Mode: RestoreModeMerge}); err == nil {
+		t.Fatal("restore into a data file was accepted")
+	}
+}
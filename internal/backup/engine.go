@@ -61,6 +61,8 @@ func PlanFor(cfg *config.ConnectionConfig) (NativePlan, error) {
 		return NativePlan{Format: "sqlite_sql", FormatLabel: "SQLite-compatible SQL dump", ToolLabel: "dbterm logical exporter", Extension: ".sql"}, nil
 	case config.CloudflareD1:
 		return NativePlan{Format: "sqlite_sql", FormatLabel: "Cloudflare D1 native SQL export", ToolLabel: "Cloudflare D1 export API", Extension: ".sql"}, nil
+	case config.DuckDB:
+		return NativePlan{Format: string(FormatDuckDBExport), FormatLabel: "DuckDB EXPORT DATABASE archive", ToolLabel: "duckdb", Extension: ".tar"}, nil
 	default:
 		return NativePlan{}, fmt.Errorf("backup is not supported for %s", cfg.TypeLabel())
 	}
@@ -82,6 +84,11 @@ func CreateNativeBackup(ctx context.Context, cfg *config.ConnectionConfig, outpu
 	if err != nil {
 		return err
 	}
+	// Native tools receive the resolved credentials, and the deferred
+	// redaction above scrubs them because it reads cfg at return time.
+	if cfg, err = cfg.ResolveCredentials(); err != nil {
+		return fmt.Errorf("resolve %s credentials: %w", plan.ToolLabel, err)
+	}
 	outputPath = strings.TrimSpace(outputPath)
 	if outputPath == "" {
 		return fmt.Errorf("backup output path is required")
@@ -157,6 +164,16 @@ func createNativeAtPath(ctx context.Context, cfg *config.ConnectionConfig, outpu
 	}
 	stopProgress := monitorNativeProgress(ctx, outputPath, options.Progress)
 	defer func() { stopProgress(err == nil) }()
+	if cfg.UsesSSHTunnel() {
+		// pg_dump and mysqldump dial Host:Port themselves, so they get a
+		// profile copy pointing at the local end of the forward.
+		tunneled, closeTunnel, tunnelErr := database.OpenTunnel(cfg)
+		if tunnelErr != nil {
+			return tunnelErr
+		}
+		defer closeTunnel()
+		cfg = tunneled
+	}
 	switch cfg.Type {
 	case config.PostgreSQL:
 		return runPostgresDump(ctx, cfg, outputPath, options.PostgresCompression)
@@ -168,6 +185,8 @@ func createNativeAtPath(ctx context.Context, cfg *config.ConnectionConfig, outpu
 		return runSQLiteCompatibleDump(ctx, cfg, outputPath)
 	case config.CloudflareD1:
 		return runCloudflareD1Export(ctx, cfg, outputPath, options)
+	case config.DuckDB:
+		return runDuckDBExport(ctx, cfg, outputPath)
 	default:
 		return fmt.Errorf("backup is not supported for %s", cfg.TypeLabel())
 	}
@@ -219,6 +238,10 @@ func runPostgresDump(ctx context.Context, cfg *config.ConnectionConfig, outputPa
 	if compression < 0 || compression > 9 {
 		compression = 6
 	}
+	cfg, tlsEnv, err := libpqTarget(cfg)
+	if err != nil {
+		return err
+	}
 	passwordFile, cleanup, err := writePGPassFile(filepath.Dir(outputPath), cfg)
 	if err != nil {
 		return err
@@ -238,14 +261,12 @@ func runPostgresDump(ctx context.Context, cfg *config.ConnectionConfig, outputPa
 		cfg.Database,
 	}
 	cmd := exec.CommandContext(ctx, tool, args...)
-	cmd.Env = environmentWithout(os.Environ(), "PGPASSWORD", "PGPASSFILE", "PGSSLMODE")
+	cmd.Env = environmentWithout(os.Environ(), append([]string{"PGPASSWORD", "PGPASSFILE"}, config.LibpqTLSEnvironment...)...)
 	cmd.Env = append(cmd.Env, "LC_ALL=C")
 	if passwordFile != "" {
 		cmd.Env = append(cmd.Env, "PGPASSFILE="+passwordFile)
 	}
-	if strings.TrimSpace(cfg.SSLMode) != "" {
-		cmd.Env = append(cmd.Env, "PGSSLMODE="+strings.TrimSpace(cfg.SSLMode))
-	}
+	cmd.Env = append(cmd.Env, tlsEnv...)
 	return runNativeCommandToFile(ctx, "pg_dump", cmd, outputPath)
 }
 
@@ -274,8 +295,13 @@ func runMySQLDump(ctx context.Context, cfg *config.ConnectionConfig, outputPath
 		fmt.Sprintf("--host=%s", nonEmpty(cfg.Host, "localhost")),
 		fmt.Sprintf("--port=%s", defaultPort(cfg)),
 		fmt.Sprintf("--user=%s", cfg.User),
-		"--", cfg.Database,
 	)
+	tlsArgs, err := cfg.MySQLClientTLSArgs()
+	if err != nil {
+		return err
+	}
+	args = append(args, tlsArgs...)
+	args = append(args, "--", cfg.Database)
 	cmd := exec.CommandContext(ctx, tool, args...)
 	cmd.Env = environmentWithout(os.Environ(), "MYSQL_PWD")
 	cmd.Env = append(cmd.Env, "LC_ALL=C")
@@ -38,6 +38,10 @@ func verifyNativeBackup(cfg *config.ConnectionConfig, path string) error {
 		if !bytes.HasPrefix(prefix, []byte("SQLite format 3\x00")) {
 			return fmt.Errorf("SQLite backup validation failed: snapshot header is invalid")
 		}
+	case config.DuckDB:
+		if !bytes.HasPrefix(prefix, []byte(duckDBSchemaEntry+"\x00")) {
+			return fmt.Errorf("DuckDB backup validation failed: archive does not start with %s", duckDBSchemaEntry)
+		}
 	case config.MySQL, config.Turso, config.CloudflareD1:
 		if len(bytes.TrimSpace(prefix)) == 0 {
 			return fmt.Errorf("logical backup validation failed: output contains no SQL")
@@ -282,7 +286,7 @@ func redactConnectionError(err error, cfg *config.ConnectionConfig) error {
 		return err
 	}
 	message := err.Error()
-	for _, secret := range []string{cfg.Password, cfg.AuthToken} {
+	for _, secret := range []string{cfg.Password, cfg.AuthToken, cfg.SSHPassword} {
 		if secret != "" {
 			message = strings.ReplaceAll(message, secret, "[redacted]")
 		}
@@ -44,6 +44,7 @@ const (
 	FormatMySQLSQL       Format = "mysql_sql"
 	FormatSQLiteDatabase Format = "sqlite_database"
 	FormatSQLiteSQL      Format = "sqlite_sql"
+	FormatDuckDBExport   Format = "duckdb_export"
 	FormatGenericSQL     Format = "generic_sql"
 	FormatUnknown        Format = "unknown"
 )
@@ -512,6 +513,13 @@ func detectPayload(ctx context.Context, source *payloadSource) (Format, config.D
 			[]string{"SQLite format 3 header"}, nil, nil
 	}
 
+	if isDuckDB, err := detectDuckDBExport(ctx, source); err != nil {
+		return FormatUnknown, "", ConfidenceUnknown, nil, nil, err
+	} else if isDuckDB {
+		return FormatDuckDBExport, config.DuckDB, ConfidenceExact,
+			[]string{"tar archive starts with DuckDB schema.sql and load.sql"}, nil, nil
+	}
+
 	isPGTar, tarWarning, err := detectPostgresTar(ctx, source, prefix)
 	if err != nil {
 		return FormatUnknown, "", ConfidenceUnknown, nil, nil, err
@@ -708,6 +716,8 @@ func requiredToolsFor(format Format) []string {
 		return []string{"mysql"}
 	case FormatSQLiteSQL:
 		return []string{"sqlite3"}
+	case FormatDuckDBExport:
+		return []string{"duckdb"}
 	default:
 		return nil
 	}
@@ -757,7 +767,7 @@ func extensionMatchesFormat(ext string, format Format) bool {
 	switch format {
 	case FormatPostgresCustom:
 		return ext == ".dump" || ext == ".backup" || ext == ".pgdump"
-	case FormatPostgresTar:
+	case FormatPostgresTar, FormatDuckDBExport:
 		return ext == ".tar"
 	case FormatPostgresSQL, FormatMySQLSQL, FormatSQLiteSQL, FormatGenericSQL:
 		return ext == ".sql"
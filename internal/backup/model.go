@@ -49,9 +49,10 @@ const (
 )
 
 // EmailNotification is stored in the private backup catalog as part of the
-// job JSON. Password is necessarily plaintext so the unattended OS service
-// can authenticate; the SQLite catalog and its parent state directory are
-// protected with 0600/0700 permissions and must be treated as secrets.
+// job JSON. Without a secrets vault Password is plaintext so the unattended
+// OS service can authenticate, and the 0600 catalog must be treated as a
+// secret. With a vault, Password is sealed there and the agent unlocks it
+// non-interactively (see secrets.UnlockNonInteractive).
 type EmailNotification struct {
 	Policy     NotificationPolicy `json:"policy"`
 	SMTPHost   string             `json:"smtp_host,omitempty"`
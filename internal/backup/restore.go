@@ -14,6 +14,8 @@ import (
 	"unicode"
 
 	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
 )
 
 type RestoreMode string
@@ -131,6 +133,10 @@ func BuildRestorePlan(inspection *Inspection, target *config.ConnectionConfig, o
 		}
 	}
 
+	if inspection.Format == FormatDuckDBExport && options.Mode == RestoreModeClean {
+		return nil, fmt.Errorf("DuckDB restore imports into the target database and cannot clean it first; use merge mode with a new or empty target file")
+	}
+
 	inspectionCopy := cloneInspection(inspection)
 	plan := &RestorePlan{
 		Inspection: inspectionCopy,
@@ -148,9 +154,13 @@ func ExecuteRestore(ctx context.Context, plan *RestorePlan, emit func(string)) e
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	validated, err := BuildRestorePlan(plan.Inspection, &plan.Target, plan.Options)
+	target, err := plan.Target.ResolveCredentials()
 	if err != nil {
-		return err
+		return fmt.Errorf("resolve restore target credentials: %w", err)
+	}
+	validated, err := BuildRestorePlan(plan.Inspection, target, plan.Options)
+	if err != nil {
+		return redactRestoreError(err, target)
 	}
 	emitRestore(emit, "Verifying the backup checksum and content")
 	payload, err := materializeRestorePayload(ctx, validated.Inspection, validated.Options)
@@ -159,6 +169,16 @@ func ExecuteRestore(ctx context.Context, plan *RestorePlan, emit func(string)) e
 	}
 	defer payload.cleanup()
 
+	if validated.Target.UsesSSHTunnel() {
+		emitRestore(emit, "Opening SSH tunnel to "+validated.Target.SSHHost)
+		tunneled, closeTunnel, tunnelErr := database.OpenTunnel(&validated.Target)
+		if tunnelErr != nil {
+			return redactRestoreError(tunnelErr, &validated.Target)
+		}
+		defer closeTunnel()
+		validated.Target = *tunneled
+	}
+
 	emitRestore(emit, "Backup verified; starting restore")
 	switch validated.Inspection.Format {
 	case FormatPostgresCustom, FormatPostgresTar:
@@ -171,6 +191,8 @@ func ExecuteRestore(ctx context.Context, plan *RestorePlan, emit func(string)) e
 		err = executeSQLiteDatabaseRestore(ctx, validated, payload, emit)
 	case FormatSQLiteSQL:
 		err = executeSQLiteSQLRestore(ctx, validated, payload, emit)
+	case FormatDuckDBExport:
+		err = executeDuckDBImportRestore(ctx, validated, payload.path, emit)
 	default:
 		err = fmt.Errorf("restore is not implemented for format %s", validated.Inspection.Format)
 	}
@@ -192,6 +214,8 @@ func engineForRestoreFormat(format Format) (config.DBType, error) {
 		return config.MySQL, nil
 	case FormatSQLiteDatabase, FormatSQLiteSQL:
 		return config.SQLite, nil
+	case FormatDuckDBExport:
+		return config.DuckDB, nil
 	case FormatGenericSQL:
 		return "", fmt.Errorf("generic SQL must be classified before restore")
 	case FormatUnknown:
@@ -245,6 +269,9 @@ func normalizeAndValidateRestoreTarget(target *config.ConnectionConfig) error {
 				return err
 			}
 		}
+		if err := target.ValidateTLS(); err != nil {
+			return fmt.Errorf("restore target %w", err)
+		}
 	case config.SQLite:
 		if strings.TrimSpace(target.FilePath) == "" {
 			return fmt.Errorf("SQLite restore target file path is required")
@@ -257,6 +284,18 @@ func normalizeAndValidateRestoreTarget(target *config.ConnectionConfig) error {
 			return fmt.Errorf("resolve SQLite restore target %q: %w", target.FilePath, err)
 		}
 		target.FilePath = absolute
+	case config.DuckDB:
+		if strings.TrimSpace(target.FilePath) == "" || duckdbsql.IsDataFile(target.FilePath) {
+			return fmt.Errorf("DuckDB restore target must be a database file path")
+		}
+		if strings.IndexByte(target.FilePath, 0) >= 0 {
+			return fmt.Errorf("DuckDB restore target file path contains a NUL byte")
+		}
+		absolute, err := filepath.Abs(filepath.Clean(target.FilePath))
+		if err != nil {
+			return fmt.Errorf("resolve DuckDB restore target %q: %w", target.FilePath, err)
+		}
+		target.FilePath = absolute
 	default:
 		return fmt.Errorf("restore target type %q is not supported", target.Type)
 	}
@@ -304,6 +343,9 @@ func restorePlanWarnings(format Format, options RestoreOptions) []string {
 	var warnings []string
 	if options.Mode == RestoreModeMerge {
 		warnings = append(warnings, "Merge mode will not pre-emptively remove existing target objects; name or data conflicts can still stop the restore.")
+		if format == FormatDuckDBExport {
+			warnings = append(warnings, "DuckDB IMPORT DATABASE creates every exported object; restore into a new or empty database file.")
+		}
 		if format == FormatSQLiteSQL {
 			warnings = append(warnings, "SQLite merge mode applies SQL to a staged copy of a consistent target snapshot; the live target is replaced only after integrity checks pass.")
 		}
@@ -321,7 +363,7 @@ func restorePlanWarnings(format Format, options RestoreOptions) []string {
 	}
 	if !options.StopOnError && format == FormatSQLiteSQL {
 		warnings = append(warnings, "SQLite SQL restore always stops at the first client error so a partial stage can never be published.")
-	} else if !options.StopOnError && format != FormatSQLiteDatabase {
+	} else if !options.StopOnError && format != FormatSQLiteDatabase && format != FormatDuckDBExport {
 		warnings = append(warnings, "Stop-on-error is disabled; a client may continue after a failed statement and leave a partial restore.")
 	}
 	if !options.SingleTransaction && (format == FormatPostgresCustom || format == FormatPostgresTar || format == FormatPostgresSQL) {
@@ -453,7 +495,7 @@ func redactRestoreError(err error, target *config.ConnectionConfig) error {
 		return err
 	}
 	message := err.Error()
-	for _, secret := range []string{target.Password, target.AuthToken} {
+	for _, secret := range []string{target.Password, target.AuthToken, target.SSHPassword} {
 		if secret != "" {
 			message = strings.ReplaceAll(message, secret, "[redacted]")
 		}
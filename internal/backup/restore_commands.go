@@ -30,6 +30,10 @@ func executePostgresArchiveRestore(ctx context.Context, plan *RestorePlan, paylo
 	if err != nil {
 		return err
 	}
+	plan, tlsEnv, err := libpqRestorePlan(plan)
+	if err != nil {
+		return err
+	}
 	passwordFile, cleanup, err := writePGPassFile(filepath.Dir(payloadPath), &plan.Target)
 	if err != nil {
 		return err
@@ -38,7 +42,7 @@ func executePostgresArchiveRestore(ctx context.Context, plan *RestorePlan, paylo
 
 	args := postgresArchiveRestoreArgs(plan)
 	args = append(args, payloadPath)
-	environment := postgresRestoreEnvironment(&plan.Target, passwordFile)
+	environment := postgresRestoreEnvironment(passwordFile, tlsEnv)
 	emitRestore(emit, "Running pg_restore with the verified staged archive")
 	return runRestoreInvocation(ctx, restoreInvocation{
 		label: "pg_restore", toolPath: tool, args: args, env: environment,
@@ -53,6 +57,10 @@ func executePostgresSQLRestore(ctx context.Context, plan *RestorePlan, payloadPa
 	if err != nil {
 		return err
 	}
+	plan, tlsEnv, err := libpqRestorePlan(plan)
+	if err != nil {
+		return err
+	}
 	passwordFile, cleanup, err := writePGPassFile(filepath.Dir(payloadPath), &plan.Target)
 	if err != nil {
 		return err
@@ -60,7 +68,7 @@ func executePostgresSQLRestore(ctx context.Context, plan *RestorePlan, payloadPa
 	defer cleanup()
 
 	args := postgresSQLRestoreArgs(plan, payloadPath)
-	environment := postgresRestoreEnvironment(&plan.Target, passwordFile)
+	environment := postgresRestoreEnvironment(passwordFile, tlsEnv)
 	emitRestore(emit, "Running psql with the verified staged SQL file")
 	return runRestoreInvocation(ctx, restoreInvocation{
 		label: "psql", toolPath: tool, args: args, env: environment,
@@ -81,7 +89,10 @@ func executeMySQLRestore(ctx context.Context, plan *RestorePlan, payloadPath str
 	}
 	defer cleanup()
 
-	args := mysqlRestoreArgs(plan, defaultsFile)
+	args, err := mysqlRestoreArgs(plan, defaultsFile)
+	if err != nil {
+		return err
+	}
 	environment := environmentWithout(os.Environ(), "MYSQL_PWD")
 	environment = append(environment, "LC_ALL=C")
 	emitRestore(emit, "Running mysql with the verified staged SQL file")
@@ -132,7 +143,7 @@ func postgresSQLRestoreArgs(plan *RestorePlan, payloadPath string) []string {
 	return append(args, "--file", payloadPath)
 }
 
-func mysqlRestoreArgs(plan *RestorePlan, defaultsFile string) []string {
+func mysqlRestoreArgs(plan *RestorePlan, defaultsFile string) ([]string, error) {
 	target := &plan.Target
 	args := make([]string, 0, 12)
 	if defaultsFile != "" {
@@ -152,22 +163,37 @@ func mysqlRestoreArgs(plan *RestorePlan, defaultsFile string) []string {
 		"--skip-auto-rehash",
 		"--disable-pager",
 	)
+	tlsArgs, err := target.MySQLClientTLSArgs()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, tlsArgs...)
+	args = append(args, target.MySQLClientSessionArgs()...)
 	if !plan.Options.StopOnError {
 		args = append(args, "--force")
 	}
-	return args
+	return args, nil
+}
+
+// libpqRestorePlan returns a copy of plan whose target is addressed for
+// native PostgreSQL tools, plus the TLS environment they need.
+func libpqRestorePlan(plan *RestorePlan) (*RestorePlan, []string, error) {
+	target, tlsEnv, err := libpqTarget(&plan.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+	nativePlan := *plan
+	nativePlan.Target = *target
+	return &nativePlan, tlsEnv, nil
 }
 
-func postgresRestoreEnvironment(target *config.ConnectionConfig, passwordFile string) []string {
-	environment := environmentWithout(os.Environ(), "PGPASSWORD", "PGPASSFILE", "PGSSLMODE")
+func postgresRestoreEnvironment(passwordFile string, tlsEnv []string) []string {
+	environment := environmentWithout(os.Environ(), append([]string{"PGPASSWORD", "PGPASSFILE"}, config.LibpqTLSEnvironment...)...)
 	environment = append(environment, "LC_ALL=C")
 	if passwordFile != "" {
 		environment = append(environment, "PGPASSFILE="+passwordFile)
 	}
-	if strings.TrimSpace(target.SSLMode) != "" {
-		environment = append(environment, "PGSSLMODE="+strings.TrimSpace(target.SSLMode))
-	}
-	return environment
+	return append(environment, tlsEnv...)
 }
 
 func environmentWithout(environment []string, names ...string) []string {
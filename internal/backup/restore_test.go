@@ -165,7 +165,10 @@ func TestRestoreCommandArgumentsUseSafeDefaults(t *testing.T) {
 		Target:  restoreMySQLTarget(),
 		Options: RestoreOptions{Mode: RestoreModeMerge, StopOnError: true},
 	}
-	mysqlArgs := mysqlRestoreArgs(mysqlPlan, "/private/client.cnf")
+	mysqlArgs, err := mysqlRestoreArgs(mysqlPlan, "/private/client.cnf")
+	if err != nil {
+		t.Fatal(err)
+	}
 	if !strings.HasPrefix(mysqlArgs[0], "--defaults-extra-file=") {
 		t.Fatalf("mysql first arg = %q, want defaults file first", mysqlArgs[0])
 	}
@@ -178,7 +181,7 @@ func TestRestoreCommandArgumentsUseSafeDefaults(t *testing.T) {
 		t.Fatal("MySQL password appeared in command arguments")
 	}
 	mysqlPlan.Options.StopOnError = false
-	if args := mysqlRestoreArgs(mysqlPlan, ""); !slices.Contains(args, "--force") {
+	if args, _ := mysqlRestoreArgs(mysqlPlan, ""); !slices.Contains(args, "--force") {
 		t.Errorf("mysql continue-on-error args = %v, want --force", args)
 	}
 }
@@ -191,7 +194,16 @@ func TestRestoreEnvironmentRemovesAmbientPasswordVariables(t *testing.T) {
 		t.Fatalf("environmentWithout() = %v", environment)
 	}
 	target := restorePostgresTarget()
-	for _, entry := range postgresRestoreEnvironment(&target, "/private/pgpass") {
+	target.SSLMode = "verify-full"
+	_, tlsEnv, err := target.TLSEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	environment = postgresRestoreEnvironment("/private/pgpass", tlsEnv)
+	if !slices.Contains(environment, "PGSSLMODE=verify-full") {
+		t.Fatalf("postgres environment lacks the target SSL mode: %v", environment)
+	}
+	for _, entry := range environment {
 		if strings.HasPrefix(strings.ToUpper(entry), "PGPASSWORD=") {
 			t.Fatalf("postgres environment contains PGPASSWORD: %q", entry)
 		}
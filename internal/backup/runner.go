@@ -423,7 +423,7 @@ func backupDatabaseName(cfg *config.ConnectionConfig) string {
 	if cfg == nil {
 		return "database"
 	}
-	if cfg.Type == config.SQLite && cfg.FilePath != "" {
+	if (cfg.Type == config.SQLite || cfg.Type == config.DuckDB) && cfg.FilePath != "" {
 		base := filepath.Base(cfg.FilePath)
 		return strings.TrimSuffix(base, filepath.Ext(base))
 	}
@@ -0,0 +1,113 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shreyam1008/dbterm/internal/secrets"
+)
+
+func smtpPasswordRef(jobID string) string {
+	return "backup-job/" + jobID + "/smtp_password"
+}
+
+// encodeJob marshals a job for the catalog. When the secrets vault exists the
+// SMTP password is sealed there and the stored JSON keeps an empty field. An
+// empty password with a username means "unchanged", because jobs read while
+// the vault was locked carry no password.
+func encodeJob(job Job) ([]byte, error) {
+	if secrets.Enabled() {
+		vault := secrets.Unlocked()
+		switch {
+		case vault != nil:
+			ref := smtpPasswordRef(job.ID)
+			if job.Notification.Username == "" {
+				vault.Set(ref, "")
+			} else if job.Notification.Password != "" {
+				vault.Set(ref, job.Notification.Password)
+			}
+			if err := vault.Save(); err != nil {
+				return nil, err
+			}
+		case job.Notification.Password != "":
+			return nil, secrets.ErrLocked
+		}
+		job.Notification.Password = ""
+	}
+	return json.Marshal(job)
+}
+
+func revealJobSecrets(job *Job) {
+	if job.Notification.Password != "" || job.Notification.Username == "" {
+		return
+	}
+	if vault := secrets.Unlocked(); vault != nil {
+		if value, ok := vault.Get(smtpPasswordRef(job.ID)); ok {
+			job.Notification.Password = value
+		}
+	}
+}
+
+func forgetJobSecrets(jobID string) {
+	if vault := secrets.Unlocked(); vault != nil {
+		vault.Set(smtpPasswordRef(jobID), "")
+		_ = vault.Save()
+	}
+}
+
+// SealSecrets moves plaintext SMTP passwords already in the catalog into the
+// unlocked vault and compacts the database so the old pages are discarded.
+func (s *Store) SealSecrets(ctx context.Context) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("backup store is not open")
+	}
+	if secrets.Unlocked() == nil {
+		return 0, secrets.ErrLocked
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, job_json FROM backup_jobs ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("list backup jobs: %w", err)
+	}
+	type storedJob struct {
+		id      string
+		payload []byte
+	}
+	var stored []storedJob
+	for rows.Next() {
+		var item storedJob
+		if err := rows.Scan(&item.id, &item.payload); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("read backup job: %w", err)
+		}
+		stored = append(stored, item)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, fmt.Errorf("list backup jobs: %w", err)
+	}
+
+	sealed := 0
+	for _, item := range stored {
+		var job Job
+		if err := json.Unmarshal(item.payload, &job); err != nil {
+			return sealed, fmt.Errorf("decode backup job %s: %w", item.id, err)
+		}
+		if job.Notification.Password == "" {
+			continue
+		}
+		payload, err := encodeJob(job)
+		if err != nil {
+			return sealed, err
+		}
+		if _, err := s.db.ExecContext(ctx, `UPDATE backup_jobs SET job_json = ? WHERE id = ?`, payload, item.id); err != nil {
+			return sealed, fmt.Errorf("seal backup job %s: %w", item.id, err)
+		}
+		sealed++
+	}
+	if sealed > 0 {
+		if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+			return sealed, fmt.Errorf("compact backup catalog: %w", err)
+		}
+	}
+	return sealed, nil
+}
@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/shreyam1008/dbterm/internal/secrets"
+)
+
+func TestStoreSealsSMTPPasswordIntoVault(t *testing.T) {
+	t.Setenv("DBTERM_CONFIG_DIR", filepath.Join(t.TempDir(), "config"))
+	store, err := OpenStore(filepath.Join(t.TempDir(), "backups.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	job := Job{
+		Name: "nightly", ConnectionID: "conn", Destination: t.TempDir(),
+		Compression: CompressionNone, Schedule: Schedule{Kind: ScheduleManual}, Retention: Retention{KeepLast: 1}, TimeoutMinutes: 5,
+		Notification: EmailNotification{
+			Policy: NotificationFailure, SMTPHost: "smtp.example.com", SMTPPort: 587, TLSMode: SMTPTLSStartTLS,
+			Username: "alerts@example.com", Password: "smtp-app-password", From: "alerts@example.com",
+			Recipients: []string{"ops@example.com"},
+		},
+	}
+	if err := job.ApplyDefaults(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpsertJob(ctx, &job); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "vault-key.txt")
+	if err := os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secrets.Initialize(secrets.Key{IdentityFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(secrets.Lock)
+
+	sealed, err := store.SealSecrets(ctx)
+	if err != nil || sealed != 1 {
+		t.Fatalf("SealSecrets() = %d, %v", sealed, err)
+	}
+	var payload string
+	if err := store.db.QueryRowContext(ctx, `SELECT job_json FROM backup_jobs WHERE id = ?`, job.ID).Scan(&payload); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(payload, "smtp-app-password") {
+		t.Fatalf("catalog still stores the SMTP password: %s", payload)
+	}
+	stored, err := store.GetJob(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Notification.Password != "smtp-app-password" {
+		t.Fatalf("unlocked job password = %q", stored.Notification.Password)
+	}
+
+	secrets.Lock()
+	locked, err := store.GetJob(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locked.Notification.Password != "" {
+		t.Fatal("locked vault exposed the SMTP password")
+	}
+}
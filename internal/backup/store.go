@@ -124,7 +124,7 @@ func (s *Store) UpsertJob(ctx context.Context, job *Job) error {
 	if !job.Enabled {
 		job.NextRunAt = time.Time{}
 	}
-	payload, err := json.Marshal(job)
+	payload, err := encodeJob(*job)
 	if err != nil {
 		return fmt.Errorf("encode backup job: %w", err)
 	}
@@ -249,6 +249,7 @@ func (s *Store) DeleteJob(ctx context.Context, id string) error {
 		}
 		return fmt.Errorf("backup job %q was not found", id)
 	}
+	forgetJobSecrets(strings.TrimSpace(id))
 	return nil
 }
 
@@ -326,7 +327,7 @@ func (s *Store) advanceMissedJob(ctx context.Context, job Job, dueNow, leaseNow
 		job.NextRunAt = time.Time{}
 	}
 	job.UpdatedAt = leaseNow.UTC()
-	payload, err := json.Marshal(job)
+	payload, err := encodeJob(job)
 	if err != nil {
 		return false, fmt.Errorf("encode advanced backup job %q: %w", job.Name, err)
 	}
@@ -432,7 +433,10 @@ func (s *Store) FinishRun(ctx context.Context, run *Run, leaseOwner string) erro
 	} else if parsed, ok := parseNullableTime(nextRaw); ok {
 		job.NextRunAt = parsed
 	}
-	jobPayload, _ := json.Marshal(job)
+	jobPayload, err := encodeJob(job)
+	if err != nil {
+		return fmt.Errorf("encode completed backup job: %w", err)
+	}
 	runPayload, _ := json.Marshal(run)
 	if _, err := tx.ExecContext(ctx, `UPDATE backup_runs SET status = ?, finished_at = ?, run_json = ? WHERE id = ?`,
 		run.Status, formatTime(run.FinishedAt), runPayload, run.ID); err != nil {
@@ -761,6 +765,7 @@ func scanJob(row rowScanner) (Job, error) {
 	} else {
 		job.NextRunAt = time.Time{}
 	}
+	revealJobSecrets(&job)
 	return job, nil
 }
 
@@ -240,6 +240,43 @@ func TestCompactRowEnvelopeCompressesAndReadsLegacyRows(t *testing.T) {
 	}
 }
 
+func TestComparableRowsMatchAcrossDriversAndReportChangedColumns(t *testing.T) {
+	names := []string{"id", "total", "note"}
+	mysql, err := ComparableRow(names, []string{"BIGINT", "DECIMAL", "BLOB"}, []any{[]byte("7"), []byte("2.5"), []byte{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgres, err := ComparableRow(names, []string{"INT8", "FLOAT8", "BYTEA"}, []any{int64(7), 2.5, []byte{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(mysql, postgres) {
+		t.Fatalf("equal rows encode differently:\n%s\n%s", mysql, postgres)
+	}
+	changed, err := ComparableRow(names, nil, []any{int64(7), 3.0, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns, err := ChangedColumns(postgres, changed)
+	if err != nil || strings.Join(columns, ",") != "total,note" {
+		t.Fatalf("changed columns = %v, err %v", columns, err)
+	}
+
+	byID, _ := ComparableKey(postgres, []string{"id"}, 0)
+	changedByID, _ := ComparableKey(changed, []string{"id"}, 0)
+	if byID != changedByID {
+		t.Fatal("rows with the same id have different keys")
+	}
+	fullRow, _ := ComparableKey(postgres, nil, 0)
+	changedFullRow, _ := ComparableKey(changed, nil, 0)
+	if fullRow == changedFullRow {
+		t.Fatal("different rows share a full-row key")
+	}
+	if second, _ := ComparableKey(postgres, nil, 1); second == fullRow {
+		t.Fatal("a duplicate row shares the key of its first occurrence")
+	}
+}
+
 func TestProgressMeterWeightsKnownRowsAndCompletes(t *testing.T) {
 	meter := newProgressMeter([]TablePlan{{Name: "small", EstimatedRows: 100}, {Name: "large", EstimatedRows: 300}}, false)
 	progress := meter.decorate(Progress{Phase: "capturing", Table: "large", Rows: 150}, false)
@@ -0,0 +1,57 @@
+package changeprofiler
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// ComparableRow encodes a scanned row for comparison with a row read from
+// another query or connection. Database types are dropped and scalar kinds
+// share one canonical text form, so 1 from an INT8 column equals "1" from a
+// driver that returns DECIMAL text. NULL and binary values stay distinct.
+func ComparableRow(columnNames, databaseTypes []string, values []any) ([]byte, error) {
+	payload, _, err := encodeScannedRow(columnNames, databaseTypes, values)
+	if err != nil {
+		return nil, err
+	}
+	var row encodedRow
+	if err := json.Unmarshal(payload, &row); err != nil {
+		return nil, err
+	}
+	for index := range row.Cells {
+		cell := &row.Cells[index]
+		cell.Type = ""
+		switch cell.Kind {
+		case "null", "bytes":
+		case "float":
+			if bits, err := strconv.ParseUint(cell.Data, 16, 64); err == nil {
+				cell.Data = strconv.FormatFloat(math.Float64frombits(bits), 'g', -1, 64)
+			}
+			cell.Kind = "value"
+		default:
+			cell.Kind = "value"
+		}
+	}
+	return json.Marshal(row)
+}
+
+// ComparableKey identifies a ComparableRow by keyColumns, or by the whole
+// row when there are none. occurrence tells apart rows sharing a key.
+func ComparableKey(row []byte, keyColumns []string, occurrence int) (string, error) {
+	kind := KeyUnique
+	if len(keyColumns) == 0 {
+		kind = KeyFullRow
+	}
+	key, _, err := encodeKey(row, keyColumns, kind, occurrence)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// ChangedColumns lists the columns whose values differ between two encoded
+// rows, including columns present in only one of them.
+func ChangedColumns(before, after []byte) ([]string, error) {
+	return changedColumns(before, after)
+}
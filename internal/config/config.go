@@ -14,7 +14,9 @@ import (
 	mysql "github.com/go-sql-driver/mysql"
 	"github.com/shreyam1008/dbterm/internal/appdirs"
 	"github.com/shreyam1008/dbterm/internal/d1sql"
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
 	"github.com/shreyam1008/dbterm/internal/persist"
+	"github.com/shreyam1008/dbterm/internal/secrets"
 )
 
 // DBType represents the supported database types
@@ -26,6 +28,7 @@ const (
 	SQLite       DBType = "sqlite"
 	Turso        DBType = "turso"
 	CloudflareD1 DBType = "d1"
+	DuckDB       DBType = "duckdb"
 )
 
 // ConnectionConfig holds all info for a saved database connection
@@ -39,13 +42,58 @@ type ConnectionConfig struct {
 	Password   string `json:"password,omitempty"`
 	Database   string `json:"database,omitempty"`
 	ReadOnly   bool   `json:"read_only,omitempty"`
-	FilePath   string `json:"file_path,omitempty"`   // SQLite only
-	SSLMode    string `json:"ssl_mode,omitempty"`    // PostgreSQL only
+	FilePath   string `json:"file_path,omitempty"`   // SQLite & DuckDB
+	SSLMode    string `json:"ssl_mode,omitempty"`    // PostgreSQL & MySQL
 	AccountID  string `json:"account_id,omitempty"`  // Cloudflare D1 only
 	DatabaseID string `json:"database_id,omitempty"` // Cloudflare D1 only
 	AuthToken  string `json:"auth_token,omitempty"`  // Turso & D1
 	LastUsed   string `json:"last_used,omitempty"`
 	Active     bool   `json:"active"`
+
+	// Environment tags the profile as dev, staging, prod, or a custom label;
+	// Color overrides the tag's default color. Prod profiles ask for a typed
+	// confirmation before statements that change data.
+	Environment string `json:"environment,omitempty"`
+	Color       string `json:"color,omitempty"`
+
+	// InitSQL runs on every new pooled session, such as SET search_path or
+	// SET ROLE. StatementTimeout ("30s") is enforced by the server where the
+	// engine supports it and by a context deadline otherwise.
+	InitSQL          string `json:"init_sql,omitempty"`
+	StatementTimeout string `json:"statement_timeout,omitempty"`
+
+	// SSH tunnel (PostgreSQL & MySQL). SSHHost is the server that can reach
+	// Host:Port; SSHJumpHosts lists ProxyJump-style hops dialed before it.
+	SSHHost       string `json:"ssh_host,omitempty"`
+	SSHPort       string `json:"ssh_port,omitempty"`
+	SSHUser       string `json:"ssh_user,omitempty"`
+	SSHPassword   string `json:"ssh_password,omitempty"` // password or key passphrase
+	SSHKeyFile    string `json:"ssh_key_file,omitempty"`
+	SSHUseAgent   bool   `json:"ssh_use_agent,omitempty"`
+	SSHKnownHosts string `json:"ssh_known_hosts,omitempty"` // default ~/.ssh/known_hosts
+	SSHJumpHosts  string `json:"ssh_jump_hosts,omitempty"`  // user@host:port,...
+
+	// TLS (PostgreSQL & MySQL). The CA file pins the server's issuer, the
+	// certificate and key enable mutual TLS, and TLSServerName replaces Host
+	// as the name checked against the server certificate.
+	TLSCAFile     string `json:"tls_ca_file,omitempty"`
+	TLSCertFile   string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile    string `json:"tls_key_file,omitempty"`
+	TLSServerName string `json:"tls_server_name,omitempty"`
+
+	// PasswordCommand prints the password, or the Turso/D1 token, on stdout
+	// at connect time. User and the secret fields may also hold ${ENV_VAR}
+	// references; ResolveCredentials fills both in.
+	PasswordCommand string `json:"password_command,omitempty"`
+
+	// credentialsResolved marks copies returned by ResolveCredentials so they
+	// are not resolved twice.
+	credentialsResolved bool
+}
+
+// UsesSSHTunnel reports whether connections must be routed through SSH.
+func (c *ConnectionConfig) UsesSSHTunnel() bool {
+	return (c.Type == PostgreSQL || c.Type == MySQL) && strings.TrimSpace(c.SSHHost) != ""
 }
 
 // Store manages the collection of saved connections
@@ -54,6 +102,9 @@ type Store struct {
 	configPath    string
 	recoveryPath  string
 	recoveredFrom string
+	// revealed is set once vaulted secrets were merged into Connections, so
+	// a save never mistakes not-yet-loaded secrets for cleared ones.
+	revealed bool
 }
 
 const (
@@ -119,6 +170,9 @@ func LoadStore() (*Store, error) {
 	if err != nil {
 		return s, fmt.Errorf("validate saved connection identities: %w", err)
 	}
+	if vault := secrets.Unlocked(); vault != nil {
+		s.revealSecrets(vault)
+	}
 	if changed {
 		if err := s.Save(); err != nil {
 			return s, fmt.Errorf("persist generated connection identities: %w", err)
@@ -169,7 +223,11 @@ func (s *Store) Save() error {
 	if _, err := s.ensureConnectionIDs(); err != nil {
 		return fmt.Errorf("validate connection identities: %w", err)
 	}
-	if err := saveConnectionsWithRecovery(s.configPath, s.recoveryPath, s.Connections); err != nil {
+	connections, err := s.sealedConnections()
+	if err != nil {
+		return fmt.Errorf("save connections: %w", err)
+	}
+	if err := saveConnectionsWithRecovery(s.configPath, s.recoveryPath, connections); err != nil {
 		return fmt.Errorf("save connections: %w", err)
 	}
 	return nil
@@ -297,6 +355,33 @@ func (s *Store) Add(c ConnectionConfig) error {
 	return nil
 }
 
+// AddAll appends several connections with one recovery-safe save. Entries
+// without an ID, or whose ID is already taken, receive a fresh one. Nothing
+// is kept if the save fails.
+func (s *Store) AddAll(connections []ConnectionConfig) error {
+	if s == nil {
+		return fmt.Errorf("connection store is required")
+	}
+	original := append([]ConnectionConfig(nil), s.Connections...)
+	for _, c := range connections {
+		if strings.TrimSpace(c.ID) == "" || s.hasConnectionID(c.ID, -1) {
+			id, err := s.nextConnectionID()
+			if err != nil {
+				s.Connections = original
+				return err
+			}
+			c.ID = id
+		}
+		c.Active = false
+		s.Connections = append(s.Connections, c)
+	}
+	if err := s.Save(); err != nil {
+		s.Connections = original
+		return err
+	}
+	return nil
+}
+
 // Update replaces a connection at the given index and saves
 func (s *Store) Update(index int, c ConnectionConfig) error {
 	if s == nil {
@@ -476,10 +561,6 @@ func (c *ConnectionConfig) BuildConnString() string {
 		}).String()
 
 	case PostgreSQL:
-		sslMode := c.SSLMode
-		if sslMode == "" {
-			sslMode = "disable"
-		}
 		databaseName := strings.TrimSpace(c.Database)
 		if databaseName == "" {
 			// A profile may represent the whole server. PostgreSQL still needs a
@@ -500,7 +581,9 @@ func (c *ConnectionConfig) BuildConnString() string {
 			Path:   databaseName,
 		}
 		q := u.Query()
-		q.Set("sslmode", sslMode)
+		for key, value := range c.postgresTLSParams() {
+			q.Set(key, value)
+		}
 		q.Set("connect_timeout", "5")
 		u.RawQuery = q.Encode()
 		return u.String()
@@ -519,9 +602,15 @@ func (c *ConnectionConfig) BuildConnString() string {
 		cfg.Timeout = 5 * time.Second
 		cfg.ReadTimeout = 30 * time.Second
 		cfg.WriteTimeout = 30 * time.Second
+		cfg.TLSConfig = c.mysqlTLSParam()
 		return cfg.FormatDSN()
 	case SQLite:
 		return c.FilePath
+	case DuckDB:
+		if strings.TrimSpace(c.FilePath) == "" {
+			return ""
+		}
+		return duckdbsql.DSN(c.FilePath, c.ReadOnly)
 	default:
 		return ""
 	}
@@ -540,6 +629,8 @@ func (c *ConnectionConfig) DriverName() string {
 		return "libsql"
 	case CloudflareD1:
 		return d1sql.DriverName
+	case DuckDB:
+		return duckdbsql.DriverName
 	default:
 		return ""
 	}
@@ -548,7 +639,7 @@ func (c *ConnectionConfig) DriverName() string {
 // DisplayLabel returns a human-friendly label for the connection
 func (c *ConnectionConfig) DisplayLabel() string {
 	switch c.Type {
-	case SQLite:
+	case SQLite, DuckDB:
 		return fmt.Sprintf("[%s] %s (%s)", c.Type, c.Name, c.FilePath)
 	case Turso:
 		return fmt.Sprintf("[%s] %s (%s)", c.Type, c.Name, c.Host)
@@ -572,6 +663,8 @@ func (c *ConnectionConfig) TypeLabel() string {
 		return "Turso"
 	case CloudflareD1:
 		return "Cloudflare D1"
+	case DuckDB:
+		return "DuckDB"
 	default:
 		return string(c.Type)
 	}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// passwordCommandTimeout bounds PasswordCommand, which may wait on an unlock
+// prompt from a password manager.
+const passwordCommandTimeout = 30 * time.Second
+
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// HasCredentialReference reports whether value holds a ${ENV_VAR} reference.
+func HasCredentialReference(value string) bool {
+	return envReferencePattern.MatchString(value)
+}
+
+// CredentialOptions adjusts ResolveCredentials.
+type CredentialOptions struct {
+	// SkipPasswordFiles leaves an empty password empty instead of reading
+	// ~/.pgpass or ~/.my.cnf, for profiles whose host someone else chose.
+	SkipPasswordFiles bool
+}
+
+// ResolveCredentials returns a copy of c with indirect credentials filled in:
+// ${ENV_VAR} references in User and the secret fields are expanded, then an
+// empty password comes from PasswordCommand, ~/.pgpass (PostgreSQL), or the
+// [client] and [dbterm] groups of ~/.my.cnf (MySQL). The copy is meant for
+// one connection attempt and must never be saved.
+func (c *ConnectionConfig) ResolveCredentials() (*ConnectionConfig, error) {
+	return c.ResolveCredentialsWith(CredentialOptions{})
+}
+
+// ResolveCredentialsWith is ResolveCredentials with options.
+func (c *ConnectionConfig) ResolveCredentialsWith(options CredentialOptions) (*ConnectionConfig, error) {
+	resolved := *c
+	if c.credentialsResolved {
+		return &resolved, nil
+	}
+	for _, field := range []struct {
+		label string
+		value *string
+	}{
+		{"user", &resolved.User},
+		{"password", &resolved.Password},
+		{"auth token", &resolved.AuthToken},
+		{"SSH password", &resolved.SSHPassword},
+	} {
+		expanded, err := expandEnvReferences(*field.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.label, err)
+		}
+		*field.value = expanded
+	}
+
+	secret := &resolved.Password
+	if resolved.Type == Turso || resolved.Type == CloudflareD1 {
+		secret = &resolved.AuthToken
+	}
+	if *secret == "" && strings.TrimSpace(resolved.PasswordCommand) != "" {
+		value, err := runPasswordCommand(resolved.PasswordCommand)
+		if err != nil {
+			return nil, err
+		}
+		*secret = value
+	}
+	switch {
+	case resolved.Password != "", options.SkipPasswordFiles:
+	case resolved.Type == PostgreSQL:
+		password, err := lookupPGPass(&resolved)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Password = password
+	case resolved.Type == MySQL:
+		options, err := readMySQLOptionFile()
+		if err != nil {
+			return nil, err
+		}
+		if resolved.User == "" {
+			resolved.User = options["user"]
+		}
+		resolved.Password = options["password"]
+	}
+	resolved.credentialsResolved = true
+	return &resolved, nil
+}
+
+func expandEnvReferences(value string) (string, error) {
+	return expandReferences(value, os.LookupEnv)
+}
+
+// runPasswordCommand runs command through the platform shell and returns its
+// first output line. Output is never included in errors.
+func runPasswordCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), passwordCommandTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("password command timed out after %s", passwordCommandTimeout)
+		}
+		if detail := firstLine(stderr.String()); detail != "" {
+			return "", fmt.Errorf("password command failed: %w: %s", err, detail)
+		}
+		return "", fmt.Errorf("password command failed: %w", err)
+	}
+	password := strings.TrimRight(firstLine(stdout.String()), "\r")
+	if password == "" {
+		return "", fmt.Errorf("password command printed nothing")
+	}
+	return password, nil
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimLeft(text, "\r\n"), "\n")
+	return strings.TrimSpace(line)
+}
+
+// pgpassPath follows libpq: PGPASSFILE, then the per-user default.
+func pgpassPath() string {
+	if path := os.Getenv("PGPASSFILE"); path != "" {
+		return path
+	}
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "postgresql", "pgpass.conf")
+		}
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".pgpass")
+}
+
+// lookupPGPass returns the first pgpass entry matching cfg, applying libpq's
+// defaults and its refusal to read a file other users can access.
+func lookupPGPass(cfg *ConnectionConfig) (string, error) {
+	path := pgpassPath()
+	if path == "" {
+		return "", nil
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	host := strings.TrimSpace(cfg.Host)
+	if host == "" || strings.HasPrefix(host, "/") {
+		host = "localhost"
+	}
+	port := firstNonEmpty(strings.TrimSpace(cfg.Port), "5432")
+	database := firstNonEmpty(strings.TrimSpace(cfg.Database), cfg.User)
+	want := []string{host, port, database, cfg.User}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := splitPGPassLine(line)
+		if len(fields) != 5 {
+			continue
+		}
+		matched := true
+		for i, value := range want {
+			if fields[i] != "*" && fields[i] != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return fields[4], nil
+		}
+	}
+	return "", nil
+}
+
+// splitPGPassLine splits on unescaped colons and removes backslash escapes.
+func splitPGPassLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':' && len(fields) < 4:
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
+
+// readMySQLOptionFile reads ~/.my.cnf the way the mysql tools do: [client]
+// first, then dbterm's own [dbterm] group overriding it.
+func readMySQLOptionFile() (map[string]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil
+	}
+	path := filepath.Join(home, ".my.cnf")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	groups := map[string]map[string]string{}
+	var current map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';' || line[0] == '!':
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			if groups[name] == nil {
+				groups[name] = map[string]string{}
+			}
+			current = groups[name]
+		case current != nil:
+			key, value, _ := strings.Cut(line, "=")
+			key = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+			current[key] = unquoteOptionValue(strings.TrimSpace(value))
+		}
+	}
+	options := map[string]string{}
+	for _, group := range []string{"client", "dbterm"} {
+		for key, value := range groups[group] {
+			options[key] = value
+		}
+	}
+	return options, nil
+}
+
+func unquoteOptionValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestResolveCredentialsExpandsEnvironmentReferences(t *testing.T) {
+	t.Setenv("DBTERM_TEST_USER", "reporter")
+	t.Setenv("DBTERM_TEST_PASSWORD", "p@ss ${NOT_EXPANDED}")
+	cfg := ConnectionConfig{Type: MySQL, User: "${DBTERM_TEST_USER}", Password: "${DBTERM_TEST_PASSWORD}"}
+	resolved, err := cfg.ResolveCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.User != "reporter" || resolved.Password != "p@ss ${NOT_EXPANDED}" {
+		t.Fatalf("resolved = %q / %q", resolved.User, resolved.Password)
+	}
+	if cfg.Password != "${DBTERM_TEST_PASSWORD}" {
+		t.Fatalf("ResolveCredentials modified the profile: %q", cfg.Password)
+	}
+	// A resolved copy is returned as-is, so values are never expanded twice.
+	again, err := resolved.ResolveCredentials()
+	if err != nil || again.Password != resolved.Password {
+		t.Fatalf("second resolution = %q, %v", again.Password, err)
+	}
+
+	cfg.Password = "${DBTERM_TEST_UNSET}"
+	if _, err := cfg.ResolveCredentials(); err == nil || !strings.Contains(err.Error(), "DBTERM_TEST_UNSET") {
+		t.Fatalf("unset variable error = %v", err)
+	}
+}
+
+func TestResolveCredentialsRunsPasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	cfg := ConnectionConfig{Type: Turso, Host: "libsql://db.example.com", PasswordCommand: "printf 'token-from-command\\nsecond line\\n'"}
+	resolved, err := cfg.ResolveCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.AuthToken != "token-from-command" || resolved.Password != "" {
+		t.Fatalf("resolved token = %q, password = %q", resolved.AuthToken, resolved.Password)
+	}
+
+	cfg.PasswordCommand = "echo leaked-secret; exit 3"
+	if _, err := cfg.ResolveCredentials(); err == nil || strings.Contains(err.Error(), "leaked-secret") {
+		t.Fatalf("failed command error = %v", err)
+	}
+}
+
+func TestResolveCredentialsReadsPGPass(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pgpass")
+	entries := "# comment\nother.example.com:*:*:*:wrong\ndb.example.com:5432:orders:app:p\\:w\\\\d\n*:*:*:app:fallback\n"
+	if err := os.WriteFile(path, []byte(entries), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PGPASSFILE", path)
+	cfg := ConnectionConfig{Type: PostgreSQL, Host: "db.example.com", User: "app", Database: "orders"}
+	resolved, err := cfg.ResolveCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Password != `p:w\d` {
+		t.Fatalf("password = %q", resolved.Password)
+	}
+	cfg.Database = "billing"
+	if resolved, _ := cfg.ResolveCredentials(); resolved.Password != "fallback" {
+		t.Fatalf("wildcard password = %q", resolved.Password)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if resolved, _ := cfg.ResolveCredentials(); resolved.Password != "" {
+			t.Fatalf("world-readable pgpass was used: %q", resolved.Password)
+		}
+	}
+}
+
+func TestResolveCredentialsReadsMyCnfGroups(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	options := "[mysqld]\npassword = server\n\n[client]\nuser = app\npassword = \"client pass\"\n\n[dbterm]\npassword=dbterm-pass\n"
+	if err := os.WriteFile(filepath.Join(home, ".my.cnf"), []byte(options), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := (&ConnectionConfig{Type: MySQL, Host: "db"}).ResolveCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.User != "app" || resolved.Password != "dbterm-pass" {
+		t.Fatalf("resolved = %q / %q", resolved.User, resolved.Password)
+	}
+}
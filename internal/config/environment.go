@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Environment tags. Any other non-empty value is a custom label.
+const (
+	EnvironmentDevelopment = "dev"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "prod"
+)
+
+const maxEnvironmentLength = 16
+
+// environmentColors are the named colors accepted for a connection tag.
+// Values are hex so every surface (TUI tags, borders, JSON) agrees.
+var environmentColors = map[string]string{
+	"red":    "#f38ba8",
+	"orange": "#fab387",
+	"yellow": "#f9e2af",
+	"green":  "#a6e3a1",
+	"teal":   "#94e2d5",
+	"blue":   "#89b4fa",
+	"purple": "#cba6f7",
+	"pink":   "#f5c2e7",
+	"gray":   "#9399b2",
+}
+
+// NormalizeEnvironment lowercases a tag and folds common spellings of the
+// built-in environments onto their short names.
+func NormalizeEnvironment(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "development", "develop", "local":
+		return EnvironmentDevelopment
+	case "stage", "stg":
+		return EnvironmentStaging
+	case "production", "prd", "live":
+		return EnvironmentProduction
+	}
+	return value
+}
+
+// EnvironmentName returns the normalized environment tag, or "" when untagged.
+func (c *ConnectionConfig) EnvironmentName() string {
+	return NormalizeEnvironment(c.Environment)
+}
+
+// IsProduction reports whether statements that change data need a typed
+// confirmation before they run.
+func (c *ConnectionConfig) IsProduction() bool {
+	return c.EnvironmentName() == EnvironmentProduction
+}
+
+// EnvironmentColor returns the tag color as #rrggbb. An unset color falls back
+// to red for prod, yellow for staging, green for dev, and blue for custom tags.
+func (c *ConnectionConfig) EnvironmentColor() string {
+	if color, err := normalizeEnvironmentColor(c.Color); err == nil && color != "" {
+		return color
+	}
+	switch c.EnvironmentName() {
+	case "":
+		return ""
+	case EnvironmentProduction:
+		return environmentColors["red"]
+	case EnvironmentStaging:
+		return environmentColors["yellow"]
+	case EnvironmentDevelopment:
+		return environmentColors["green"]
+	default:
+		return environmentColors["blue"]
+	}
+}
+
+// ValidateEnvironment checks the tag and color, then stores both normalized.
+func (c *ConnectionConfig) ValidateEnvironment() error {
+	environment := NormalizeEnvironment(c.Environment)
+	if len(environment) > maxEnvironmentLength {
+		return fmt.Errorf("environment %q is longer than %d characters", c.Environment, maxEnvironmentLength)
+	}
+	for _, r := range environment {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("environment %q may only use letters, digits, '-' and '_'", c.Environment)
+		}
+	}
+	color, err := normalizeEnvironmentColor(c.Color)
+	if err != nil {
+		return err
+	}
+	if color != "" && environment == "" {
+		return fmt.Errorf("a color needs an environment tag")
+	}
+	c.Environment, c.Color = environment, color
+	return nil
+}
+
+// EnvironmentColorNames lists the accepted color names for help text.
+func EnvironmentColorNames() []string {
+	names := make([]string, 0, len(environmentColors))
+	for name := range environmentColors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func normalizeEnvironmentColor(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", nil
+	}
+	if hex, ok := environmentColors[value]; ok {
+		return hex, nil
+	}
+	if len(value) == 7 && value[0] == '#' {
+		valid := true
+		for _, r := range value[1:] {
+			if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported color %q (use #rrggbb or one of %s)", value, strings.Join(EnvironmentColorNames(), ", "))
+}
@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestValidateEnvironmentNormalizesTagAndColor(t *testing.T) {
+	cfg := ConnectionConfig{Environment: " Production ", Color: "Orange"}
+	if err := cfg.ValidateEnvironment(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Environment != EnvironmentProduction || cfg.Color != "#fab387" || !cfg.IsProduction() {
+		t.Fatalf("normalized = %q/%q", cfg.Environment, cfg.Color)
+	}
+
+	for _, tc := range []struct {
+		cfg  ConnectionConfig
+		want string
+	}{
+		{ConnectionConfig{}, ""},
+		{ConnectionConfig{Environment: "prod"}, "#f38ba8"},
+		{ConnectionConfig{Environment: "stage"}, "#f9e2af"},
+		{ConnectionConfig{Environment: "dev"}, "#a6e3a1"},
+		{ConnectionConfig{Environment: "qa"}, "#89b4fa"},
+		{ConnectionConfig{Environment: "qa", Color: "#00FF00"}, "#00ff00"},
+	} {
+		if got := tc.cfg.EnvironmentColor(); got != tc.want {
+			t.Fatalf("EnvironmentColor(%+v) = %q, want %q", tc.cfg, got, tc.want)
+		}
+	}
+
+	for _, invalid := range []ConnectionConfig{
+		{Environment: "prod[red]"},
+		{Environment: "a-very-long-environment-name"},
+		{Environment: "prod", Color: "#12345"},
+		{Environment: "prod", Color: "crimson"},
+		{Color: "red"},
+	} {
+		if err := invalid.ValidateEnvironment(); err == nil {
+			t.Fatalf("ValidateEnvironment(%+v) succeeded", invalid)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/secrets"
+)
+
+const connectionSecretPrefix = "connection/"
+
+func connectionSecretRef(id, field string) string {
+	return connectionSecretPrefix + id + "/" + field
+}
+
+// secretFields lists every credential a profile can hold, keyed by the name
+// used in its vault reference.
+func (c *ConnectionConfig) secretFields() map[string]*string {
+	return map[string]*string{
+		"password":     &c.Password,
+		"auth_token":   &c.AuthToken,
+		"ssh_password": &c.SSHPassword,
+	}
+}
+
+// HasPlaintextSecrets reports whether any saved profile still carries a
+// credential in memory, which is what Save would write without a vault.
+func (s *Store) HasPlaintextSecrets() bool {
+	for i := range s.Connections {
+		for _, value := range s.Connections[i].secretFields() {
+			if *value != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RevealSecrets merges vaulted credentials into the loaded profiles. Call it
+// after unlocking the vault for a store that was loaded while locked.
+func (s *Store) RevealSecrets() error {
+	if s == nil {
+		return fmt.Errorf("connection store is required")
+	}
+	if !secrets.Enabled() {
+		return nil
+	}
+	vault := secrets.Unlocked()
+	if vault == nil {
+		return secrets.ErrLocked
+	}
+	s.revealSecrets(vault)
+	return nil
+}
+
+func (s *Store) revealSecrets(vault *secrets.Vault) {
+	for i := range s.Connections {
+		id := s.Connections[i].ID
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+		for field, value := range s.Connections[i].secretFields() {
+			// A value typed since load wins over the sealed copy.
+			if *value != "" {
+				continue
+			}
+			if stored, ok := vault.Get(connectionSecretRef(id, field)); ok {
+				*value = stored
+			}
+		}
+	}
+	s.revealed = true
+}
+
+// sealedConnections returns the on-disk form of the store. With a vault, the
+// credentials move into it and the JSON copies keep only empty fields.
+func (s *Store) sealedConnections() ([]ConnectionConfig, error) {
+	if !secrets.Enabled() {
+		return s.Connections, nil
+	}
+	vault := secrets.Unlocked()
+	if vault == nil {
+		// Locked stores were loaded without secrets; saving them is safe as
+		// long as nothing new would have to be sealed.
+		if s.HasPlaintextSecrets() {
+			return nil, secrets.ErrLocked
+		}
+		return s.Connections, nil
+	}
+	if !s.revealed {
+		s.revealSecrets(vault)
+	}
+
+	sealed := make([]ConnectionConfig, len(s.Connections))
+	live := make(map[string]struct{}, len(s.Connections)*3)
+	for i, connection := range s.Connections {
+		for field, value := range connection.secretFields() {
+			ref := connectionSecretRef(connection.ID, field)
+			vault.Set(ref, *value)
+			live[ref] = struct{}{}
+			*value = ""
+		}
+		sealed[i] = connection
+	}
+	for _, ref := range vault.Refs(connectionSecretPrefix) {
+		if _, ok := live[ref]; !ok {
+			vault.Set(ref, "")
+		}
+	}
+	if err := vault.Save(); err != nil {
+		return nil, err
+	}
+	return sealed, nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/shreyam1008/dbterm/internal/secrets"
+)
+
+func initializeTestVault(t *testing.T) string {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "vault-key.txt")
+	if err := os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secrets.Initialize(secrets.Key{IdentityFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(secrets.Lock)
+	return keyFile
+}
+
+func TestStoreSealsSecretsIntoVault(t *testing.T) {
+	dir := useTestConfigDir(t)
+	keyFile := initializeTestVault(t)
+
+	store := &Store{}
+	if err := store.Add(ConnectionConfig{Name: "prod", Type: PostgreSQL, Host: "db", User: "app", Password: "pg-secret-value", SSHHost: "bastion", SSHUser: "ops", SSHPassword: "ssh-secret-value"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"connections.json", "connections.json.bak"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "secret-value") {
+			t.Fatalf("%s still contains a plaintext secret:\n%s", name, data)
+		}
+	}
+	if store.Connections[0].Password != "pg-secret-value" {
+		t.Fatal("sealing cleared the in-memory password")
+	}
+
+	secrets.Lock()
+	locked, err := LoadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locked.Connections[0].Password != "" {
+		t.Fatal("locked store exposed a password")
+	}
+	// Saving a locked store without new credentials must not drop the vaulted ones.
+	if err := locked.MarkUsed(0); err != nil {
+		t.Fatal(err)
+	}
+	locked.Connections[0].AuthToken = "new-token"
+	if err := locked.Save(); err == nil {
+		t.Fatal("locked store sealed a new secret without the vault")
+	}
+	locked.Connections[0].AuthToken = ""
+
+	if _, err := secrets.Unlock(secrets.Key{IdentityFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	if err := locked.RevealSecrets(); err != nil {
+		t.Fatal(err)
+	}
+	got := locked.Connections[0]
+	if got.Password != "pg-secret-value" || got.SSHPassword != "ssh-secret-value" {
+		t.Fatalf("revealed secrets = %q / %q", got.Password, got.SSHPassword)
+	}
+
+	if err := locked.Delete(0); err != nil {
+		t.Fatal(err)
+	}
+	if refs := secrets.Unlocked().Refs(connectionSecretPrefix); len(refs) != 0 {
+		t.Fatalf("deleted profile left vault entries %v", refs)
+	}
+}
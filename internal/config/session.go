@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxStatementTimeout bounds StatementTimeout so a typo cannot disable it.
+const MaxStatementTimeout = 24 * time.Hour
+
+// SessionStatements returns InitSQL split into individual statements, in the
+// order they run on every new pooled connection.
+func (c *ConnectionConfig) SessionStatements() []string {
+	return SplitStatements(c.InitSQL)
+}
+
+// StatementTimeoutDuration returns the profile's statement timeout, or 0 when
+// none is configured. Bare numbers are seconds.
+func (c *ConnectionConfig) StatementTimeoutDuration() time.Duration {
+	timeout, err := parseStatementTimeout(c.StatementTimeout)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// ValidateSession checks InitSQL and StatementTimeout. Session statements
+// need a connection that persists between queries, which D1, DuckDB, and
+// Turso's HTTP protocol do not provide.
+func (c *ConnectionConfig) ValidateSession() error {
+	if _, err := parseStatementTimeout(c.StatementTimeout); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.InitSQL) == "" {
+		return nil
+	}
+	switch c.Type {
+	case CloudflareD1, DuckDB, Turso:
+		return fmt.Errorf("session init SQL is not supported for %s, which has no persistent session", c.TypeLabel())
+	}
+	if strings.IndexByte(c.InitSQL, 0) >= 0 {
+		return fmt.Errorf("session init SQL cannot contain a NUL byte")
+	}
+	return nil
+}
+
+func parseStatementTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if seconds, convErr := strconv.Atoi(value); convErr == nil {
+		timeout, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("statement timeout %q must be a duration such as 30s or 2m", value)
+	}
+	if timeout < time.Millisecond || timeout > MaxStatementTimeout {
+		return 0, fmt.Errorf("statement timeout %q must be between 1ms and %s", value, MaxStatementTimeout)
+	}
+	return timeout, nil
+}
+
+// SplitStatements splits SQL on semicolons outside quotes and comments and
+// drops empty statements.
+func SplitStatements(sql string) []string {
+	var statements []string
+	appendStatement := func(statement string) {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	start := 0
+	for i := 0; i < len(sql); i++ {
+		switch ch := sql[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			for i++; i < len(sql) && sql[i] != ch; i++ {
+			}
+		case ch == '-' && strings.HasPrefix(sql[i:], "--"):
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case ch == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+		case ch == ';':
+			appendStatement(sql[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(sql) {
+		appendStatement(sql[start:])
+	}
+	return statements
+}
+
+var (
+	libpqSetPattern  = regexp.MustCompile(`(?is)^SET\s+(?:SESSION\s+)?([a-z_][a-z0-9_.]*)\s*(?:=|\s+TO\s+)\s*(.+)$`)
+	libpqRolePattern = regexp.MustCompile(`(?is)^SET\s+(?:SESSION\s+)?(ROLE|TIME\s+ZONE)\s+(.+)$`)
+)
+
+// LibpqSessionOptions converts InitSQL into a PGOPTIONS value for native
+// PostgreSQL tools. Only SET statements can be passed that way; anything
+// else is an error rather than being silently skipped.
+func (c *ConnectionConfig) LibpqSessionOptions() (string, error) {
+	var options []string
+	for _, statement := range c.SessionStatements() {
+		var name, value string
+		if match := libpqRolePattern.FindStringSubmatch(statement); match != nil {
+			name = strings.ToLower(strings.Join(strings.Fields(match[1]), ""))
+			if name == "timezone" {
+				name = "TimeZone"
+			}
+			value = match[2]
+		} else if match := libpqSetPattern.FindStringSubmatch(statement); match != nil {
+			name, value = match[1], match[2]
+		} else {
+			return "", fmt.Errorf("session statement %q cannot be passed to native PostgreSQL tools; use SET name = value", statement)
+		}
+		options = append(options, "-c "+name+"="+libpqOptionValue(value))
+	}
+	return strings.Join(options, " "), nil
+}
+
+// libpqOptionValue unquotes a SQL literal and escapes the characters
+// PGOPTIONS treats specially.
+func libpqOptionValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return strings.NewReplacer(`\`, `\\`, " ", `\ `).Replace(value)
+}
+
+// LibpqSessionEnv returns the PGOPTIONS entry that carries InitSQL to native
+// PostgreSQL tools. The statement timeout is left out: dumps and restores are
+// expected to outlast it, and pg_dump disables it anyway.
+func (c *ConnectionConfig) LibpqSessionEnv() ([]string, error) {
+	options, err := c.LibpqSessionOptions()
+	if err != nil || options == "" {
+		return nil, err
+	}
+	return []string{"PGOPTIONS=" + options}, nil
+}
+
+// MySQLClientSessionArgs returns the mysql client's --init-command for
+// InitSQL. mysqldump has no equivalent option.
+func (c *ConnectionConfig) MySQLClientSessionArgs() []string {
+	statements := c.SessionStatements()
+	if len(statements) == 0 {
+		return nil
+	}
+	return []string{"--init-command=" + strings.Join(statements, "; ")}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSessionSettingsParseAndValidate(t *testing.T) {
+	cfg := ConnectionConfig{Type: PostgreSQL, StatementTimeout: "45", InitSQL: "SET search_path = 'app, public'; -- ; trailing\nSET ROLE reporting;"}
+	if err := cfg.ValidateSession(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.StatementTimeoutDuration(); got != 45*time.Second {
+		t.Fatalf("timeout = %s", got)
+	}
+	want := []string{"SET search_path = 'app, public'", "-- ; trailing\nSET ROLE reporting"}
+	if got := cfg.SessionStatements(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("statements = %q", got)
+	}
+
+	for _, invalid := range []ConnectionConfig{
+		{Type: PostgreSQL, StatementTimeout: "soon"},
+		{Type: PostgreSQL, StatementTimeout: "48h"},
+		{Type: CloudflareD1, InitSQL: "PRAGMA foreign_keys = ON"},
+		{Type: DuckDB, InitSQL: "SET threads = 2"},
+	} {
+		if err := invalid.ValidateSession(); err == nil {
+			t.Fatalf("ValidateSession(%+v) succeeded", invalid)
+		}
+	}
+}
+
+func TestLibpqSessionOptionsTranslatesSetStatements(t *testing.T) {
+	cfg := ConnectionConfig{Type: PostgreSQL, InitSQL: "SET search_path TO app, public; SET ROLE reporting; SET TIME ZONE 'UTC'; set session work_mem = '64MB'"}
+	got, err := cfg.LibpqSessionOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `-c search_path=app,\ public -c role=reporting -c TimeZone=UTC -c work_mem=64MB`; got != want {
+		t.Fatalf("PGOPTIONS = %q, want %q", got, want)
+	}
+	cfg.InitSQL = "SELECT set_config('app.tenant', '1', false)"
+	if _, err := cfg.LibpqSessionOptions(); err == nil {
+		t.Fatal("a non-SET statement was accepted for native tools")
+	}
+}
@@ -17,6 +17,16 @@ const (
 	AgentConnectionScopeActive = "active"
 	AgentConnectionScopeAll    = "all"
 
+	ScriptOnErrorStop     = "stop"
+	ScriptOnErrorContinue = "continue"
+
+	SQLKeywordCaseUpper    = "upper"
+	SQLKeywordCaseLower    = "lower"
+	SQLKeywordCasePreserve = "preserve"
+
+	TransactionIdleTimeoutOff     = "off"
+	DefaultTransactionIdleTimeout = "15m"
+
 	ActionFocusTables    = "focus_tables"
 	ActionFocusQuery     = "focus_query"
 	ActionFocusResults   = "focus_results"
@@ -29,12 +39,21 @@ const (
 	ActionChangeProfiler = "change_profiler"
 	ActionExportCSV      = "export_csv"
 	ActionHistory        = "history"
+	ActionQueryLibrary   = "query_library"
 	ActionSettings       = "settings"
 	ActionImportDump     = "import_dump"
+	ActionImportData     = "import_data"
 	ActionInspectSchema  = "inspect_schema"
+	ActionExplainQuery   = "explain_query"
+	ActionFormatSQL      = "format_sql"
+	ActionTransaction    = "transaction"
 	ActionSelectAll      = "select_all"
 	ActionClearSelection = "clear_selection"
 	ActionCommandPalette = "command_palette"
+	ActionNewEditorTab   = "new_editor_tab"
+	ActionCloseEditorTab = "close_editor_tab"
+	ActionNextEditorTab  = "next_editor_tab"
+	ActionPrevEditorTab  = "prev_editor_tab"
 )
 
 // AgentAccessSettings controls the local, on-demand MCP server. Database
@@ -58,28 +77,43 @@ var defaultKeymapBindings = map[string][]string{
 	ActionChangeProfiler: {"alt+w"},
 	ActionExportCSV:      {"alt+e"},
 	ActionHistory:        {"alt+y"},
+	ActionQueryLibrary:   {"alt+o"},
 	ActionSettings:       {"alt+,", "alt+g"},
 	ActionImportDump:     {"alt+i"},
+	ActionImportData:     {"alt+l"},
 	ActionInspectSchema:  {"alt+m"},
+	ActionExplainQuery:   {"alt+v"},
+	ActionFormatSQL:      {"alt+j"},
+	ActionTransaction:    {"alt+z"},
 	ActionSelectAll:      {"alt+a"},
 	ActionClearSelection: {"alt+c"},
 	ActionCommandPalette: {"ctrl+p"},
+	ActionNewEditorTab:   {"alt+n"},
+	ActionCloseEditorTab: {"alt+x"},
+	ActionNextEditorTab:  {"alt+>"},
+	ActionPrevEditorTab:  {"alt+<"},
 }
 
 // Settings stores user-adjustable runtime settings.
 type Settings struct {
-	Keymap                map[string][]string                  `json:"keymap"`
-	DashboardHealthChecks string                               `json:"dashboard_health_checks"`
-	AgentAccess           AgentAccessSettings                  `json:"agent_access"`
-	TableColumnWidths     map[string]map[string]map[string]int `json:"table_column_widths,omitempty"`
-	PinnedTables          map[string][]string                  `json:"pinned_tables,omitempty"`
+	Keymap                 map[string][]string                  `json:"keymap"`
+	DashboardHealthChecks  string                               `json:"dashboard_health_checks"`
+	ScriptOnError          string                               `json:"script_on_error"`
+	SQLKeywordCase         string                               `json:"sql_keyword_case"`
+	TransactionIdleTimeout string                               `json:"transaction_idle_timeout"`
+	AgentAccess            AgentAccessSettings                  `json:"agent_access"`
+	TableColumnWidths      map[string]map[string]map[string]int `json:"table_column_widths,omitempty"`
+	PinnedTables           map[string][]string                  `json:"pinned_tables,omitempty"`
 }
 
 // DefaultSettings returns a deep-copied default settings value.
 func DefaultSettings() *Settings {
 	return &Settings{
-		Keymap:                DefaultKeymapBindings(),
-		DashboardHealthChecks: "auto",
+		Keymap:                 DefaultKeymapBindings(),
+		DashboardHealthChecks:  "auto",
+		ScriptOnError:          ScriptOnErrorStop,
+		SQLKeywordCase:         SQLKeywordCaseUpper,
+		TransactionIdleTimeout: DefaultTransactionIdleTimeout,
 		AgentAccess: AgentAccessSettings{
 			ConnectionScope: AgentConnectionScopeActive,
 		},
@@ -226,8 +260,11 @@ func loadSettingsRecovery(path string) (*Settings, error) {
 
 func mergeSettings(defaults, loaded *Settings) *Settings {
 	merged := &Settings{
-		Keymap:                map[string][]string{},
-		DashboardHealthChecks: "auto",
+		Keymap:                 map[string][]string{},
+		DashboardHealthChecks:  "auto",
+		ScriptOnError:          ScriptOnErrorStop,
+		SQLKeywordCase:         SQLKeywordCaseUpper,
+		TransactionIdleTimeout: DefaultTransactionIdleTimeout,
 		AgentAccess: AgentAccessSettings{
 			ConnectionScope: AgentConnectionScopeActive,
 		},
@@ -238,6 +275,9 @@ func mergeSettings(defaults, loaded *Settings) *Settings {
 	if defaults != nil {
 		merged.Keymap = cloneKeymapBindings(defaults.Keymap)
 		merged.DashboardHealthChecks = normalizeDashboardHealthChecks(defaults.DashboardHealthChecks)
+		merged.ScriptOnError = NormalizeScriptOnError(defaults.ScriptOnError)
+		merged.SQLKeywordCase = NormalizeSQLKeywordCase(defaults.SQLKeywordCase)
+		merged.TransactionIdleTimeout = NormalizeTransactionIdleTimeout(defaults.TransactionIdleTimeout)
 		merged.AgentAccess = normalizeAgentAccess(defaults.AgentAccess)
 		merged.TableColumnWidths = cloneTableColumnWidths(defaults.TableColumnWidths)
 		merged.PinnedTables = clonePinnedTables(defaults.PinnedTables)
@@ -250,6 +290,15 @@ func mergeSettings(defaults, loaded *Settings) *Settings {
 	if mode := normalizeDashboardHealthChecks(loaded.DashboardHealthChecks); mode != "" {
 		merged.DashboardHealthChecks = mode
 	}
+	if strings.TrimSpace(loaded.ScriptOnError) != "" {
+		merged.ScriptOnError = NormalizeScriptOnError(loaded.ScriptOnError)
+	}
+	if strings.TrimSpace(loaded.SQLKeywordCase) != "" {
+		merged.SQLKeywordCase = NormalizeSQLKeywordCase(loaded.SQLKeywordCase)
+	}
+	if strings.TrimSpace(loaded.TransactionIdleTimeout) != "" {
+		merged.TransactionIdleTimeout = NormalizeTransactionIdleTimeout(loaded.TransactionIdleTimeout)
+	}
 	merged.AgentAccess = normalizeAgentAccess(loaded.AgentAccess)
 	merged.TableColumnWidths = cloneTableColumnWidths(loaded.TableColumnWidths)
 	merged.PinnedTables = clonePinnedTables(loaded.PinnedTables)
@@ -354,3 +403,53 @@ func normalizeDashboardHealthChecks(mode string) string {
 		return "auto"
 	}
 }
+
+// NormalizeScriptOnError maps a script error policy to stop or continue;
+// anything unrecognized stops at the first failing statement.
+func NormalizeScriptOnError(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "continue", "skip", "ignore":
+		return ScriptOnErrorContinue
+	default:
+		return ScriptOnErrorStop
+	}
+}
+
+// NormalizeSQLKeywordCase maps how the SQL formatter writes keywords to
+// upper, lower, or preserve; anything unrecognized is upper case.
+func NormalizeSQLKeywordCase(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "lower", "lowercase":
+		return SQLKeywordCaseLower
+	case "preserve", "keep", "as typed":
+		return SQLKeywordCasePreserve
+	default:
+		return SQLKeywordCaseUpper
+	}
+}
+
+// NormalizeTransactionIdleTimeout maps how long an explicit transaction may
+// sit idle to a duration of at least a minute, or off; anything
+// unrecognized is the 15 minute default.
+func NormalizeTransactionIdleTimeout(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "off", "never", "none", "0":
+		return TransactionIdleTimeoutOff
+	}
+	if timeout, err := time.ParseDuration(value); err == nil && timeout >= time.Minute {
+		return value
+	}
+	return DefaultTransactionIdleTimeout
+}
+
+// TransactionIdleTimeoutDuration returns the idle limit of an explicit
+// transaction, or 0 when idle transactions stay open.
+func TransactionIdleTimeoutDuration(value string) time.Duration {
+	value = NormalizeTransactionIdleTimeout(value)
+	if value == TransactionIdleTimeoutOff {
+		return 0
+	}
+	timeout, _ := time.ParseDuration(value)
+	return timeout
+}
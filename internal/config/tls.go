@@ -0,0 +1,311 @@
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	mysql "github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// SSL modes shared by PostgreSQL and MySQL profiles. They follow libpq's
+// names; MySQL profiles map them onto the driver's and client's equivalents.
+const (
+	SSLModeDisable    = "disable"
+	SSLModePrefer     = "prefer"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// LibpqTLSEnvironment lists the variables TLSEnv sets, so callers can drop
+// inherited values before appending the profile's own.
+var LibpqTLSEnvironment = []string{"PGSSLMODE", "PGSSLROOTCERT", "PGSSLCERT", "PGSSLKEY", "PGHOSTADDR"}
+
+// HasTLSFiles reports whether a CA file or client certificate is configured.
+func (c *ConnectionConfig) HasTLSFiles() bool {
+	return strings.TrimSpace(c.TLSCAFile) != "" || strings.TrimSpace(c.TLSCertFile) != "" ||
+		strings.TrimSpace(c.TLSKeyFile) != ""
+}
+
+// EffectiveSSLMode returns SSLMode normalized to the shared names. A profile
+// with certificate files but no mode verifies the server fully; otherwise an
+// empty mode keeps each driver's historical default.
+func (c *ConnectionConfig) EffectiveSSLMode() string {
+	mode := strings.ToLower(strings.TrimSpace(c.SSLMode))
+	switch mode {
+	case "preferred":
+		mode = SSLModePrefer
+	case "required":
+		mode = SSLModeRequire
+	case "verify_ca":
+		mode = SSLModeVerifyCA
+	case "verify_identity", "verify-identity":
+		mode = SSLModeVerifyFull
+	case "disabled":
+		mode = SSLModeDisable
+	}
+	if mode == "" && c.HasTLSFiles() {
+		return SSLModeVerifyFull
+	}
+	return mode
+}
+
+// ValidateTLS checks the SSL mode and that configured certificate files exist.
+func (c *ConnectionConfig) ValidateTLS() error {
+	switch mode := c.EffectiveSSLMode(); mode {
+	case "", SSLModeDisable, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull:
+	case "allow":
+		if c.Type != PostgreSQL {
+			return fmt.Errorf("SSL mode %q is only supported for PostgreSQL", c.SSLMode)
+		}
+	default:
+		return fmt.Errorf("unsupported SSL mode %q (use disable, prefer, require, verify-ca, or verify-full)", c.SSLMode)
+	}
+	if strings.TrimSpace(c.TLSKeyFile) != "" && strings.TrimSpace(c.TLSCertFile) == "" {
+		return fmt.Errorf("a TLS client key needs a client certificate")
+	}
+	for _, file := range []struct{ label, path string }{
+		{"TLS CA file", c.TLSCAFile}, {"TLS client certificate", c.TLSCertFile}, {"TLS client key", c.TLSKeyFile},
+	} {
+		if strings.TrimSpace(file.path) == "" {
+			continue
+		}
+		if _, err := os.Stat(expandTLSPath(file.path)); err != nil {
+			return fmt.Errorf("%s: %w", file.label, err)
+		}
+	}
+	return nil
+}
+
+// TLSEnv returns the libpq variables native PostgreSQL tools need for this
+// profile, plus the host name to pass them. With a server name override the
+// host becomes that name and PGHOSTADDR carries the real address, which is
+// how libpq checks a certificate issued for a different name.
+func (c *ConnectionConfig) TLSEnv() (string, []string, error) {
+	host := c.Host
+	if strings.TrimSpace(host) == "" {
+		host = "localhost"
+	}
+	mode := c.EffectiveSSLMode()
+	var env []string
+	if mode != "" {
+		env = append(env, "PGSSLMODE="+mode)
+	}
+	for _, file := range []struct{ name, path string }{
+		{"PGSSLROOTCERT", c.TLSCAFile}, {"PGSSLCERT", c.TLSCertFile}, {"PGSSLKEY", c.TLSKeyFile},
+	} {
+		if path := strings.TrimSpace(file.path); path != "" {
+			env = append(env, file.name+"="+expandTLSPath(path))
+		}
+	}
+	if serverName := c.tlsServerNameOverride(); serverName != "" && mode == SSLModeVerifyFull {
+		address, err := hostAddress(host)
+		if err != nil {
+			return "", nil, err
+		}
+		env = append(env, "PGHOSTADDR="+address)
+		host = serverName
+	}
+	return host, env, nil
+}
+
+// MySQLClientTLSArgs returns the --ssl-* options for the mysql and
+// mysqldump clients. The clients cannot check a certificate against a name
+// other than the one they dial, so verify-full with a server name override,
+// which every SSH tunnel sets, is an error rather than a silent downgrade to
+// verify-ca.
+func (c *ConnectionConfig) MySQLClientTLSArgs() ([]string, error) {
+	var args []string
+	switch c.EffectiveSSLMode() {
+	case SSLModeDisable:
+		args = append(args, "--ssl-mode=DISABLED")
+	case SSLModePrefer:
+		args = append(args, "--ssl-mode=PREFERRED")
+	case SSLModeRequire:
+		args = append(args, "--ssl-mode=REQUIRED")
+	case SSLModeVerifyCA:
+		args = append(args, "--ssl-mode=VERIFY_CA")
+	case SSLModeVerifyFull:
+		if serverName := c.tlsServerNameOverride(); serverName != "" {
+			return nil, fmt.Errorf("mysql and mysqldump can only check the certificate against the host they dial (%s), not %s; "+
+				"set the SSL mode to verify-ca to accept CA-only verification for this connection", c.Host, serverName)
+		}
+		args = append(args, "--ssl-mode=VERIFY_IDENTITY")
+	}
+	for _, file := range []struct{ flag, path string }{
+		{"--ssl-ca", c.TLSCAFile}, {"--ssl-cert", c.TLSCertFile}, {"--ssl-key", c.TLSKeyFile},
+	} {
+		if path := strings.TrimSpace(file.path); path != "" {
+			args = append(args, file.flag+"="+expandTLSPath(path))
+		}
+	}
+	return args, nil
+}
+
+// tlsServerNameOverride returns TLSServerName when it differs from Host.
+func (c *ConnectionConfig) tlsServerNameOverride() string {
+	name := strings.TrimSpace(c.TLSServerName)
+	if name == "" || strings.EqualFold(name, strings.TrimSpace(c.Host)) {
+		return ""
+	}
+	return name
+}
+
+// postgresTLSParams returns the sslmode and certificate parameters for the
+// lib/pq DSN. A verify-full override of the server name needs a registered
+// TLS config because lib/pq otherwise checks the dialed host, and sslsni is
+// turned off because lib/pq would overwrite the config's ServerName with the
+// host, which is 127.0.0.1 behind an SSH tunnel. lib/pq has no plaintext
+// fallback, so prefer and allow become require here; TLSEnv keeps them for
+// the native tools.
+func (c *ConnectionConfig) postgresTLSParams() map[string]string {
+	mode := c.EffectiveSSLMode()
+	switch mode {
+	case "":
+		mode = SSLModeDisable
+	case SSLModePrefer, "allow":
+		mode = SSLModeRequire
+	}
+	params := map[string]string{"sslmode": mode}
+	if mode == SSLModeDisable {
+		return params
+	}
+	for key, path := range map[string]string{"sslrootcert": c.TLSCAFile, "sslcert": c.TLSCertFile, "sslkey": c.TLSKeyFile} {
+		if path = strings.TrimSpace(path); path != "" {
+			params[key] = expandTLSPath(path)
+		}
+	}
+	if serverName := c.tlsServerNameOverride(); serverName != "" && mode == SSLModeVerifyFull {
+		name := c.tlsConfigName()
+		_ = pq.RegisterTLSConfig(name, &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12})
+		params["sslmode"] = "pqgo-" + name
+		params["sslsni"] = "0"
+	}
+	return params
+}
+
+// mysqlTLSParam returns the go-sql-driver tls parameter. Certificate paths,
+// verify-ca, and server name overrides use a registered config; the plain
+// modes map onto the driver's built-in values.
+func (c *ConnectionConfig) mysqlTLSParam() string {
+	mode := c.EffectiveSSLMode()
+	custom := mode == SSLModeVerifyCA ||
+		((mode == SSLModeRequire || mode == SSLModeVerifyFull) && (c.HasTLSFiles() || c.tlsServerNameOverride() != ""))
+	switch {
+	case mode == "":
+		return ""
+	case mode == SSLModeDisable:
+		return "false"
+	case mode == SSLModePrefer:
+		return "preferred"
+	case custom:
+		name := c.tlsConfigName()
+		_ = mysql.RegisterTLSConfig(name, c.clientTLSConfig(mode))
+		return name
+	case mode == SSLModeRequire:
+		return "skip-verify"
+	default:
+		return "true"
+	}
+}
+
+// clientTLSConfig builds a TLS config that reads its files at handshake time,
+// so a missing or unreadable file surfaces as a connection error. require
+// with a CA file verifies the chain, as libpq does.
+func (c *ConnectionConfig) clientTLSConfig(mode string) *tls.Config {
+	caFile := expandTLSPath(strings.TrimSpace(c.TLSCAFile))
+	certFile := expandTLSPath(strings.TrimSpace(c.TLSCertFile))
+	keyFile := expandTLSPath(strings.TrimSpace(c.TLSKeyFile))
+	serverName := strings.TrimSpace(c.TLSServerName)
+	if serverName == "" {
+		serverName = strings.TrimSpace(c.Host)
+	}
+	if mode == SSLModeRequire && caFile != "" {
+		mode = SSLModeVerifyCA
+	}
+
+	// Verification runs in VerifyConnection so the CA file is read lazily.
+	conf := &tls.Config{ServerName: serverName, InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}
+	if certFile != "" {
+		if keyFile == "" {
+			keyFile = certFile
+		}
+		conf.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("load TLS client certificate: %w", err)
+			}
+			return &certificate, nil
+		}
+	}
+	if mode != SSLModeVerifyCA && mode != SSLModeVerifyFull {
+		return conf
+	}
+	conf.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("server sent no TLS certificate")
+		}
+		options := x509.VerifyOptions{Intermediates: x509.NewCertPool()}
+		if caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return fmt.Errorf("read TLS CA file: %w", err)
+			}
+			options.Roots = x509.NewCertPool()
+			if !options.Roots.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("TLS CA file %s contains no PEM certificates", caFile)
+			}
+		}
+		if mode == SSLModeVerifyFull {
+			options.DNSName = serverName
+		}
+		for _, certificate := range state.PeerCertificates[1:] {
+			options.Intermediates.AddCert(certificate)
+		}
+		if _, err := state.PeerCertificates[0].Verify(options); err != nil {
+			return fmt.Errorf("verify server certificate: %w", err)
+		}
+		return nil
+	}
+	return conf
+}
+
+// tlsConfigName derives a stable driver registration key from every TLS
+// setting, so profiles sharing settings share one entry.
+func (c *ConnectionConfig) tlsConfigName() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(c.Type), c.EffectiveSSLMode(), c.Host, c.TLSCAFile, c.TLSCertFile, c.TLSKeyFile, c.TLSServerName,
+	}, "\x00")))
+	return "dbterm-" + hex.EncodeToString(sum[:8])
+}
+
+func hostAddress(host string) (string, error) {
+	if net.ParseIP(host) != nil {
+		return host, nil
+	}
+	addresses, err := net.LookupHost(host)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s for TLS server name override: %w", host, err)
+	}
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("resolve %s for TLS server name override: no addresses", host)
+	}
+	return addresses[0], nil
+}
+
+func expandTLSPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
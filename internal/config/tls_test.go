@@ -0,0 +1,249 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	mysql "github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestBuildConnStringCarriesTLSSettings(t *testing.T) {
+	pg := ConnectionConfig{Type: PostgreSQL, Host: "db", Port: "5432", User: "app", TLSCAFile: "/etc/ca.pem", TLSCertFile: "/etc/client.pem", TLSKeyFile: "/etc/client.key"}
+	parsed, err := url.Parse(pg.BuildConnString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("sslmode") != SSLModeVerifyFull || query.Get("sslrootcert") != "/etc/ca.pem" || query.Get("sslkey") != "/etc/client.key" {
+		t.Fatalf("PostgreSQL DSN query = %v", query)
+	}
+
+	pg.TLSServerName = "db.internal"
+	if parsed, _ = url.Parse(pg.BuildConnString()); !strings.HasPrefix(parsed.Query().Get("sslmode"), "pqgo-dbterm-") {
+		t.Fatalf("server name override did not select a registered config: %v", parsed.Query())
+	}
+	if parsed.Query().Get("sslsni") != "0" {
+		t.Fatalf("server name override left lib/pq's SNI rewrite on: %v", parsed.Query())
+	}
+	pg.TLSServerName = ""
+	if parsed, _ = url.Parse(pg.BuildConnString()); parsed.Query().Has("sslsni") {
+		t.Fatalf("sslsni set without a server name override: %v", parsed.Query())
+	}
+
+	plain := ConnectionConfig{Type: PostgreSQL, Host: "db", Port: "5432", User: "app"}
+	if parsed, _ = url.Parse(plain.BuildConnString()); parsed.Query().Get("sslmode") != SSLModeDisable {
+		t.Fatalf("profile without TLS settings changed sslmode: %v", parsed.Query())
+	}
+
+	for mode, want := range map[string]string{"": "", "disable": "false", "require": "skip-verify", "verify-full": "true"} {
+		cfg := ConnectionConfig{Type: MySQL, Host: "db", Port: "3306", User: "app", SSLMode: mode}
+		dsn, err := mysql.ParseDSN(cfg.BuildConnString())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dsn.TLSConfig != want {
+			t.Errorf("MySQL sslmode %q gave tls=%q, want %q", mode, dsn.TLSConfig, want)
+		}
+	}
+	withCA := ConnectionConfig{Type: MySQL, Host: "db", Port: "3306", User: "app", TLSCAFile: "/etc/ca.pem"}
+	dsn, err := mysql.ParseDSN(withCA.BuildConnString())
+	if err != nil {
+		t.Fatalf("registered MySQL TLS config was not accepted: %v", err)
+	}
+	if !strings.HasPrefix(dsn.TLSConfig, "dbterm-") {
+		t.Fatalf("MySQL CA file gave tls=%q", dsn.TLSConfig)
+	}
+}
+
+func TestPostgresDSNAcceptsEverySSLMode(t *testing.T) {
+	for _, mode := range []string{"", "disable", "allow", "prefer", "require", "verify-ca", "verify-full"} {
+		cfg := ConnectionConfig{Type: PostgreSQL, Host: "db", Port: "5432", User: "app", SSLMode: mode}
+		if err := cfg.ValidateTLS(); err != nil {
+			t.Fatalf("ValidateTLS(%q): %v", mode, err)
+		}
+		if _, err := pq.NewConnector(cfg.BuildConnString()); err != nil {
+			t.Errorf("lib/pq rejected the DSN for sslmode %q: %v", mode, err)
+		}
+	}
+	prefer := ConnectionConfig{Type: PostgreSQL, Host: "db", SSLMode: "prefer"}
+	if _, env, _ := prefer.TLSEnv(); !slices.Contains(env, "PGSSLMODE=prefer") {
+		t.Fatalf("native tools lost libpq's prefer fallback: %v", env)
+	}
+}
+
+func TestNativeClientTLSOptions(t *testing.T) {
+	cfg := ConnectionConfig{Type: PostgreSQL, Host: "127.0.0.1", TLSCAFile: "/etc/ca.pem", TLSServerName: "db.internal"}
+	host, env, err := cfg.TLSEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "db.internal" {
+		t.Fatalf("TLSEnv host = %q, want the server name", host)
+	}
+	for _, want := range []string{"PGSSLMODE=verify-full", "PGSSLROOTCERT=/etc/ca.pem", "PGHOSTADDR=127.0.0.1"} {
+		if !slices.Contains(env, want) {
+			t.Errorf("TLSEnv() = %v, missing %s", env, want)
+		}
+	}
+
+	// An SSH tunnel dials 127.0.0.1 and sets the server name to the database
+	// host; the clients cannot honor verify-full there.
+	cfg.Type = MySQL
+	if args, err := cfg.MySQLClientTLSArgs(); err == nil || !strings.Contains(err.Error(), "verify-ca") {
+		t.Fatalf("verify-full with a server name override gave %v, %v", args, err)
+	}
+	cfg.SSLMode = SSLModeVerifyCA
+	args, err := cfg.MySQLClientTLSArgs()
+	if err != nil || !slices.Contains(args, "--ssl-mode=VERIFY_CA") || !slices.Contains(args, "--ssl-ca=/etc/ca.pem") {
+		t.Fatalf("MySQLClientTLSArgs() = %v, %v", args, err)
+	}
+	cfg.SSLMode, cfg.TLSServerName = "", ""
+	if args, err := cfg.MySQLClientTLSArgs(); err != nil || !slices.Contains(args, "--ssl-mode=VERIFY_IDENTITY") {
+		t.Fatalf("MySQLClientTLSArgs() without override = %v, %v", args, err)
+	}
+}
+
+func TestClientTLSConfigPinsCAAndServerName(t *testing.T) {
+	dir := t.TempDir()
+	caFile, serverCert := writeTestCertificates(t, dir, "db.internal")
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	handshake := func(cfg ConnectionConfig, mode string) error {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", listener.Addr().String(), cfg.clientTLSConfig(mode))
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err
+	}
+	cfg := ConnectionConfig{Type: MySQL, Host: "127.0.0.1", TLSCAFile: caFile, TLSServerName: "db.internal"}
+	if err := handshake(cfg, SSLModeVerifyFull); err != nil {
+		t.Fatalf("verify-full with pinned CA and server name: %v", err)
+	}
+	cfg.TLSServerName = "other.internal"
+	if err := handshake(cfg, SSLModeVerifyFull); err == nil {
+		t.Fatal("verify-full accepted a certificate for a different name")
+	}
+	if err := handshake(cfg, SSLModeVerifyCA); err != nil {
+		t.Fatalf("verify-ca should ignore the name: %v", err)
+	}
+	otherCA, _ := writeTestCertificates(t, t.TempDir(), "db.internal")
+	cfg.TLSCAFile = otherCA
+	if err := handshake(cfg, SSLModeVerifyCA); err == nil {
+		t.Fatal("verify-ca accepted a certificate from an unpinned CA")
+	}
+}
+
+func TestPostgresServerNameOverrideSurvivesTunnelHost(t *testing.T) {
+	caFile, serverCert := writeTestCertificates(t, t.TempDir(), "db.internal")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	handshakes := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			handshakes <- err
+			return
+		}
+		defer conn.Close()
+		// Answer the 8-byte SSLRequest with 'S', then upgrade like a server.
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			handshakes <- err
+			return
+		}
+		if _, err := conn.Write([]byte{'S'}); err != nil {
+			handshakes <- err
+			return
+		}
+		server := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{serverCert}})
+		handshakes <- server.Handshake()
+	}()
+
+	// Host and port are what OpenTunnel leaves behind: a loopback address.
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	cfg := ConnectionConfig{Type: PostgreSQL, Host: "127.0.0.1", Port: port, User: "app", TLSCAFile: caFile, TLSServerName: "db.internal"}
+	db, err := sql.Open("postgres", cfg.BuildConnString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	pingErr := db.Ping()
+	select {
+	case err := <-handshakes:
+		if err != nil {
+			t.Fatalf("TLS handshake through the loopback host failed: %v (ping: %v)", err, pingErr)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("server saw no handshake (ping: %v)", pingErr)
+	}
+}
+
+func writeTestCertificates(t *testing.T, dir, serverName string) (string, tls.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "dbterm test CA"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: serverName}, DNSNames: []string{serverName},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, KeyUsage: x509.KeyUsageDigitalSignature,
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return caFile, tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}
+}
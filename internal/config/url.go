@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
+)
+
+// ParseConnectionURL builds a profile from a postgres://, postgresql://,
+// mysql://, libsql://, d1://, duckdb: or file: URL. Bare paths ending in a
+// SQLite extension are accepted as file URLs, and paths ending in .duckdb,
+// .parquet, .csv, or .json open with DuckDB. Name and ID are left empty.
+//
+// D1 URLs take the form d1://<account-id>/<database-id>?token=<api-token>.
+func ParseConnectionURL(raw string) (ConnectionConfig, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ConnectionConfig{}, fmt.Errorf("connection URL is required")
+	}
+	lower := strings.ToLower(raw)
+	switch {
+	case strings.HasPrefix(lower, "file:"), strings.HasPrefix(lower, "sqlite:"):
+		return parseSQLiteURL(raw)
+	case strings.HasPrefix(lower, "duckdb:"):
+		cfg, err := parseSQLiteURL(raw)
+		if err != nil {
+			return ConnectionConfig{}, err
+		}
+		cfg.Type, cfg.ReadOnly = DuckDB, duckdbsql.IsDataFile(cfg.FilePath)
+		return cfg, nil
+	case !strings.Contains(raw, "://"):
+		switch strings.ToLower(filepath.Ext(raw)) {
+		case ".db", ".sqlite", ".sqlite3", ".db3":
+			return ConnectionConfig{Type: SQLite, FilePath: raw}, nil
+		case ".duckdb", ".ddb":
+			return ConnectionConfig{Type: DuckDB, FilePath: raw}, nil
+		}
+		if duckdbsql.IsDataFile(raw) {
+			return ConnectionConfig{Type: DuckDB, FilePath: raw, ReadOnly: true}, nil
+		}
+		return ConnectionConfig{}, fmt.Errorf("unrecognized connection URL %q; expected postgres://, mysql://, libsql://, d1://, duckdb: or file:", redactURL(raw))
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		// url.Error quotes the whole input, so neither it nor raw is echoed.
+		return ConnectionConfig{}, fmt.Errorf("invalid connection URL %q; percent-encode reserved characters in the user and password", stripUserinfo(raw))
+	}
+	query := parsed.Query()
+	switch strings.ToLower(parsed.Scheme) {
+	case "postgres", "postgresql":
+		cfg := networkFromURL(parsed, PostgreSQL, "5432")
+		cfg.SSLMode = query.Get("sslmode")
+		cfg.TLSCAFile, cfg.TLSCertFile, cfg.TLSKeyFile = query.Get("sslrootcert"), query.Get("sslcert"), query.Get("sslkey")
+		return cfg, nil
+	case "mysql":
+		// Accept the mysql client's --ssl-* option names as parameters.
+		cfg := networkFromURL(parsed, MySQL, "3306")
+		cfg.SSLMode = query.Get("ssl-mode")
+		cfg.TLSCAFile, cfg.TLSCertFile, cfg.TLSKeyFile = query.Get("ssl-ca"), query.Get("ssl-cert"), query.Get("ssl-key")
+		return cfg, nil
+	case "libsql", "wss", "https":
+		token := query.Get("authToken")
+		query.Del("authToken")
+		parsed.RawQuery = query.Encode()
+		parsed.User = nil
+		return ConnectionConfig{Type: Turso, Host: parsed.String(), AuthToken: token}, nil
+	case "d1":
+		databaseID := strings.Trim(parsed.Path, "/")
+		if parsed.Host == "" || databaseID == "" || strings.Contains(databaseID, "/") {
+			return ConnectionConfig{}, fmt.Errorf("d1 URL must be d1://<account-id>/<database-id>")
+		}
+		return ConnectionConfig{Type: CloudflareD1, AccountID: parsed.Host, DatabaseID: databaseID, AuthToken: query.Get("token")}, nil
+	default:
+		return ConnectionConfig{}, fmt.Errorf("unsupported connection URL scheme %q", parsed.Scheme)
+	}
+}
+
+func networkFromURL(parsed *url.URL, dbType DBType, defaultPort string) ConnectionConfig {
+	host, port := parsed.Hostname(), parsed.Port()
+	if host == "" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	cfg := ConnectionConfig{Type: dbType, Host: host, Port: port, Database: strings.TrimPrefix(parsed.Path, "/")}
+	if parsed.User != nil {
+		cfg.User = parsed.User.Username()
+		cfg.Password, _ = parsed.User.Password()
+	}
+	return cfg
+}
+
+func parseSQLiteURL(raw string) (ConnectionConfig, error) {
+	path := raw[strings.Index(raw, ":")+1:]
+	path = strings.TrimPrefix(path, "//")
+	if index := strings.IndexByte(path, '?'); index >= 0 {
+		path = path[:index]
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	if path == "" {
+		return ConnectionConfig{}, fmt.Errorf("file URL needs a path")
+	}
+	return ConnectionConfig{Type: SQLite, FilePath: path}, nil
+}
+
+// redactURL hides userinfo passwords and token query values so a URL can be
+// echoed back in an error.
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return stripUserinfo(raw)
+	}
+	if parsed.Host == "" {
+		return raw
+	}
+	if parsed.User != nil {
+		if _, ok := parsed.User.Password(); ok {
+			parsed.User = url.UserPassword(parsed.User.Username(), "redacted")
+		}
+	}
+	query := parsed.Query()
+	for _, key := range []string{"authToken", "token", "password"} {
+		if query.Has(key) {
+			query.Set(key, "redacted")
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// stripUserinfo drops everything between "://" and the last "@" of the
+// authority, and any query string, without parsing the URL. It is the
+// fallback for input url.Parse rejects.
+func stripUserinfo(raw string) string {
+	if index := strings.IndexByte(raw, '?'); index >= 0 {
+		raw = raw[:index]
+	}
+	start := strings.Index(raw, "://")
+	if start < 0 {
+		return raw
+	}
+	start += len("://")
+	if at := strings.LastIndexByte(raw[start:], '@'); at >= 0 {
+		return raw[:start] + "redacted@" + raw[start+at+1:]
+	}
+	return raw
+}
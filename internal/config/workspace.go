@@ -0,0 +1,295 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorkspaceFileName is the project file dbterm looks for in the working
+// directory and its parents.
+const WorkspaceFileName = ".dbterm.json"
+
+// Workspace is a project-local file checked into a repository. Its
+// connections are shown beside the user's saved profiles but never written
+// to connections.json.
+type Workspace struct {
+	Name         string                `json:"name,omitempty"`
+	EnvFile      string                `json:"env_file,omitempty"` // default .env beside the workspace file
+	Connections  []WorkspaceConnection `json:"connections"`
+	Queries      []WorkspaceQuery      `json:"queries,omitempty"`
+	QueryLibrary string                `json:"query_library,omitempty"` // directory of shared saved-query files
+
+	// Path is the workspace file; Warnings lists connections that were
+	// skipped, such as those whose variables are not set. Trusted is set when
+	// the user named the file with --workspace rather than dbterm finding it.
+	Path     string   `json:"-"`
+	Warnings []string `json:"-"`
+	Trusted  bool     `json:"-"`
+}
+
+// WorkspaceConnection accepts the same fields as a saved profile, plus a URL
+// that is parsed first and then refined by any explicit fields.
+type WorkspaceConnection struct {
+	ConnectionConfig
+	URL          string   `json:"url,omitempty"`
+	PinnedTables []string `json:"pinned_tables,omitempty"`
+}
+
+// WorkspaceQuery is a shared saved query. Connection limits it to one
+// workspace connection by name; empty means every connection.
+type WorkspaceQuery struct {
+	Name        string `json:"name"`
+	SQL         string `json:"sql"`
+	Description string `json:"description,omitempty"`
+	Connection  string `json:"connection,omitempty"`
+}
+
+// DisplayName returns the workspace name, or its directory name.
+func (w *Workspace) DisplayName() string {
+	if name := strings.TrimSpace(w.Name); name != "" {
+		return name
+	}
+	return filepath.Base(filepath.Dir(w.Path))
+}
+
+// FindWorkspace returns the nearest workspace file at or above dir, or ""
+// when there is none.
+func FindWorkspace(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, WorkspaceFileName)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("check %s: %w", candidate, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadWorkspace reads a workspace file, or the file inside a directory.
+// ${VAR} references are filled from the env file; a trusted workspace checks
+// the process environment first. An untrusted file, such as one in a freshly
+// cloned repository, must not copy the user's environment into hosts it
+// chooses. Connections that cannot be resolved are skipped with a warning.
+func LoadWorkspace(path string, trusted bool) (*Workspace, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, WorkspaceFileName)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read workspace: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	workspace := &Workspace{}
+	if err := decoder.Decode(workspace); err != nil {
+		return nil, fmt.Errorf("parse workspace %s: %w", path, err)
+	}
+	workspace.Path, workspace.Trusted = path, trusted
+	dir := filepath.Dir(path)
+
+	envFile := workspace.EnvFile
+	if envFile == "" {
+		envFile = ".env"
+	}
+	if !filepath.IsAbs(envFile) {
+		envFile = filepath.Join(dir, envFile)
+	}
+	var dotenv map[string]string
+	if trusted {
+		dotenv, err = ReadEnvFile(envFile)
+	} else if err = checkInsideDir(dir, envFile); err == nil {
+		dotenv, err = ReadEnvFile(envFile)
+	}
+	if err != nil && (workspace.EnvFile != "" || !errors.Is(err, os.ErrNotExist)) {
+		return nil, err
+	}
+	lookup := func(name string) (string, bool) {
+		if trusted {
+			if value, ok := os.LookupEnv(name); ok {
+				return value, true
+			}
+		}
+		value, ok := dotenv[name]
+		return value, ok
+	}
+
+	connections := workspace.Connections[:0]
+	seen := map[string]bool{}
+	for _, connection := range workspace.Connections {
+		name := strings.TrimSpace(connection.Name)
+		if name == "" {
+			return nil, fmt.Errorf("workspace %s: every connection needs a name", path)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("workspace %s: connection %q is defined twice", path, name)
+		}
+		seen[name] = true
+		if err := connection.resolve(dir, lookup, trusted); err != nil {
+			workspace.Warnings = append(workspace.Warnings, fmt.Sprintf("skipped %q: %v", name, err))
+			continue
+		}
+		connections = append(connections, connection)
+	}
+	workspace.Connections = connections
+	for _, query := range workspace.Queries {
+		if strings.TrimSpace(query.Name) == "" || strings.TrimSpace(query.SQL) == "" {
+			return nil, fmt.Errorf("workspace %s: every query needs a name and sql", path)
+		}
+	}
+	if library := strings.TrimSpace(workspace.QueryLibrary); library != "" && !filepath.IsAbs(library) {
+		workspace.QueryLibrary = filepath.Join(dir, library)
+	}
+	return workspace, nil
+}
+
+// checkInsideDir rejects a path that leaves dir once both are cleaned and
+// their symlinks followed. It keeps an untrusted workspace from reading
+// secrets out of arbitrary files through env_file or a symlinked .env.
+func checkInsideDir(dir, path string) error {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("resolve workspace directory: %w", err)
+	}
+	realPath, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read env file: %w", err)
+	}
+	if rel, err := filepath.Rel(realDir, realPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("env file %s is outside the workspace directory; open the workspace with --workspace to allow it", path)
+	}
+	return nil
+}
+
+// resolve expands variables, applies the URL, and validates the result.
+func (w *WorkspaceConnection) resolve(dir string, lookup func(string) (string, bool), trusted bool) error {
+	// A shared file must not be able to run commands on a teammate's machine.
+	if w.PasswordCommand != "" {
+		return fmt.Errorf("password_command is not allowed in workspace files")
+	}
+	for _, field := range []*string{&w.URL, &w.Host, &w.Port, &w.User, &w.Password, &w.Database, &w.FilePath, &w.AuthToken} {
+		expanded, err := expandReferences(*field, lookup)
+		if err != nil {
+			if !trusted {
+				return fmt.Errorf("%w in the workspace env file; open it with --workspace to also read the process environment", err)
+			}
+			return err
+		}
+		*field = expanded
+	}
+	if strings.TrimSpace(w.URL) != "" {
+		parsed, err := ParseConnectionURL(w.URL)
+		if err != nil {
+			return err
+		}
+		if w.Type != "" && w.Type != parsed.Type {
+			return fmt.Errorf("type %q does not match the %s URL", w.Type, parsed.TypeLabel())
+		}
+		overlayConnection(&parsed, w.ConnectionConfig)
+		w.ConnectionConfig = parsed
+	}
+	w.ID, w.Active, w.LastUsed = "", false, ""
+	if (w.Type == SQLite || w.Type == DuckDB) && w.FilePath != "" && w.FilePath != ":memory:" && !filepath.IsAbs(w.FilePath) {
+		w.FilePath = filepath.Join(dir, w.FilePath)
+	}
+	if w.DriverName() == "" {
+		return fmt.Errorf("unsupported database type %q", w.Type)
+	}
+	w.Environment = NormalizeEnvironment(w.Environment)
+	if err := w.ValidateEnvironment(); err != nil {
+		return err
+	}
+	return w.ValidateSession()
+}
+
+// overlayConnection copies the explicitly set fields of src onto dst.
+func overlayConnection(dst *ConnectionConfig, src ConnectionConfig) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&dst.Name, src.Name}, {&dst.Host, src.Host}, {&dst.Port, src.Port}, {&dst.User, src.User},
+		{&dst.Password, src.Password}, {&dst.Database, src.Database}, {&dst.FilePath, src.FilePath},
+		{&dst.SSLMode, src.SSLMode}, {&dst.AuthToken, src.AuthToken}, {&dst.AccountID, src.AccountID},
+		{&dst.DatabaseID, src.DatabaseID}, {&dst.Environment, src.Environment}, {&dst.Color, src.Color},
+		{&dst.InitSQL, src.InitSQL}, {&dst.StatementTimeout, src.StatementTimeout},
+		{&dst.TLSCAFile, src.TLSCAFile}, {&dst.TLSCertFile, src.TLSCertFile},
+		{&dst.TLSKeyFile, src.TLSKeyFile}, {&dst.TLSServerName, src.TLSServerName},
+	} {
+		if strings.TrimSpace(field.src) != "" {
+			*field.dst = field.src
+		}
+	}
+	dst.ReadOnly = dst.ReadOnly || src.ReadOnly
+}
+
+func expandReferences(value string, lookup func(string) (string, bool)) (string, error) {
+	var missing string
+	expanded := envReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		name := reference[2 : len(reference)-1]
+		resolved, ok := lookup(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return resolved
+	})
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %s is not set", missing)
+	}
+	return expanded, nil
+}
+
+// ReadEnvFile parses a .env file: KEY=VALUE lines with optional export
+// prefixes, quotes, and # comments. Variables are not interpolated.
+func ReadEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if index := strings.Index(value, " #"); index >= 0 {
+				value = strings.TrimSpace(value[:index])
+			}
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
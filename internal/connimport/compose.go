@@ -0,0 +1,271 @@
+package connimport
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// parseCompose turns PostgreSQL and MySQL/MariaDB services with a published
+// port into local profiles. ${VAR} references are interpolated like compose
+// does, from the environment and then dotenv; a credential that still
+// refers to an unset variable is kept as a ${VAR} reference for dbterm to
+// expand when it connects.
+func parseCompose(data []byte, dotenv map[string]string) (Result, error) {
+	document, err := parseYAML(data)
+	if err != nil {
+		return Result{}, fmt.Errorf("decode compose file: %w", err)
+	}
+	root, ok := document.(*yamlMap)
+	if !ok {
+		return Result{}, fmt.Errorf("decode compose file: expected a mapping at the top level")
+	}
+	services, ok := root.get("services").(*yamlMap)
+	if !ok {
+		return Result{}, fmt.Errorf("decode compose file: no services")
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotenv[name]
+		return value, ok
+	}
+
+	result := Result{Source: DockerCompose}
+	for _, name := range services.keys {
+		service, ok := services.get(name).(*yamlMap)
+		if !ok {
+			continue
+		}
+		image, _ := service.get("image").(string)
+		image, _ = interpolateCompose(image, lookup)
+		engine, ok := composeEngine(image)
+		if !ok {
+			continue
+		}
+		env, missing := composeEnvironment(service.get("environment"), lookup)
+
+		containerPort := "5432"
+		if engine == config.MySQL {
+			containerPort = "3306"
+		}
+		host, port := composePublishedPort(service.get("ports"), containerPort, lookup)
+		if port == "" {
+			result.warnf("skipped %q: container port %s is not published to the host", name, containerPort)
+			continue
+		}
+		cfg := config.ConnectionConfig{
+			Name:        name,
+			Type:        engine,
+			Host:        host,
+			Port:        port,
+			Environment: config.EnvironmentDevelopment,
+		}
+		if engine == config.PostgreSQL {
+			cfg.SSLMode = "disable"
+			cfg.User = nonEmpty(env["POSTGRES_USER"], "postgres")
+			cfg.Password = env["POSTGRES_PASSWORD"]
+			cfg.Database = nonEmpty(env["POSTGRES_DB"], cfg.User)
+			if env["POSTGRES_PASSWORD_FILE"] != "" {
+				result.warnf("%q reads its password from a secret file; enter it before connecting", name)
+			}
+		} else {
+			cfg.User = nonEmpty(env["MYSQL_USER"], env["MARIADB_USER"])
+			cfg.Password = nonEmpty(env["MYSQL_PASSWORD"], env["MARIADB_PASSWORD"])
+			if cfg.User == "" {
+				cfg.User = "root"
+				cfg.Password = nonEmpty(env["MYSQL_ROOT_PASSWORD"], env["MARIADB_ROOT_PASSWORD"])
+			}
+			cfg.Database = nonEmpty(env["MYSQL_DATABASE"], env["MARIADB_DATABASE"])
+		}
+		if len(missing) > 0 {
+			result.warnf("%q uses unset variable(s) %s; they are kept as ${NAME} references", name, strings.Join(missing, ", "))
+		}
+		result.finish(cfg, name, port)
+	}
+	return result, nil
+}
+
+func composeEngine(image string) (config.DBType, bool) {
+	repository, _, _ := strings.Cut(image, "@")
+	if slash := strings.LastIndex(repository, "/"); slash >= 0 {
+		repository = repository[slash+1:]
+	}
+	repository, _, _ = strings.Cut(repository, ":")
+	engine, ok := engineFor(repository)
+	if engine != config.PostgreSQL && engine != config.MySQL {
+		return "", false
+	}
+	return engine, ok
+}
+
+// composeEnvironment reads the map or KEY=VALUE list forms of environment.
+func composeEnvironment(value any, lookup func(string) (string, bool)) (map[string]string, []string) {
+	env := map[string]string{}
+	var missing []string
+	add := func(key, raw string, set bool) {
+		if !set {
+			// A bare key passes the variable through from the shell.
+			raw, set = lookup(key)
+			if !set {
+				return
+			}
+		}
+		expanded, unset := interpolateCompose(raw, lookup)
+		env[key] = expanded
+		missing = append(missing, unset...)
+	}
+	switch typed := value.(type) {
+	case *yamlMap:
+		for _, key := range typed.keys {
+			text, ok := typed.get(key).(string)
+			add(key, text, ok)
+		}
+	case []any:
+		for _, item := range typed {
+			text, _ := item.(string)
+			key, raw, set := strings.Cut(text, "=")
+			add(strings.TrimSpace(key), raw, set)
+		}
+	}
+	return env, missing
+}
+
+// composePublishedPort returns the host address and port that publish
+// containerPort, from the short "[ip:]host:container" or long syntax.
+func composePublishedPort(value any, containerPort string, lookup func(string) (string, bool)) (string, string) {
+	ports, _ := value.([]any)
+	for _, item := range ports {
+		var hostIP, published, target string
+		switch typed := item.(type) {
+		case string:
+			text, _ := interpolateCompose(typed, lookup)
+			text, _, _ = strings.Cut(text, "/")
+			parts := strings.Split(text, ":")
+			target = parts[len(parts)-1]
+			if len(parts) >= 2 {
+				published = parts[len(parts)-2]
+			}
+			if len(parts) >= 3 {
+				hostIP = strings.Join(parts[:len(parts)-2], ":")
+			}
+		case *yamlMap:
+			target, _ = typed.get("target").(string)
+			published, _ = typed.get("published").(string)
+			hostIP, _ = typed.get("host_ip").(string)
+		}
+		if target != containerPort || published == "" || strings.Contains(published, "-") {
+			continue
+		}
+		hostIP = strings.Trim(hostIP, "[]")
+		if hostIP == "" || hostIP == "0.0.0.0" || hostIP == "::" {
+			hostIP = "127.0.0.1"
+		}
+		return hostIP, published
+	}
+	return "", ""
+}
+
+// interpolateCompose expands $NAME, ${NAME}, and the ${NAME:-default},
+// ${NAME-default}, ${NAME:?error}, and ${NAME:+alternate} forms; $$ is a
+// literal dollar. Unset variables without a default stay as ${NAME}.
+func interpolateCompose(value string, lookup func(string) (string, bool)) (string, []string) {
+	var output strings.Builder
+	var missing []string
+	for index := 0; index < len(value); index++ {
+		if value[index] != '$' || index+1 == len(value) {
+			output.WriteByte(value[index])
+			continue
+		}
+		next := value[index+1]
+		switch {
+		case next == '$':
+			output.WriteByte('$')
+			index++
+		case next == '{':
+			end := strings.IndexByte(value[index:], '}')
+			if end < 0 {
+				output.WriteString(value[index:])
+				return output.String(), missing
+			}
+			expression := value[index+2 : index+end]
+			index += end
+			expanded, err := expandComposeExpression(expression, lookup)
+			if err != nil {
+				name := composeVariableName(expression)
+				missing = append(missing, name)
+				output.WriteString("${" + name + "}")
+				continue
+			}
+			output.WriteString(expanded)
+		case next == '_' || (next|0x20 >= 'a' && next|0x20 <= 'z'):
+			end := index + 1
+			for end < len(value) && (value[end] == '_' || (value[end]|0x20 >= 'a' && value[end]|0x20 <= 'z') || (value[end] >= '0' && value[end] <= '9')) {
+				end++
+			}
+			name := value[index+1 : end]
+			index = end - 1
+			if resolved, ok := lookup(name); ok {
+				output.WriteString(resolved)
+			} else {
+				missing = append(missing, name)
+				output.WriteString("${" + name + "}")
+			}
+		default:
+			output.WriteByte('$')
+		}
+	}
+	return output.String(), missing
+}
+
+var errUnsetComposeVariable = errors.New("unset variable")
+
+func expandComposeExpression(expression string, lookup func(string) (string, bool)) (string, error) {
+	name := composeVariableName(expression)
+	operator := expression[len(name):]
+	value, set := lookup(name)
+	switch {
+	case strings.HasPrefix(operator, ":-"):
+		if !set || value == "" {
+			return operator[2:], nil
+		}
+	case strings.HasPrefix(operator, "-"):
+		if !set {
+			return operator[1:], nil
+		}
+	case strings.HasPrefix(operator, ":+"):
+		if set && value != "" {
+			return operator[2:], nil
+		}
+		return "", nil
+	case strings.HasPrefix(operator, "+"):
+		if set {
+			return operator[1:], nil
+		}
+		return "", nil
+	}
+	if !set {
+		return "", errUnsetComposeVariable
+	}
+	return value, nil
+}
+
+func composeVariableName(expression string) string {
+	end := strings.IndexAny(expression, ":-+?")
+	if end < 0 {
+		return expression
+	}
+	return expression[:end]
+}
+
+func readEnvFile(path string) (map[string]string, error) {
+	env, err := config.ReadEnvFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return env, err
+}
@@ -0,0 +1,246 @@
+// Package connimport converts connection definitions saved by other database
+// tools into dbterm profiles.
+package connimport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// Source identifies the tool a connection file came from.
+type Source string
+
+const (
+	DBeaver       Source = "dbeaver"
+	PgAdmin       Source = "pgadmin"
+	TablePlus     Source = "tableplus"
+	DockerCompose Source = "docker-compose"
+)
+
+// Sources lists the supported tools in display order.
+var Sources = []Source{DBeaver, PgAdmin, TablePlus, DockerCompose}
+
+// Label returns the tool's product name.
+func (s Source) Label() string {
+	switch s {
+	case DBeaver:
+		return "DBeaver"
+	case PgAdmin:
+		return "pgAdmin"
+	case TablePlus:
+		return "TablePlus"
+	case DockerCompose:
+		return "docker-compose"
+	default:
+		return string(s)
+	}
+}
+
+// FileHint describes the file each tool's importer expects.
+func (s Source) FileHint() string {
+	switch s {
+	case DBeaver:
+		return "data-sources.json from the DBeaver workspace"
+	case PgAdmin:
+		return "servers.json from Tools > Import/Export Servers"
+	case TablePlus:
+		return "Connections.plist or an unencrypted connection export"
+	case DockerCompose:
+		return "docker-compose.yml or compose.yaml"
+	default:
+		return ""
+	}
+}
+
+// ParseSource accepts a tool name, ignoring case and common aliases.
+func ParseSource(value string) (Source, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "dbeaver":
+		return DBeaver, nil
+	case "pgadmin", "pgadmin4":
+		return PgAdmin, nil
+	case "tableplus":
+		return TablePlus, nil
+	case "docker-compose", "compose", "docker":
+		return DockerCompose, nil
+	}
+	names := make([]string, 0, len(Sources))
+	for _, source := range Sources {
+		names = append(names, string(source))
+	}
+	return "", fmt.Errorf("unknown import source %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// Result holds the converted profiles and the entries that were skipped or
+// need attention, such as passwords the source file does not contain.
+type Result struct {
+	Source      Source
+	Connections []config.ConnectionConfig
+	Warnings    []string
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// ReadFile reads and converts one tool's connection file.
+func ReadFile(source Source, path string) (Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Result{}, fmt.Errorf("read %s file: %w", source.Label(), err)
+	}
+	if source == DockerCompose {
+		// Compose interpolates ${VAR} from the .env file beside it.
+		env, err := readEnvFile(filepath.Join(filepath.Dir(path), ".env"))
+		if err != nil {
+			return Result{}, err
+		}
+		return parseCompose(data, env)
+	}
+	return Parse(source, data)
+}
+
+// Parse converts the contents of one tool's connection file.
+func Parse(source Source, data []byte) (Result, error) {
+	switch source {
+	case DBeaver:
+		return parseDBeaver(data)
+	case PgAdmin:
+		return parsePgAdmin(data)
+	case TablePlus:
+		return parseTablePlus(data)
+	case DockerCompose:
+		return parseCompose(data, nil)
+	default:
+		return Result{}, fmt.Errorf("unknown import source %q", source)
+	}
+}
+
+// DefaultPath returns the tool's usual file location on this machine, or ""
+// when it does not exist.
+func DefaultPath(source Source) string {
+	var candidates []string
+	home, _ := os.UserHomeDir()
+	switch source {
+	case DBeaver:
+		workspace := filepath.Join("DBeaverData", "workspace6", "General", ".dbeaver", "data-sources.json")
+		switch runtime.GOOS {
+		case "windows":
+			candidates = append(candidates, filepath.Join(os.Getenv("APPDATA"), workspace))
+		case "darwin":
+			candidates = append(candidates, filepath.Join(home, "Library", workspace))
+		default:
+			candidates = append(candidates, filepath.Join(home, ".local", "share", workspace))
+		}
+	case TablePlus:
+		candidates = append(candidates, filepath.Join(home, "Library", "Application Support", "com.tinyapp.TablePlus", "Data", "Connections.plist"))
+	case DockerCompose:
+		if wd, err := os.Getwd(); err == nil {
+			for _, name := range []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml"} {
+				candidates = append(candidates, filepath.Join(wd, name))
+			}
+		}
+	}
+	for _, candidate := range candidates {
+		if home == "" && !filepath.IsAbs(candidate) {
+			continue
+		}
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Plan splits imported profiles into new ones and ones already saved: the
+// same stable ID, or the same engine, endpoint, user, and database.
+func Plan(current, imported []config.ConnectionConfig) (added, skipped []config.ConnectionConfig) {
+	seen := map[string]bool{}
+	for _, existing := range current {
+		seen["id:"+existing.ID] = true
+		seen[endpointKey(existing)] = true
+	}
+	for _, candidate := range imported {
+		if seen["id:"+candidate.ID] || seen[endpointKey(candidate)] {
+			skipped = append(skipped, candidate)
+			continue
+		}
+		seen["id:"+candidate.ID] = true
+		seen[endpointKey(candidate)] = true
+		added = append(added, candidate)
+	}
+	return added, skipped
+}
+
+func endpointKey(cfg config.ConnectionConfig) string {
+	return strings.ToLower(strings.Join([]string{
+		"endpoint", string(cfg.Type), strings.TrimSpace(cfg.Host), strings.TrimSpace(cfg.Port),
+		strings.TrimSpace(cfg.User), strings.TrimSpace(cfg.Database), filepath.Clean(cfg.FilePath),
+		cfg.AccountID, cfg.DatabaseID, cfg.SSHHost,
+	}, "\x00"))
+}
+
+// stableID derives a profile ID from the source tool and the entry's own
+// identity, so importing the same file again is recognized.
+func stableID(source Source, parts ...string) string {
+	hash := sha256.Sum256([]byte(string(source) + "\x00" + strings.Join(parts, "\x00")))
+	return "import-" + string(source) + "-" + hex.EncodeToString(hash[:8])
+}
+
+// finish fills defaults shared by every importer and validates the result.
+func (r *Result) finish(cfg config.ConnectionConfig, sourceKey ...string) {
+	cfg.Name = strings.TrimSpace(cfg.Name)
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSpace(cfg.Host + " " + cfg.Database)
+	}
+	if cfg.Type == config.PostgreSQL || cfg.Type == config.MySQL {
+		if cfg.Host == "" {
+			cfg.Host = "localhost"
+		}
+		if cfg.Port == "" {
+			cfg.Port = "5432"
+			if cfg.Type == config.MySQL {
+				cfg.Port = "3306"
+			}
+		}
+	}
+	if cfg.Type == config.PostgreSQL && cfg.SSLMode == "" {
+		cfg.SSLMode = "prefer"
+	}
+	if (cfg.Type == config.SQLite || cfg.Type == config.DuckDB) && strings.TrimSpace(cfg.FilePath) == "" {
+		r.warnf("skipped %q: no database file path", cfg.Name)
+		return
+	}
+	cfg.Environment = config.NormalizeEnvironment(cfg.Environment)
+	if err := cfg.ValidateEnvironment(); err != nil {
+		cfg.Environment, cfg.Color = "", ""
+	}
+	cfg.ID = stableID(r.Source, sourceKey...)
+	r.Connections = append(r.Connections, cfg)
+}
+
+// engineFor maps a tool's driver or provider name to a dbterm engine.
+func engineFor(name string) (config.DBType, bool) {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "postgres"), strings.Contains(name, "redshift"), strings.Contains(name, "cockroach"),
+		strings.Contains(name, "timescale"), strings.Contains(name, "postgis"), name == "pg", name == "pgsql":
+		return config.PostgreSQL, true
+	case strings.Contains(name, "mysql"), strings.Contains(name, "maria"), strings.Contains(name, "percona"), strings.Contains(name, "tidb"):
+		return config.MySQL, true
+	case strings.Contains(name, "sqlite"):
+		return config.SQLite, true
+	case strings.Contains(name, "duckdb"):
+		return config.DuckDB, true
+	case strings.Contains(name, "libsql"), strings.Contains(name, "turso"):
+		return config.Turso, true
+	}
+	return "", false
+}
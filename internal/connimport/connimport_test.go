@@ -0,0 +1,236 @@
+package connimport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestParseDBeaverDataSources(t *testing.T) {
+	data := `{
+  "folders": {},
+  "connections": {
+    "postgres-jdbc-18a": {
+      "provider": "postgresql", "driver": "postgres-jdbc", "name": "Orders prod",
+      "configuration": {
+        "host": "db.example.com", "port": 6432, "database": "orders", "user": "reporter", "type": "prod",
+        "handlers": {
+          "ssh_tunnel": {"type": "TUNNEL", "enabled": true, "properties": {"host": "bastion", "port": 22, "keyPath": "~/.ssh/id_ed25519"}},
+          "postgre_ssl": {"type": "CONFIG", "enabled": true, "properties": {"sslMode": "verify-full"}}
+        }
+      }
+    },
+    "mariaDB-19b": {
+      "provider": "mysql", "driver": "mariaDB", "name": "Legacy",
+      "configuration": {"url": "jdbc:mariadb://legacy.internal:3307/shop"}
+    },
+    "sqlite_jdbc-1c": {
+      "provider": "generic", "driver": "sqlite_jdbc", "name": "Fixtures",
+      "configuration": {"url": "jdbc:sqlite:/srv/app/fixtures.db"}
+    },
+    "oracle_thin-1d": {"provider": "oracle", "driver": "oracle_thin", "name": "ERP", "configuration": {}}
+  }
+}`
+	result, err := Parse(DBeaver, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Connections) != 3 {
+		t.Fatalf("connections = %+v, warnings = %q", result.Connections, result.Warnings)
+	}
+	legacy, oracleWarning := result.Connections[0], false
+	if legacy.Type != config.MySQL || legacy.Host != "legacy.internal" || legacy.Port != "3307" || legacy.Database != "shop" {
+		t.Fatalf("legacy = %+v", legacy)
+	}
+	orders := result.Connections[1]
+	if orders.Type != config.PostgreSQL || orders.Port != "6432" || orders.User != "reporter" || orders.SSLMode != "verify-full" ||
+		orders.SSHHost != "bastion" || orders.SSHPort != "22" || orders.Environment != config.EnvironmentProduction {
+		t.Fatalf("orders = %+v", orders)
+	}
+	if fixtures := result.Connections[2]; fixtures.Type != config.SQLite || fixtures.FilePath != "/srv/app/fixtures.db" {
+		t.Fatalf("fixtures = %+v", fixtures)
+	}
+	for _, warning := range result.Warnings {
+		oracleWarning = oracleWarning || strings.Contains(warning, "ERP")
+	}
+	if !oracleWarning {
+		t.Fatalf("warnings = %q", result.Warnings)
+	}
+
+	again, err := Parse(DBeaver, []byte(data))
+	if err != nil || again.Connections[1].ID != orders.ID || !strings.HasPrefix(orders.ID, "import-dbeaver-") {
+		t.Fatalf("IDs are not stable: %q vs %q (%v)", orders.ID, again.Connections[1].ID, err)
+	}
+}
+
+func TestParsePgAdminServers(t *testing.T) {
+	data := `{"Servers": {
+  "2": {"Name": "Replica", "Group": "Prod", "Host": "replica.example.com", "Port": 5432, "MaintenanceDB": "postgres",
+        "Username": "ro", "ConnectionParameters": {"sslmode": "require", "passfile": "/home/me/.pgpass-prod"}},
+  "1": {"Name": "Local", "Group": "Servers", "Host": "localhost", "Port": "5433", "MaintenanceDB": "app", "Username": "app",
+        "SSLMode": "disable", "UseSSHTunnel": 0, "TunnelHost": "ignored"}
+}}`
+	result, err := Parse(PgAdmin, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Connections) != 2 || result.Connections[0].Name != "Local" {
+		t.Fatalf("connections = %+v", result.Connections)
+	}
+	local, replica := result.Connections[0], result.Connections[1]
+	if local.Port != "5433" || local.SSLMode != "disable" || local.SSHHost != "" || local.Database != "app" {
+		t.Fatalf("local = %+v", local)
+	}
+	if replica.SSLMode != "require" || replica.User != "ro" {
+		t.Fatalf("replica = %+v", replica)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "pgpass-prod") {
+		t.Fatalf("warnings = %q", result.Warnings)
+	}
+}
+
+func TestParseTablePlusPlist(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<array>
+  <dict>
+    <key>ID</key><string>6F1C2A</string>
+    <key>ConnectionName</key><string>Staging API</string>
+    <key>Driver</key><string>PostgreSQL</string>
+    <key>DatabaseHost</key><string>staging.db</string>
+    <key>DatabasePort</key><string>5432</string>
+    <key>DatabaseUser</key><string>api</string>
+    <key>DatabaseName</key><string>api</string>
+    <key>Enviroment</key><string>staging</string>
+    <key>isOverSSH</key><true/>
+    <key>ServerAddress</key><string>jump.example.com</string>
+    <key>ServerUser</key><string>deploy</string>
+  </dict>
+  <dict>
+    <key>ConnectionName</key><string>Cache</string>
+    <key>Driver</key><string>Redis</string>
+  </dict>
+</array>
+</plist>`
+	result, err := Parse(TablePlus, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Connections) != 1 {
+		t.Fatalf("connections = %+v", result.Connections)
+	}
+	staging := result.Connections[0]
+	if staging.Name != "Staging API" || staging.Host != "staging.db" || staging.Environment != config.EnvironmentStaging ||
+		staging.SSHHost != "jump.example.com" || staging.SSHUser != "deploy" {
+		t.Fatalf("staging = %+v", staging)
+	}
+	if _, err := Parse(TablePlus, []byte("bplist00...")); err == nil || !strings.Contains(err.Error(), "plutil") {
+		t.Fatalf("binary plist error = %v", err)
+	}
+}
+
+func TestReadComposeFile(t *testing.T) {
+	dir := t.TempDir()
+	compose := `x-db-env: &db-env
+  POSTGRES_USER: app
+  POSTGRES_DB: orders
+
+services:
+  db:
+    image: "postgres:16-alpine"   # primary
+    environment:
+      <<: *db-env
+      POSTGRES_PASSWORD: ${DB_PASSWORD}
+    ports:
+      - "127.0.0.1:${DB_PORT:-5433}:5432"
+  mysql:
+    image: docker.io/library/mariadb:11
+    environment:
+      - MARIADB_ROOT_PASSWORD=$$ecret
+      - MARIADB_DATABASE=shop
+    ports:
+      - target: 3306
+        published: 3307
+  internal:
+    image: postgres
+    expose: ["5432"]
+  cache:
+    image: redis:7
+    ports: ["6379:6379"]
+`
+	path := filepath.Join(dir, "compose.yaml")
+	if err := os.WriteFile(path, []byte(compose), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("DB_PASSWORD=from-dotenv\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err := ReadFile(DockerCompose, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Connections) != 2 {
+		t.Fatalf("connections = %+v, warnings = %q", result.Connections, result.Warnings)
+	}
+	db, mysql := result.Connections[0], result.Connections[1]
+	if db.Type != config.PostgreSQL || db.Host != "127.0.0.1" || db.Port != "5433" || db.User != "app" ||
+		db.Password != "from-dotenv" || db.Database != "orders" || db.SSLMode != "disable" {
+		t.Fatalf("db = %+v", db)
+	}
+	if mysql.Type != config.MySQL || mysql.Port != "3307" || mysql.User != "root" || mysql.Password != "$ecret" || mysql.Database != "shop" {
+		t.Fatalf("mysql = %+v", mysql)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], `"internal"`) {
+		t.Fatalf("warnings = %q", result.Warnings)
+	}
+}
+
+func TestParseComposeHandlesFullYAML(t *testing.T) {
+	compose := `x-base: &base {POSTGRES_USER: app}
+x-db: &db {POSTGRES_DB: orders, POSTGRES_USER: ignored}
+services:
+  db: {
+    image: postgres,
+    environment: {<<: [*base, *db], POSTGRES_PASSWORD: 0123},
+    ports: [
+      "5434:5432",
+    ],
+  }
+`
+	result, err := Parse(DockerCompose, []byte(compose))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Connections) != 1 {
+		t.Fatalf("connections = %+v, warnings = %q", result.Connections, result.Warnings)
+	}
+	if db := result.Connections[0]; db.Port != "5434" || db.User != "app" || db.Database != "orders" || db.Password != "0123" {
+		t.Fatalf("db = %+v", db)
+	}
+
+	for _, broken := range []string{"services: [db\n", "services: &loop\n  db: *loop\n"} {
+		if _, err := Parse(DockerCompose, []byte(broken)); err == nil || !strings.Contains(err.Error(), "line ") {
+			t.Fatalf("Parse(%q) error = %v", broken, err)
+		}
+	}
+}
+
+func TestPlanSkipsAlreadyImportedProfiles(t *testing.T) {
+	imported := []config.ConnectionConfig{
+		{ID: "import-pgadmin-1", Name: "A", Type: config.PostgreSQL, Host: "a", Port: "5432", User: "u"},
+		{ID: "import-pgadmin-2", Name: "B", Type: config.PostgreSQL, Host: "b", Port: "5432", User: "u"},
+		{ID: "import-pgadmin-3", Name: "C", Type: config.PostgreSQL, Host: "c", Port: "5432", User: "u"},
+	}
+	current := []config.ConnectionConfig{
+		{ID: "import-pgadmin-1", Name: "Renamed", Type: config.PostgreSQL, Host: "changed"},
+		{ID: "abc", Name: "Typed by hand", Type: config.PostgreSQL, Host: "B", Port: "5432", User: "u", Password: "secret"},
+	}
+	added, skipped := Plan(current, imported)
+	if len(added) != 1 || added[0].Name != "C" || len(skipped) != 2 {
+		t.Fatalf("added = %+v, skipped = %+v", added, skipped)
+	}
+}
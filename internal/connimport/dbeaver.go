@@ -0,0 +1,190 @@
+package connimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// flexString accepts JSON strings, numbers, and booleans, which the tools
+// use interchangeably for ports and flags.
+type flexString string
+
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*f = flexString(text)
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil {
+		*f = ""
+		return nil
+	}
+	*f = flexString(fmt.Sprint(value))
+	return nil
+}
+
+func (f flexString) String() string {
+	return strings.TrimSpace(string(f))
+}
+
+func (f flexString) Bool() bool {
+	switch strings.ToLower(f.String()) {
+	case "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+type dbeaverFile struct {
+	Connections map[string]dbeaverConnection `json:"connections"`
+}
+
+type dbeaverConnection struct {
+	Provider      string        `json:"provider"`
+	Driver        string        `json:"driver"`
+	Name          string        `json:"name"`
+	ReadOnly      flexString    `json:"read-only"`
+	Configuration dbeaverConfig `json:"configuration"`
+}
+
+type dbeaverConfig struct {
+	Host     flexString                `json:"host"`
+	Port     flexString                `json:"port"`
+	Database flexString                `json:"database"`
+	URL      flexString                `json:"url"`
+	User     flexString                `json:"user"`
+	Type     flexString                `json:"type"`
+	ReadOnly flexString                `json:"read-only"`
+	Handlers map[string]dbeaverHandler `json:"handlers"`
+}
+
+type dbeaverHandler struct {
+	Type       string                `json:"type"`
+	Enabled    flexString            `json:"enabled"`
+	Properties map[string]flexString `json:"properties"`
+}
+
+// parseDBeaver reads data-sources.json. DBeaver keeps passwords in a
+// separate encrypted credentials file, so profiles arrive without them.
+func parseDBeaver(data []byte) (Result, error) {
+	var file dbeaverFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Result{}, fmt.Errorf("decode DBeaver data-sources.json: %w", err)
+	}
+	if file.Connections == nil {
+		return Result{}, fmt.Errorf("decode DBeaver data-sources.json: no \"connections\" object")
+	}
+	result := Result{Source: DBeaver}
+	ids := make([]string, 0, len(file.Connections))
+	for id := range file.Connections {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		connection := file.Connections[id]
+		name := strings.TrimSpace(connection.Name)
+		if name == "" {
+			name = id
+		}
+		engine, ok := engineFor(connection.Provider + " " + connection.Driver)
+		if !ok {
+			result.warnf("skipped %q: unsupported %s driver", name, nonEmpty(connection.Driver, connection.Provider))
+			continue
+		}
+		settings := connection.Configuration
+		cfg := config.ConnectionConfig{
+			Name:        name,
+			Type:        engine,
+			Host:        settings.Host.String(),
+			Port:        settings.Port.String(),
+			User:        settings.User.String(),
+			Database:    settings.Database.String(),
+			Environment: settings.Type.String(),
+			ReadOnly:    connection.ReadOnly.Bool() || settings.ReadOnly.Bool(),
+		}
+		if err := applyJDBCURL(&cfg, settings.URL.String()); err != nil {
+			result.warnf("skipped %q: %v", name, err)
+			continue
+		}
+		if cfg.Type == config.SQLite || cfg.Type == config.DuckDB {
+			cfg.FilePath = nonEmpty(cfg.FilePath, cfg.Database)
+			cfg.Host, cfg.Port, cfg.User, cfg.Database = "", "", "", ""
+		}
+		for handlerID, handler := range connection.Configuration.Handlers {
+			if !handler.Enabled.Bool() {
+				continue
+			}
+			properties := handler.Properties
+			if handlerID == "ssh_tunnel" || strings.EqualFold(handler.Type, "TUNNEL") {
+				cfg.SSHHost = properties["host"].String()
+				cfg.SSHPort = properties["port"].String()
+				cfg.SSHUser = properties["user"].String()
+				cfg.SSHKeyFile = properties["keyPath"].String()
+			}
+			if mode := nonEmpty(properties["sslMode"].String(), properties["ssl.mode"].String()); mode != "" {
+				cfg.SSLMode = strings.ToLower(mode)
+			}
+		}
+		if cfg.Type == config.PostgreSQL || cfg.Type == config.MySQL {
+			result.warnf("%q has no password; DBeaver stores it in its encrypted credentials file", name)
+		}
+		result.finish(cfg, id)
+	}
+	return result, nil
+}
+
+// applyJDBCURL fills fields the configuration left empty from a JDBC URL.
+func applyJDBCURL(cfg *config.ConnectionConfig, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	lower := strings.ToLower(raw)
+	var converted string
+	switch {
+	case strings.HasPrefix(lower, "jdbc:sqlite:"), strings.HasPrefix(lower, "jdbc:duckdb:"):
+		_, path, _ := strings.Cut(raw[len("jdbc:"):], ":")
+		if cfg.FilePath == "" && cfg.Database == "" {
+			cfg.FilePath = path
+		}
+		return nil
+	case strings.HasPrefix(lower, "jdbc:postgresql://"):
+		converted = "postgres://" + raw[len("jdbc:postgresql://"):]
+	case strings.HasPrefix(lower, "jdbc:mysql://"):
+		converted = "mysql://" + raw[len("jdbc:mysql://"):]
+	case strings.HasPrefix(lower, "jdbc:mariadb://"):
+		converted = "mysql://" + raw[len("jdbc:mariadb://"):]
+	default:
+		return nil
+	}
+	if cfg.Host != "" {
+		return nil
+	}
+	parsed, err := config.ParseConnectionURL(converted)
+	if err != nil {
+		return fmt.Errorf("unreadable JDBC URL: %w", err)
+	}
+	cfg.Host = parsed.Host
+	cfg.Port = nonEmpty(cfg.Port, parsed.Port)
+	cfg.User = nonEmpty(cfg.User, parsed.User)
+	cfg.Database = nonEmpty(cfg.Database, parsed.Database)
+	cfg.SSLMode = nonEmpty(cfg.SSLMode, parsed.SSLMode)
+	return nil
+}
+
+func nonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package connimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+type pgAdminFile struct {
+	Servers map[string]pgAdminServer `json:"Servers"`
+}
+
+type pgAdminServer struct {
+	Name                 string                `json:"Name"`
+	Group                string                `json:"Group"`
+	Host                 flexString            `json:"Host"`
+	HostAddr             flexString            `json:"HostAddr"`
+	Port                 flexString            `json:"Port"`
+	MaintenanceDB        flexString            `json:"MaintenanceDB"`
+	Username             flexString            `json:"Username"`
+	SSLMode              flexString            `json:"SSLMode"`
+	SSLRootCert          flexString            `json:"SSLRootCert"`
+	SSLCert              flexString            `json:"SSLCert"`
+	SSLKey               flexString            `json:"SSLKey"`
+	Service              flexString            `json:"Service"`
+	UseSSHTunnel         flexString            `json:"UseSSHTunnel"`
+	TunnelHost           flexString            `json:"TunnelHost"`
+	TunnelPort           flexString            `json:"TunnelPort"`
+	TunnelUsername       flexString            `json:"TunnelUsername"`
+	TunnelIdentityFile   flexString            `json:"TunnelIdentityFile"`
+	ConnectionParameters map[string]flexString `json:"ConnectionParameters"`
+}
+
+// parsePgAdmin reads a servers.json export. pgAdmin never exports
+// passwords; profiles fall back to ~/.pgpass when connecting.
+func parsePgAdmin(data []byte) (Result, error) {
+	var file pgAdminFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Result{}, fmt.Errorf("decode pgAdmin servers.json: %w", err)
+	}
+	if file.Servers == nil {
+		return Result{}, fmt.Errorf("decode pgAdmin servers.json: no \"Servers\" object")
+	}
+	keys := make([]string, 0, len(file.Servers))
+	for key := range file.Servers {
+		keys = append(keys, key)
+	}
+	// Keys are "1", "2", ...; keep the export's numeric order.
+	sort.Slice(keys, func(i, j int) bool {
+		left, leftErr := strconv.Atoi(keys[i])
+		right, rightErr := strconv.Atoi(keys[j])
+		if leftErr == nil && rightErr == nil {
+			return left < right
+		}
+		return keys[i] < keys[j]
+	})
+	result := Result{Source: PgAdmin}
+	for _, key := range keys {
+		server := file.Servers[key]
+		name := nonEmpty(server.Name, server.Host.String(), "pgAdmin server "+key)
+		if server.Service.String() != "" && server.Host.String() == "" {
+			result.warnf("skipped %q: pg_service.conf services are not supported", name)
+			continue
+		}
+		parameters := server.ConnectionParameters
+		cfg := config.ConnectionConfig{
+			Name:        name,
+			Type:        config.PostgreSQL,
+			Host:        nonEmpty(server.Host.String(), server.HostAddr.String()),
+			Port:        server.Port.String(),
+			User:        server.Username.String(),
+			Database:    server.MaintenanceDB.String(),
+			SSLMode:     nonEmpty(parameters["sslmode"].String(), server.SSLMode.String()),
+			TLSCAFile:   nonEmpty(parameters["sslrootcert"].String(), server.SSLRootCert.String()),
+			TLSCertFile: nonEmpty(parameters["sslcert"].String(), server.SSLCert.String()),
+			TLSKeyFile:  nonEmpty(parameters["sslkey"].String(), server.SSLKey.String()),
+		}
+		if server.UseSSHTunnel.Bool() {
+			cfg.SSHHost = server.TunnelHost.String()
+			cfg.SSHPort = server.TunnelPort.String()
+			cfg.SSHUser = server.TunnelUsername.String()
+			cfg.SSHKeyFile = server.TunnelIdentityFile.String()
+		}
+		if passfile := parameters["passfile"].String(); passfile != "" {
+			result.warnf("%q used passfile %s; set PGPASSFILE or copy its entry to ~/.pgpass", name, passfile)
+		}
+		result.finish(cfg, server.Group, name, cfg.Host, cfg.Port)
+	}
+	return result, nil
+}
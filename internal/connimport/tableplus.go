@@ -0,0 +1,183 @@
+package connimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// parseTablePlus reads TablePlus's Connections.plist (XML) or a JSON array
+// of the same records. Passwords live in the macOS Keychain and are not
+// included.
+func parseTablePlus(data []byte) (Result, error) {
+	trimmed := bytes.TrimSpace(data)
+	var records []map[string]any
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("bplist")):
+		return Result{}, fmt.Errorf("binary plist; convert it first with: plutil -convert xml1 -o connections.plist Connections.plist")
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return Result{}, fmt.Errorf("decode TablePlus export: %w", err)
+		}
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		value, err := parsePlist(trimmed)
+		if err != nil {
+			return Result{}, fmt.Errorf("decode TablePlus plist: %w", err)
+		}
+		list, ok := value.([]any)
+		if !ok {
+			return Result{}, fmt.Errorf("decode TablePlus plist: expected an array of connections")
+		}
+		for _, item := range list {
+			if record, ok := item.(map[string]any); ok {
+				records = append(records, record)
+			}
+		}
+	default:
+		return Result{}, fmt.Errorf("unrecognized TablePlus file; encrypted .tableplusconnection exports must be exported again without a password")
+	}
+
+	result := Result{Source: TablePlus}
+	for index, record := range records {
+		field := func(keys ...string) string {
+			for _, key := range keys {
+				if value, ok := record[key]; ok && value != nil {
+					if text := strings.TrimSpace(fmt.Sprint(value)); text != "" {
+						return text
+					}
+				}
+			}
+			return ""
+		}
+		name := nonEmpty(field("ConnectionName", "name"), fmt.Sprintf("TablePlus connection %d", index+1))
+		driver := field("Driver", "driver")
+		engine, ok := engineFor(driver)
+		if !ok {
+			result.warnf("skipped %q: unsupported %s driver", name, nonEmpty(driver, "unknown"))
+			continue
+		}
+		cfg := config.ConnectionConfig{
+			Name:        name,
+			Type:        engine,
+			Host:        field("DatabaseHost", "host"),
+			Port:        field("DatabasePort", "port"),
+			User:        field("DatabaseUser", "user"),
+			Database:    field("DatabaseName", "database"),
+			FilePath:    field("DatabasePath", "path"),
+			Environment: tablePlusEnvironment(field("Enviroment", "Environment", "environment")),
+		}
+		if cfg.Type == config.Turso {
+			cfg.Host = nonEmpty(field("DatabaseURL", "url"), cfg.Host)
+		}
+		if overSSH := strings.ToLower(field("isOverSSH", "OverSSH")); overSSH == "true" || overSSH == "1" {
+			cfg.SSHHost = field("ServerAddress")
+			cfg.SSHPort = field("ServerPort")
+			cfg.SSHUser = field("ServerUser")
+			cfg.SSHKeyFile = field("ServerPrivateKeyName", "ServerPrivateKeyPath")
+		}
+		if cfg.Type != config.SQLite && cfg.Type != config.DuckDB {
+			result.warnf("%q has no password; TablePlus keeps it in the macOS Keychain", name)
+		}
+		result.finish(cfg, nonEmpty(field("ID", "id"), name+"\x00"+cfg.Host+"\x00"+cfg.Port))
+	}
+	return result, nil
+}
+
+func tablePlusEnvironment(value string) string {
+	switch strings.ToLower(value) {
+	case "local", "development":
+		return config.EnvironmentDevelopment
+	case "testing":
+		return "test"
+	case "staging":
+		return config.EnvironmentStaging
+	case "production":
+		return config.EnvironmentProduction
+	}
+	return ""
+}
+
+// parsePlist decodes an XML property list into maps, slices, strings, and
+// booleans. Numbers and dates stay strings.
+func parsePlist(data []byte) (any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("empty property list")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local == "plist" {
+				continue
+			}
+			return decodePlistValue(decoder, start)
+		}
+	}
+}
+
+func decodePlistValue(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		result := map[string]any{}
+		key := ""
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch element := token.(type) {
+			case xml.StartElement:
+				if element.Name.Local == "key" {
+					if err := decoder.DecodeElement(&key, &element); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				value, err := decodePlistValue(decoder, element)
+				if err != nil {
+					return nil, err
+				}
+				result[key] = value
+			case xml.EndElement:
+				return result, nil
+			}
+		}
+	case "array":
+		var result []any
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch element := token.(type) {
+			case xml.StartElement:
+				value, err := decodePlistValue(decoder, element)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+			case xml.EndElement:
+				return result, nil
+			}
+		}
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	default:
+		var text string
+		if err := decoder.DecodeElement(&text, &start); err != nil {
+			return nil, err
+		}
+		return text, nil
+	}
+}
@@ -0,0 +1,135 @@
+package connimport
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlMap keeps keys in document order so imported services stay in the
+// order they were written.
+type yamlMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *yamlMap) get(key string) any {
+	if m == nil {
+		return nil
+	}
+	return m.values[key]
+}
+
+func (m *yamlMap) set(key string, value any) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func newYAMLMap() *yamlMap {
+	return &yamlMap{values: map[string]any{}}
+}
+
+// parseYAML decodes the first document into *yamlMap, []any, string, and
+// nil values. Scalars keep their source text, so a port or password written
+// as 5432 stays "5432" the way compose reads it. Aliases and << merge keys
+// are expanded.
+func parseYAML(data []byte) (any, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil, nil
+	}
+	converter := yamlConverter{aliases: map[*yaml.Node]any{}}
+	return converter.convert(document.Content[0])
+}
+
+// errYAMLAliasCycle marks an anchor whose value is still being converted.
+var errYAMLAliasCycle = errors.New("alias cycle")
+
+// yamlConverter shares the value of each anchored node between its aliases,
+// so a document that repeats aliases cannot expand exponentially.
+type yamlConverter struct {
+	aliases map[*yaml.Node]any
+}
+
+func (c *yamlConverter) convert(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		if value, ok := c.aliases[node.Alias]; ok {
+			if value == errYAMLAliasCycle {
+				return nil, fmt.Errorf("line %d: alias *%s refers to itself", node.Line, node.Value)
+			}
+			return value, nil
+		}
+		c.aliases[node.Alias] = errYAMLAliasCycle
+		value, err := c.convert(node.Alias)
+		if err != nil {
+			return nil, err
+		}
+		c.aliases[node.Alias] = value
+		return value, nil
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!null" {
+			return nil, nil
+		}
+		return node.Value, nil
+	case yaml.SequenceNode:
+		items := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			item, err := c.convert(child)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case yaml.MappingNode:
+		return c.convertMapping(node)
+	}
+	return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+}
+
+// convertMapping applies << merges after the mapping's own keys, which
+// always win, with earlier merge sources taking precedence over later ones.
+func (c *yamlConverter) convertMapping(node *yaml.Node) (*yamlMap, error) {
+	mapping := newYAMLMap()
+	var merged []*yamlMap
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		key, value := node.Content[index], node.Content[index+1]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+		}
+		converted, err := c.convert(value)
+		if err != nil {
+			return nil, err
+		}
+		if key.ShortTag() != "!!merge" {
+			mapping.set(key.Value, converted)
+			continue
+		}
+		sources := []any{converted}
+		if list, ok := converted.([]any); ok {
+			sources = list
+		}
+		for _, source := range sources {
+			sourceMap, ok := source.(*yamlMap)
+			if !ok {
+				return nil, fmt.Errorf("line %d: << must merge a mapping or a list of mappings", key.Line)
+			}
+			merged = append(merged, sourceMap)
+		}
+	}
+	for _, source := range merged {
+		for _, key := range source.keys {
+			if _, exists := mapping.values[key]; !exists {
+				mapping.set(key, source.values[key])
+			}
+		}
+	}
+	return mapping, nil
+}
@@ -16,7 +16,9 @@ import (
 	"io"
 	"math"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/peterheb/cfd1"
 )
@@ -376,17 +378,57 @@ func normalizeDriverValue(value any) (driver.Value, error) {
 	}
 }
 
+// namedValues orders arguments by ordinal and converts them to the JSON
+// values D1 binds the way SQLite stores them: booleans as 1 or 0, times as
+// UTC text, and integers beyond JSON's exact range as text, which SQLite's
+// column affinity turns back into integers.
 func namedValues(values []driver.NamedValue) ([]any, error) {
 	result := make([]any, len(values))
-	for index, value := range values {
+	for _, value := range values {
 		if value.Name != "" {
 			return nil, fmt.Errorf("Cloudflare D1 named parameters are not supported; use positional ? placeholders")
 		}
-		result[index] = value.Value
+		if value.Ordinal < 1 || value.Ordinal > len(values) {
+			return nil, fmt.Errorf("Cloudflare D1 parameter ordinal %d is out of range", value.Ordinal)
+		}
+		param, err := d1Param(value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Cloudflare D1 parameter %d: %w", value.Ordinal, err)
+		}
+		result[value.Ordinal-1] = param
 	}
 	return result, nil
 }
 
+// maxExactJSONInteger is the largest integer a JSON number carries exactly.
+const maxExactJSONInteger = 1<<53 - 1
+
+func d1Param(value driver.Value) (any, error) {
+	switch typed := value.(type) {
+	case bool:
+		if typed {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case int64:
+		if typed > maxExactJSONInteger || typed < -maxExactJSONInteger {
+			return strconv.FormatInt(typed, 10), nil
+		}
+		return typed, nil
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			return nil, fmt.Errorf("%v cannot be sent to D1", typed)
+		}
+		return typed, nil
+	case time.Time:
+		return typed.UTC().Format("2006-01-02 15:04:05.999999999"), nil
+	case []byte:
+		return nil, fmt.Errorf("blob values cannot be sent to D1")
+	default:
+		return typed, nil
+	}
+}
+
 func valuesToNamed(values []driver.Value) []driver.NamedValue {
 	result := make([]driver.NamedValue, len(values))
 	for index, value := range values {
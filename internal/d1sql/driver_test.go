@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/peterheb/cfd1"
 )
@@ -98,6 +99,27 @@ func TestNormalizeDriverValueBoundsIntegers(t *testing.T) {
 	}
 }
 
+func TestExecContextBindsTypedArgumentsInOrdinalOrder(t *testing.T) {
+	client := &fakeRawClient{sets: []cfd1.RawQueryResult{rawSet([]string{"id"})}}
+	db := sql.OpenDB(fixedConnector{connection: &connection{client: client, databaseID: "database-uuid"}})
+	defer db.Close()
+
+	at := time.Date(2026, 3, 1, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	if _, err := db.Exec("UPDATE t SET a = ?, b = ?, c = ?, d = ?, e = ?", true, int64(1)<<60, at, nil, "x"); err != nil {
+		t.Fatal(err)
+	}
+	want := []any{int64(1), "1152921504606846976", "2026-03-01 09:30:00", nil, "x"}
+	if !reflect.DeepEqual(client.params, want) {
+		t.Fatalf("bound params = %#v, want %#v", client.params, want)
+	}
+	if _, err := db.Exec("SELECT ?", []byte{1}); err == nil {
+		t.Fatal("a blob argument was sent to D1")
+	}
+	if _, err := db.Exec("SELECT :id", sql.Named("id", 1)); err == nil || !strings.Contains(err.Error(), "named parameters") {
+		t.Fatalf("named argument error = %v", err)
+	}
+}
+
 func TestConnectionUsesRawQueryForEmptyOrderedResults(t *testing.T) {
 	client := &fakeRawClient{sets: []cfd1.RawQueryResult{rawSet([]string{"first", "second"})}}
 	connection := &connection{client: client, databaseID: "database-uuid", authToken: "private"}
@@ -15,16 +15,9 @@ import (
 
 // Connect opens a database connection, verifies it, and configures a small pool.
 func Connect(cfg *config.ConnectionConfig) (*sql.DB, error) {
-	driver := cfg.DriverName()
-	connStr := cfg.BuildConnString()
-
-	if driver == "" || connStr == "" {
-		return nil, fmt.Errorf("unsupported database type: %q — supported: postgresql, mysql, sqlite, turso, d1", cfg.Type)
-	}
-
-	db, err := sql.Open(driver, connStr)
+	db, err := Open(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("could not open %s connection: %w", cfg.TypeLabel(), err)
+		return nil, err
 	}
 
 	db.SetMaxOpenConns(2)
@@ -36,8 +29,54 @@ func Connect(cfg *config.ConnectionConfig) (*sql.DB, error) {
 	defer cancel()
 	if err := db.PingContext(pingCtx); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("could not reach %s at %s: %w", cfg.TypeLabel(), cfg.Host, err)
+		if cfg.UsesSSHTunnel() {
+			return nil, fmt.Errorf("could not reach %s at %s via SSH %s: %w", cfg.TypeLabel(), cfg.Host, cfg.SSHHost, err)
+		}
+		target := cfg.Host
+		if target == "" {
+			target = cfg.FilePath
+		}
+		return nil, fmt.Errorf("could not reach %s at %s: %w", cfg.TypeLabel(), target, err)
 	}
 
 	return db, nil
 }
+
+// Open prepares a pool without verifying it, resolving indirect credentials
+// and starting the profile's SSH tunnel first when one is configured. Callers
+// own the returned pool.
+func Open(cfg *config.ConnectionConfig) (*sql.DB, error) {
+	resolved, err := cfg.ResolveCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve %s credentials: %w", cfg.TypeLabel(), err)
+	}
+	cfg = resolved
+	driver := cfg.DriverName()
+	connStr := cfg.BuildConnString()
+
+	if driver == "" || connStr == "" {
+		return nil, fmt.Errorf("unsupported database type: %q — supported: postgresql, mysql, sqlite, turso, d1, duckdb", cfg.Type)
+	}
+
+	if cfg.UsesSSHTunnel() {
+		db, err := openTunneled(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s connection: %w", cfg.TypeLabel(), err)
+		}
+		return db, nil
+	}
+
+	setup := sessionSetup(cfg)
+	if len(setup) == 0 {
+		db, err := sql.Open(driver, connStr)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s connection: %w", cfg.TypeLabel(), err)
+		}
+		return db, nil
+	}
+	connector, err := openConnector(driver, connStr)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s connection: %w", cfg.TypeLabel(), err)
+	}
+	return sql.OpenDB(&sessionConnector{Connector: connector, setup: setup}), nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// QueryParams lists the placeholders of an editor buffer in order of first
+// appearance: ":name", "$1", and "?1". Anonymous ? placeholders are numbered
+// the way SQLite numbers them, one past the largest number so far, so every
+// ? of a script gets its own value. PostgreSQL has no ? placeholders: ? is a
+// jsonb operator there. Placeholders inside quotes, comments, and dollar
+// quotes are ignored, as are :: casts and := assignments.
+func QueryParams(dbType config.DBType, query string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	scanParams(dbType, query, 0, func(_, _ int, name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	})
+	return names
+}
+
+// ParamBinder rewrites the placeholders of each statement into the engine's
+// own positional form and returns the values as driver arguments, so values
+// are never spliced into SQL text. Statements of one script must be bound in
+// order through the same binder to keep anonymous ? numbering consistent
+// with QueryParams.
+type ParamBinder struct {
+	dbType    config.DBType
+	values    map[string]any
+	anonymous int
+}
+
+// NewParamBinder binds values keyed by the names QueryParams returns.
+func NewParamBinder(dbType config.DBType, values map[string]any) *ParamBinder {
+	return &ParamBinder{dbType: dbType, values: values}
+}
+
+// Bind returns the statement with $1, $2, ... placeholders on PostgreSQL and
+// DuckDB, numbered by first appearance, and one ? per occurrence elsewhere.
+func (b *ParamBinder) Bind(statement string) (string, []any, error) {
+	var out strings.Builder
+	var args []any
+	var missing string
+	positions := make(map[string]int)
+	last := 0
+	b.anonymous = scanParams(b.dbType, statement, b.anonymous, func(start, end int, name string) {
+		value, ok := b.values[name]
+		if !ok {
+			if missing == "" {
+				missing = name
+			}
+			return
+		}
+		out.WriteString(statement[last:start])
+		last = end
+		switch b.dbType {
+		case config.PostgreSQL, config.DuckDB:
+			position, seen := positions[name]
+			if !seen {
+				args = append(args, value)
+				position = len(args)
+				positions[name] = position
+			}
+			out.WriteString("$" + strconv.Itoa(position))
+		default:
+			args = append(args, value)
+			out.WriteByte('?')
+		}
+	})
+	if missing != "" {
+		return "", nil, fmt.Errorf("no value for parameter %s", missing)
+	}
+	out.WriteString(statement[last:])
+	return out.String(), args, nil
+}
+
+// scanParams calls visit for every placeholder of src with its byte range
+// and name, and returns the last anonymous ? number used.
+func scanParams(dbType config.DBType, src string, anonymous int, visit func(start, end int, name string)) int {
+	s := scriptScanner{dbType: dbType, src: src}
+	mysql := dbType == config.MySQL
+	for i := 0; i < len(src); {
+		ch := src[i]
+		switch {
+		case strings.HasPrefix(src[i:], "--") && (!mysql || i+2 == len(src) || isScriptSpace(src[i+2])):
+			i = s.lineEnd(i)
+		case ch == '#' && mysql:
+			i = s.lineEnd(i)
+		case strings.HasPrefix(src[i:], "/*"):
+			i = s.blockCommentEnd(i)
+		case ch == '\'':
+			i = s.quoteEnd(i, '\'', mysql || s.escapeStringAt(i))
+		case ch == '"':
+			i = s.quoteEnd(i, '"', mysql)
+		case ch == '`':
+			i = s.quoteEnd(i, '`', false)
+		case ch == '[' && s.sqliteFamily():
+			i = s.bracketEnd(i)
+		case ch == '$' && paramDigitsEnd(src, i+1) > i+1:
+			end := paramDigitsEnd(src, i+1)
+			visit(i, end, src[i:end])
+			i = end
+		case ch == '$' && (dbType == config.PostgreSQL || dbType == config.DuckDB):
+			i = s.dollarQuoteEnd(i)
+		case ch == '?' && dbType != config.PostgreSQL:
+			end := paramDigitsEnd(src, i+1)
+			number := anonymous + 1
+			if end > i+1 {
+				number, _ = strconv.Atoi(src[i+1 : end])
+			}
+			anonymous = max(anonymous, number)
+			visit(i, end, "?"+strconv.Itoa(number))
+			i = end
+		case ch == ':' && isParamNameStart(src, i+1) && (i == 0 || (src[i-1] != ':' && !isScriptWordByte(src[i-1]))):
+			end := i + 1
+			for end < len(src) && isScriptWordByte(src[end]) {
+				end++
+			}
+			visit(i, end, src[i:end])
+			i = end
+		case isScriptWordByte(ch):
+			// Identifiers such as price$1 are not placeholders.
+			for i < len(src) && (isScriptWordByte(src[i]) || src[i] == '$') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return anonymous
+}
+
+func paramDigitsEnd(src string, i int) int {
+	for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+func isParamNameStart(src string, i int) bool {
+	if i >= len(src) {
+		return false
+	}
+	ch := src[i]
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch >= 0x80
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestQueryParamsSkipsLiteralsCastsAndOperators(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType config.DBType
+		query  string
+		want   []string
+	}{
+		{
+			name:   "postgres",
+			dbType: config.PostgreSQL,
+			query:  "SELECT ':skip', $body$ :nope $1 $body$, id::text, data ? 'k' -- :comment\nFROM t WHERE id = :id AND org = $2 OR owner = :id /* $9 */",
+			want:   []string{":id", "$2"},
+		},
+		{
+			name:   "sqlite anonymous and numbered",
+			dbType: config.SQLite,
+			query:  "SELECT * FROM [a:b] WHERE a = ? AND b = ?3 AND c = ? AND d = :d AND e = price$1",
+			want:   []string{"?1", "?3", "?4", ":d"},
+		},
+		{
+			name:   "mysql",
+			dbType: config.MySQL,
+			query:  "SET @x := 1; # :comment\nSELECT * FROM t WHERE a = ? AND s = 'it\\'s :x' AND b = :b",
+			want:   []string{"?1", ":b"},
+		},
+		{
+			name:   "none",
+			dbType: config.DuckDB,
+			query:  "SELECT {'a': 1}, arr[1:2], '$1' FROM t",
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		if got := QueryParams(test.dbType, test.query); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: QueryParams = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestParamBinderRewritesPlaceholdersPerEngine(t *testing.T) {
+	values := map[string]any{":id": int64(7), "$2": "x", "?1": nil, "?2": 2.5}
+
+	got, args, err := NewParamBinder(config.PostgreSQL, values).Bind("SELECT :id, $2, :id, ':id'")
+	if err != nil || got != "SELECT $1, $2, $1, ':id'" || !reflect.DeepEqual(args, []any{int64(7), "x"}) {
+		t.Fatalf("postgres = %q, %v, %v", got, args, err)
+	}
+
+	got, args, err = NewParamBinder(config.CloudflareD1, values).Bind("SELECT :id, :id, $2")
+	if err != nil || got != "SELECT ?, ?, ?" || !reflect.DeepEqual(args, []any{int64(7), int64(7), "x"}) {
+		t.Fatalf("d1 = %q, %v, %v", got, args, err)
+	}
+
+	// Anonymous numbering carries from one statement of a script to the next.
+	binder := NewParamBinder(config.SQLite, values)
+	if _, args, err = binder.Bind("SELECT ?"); err != nil || !reflect.DeepEqual(args, []any{nil}) {
+		t.Fatalf("first statement args = %v, err = %v", args, err)
+	}
+	if _, args, err = binder.Bind("SELECT ?"); err != nil || !reflect.DeepEqual(args, []any{2.5}) {
+		t.Fatalf("second statement args = %v, err = %v", args, err)
+	}
+	if _, _, err = binder.Bind("SELECT ?"); err == nil {
+		t.Fatal("a placeholder without a value was bound")
+	}
+
+	got, args, err = NewParamBinder(config.MySQL, nil).Bind("SELECT 1")
+	if err != nil || got != "SELECT 1" || args != nil {
+		t.Fatalf("statement without placeholders = %q, %v, %v", got, args, err)
+	}
+}
@@ -21,6 +21,14 @@ WHERE table_type = 'BASE TABLE'
 ORDER BY table_name`
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		return `SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%' ORDER BY name`
+	case config.DuckDB:
+		// Temporary views are how bare Parquet, CSV, and JSON files are exposed.
+		return `SELECT CASE WHEN schema_name = 'main' THEN table_name ELSE schema_name || '.' || table_name END AS table_name
+FROM duckdb_tables()
+WHERE NOT internal AND database_name = current_database()
+UNION ALL
+SELECT view_name FROM duckdb_views() WHERE temporary AND NOT internal
+ORDER BY table_name`
 	default:
 		return ""
 	}
@@ -99,6 +107,21 @@ ORDER BY routine_name`
 		case ObjTriggers:
 			return `SELECT name FROM sqlite_master WHERE type='trigger' ORDER BY name`
 		}
+	case config.DuckDB:
+		switch objType {
+		case ObjViews:
+			return `SELECT CASE WHEN schema_name = 'main' THEN view_name ELSE schema_name || '.' || view_name END AS name
+FROM duckdb_views()
+WHERE NOT internal AND NOT temporary AND database_name = current_database()
+ORDER BY name`
+		case ObjFunctions:
+			return `SELECT DISTINCT function_name
+FROM duckdb_functions()
+WHERE NOT internal AND function_type IN ('macro', 'table_macro')
+ORDER BY function_name`
+		case ObjExtensions:
+			return `SELECT extension_name FROM duckdb_extensions() WHERE loaded ORDER BY extension_name`
+		}
 	}
 	return ""
 }
@@ -112,6 +135,8 @@ func SupportedObjectTypes(dbType config.DBType) []DBObjectType {
 		return []DBObjectType{ObjViews, ObjFunctions, ObjTriggers, ObjStoredProcedures}
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		return []DBObjectType{ObjViews, ObjTriggers}
+	case config.DuckDB:
+		return []DBObjectType{ObjViews, ObjFunctions, ObjExtensions}
 	default:
 		return nil
 	}
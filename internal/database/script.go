@@ -0,0 +1,276 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// ScriptStatement is one statement of a multi-statement script.
+type ScriptStatement struct {
+	SQL  string
+	Line int // 1-based line the statement starts on
+}
+
+// SplitScript splits an editor buffer into statements the way the engine's
+// own client would. Terminators inside quotes, comments, PostgreSQL/DuckDB
+// dollar quotes, and trigger or BEGIN ATOMIC bodies do not end a statement,
+// and MySQL DELIMITER lines change the terminator. Statements that hold only
+// comments are dropped.
+func SplitScript(dbType config.DBType, script string) []ScriptStatement {
+	scanner := scriptScanner{dbType: dbType, src: script, delimiter: ";", empty: true, line: 1}
+	return scanner.split()
+}
+
+// ScriptWords returns the upper-cased keywords and bare identifiers of a
+// statement in order, skipping quotes, comments, and dollar-quoted bodies
+// with the same lexing SplitScript uses.
+func ScriptWords(dbType config.DBType, statement string) []string {
+	scanner := scriptScanner{dbType: dbType, src: statement, delimiter: ";", empty: true, line: 1, words: []string{}}
+	scanner.split()
+	return scanner.words
+}
+
+type scriptScanner struct {
+	dbType     config.DBType
+	src        string
+	delimiter  string
+	statements []ScriptStatement
+
+	start int      // offset where the current statement began
+	empty bool     // nothing but whitespace and comments since start
+	head  []string // leading keywords of the current statement
+	depth int      // open BEGIN/CASE blocks inside a compound statement
+	words []string // every word seen, when ScriptWords asks for them
+
+	line    int // line number at linePos
+	linePos int
+}
+
+func (s *scriptScanner) split() []ScriptStatement {
+	mysql := s.dbType == config.MySQL
+	for i := 0; i < len(s.src); {
+		if mysql && s.empty && s.atLineStart(i) {
+			if delimiter, next, ok := s.delimiterCommand(i); ok {
+				s.delimiter = delimiter
+				i = next
+				s.start = i
+				continue
+			}
+		}
+		ch := s.src[i]
+		switch {
+		case strings.HasPrefix(s.src[i:], "--") && (!mysql || i+2 == len(s.src) || isScriptSpace(s.src[i+2])):
+			i = s.lineEnd(i)
+		case ch == '#' && mysql:
+			i = s.lineEnd(i)
+		case strings.HasPrefix(s.src[i:], "/*"):
+			i = s.blockCommentEnd(i)
+		case s.depth == 0 && strings.HasPrefix(s.src[i:], s.delimiter):
+			s.emit(i)
+			i += len(s.delimiter)
+			s.start = i
+		case ch == '\'':
+			s.empty = false
+			i = s.quoteEnd(i, '\'', mysql || s.escapeStringAt(i))
+		case ch == '"':
+			s.empty = false
+			i = s.quoteEnd(i, '"', mysql)
+		case ch == '`':
+			s.empty = false
+			i = s.quoteEnd(i, '`', false)
+		case ch == '[' && s.sqliteFamily():
+			s.empty = false
+			i = s.bracketEnd(i)
+		case ch == '$' && (s.dbType == config.PostgreSQL || s.dbType == config.DuckDB):
+			s.empty = false
+			i = s.dollarQuoteEnd(i)
+		case isScriptWordByte(ch):
+			s.empty = false
+			i = s.word(i)
+		default:
+			if !isScriptSpace(ch) {
+				s.empty = false
+			}
+			i++
+		}
+	}
+	s.emit(len(s.src))
+	return s.statements
+}
+
+func (s *scriptScanner) emit(end int) {
+	if !s.empty {
+		text := s.src[s.start:end]
+		offset := s.start + len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+		s.statements = append(s.statements, ScriptStatement{
+			SQL:  strings.TrimSpace(text),
+			Line: s.lineAt(offset),
+		})
+	}
+	s.empty = true
+	s.head = s.head[:0]
+	s.depth = 0
+}
+
+func (s *scriptScanner) lineAt(offset int) int {
+	s.line += strings.Count(s.src[s.linePos:offset], "\n")
+	s.linePos = offset
+	return s.line
+}
+
+func (s *scriptScanner) atLineStart(i int) bool {
+	lineStart := strings.LastIndexByte(s.src[:i], '\n') + 1
+	return strings.TrimSpace(s.src[lineStart:i]) == ""
+}
+
+// delimiterCommand reads a mysql client "DELIMITER x" line.
+func (s *scriptScanner) delimiterCommand(i int) (string, int, bool) {
+	const command = "delimiter"
+	if len(s.src)-i <= len(command) || !strings.EqualFold(s.src[i:i+len(command)], command) || !isScriptSpace(s.src[i+len(command)]) {
+		return "", i, false
+	}
+	end := s.lineEnd(i)
+	fields := strings.Fields(s.src[i+len(command) : end])
+	if len(fields) == 0 {
+		return "", i, false
+	}
+	return fields[0], end, true
+}
+
+func (s *scriptScanner) lineEnd(i int) int {
+	if next := strings.IndexByte(s.src[i:], '\n'); next >= 0 {
+		return i + next
+	}
+	return len(s.src)
+}
+
+// blockCommentEnd skips a /* */ comment; PostgreSQL allows them to nest.
+func (s *scriptScanner) blockCommentEnd(i int) int {
+	nested := s.dbType == config.PostgreSQL
+	depth := 0
+	for j := i; j < len(s.src)-1; j++ {
+		switch {
+		case s.src[j] == '/' && s.src[j+1] == '*' && (nested || depth == 0):
+			depth++
+			j++
+		case s.src[j] == '*' && s.src[j+1] == '/':
+			depth--
+			j++
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(s.src)
+}
+
+// quoteEnd skips a quoted string or identifier. A doubled quote is a literal
+// quote; backslash escapes apply to MySQL and to PostgreSQL E'...' strings.
+func (s *scriptScanner) quoteEnd(i int, quote byte, backslash bool) int {
+	for j := i + 1; j < len(s.src); j++ {
+		switch s.src[j] {
+		case '\\':
+			if backslash {
+				j++
+			}
+		case quote:
+			if j+1 < len(s.src) && s.src[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(s.src)
+}
+
+func (s *scriptScanner) escapeStringAt(i int) bool {
+	return i > 0 && (s.src[i-1] == 'E' || s.src[i-1] == 'e') && (i == 1 || !isScriptWordByte(s.src[i-2]))
+}
+
+func (s *scriptScanner) bracketEnd(i int) int {
+	if end := strings.IndexByte(s.src[i+1:], ']'); end >= 0 {
+		return i + end + 2
+	}
+	return len(s.src)
+}
+
+// dollarQuoteEnd skips $$...$$ and $tag$...$tag$ bodies. Positional
+// parameters such as $1 are not quotes.
+func (s *scriptScanner) dollarQuoteEnd(i int) int {
+	j := i + 1
+	for j < len(s.src) && isScriptWordByte(s.src[j]) {
+		j++
+	}
+	if j == len(s.src) || s.src[j] != '$' || (j > i+1 && s.src[i+1] >= '0' && s.src[i+1] <= '9') {
+		return i + 1
+	}
+	tag := s.src[i : j+1]
+	if end := strings.Index(s.src[j+1:], tag); end >= 0 {
+		return j + 1 + end + len(tag)
+	}
+	return len(s.src)
+}
+
+// word reads one keyword or identifier and tracks the BEGIN/CASE ... END
+// nesting of compound statements.
+func (s *scriptScanner) word(i int) int {
+	j := i
+	for j < len(s.src) && (isScriptWordByte(s.src[j]) || s.src[j] == '$') && !strings.HasPrefix(s.src[j:], s.delimiter) {
+		j++
+	}
+	word := strings.ToUpper(s.src[i:j])
+	if s.words != nil {
+		s.words = append(s.words, word)
+	}
+	if len(s.head) < 4 {
+		s.head = append(s.head, word)
+	}
+	if s.compound() {
+		switch word {
+		case "BEGIN", "CASE":
+			s.depth++
+		case "END":
+			if s.depth > 0 {
+				s.depth--
+			}
+		}
+	}
+	return j
+}
+
+// compound reports whether the current statement can contain terminators
+// in a BEGIN ... END body: SQLite triggers and PostgreSQL routines with a
+// BEGIN ATOMIC body. MySQL scripts use DELIMITER for the same purpose.
+func (s *scriptScanner) compound() bool {
+	if len(s.head) < 2 || s.head[0] != "CREATE" {
+		return false
+	}
+	rest := s.head[1:]
+	switch {
+	case s.sqliteFamily():
+		if len(rest) > 1 && (rest[0] == "TEMP" || rest[0] == "TEMPORARY") {
+			rest = rest[1:]
+		}
+		return rest[0] == "TRIGGER"
+	case s.dbType == config.PostgreSQL:
+		if len(rest) > 2 && rest[0] == "OR" && rest[1] == "REPLACE" {
+			rest = rest[2:]
+		}
+		return rest[0] == "FUNCTION" || rest[0] == "PROCEDURE"
+	}
+	return false
+}
+
+func (s *scriptScanner) sqliteFamily() bool {
+	return s.dbType == config.SQLite || s.dbType == config.Turso || s.dbType == config.CloudflareD1
+}
+
+func isScriptWordByte(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch >= 0x80
+}
+
+func isScriptSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n'
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestSplitScriptHonorsEngineLexing(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType config.DBType
+		script string
+		want   []string
+	}{
+		{
+			name:   "quotes and comments",
+			dbType: config.PostgreSQL,
+			script: "-- header; not a statement\nSELECT 'a;b', \"c;d\" /* e; /* nested; */ f; */ FROM t;\n\nSELECT E'it\\'s;';",
+			want:   []string{"-- header; not a statement\nSELECT 'a;b', \"c;d\" /* e; /* nested; */ f; */ FROM t", "SELECT E'it\\'s;'"},
+		},
+		{
+			name:   "dollar quotes",
+			dbType: config.PostgreSQL,
+			script: "CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql;\nSELECT $1, $$x;y$$;",
+			want:   []string{"CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql", "SELECT $1, $$x;y$$"},
+		},
+		{
+			name:   "begin atomic",
+			dbType: config.PostgreSQL,
+			script: "CREATE OR REPLACE PROCEDURE p() LANGUAGE sql BEGIN ATOMIC INSERT INTO t VALUES (1); SELECT CASE WHEN true THEN 1 END; END; BEGIN; COMMIT",
+			want:   []string{"CREATE OR REPLACE PROCEDURE p() LANGUAGE sql BEGIN ATOMIC INSERT INTO t VALUES (1); SELECT CASE WHEN true THEN 1 END; END", "BEGIN", "COMMIT"},
+		},
+		{
+			name:   "mysql delimiter",
+			dbType: config.MySQL,
+			script: "# setup\nDROP PROCEDURE IF EXISTS p;\nDELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT 'x\\';'; SELECT 2; END$$\ndelimiter ;\nCALL p();",
+			want:   []string{"# setup\nDROP PROCEDURE IF EXISTS p", "CREATE PROCEDURE p() BEGIN SELECT 'x\\';'; SELECT 2; END", "CALL p()"},
+		},
+		{
+			name:   "mysql double dash needs a space",
+			dbType: config.MySQL,
+			script: "SELECT 1--1; SELECT 2",
+			want:   []string{"SELECT 1--1", "SELECT 2"},
+		},
+		{
+			name:   "sqlite trigger",
+			dbType: config.SQLite,
+			script: "CREATE TEMP TRIGGER t AFTER INSERT ON [a;b] BEGIN UPDATE x SET n = n + 1; DELETE FROM y; END;\nSELECT `c;d` FROM x;",
+			want:   []string{"CREATE TEMP TRIGGER t AFTER INSERT ON [a;b] BEGIN UPDATE x SET n = n + 1; DELETE FROM y; END", "SELECT `c;d` FROM x"},
+		},
+		{
+			name:   "comment-only tail",
+			dbType: config.DuckDB,
+			script: "SELECT 1; -- done\n/* trailing */ ;",
+			want:   []string{"SELECT 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, statement := range SplitScript(tt.dbType, tt.script) {
+				got = append(got, statement.SQL)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SplitScript() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitScriptReportsStartLines(t *testing.T) {
+	statements := SplitScript(config.MySQL, "SELECT 1;\n\n  SELECT\n 2;\nDELIMITER //\n\nSELECT 3//")
+	var lines []int
+	for _, statement := range statements {
+		lines = append(lines, statement.Line)
+	}
+	if want := []int{1, 3, 7}; !reflect.DeepEqual(lines, want) {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestScriptWordsSkipsQuotesAndComments(t *testing.T) {
+	got := ScriptWords(config.PostgreSQL, "with d as (delete from \"update\" returning $$ insert $$) -- drop\nselect 'merge' /* alter */ from d")
+	want := []string{"WITH", "D", "AS", "DELETE", "FROM", "RETURNING", "SELECT", "FROM", "D"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ScriptWords() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// sessionSetupTimeout bounds the statements run on each new connection.
+const sessionSetupTimeout = 10 * time.Second
+
+// WithStatementTimeout returns ctx bounded by the profile's statement timeout,
+// or by fallback when the profile has none. PostgreSQL and MySQL also enforce
+// the profile timeout server-side; for the other engines this deadline is
+// the only limit.
+func WithStatementTimeout(ctx context.Context, cfg *config.ConnectionConfig, fallback time.Duration) (context.Context, context.CancelFunc) {
+	timeout := fallback
+	if cfg != nil {
+		if configured := cfg.StatementTimeoutDuration(); configured > 0 {
+			timeout = configured
+		}
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// sessionSetup lists what runs on every new pooled connection: the
+// server-side statement timeout first, so InitSQL can still override it.
+func sessionSetup(cfg *config.ConnectionConfig) []sessionStatement {
+	var setup []sessionStatement
+	if timeout := cfg.StatementTimeoutDuration(); timeout > 0 {
+		millis := timeout.Milliseconds()
+		switch cfg.Type {
+		case config.PostgreSQL:
+			setup = append(setup, sessionStatement{sql: fmt.Sprintf("SET statement_timeout = %d", millis)})
+		case config.MySQL:
+			// max_execution_time is MySQL's name; MariaDB spells it
+			// max_statement_time and counts seconds.
+			setup = append(setup, sessionStatement{
+				sql:      fmt.Sprintf("SET SESSION max_execution_time = %d", millis),
+				fallback: fmt.Sprintf("SET SESSION max_statement_time = %.3f", timeout.Seconds()),
+			})
+		}
+	}
+	for _, statement := range cfg.SessionStatements() {
+		setup = append(setup, sessionStatement{sql: statement})
+	}
+	return setup
+}
+
+type sessionStatement struct {
+	sql      string
+	fallback string // tried when the server does not know sql's variable
+}
+
+// sessionConnector runs the profile's session setup on every connection the
+// pool opens, so settings survive reconnects and pool growth.
+type sessionConnector struct {
+	driver.Connector
+	setup []sessionStatement
+}
+
+func (c *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setupCtx, cancel := context.WithTimeout(ctx, sessionSetupTimeout)
+	defer cancel()
+	for _, statement := range c.setup {
+		err := execOnConn(setupCtx, conn, statement.sql)
+		if err != nil && statement.fallback != "" && strings.Contains(strings.ToLower(err.Error()), "unknown system variable") {
+			err = execOnConn(setupCtx, conn, statement.fallback)
+		}
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("session setup %q: %w", statement.sql, err)
+		}
+	}
+	return conn, nil
+}
+
+// Close releases the wrapped connector when it holds resources of its own.
+func (c *sessionConnector) Close() error {
+	if closer, ok := c.Connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func execOnConn(ctx context.Context, conn driver.Conn, query string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, query, nil)
+		if !errors.Is(err, driver.ErrSkip) {
+			return err
+		}
+	}
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = conn.Prepare(query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+		return err
+	}
+	_, err = stmt.Exec(nil)
+	return err
+}
+
+// openConnector returns a connector for dsn using the registered driver.
+func openConnector(driverName, dsn string) (driver.Connector, error) {
+	probe, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	base := probe.Driver()
+	_ = probe.Close()
+	if withContext, ok := base.(driver.DriverContext); ok {
+		return withContext.OpenConnector(dsn)
+	}
+	return dsnConnector{dsn: dsn, driver: base}, nil
+}
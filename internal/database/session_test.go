@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestConnectRunsInitSQLOnEveryPooledSession(t *testing.T) {
+	cfg := &config.ConnectionConfig{
+		Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db"),
+		InitSQL: "PRAGMA foreign_keys = ON; PRAGMA busy_timeout = 4321;",
+	}
+	db, err := Connect(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	first, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	second, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	for name, pooled := range map[string]*sql.Conn{"first": first, "second": second} {
+		var foreignKeys, busyTimeout int
+		if err := pooled.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+			t.Fatal(err)
+		}
+		if err := pooled.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			t.Fatal(err)
+		}
+		if foreignKeys != 1 || busyTimeout != 4321 {
+			t.Fatalf("%s session: foreign_keys=%d busy_timeout=%d", name, foreignKeys, busyTimeout)
+		}
+	}
+}
+
+func TestSessionSetupPutsServerTimeoutBeforeInitSQL(t *testing.T) {
+	pg := sessionSetup(&config.ConnectionConfig{Type: config.PostgreSQL, StatementTimeout: "1500ms", InitSQL: "SET search_path = app"})
+	want := []sessionStatement{{sql: "SET statement_timeout = 1500"}, {sql: "SET search_path = app"}}
+	if !reflect.DeepEqual(pg, want) {
+		t.Fatalf("PostgreSQL setup = %#v", pg)
+	}
+	mysql := sessionSetup(&config.ConnectionConfig{Type: config.MySQL, StatementTimeout: "30"})
+	if len(mysql) != 1 || mysql[0].sql != "SET SESSION max_execution_time = 30000" || mysql[0].fallback != "SET SESSION max_statement_time = 30.000" {
+		t.Fatalf("MySQL setup = %#v", mysql)
+	}
+	if setup := sessionSetup(&config.ConnectionConfig{Type: config.SQLite, StatementTimeout: "5s"}); len(setup) != 0 {
+		t.Fatalf("SQLite timeout should be a context deadline, got %#v", setup)
+	}
+}
+
+func TestSessionConnectorFallsBackForMariaDB(t *testing.T) {
+	conn := &recordingConn{fail: map[string]error{
+		"SET SESSION max_execution_time = 1000": errors.New("Error 1193: Unknown system variable 'max_execution_time'"),
+	}}
+	connector := &sessionConnector{Connector: recordingConnector{conn: conn}, setup: sessionSetup(&config.ConnectionConfig{Type: config.MySQL, StatementTimeout: "1s", InitSQL: "SET time_zone = '+00:00'"})}
+	if _, err := connector.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"SET SESSION max_execution_time = 1000", "SET SESSION max_statement_time = 1.000", "SET time_zone = '+00:00'"}
+	if !reflect.DeepEqual(conn.executed, want) {
+		t.Fatalf("executed = %q", conn.executed)
+	}
+
+	failing := &recordingConn{fail: map[string]error{"SET ROLE missing": errors.New("role does not exist")}}
+	connector = &sessionConnector{Connector: recordingConnector{conn: failing}, setup: []sessionStatement{{sql: "SET ROLE missing"}}}
+	if _, err := connector.Connect(context.Background()); err == nil || !failing.closed {
+		t.Fatalf("failed setup returned %v, closed=%t", err, failing.closed)
+	}
+}
+
+func TestWithStatementTimeoutPrefersProfile(t *testing.T) {
+	ctx, cancel := WithStatementTimeout(context.Background(), &config.ConnectionConfig{StatementTimeout: "2s"}, time.Hour)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 2*time.Second {
+		t.Fatalf("deadline = %v, %t", deadline, ok)
+	}
+	ctx, cancel = WithStatementTimeout(context.Background(), &config.ConnectionConfig{}, 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("no timeout produced a deadline")
+	}
+}
+
+type recordingConnector struct{ conn *recordingConn }
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c recordingConnector) Driver() driver.Driver                        { return nil }
+
+type recordingConn struct {
+	executed []string
+	fail     map[string]error
+	closed   bool
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.executed = append(c.executed, query)
+	if err := c.fail[query]; err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recordingConn) Close() error                        { c.closed = true; return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
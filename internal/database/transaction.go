@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// Transaction is an explicit transaction pinned to one session of the pool.
+// Statements run through it see their own uncommitted changes; everything
+// else keeps using the pool and sees only committed data.
+type Transaction struct {
+	conn       *sql.Conn
+	tx         *sql.Tx
+	cancel     context.CancelFunc
+	done       <-chan struct{}
+	startedAt  time.Time
+	lastUsed   atomic.Int64 // unix nanoseconds of the last statement
+	statements atomic.Int64
+}
+
+// TransactionSupport returns why dbType cannot keep a transaction open
+// between editor runs, or nil when it can.
+func TransactionSupport(dbType config.DBType) error {
+	switch dbType {
+	case config.CloudflareD1:
+		return errors.New("Cloudflare D1 runs every statement through its HTTP API and cannot keep a transaction open between queries")
+	case config.DuckDB:
+		return errors.New("DuckDB statements run through the duckdb command-line client, which cannot keep a transaction open between queries")
+	}
+	return nil
+}
+
+// BeginTransaction reserves a session from db and begins a transaction on
+// it. The session leaves the pool for good: it is discarded once the
+// transaction ends, so nothing set inside it leaks into later queries.
+func BeginTransaction(ctx context.Context, db *sql.DB, dbType config.DBType) (*Transaction, error) {
+	if err := TransactionSupport(dbType); err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not reserve a session: %w", err)
+	}
+	// The transaction outlives ctx; canceling txCtx rolls it back.
+	txCtx, cancel := context.WithCancel(context.Background())
+	tx, err := conn.BeginTx(txCtx, nil)
+	if err != nil {
+		cancel()
+		discardConn(conn)
+		return nil, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	started := time.Now()
+	t := &Transaction{conn: conn, tx: tx, cancel: cancel, done: txCtx.Done(), startedAt: started}
+	t.lastUsed.Store(started.UnixNano())
+	return t, nil
+}
+
+// ExecContext runs a statement inside the transaction.
+func (t *Transaction) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	t.start()
+	defer t.touch()
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs a query inside the transaction. The rows must be closed
+// before the transaction ends.
+func (t *Transaction) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	t.start()
+	defer t.touch()
+	return t.tx.QueryContext(ctx, query, args...)
+}
+
+func (t *Transaction) start() {
+	t.statements.Add(1)
+	t.touch()
+}
+
+func (t *Transaction) touch() {
+	t.lastUsed.Store(time.Now().UnixNano())
+}
+
+// Commit commits the transaction and releases its session.
+func (t *Transaction) Commit() error {
+	defer t.release()
+	if err := t.tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback rolls the transaction back and releases its session.
+func (t *Transaction) Rollback() error {
+	defer t.release()
+	if err := t.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return fmt.Errorf("could not roll back transaction: %w", err)
+	}
+	return nil
+}
+
+// Abandon rolls the transaction back without waiting for the server, for
+// callers that are about to close the pool anyway.
+func (t *Transaction) Abandon() {
+	t.cancel()
+	go t.release()
+}
+
+func (t *Transaction) release() {
+	t.cancel()
+	discardConn(t.conn)
+}
+
+// Done is closed once the transaction has ended.
+func (t *Transaction) Done() <-chan struct{} { return t.done }
+
+// StartedAt is when the transaction began.
+func (t *Transaction) StartedAt() time.Time { return t.startedAt }
+
+// LastUsed is when a statement last started or finished in the transaction.
+func (t *Transaction) LastUsed() time.Time { return time.Unix(0, t.lastUsed.Load()) }
+
+// Statements counts the statements run in the transaction, including failed
+// ones.
+func (t *Transaction) Statements() int { return int(t.statements.Load()) }
+
+// discardConn closes conn without returning it to the pool.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
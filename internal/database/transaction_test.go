@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestTransactionKeepsChangesOnItsSessionUntilCommit(t *testing.T) {
+	db, err := Connect(&config.ConnectionConfig{Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	count := func() int {
+		t.Helper()
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	rolledBack, err := BeginTransaction(ctx, db, config.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rolledBack.ExecContext(ctx, "INSERT INTO items (id) VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+	var inside int
+	if err := rolledBack.tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&inside); err != nil || inside != 1 {
+		t.Fatalf("transaction sees %d rows, err %v", inside, err)
+	}
+	if got := count(); got != 0 {
+		t.Fatalf("pool sees %d uncommitted rows", got)
+	}
+	if err := rolledBack.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-rolledBack.Done():
+	default:
+		t.Fatal("Done is still open after a rollback")
+	}
+
+	committed, err := BeginTransaction(ctx, db, config.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range []string{"INSERT INTO items (id) VALUES (2)", "INSERT INTO items (id) VALUES (3)"} {
+		if _, err := committed.ExecContext(ctx, statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if committed.Statements() != 2 {
+		t.Fatalf("Statements() = %d", committed.Statements())
+	}
+	if err := committed.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := count(); got != 2 {
+		t.Fatalf("pool sees %d rows after commit, want 2", got)
+	}
+}
+
+func TestTransactionSupportRejectsStatelessEngines(t *testing.T) {
+	for _, dbType := range []config.DBType{config.CloudflareD1, config.DuckDB} {
+		if TransactionSupport(dbType) == nil {
+			t.Errorf("%s accepted an explicit transaction", dbType)
+		}
+	}
+	if err := TransactionSupport(config.PostgreSQL); err != nil {
+		t.Errorf("PostgreSQL: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/sshtunnel"
+)
+
+const tunnelDialTimeout = 10 * time.Second
+
+// OpenTunnel starts the SSH forward configured on cfg and returns a copy whose
+// Host and Port address the local end. Native clients such as pg_dump use the
+// copy for the lifetime of one command. Profiles without a tunnel are returned
+// unchanged with a no-op close.
+func OpenTunnel(cfg *config.ConnectionConfig) (*config.ConnectionConfig, func(), error) {
+	if cfg == nil {
+		return nil, func() {}, fmt.Errorf("database connection is required")
+	}
+	copyCfg := *cfg
+	if !cfg.UsesSSHTunnel() {
+		return &copyCfg, func() {}, nil
+	}
+	options, err := TunnelOptions(cfg)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tunnelDialTimeout*time.Duration(len(options.Hops)))
+	defer cancel()
+	tunnel, err := sshtunnel.Open(ctx, options, net.JoinHostPort(tunnelTargetHost(cfg), tunnelTargetPort(cfg)))
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("open SSH tunnel: %w", err)
+	}
+	copyCfg.Host, copyCfg.Port = tunnel.LocalAddress()
+	if strings.TrimSpace(copyCfg.TLSServerName) == "" {
+		// Certificates name the database host, not the local forward.
+		copyCfg.TLSServerName = tunnelTargetHost(cfg)
+	}
+	return &copyCfg, func() { _ = tunnel.Close() }, nil
+}
+
+// TunnelOptions converts a profile's SSH settings into an ordered hop chain:
+// every jump host first, then the SSH host that can reach the database.
+func TunnelOptions(cfg *config.ConnectionConfig) (sshtunnel.Options, error) {
+	user := strings.TrimSpace(cfg.SSHUser)
+	hops, err := sshtunnel.ParseJumpHosts(cfg.SSHJumpHosts, user)
+	if err != nil {
+		return sshtunnel.Options{}, err
+	}
+	hops = append(hops, sshtunnel.Hop{
+		Host: strings.TrimSpace(cfg.SSHHost),
+		Port: strings.TrimSpace(cfg.SSHPort),
+		User: user,
+	})
+	return sshtunnel.Options{
+		Hops:           hops,
+		KeyFile:        cfg.SSHKeyFile,
+		Password:       cfg.SSHPassword,
+		UseAgent:       cfg.SSHUseAgent,
+		KnownHostsFile: cfg.SSHKnownHosts,
+		DialTimeout:    tunnelDialTimeout,
+	}, nil
+}
+
+func tunnelTargetHost(cfg *config.ConnectionConfig) string {
+	if host := strings.TrimSpace(cfg.Host); host != "" {
+		return host
+	}
+	// "localhost" is resolved by the SSH server, so it names the bastion's
+	// own loopback, which is the common single-host deployment.
+	return "localhost"
+}
+
+func tunnelTargetPort(cfg *config.ConnectionConfig) string {
+	if port := strings.TrimSpace(cfg.Port); port != "" {
+		return port
+	}
+	if cfg.Type == config.MySQL {
+		return "3306"
+	}
+	return "5432"
+}
+
+// openTunneled opens a pool whose connections dial the local end of an SSH
+// forward. The tunnel belongs to the pool and closes with it.
+func openTunneled(cfg *config.ConnectionConfig) (*sql.DB, error) {
+	tunneled, closeTunnel, err := OpenTunnel(cfg)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := openConnector(cfg.DriverName(), tunneled.BuildConnString())
+	if err != nil {
+		closeTunnel()
+		return nil, err
+	}
+	if setup := sessionSetup(cfg); len(setup) > 0 {
+		connector = &sessionConnector{Connector: connector, setup: setup}
+	}
+	return sql.OpenDB(&tunnelConnector{Connector: connector, close: closeTunnel}), nil
+}
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                        { return c.driver }
+
+// tunnelConnector ties the SSH tunnel to the pool: database/sql calls Close
+// on connectors that implement io.Closer when DB.Close runs.
+type tunnelConnector struct {
+	driver.Connector
+	close func()
+}
+
+func (c *tunnelConnector) Close() error {
+	c.close()
+	return nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/sshtunnel"
+)
+
+func TestOpenTunnelPassesThroughProfilesWithoutSSH(t *testing.T) {
+	cfg := &config.ConnectionConfig{Type: config.SQLite, FilePath: "/tmp/app.db", SSHHost: "ignored.example.com"}
+	got, closeTunnel, err := OpenTunnel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeTunnel()
+	if *got != *cfg {
+		t.Fatalf("untunneled profile changed: %#v", got)
+	}
+	if got == cfg {
+		t.Fatal("OpenTunnel must return a copy so callers cannot mutate the saved profile")
+	}
+}
+
+func TestTunnelOptionsDialJumpHostsBeforeSSHHost(t *testing.T) {
+	options, err := TunnelOptions(&config.ConnectionConfig{
+		Type: config.PostgreSQL, Host: "db.internal", SSHHost: "app-1", SSHPort: "2222", SSHUser: "deploy",
+		SSHJumpHosts: "ops@bastion.example.com", SSHKeyFile: "~/.ssh/id_ed25519",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sshtunnel.Hop{
+		{Host: "bastion.example.com", User: "ops"},
+		{Host: "app-1", Port: "2222", User: "deploy"},
+	}
+	if len(options.Hops) != len(want) || options.Hops[0] != want[0] || options.Hops[1] != want[1] {
+		t.Fatalf("hops = %#v, want %#v", options.Hops, want)
+	}
+	if options.KeyFile != "~/.ssh/id_ed25519" {
+		t.Fatalf("key file = %q", options.KeyFile)
+	}
+}
+
+func TestOpenReportsTunnelFailuresBeforeDialingTheDatabase(t *testing.T) {
+	_, err := Open(&config.ConnectionConfig{
+		Type: config.MySQL, Host: "db.internal", Port: "3306", User: "app",
+		SSHHost: "127.0.0.1", SSHPort: "1", SSHUser: "deploy",
+	})
+	if err == nil || !strings.Contains(err.Error(), "SSH tunnel") {
+		t.Fatalf("Open error = %v", err)
+	}
+}
@@ -0,0 +1,545 @@
+// Package dataimport reads CSV, JSON, and NDJSON files as rows of nullable
+// strings and infers column types from a sample, so they can be loaded into
+// database tables.
+package dataimport
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format identifies the layout of an import file.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Label returns the format's display name.
+func (f Format) Label() string {
+	switch f {
+	case FormatJSON:
+		return "JSON"
+	case FormatNDJSON:
+		return "NDJSON"
+	default:
+		return "CSV"
+	}
+}
+
+// HeaderMode says whether the first CSV record names the columns.
+type HeaderMode uint8
+
+const (
+	HeaderAuto HeaderMode = iota
+	HeaderPresent
+	HeaderAbsent
+)
+
+// Delimiters lists the CSV separators detection chooses between.
+var Delimiters = []rune{',', ';', '\t', '|'}
+
+// Options pins settings that Inspect would otherwise detect.
+type Options struct {
+	Format    Format // empty detects from the extension, then the content
+	Delimiter rune   // 0 detects; CSV only
+	Header    HeaderMode
+}
+
+// Column is one file column with the type inferred from the sample.
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Record holds one row; a field is invalid when it is SQL NULL. Empty CSV
+// fields and JSON nulls and missing keys are NULL.
+type Record []sql.NullString
+
+// Preview is the detected layout of a file plus the sample it came from.
+type Preview struct {
+	Path      string
+	Size      int64
+	Format    Format
+	Delimiter rune
+	Header    bool
+	Columns   []Column
+	Rows      []Record
+}
+
+// RowError reports a row that cannot be read. The reader stays usable, so a
+// dry run can list every bad row.
+type RowError struct {
+	Row     int
+	Message string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// Reader streams the records of a file according to a Preview.
+type Reader struct {
+	file    *os.File
+	counter *countingReader
+	columns map[string]int
+	width   int
+	row     int
+
+	csv   *csv.Reader
+	json  *json.Decoder
+	lines *bufio.Reader
+}
+
+// Inspect detects the format, delimiter, and header of path and infers column
+// types from up to sampleRows rows.
+func Inspect(path string, options Options, sampleRows int) (*Preview, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open import file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat import file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	head := make([]byte, sniffBytes)
+	count, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+	head = bytes.TrimPrefix(head[:count], utf8BOM)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind import file: %w", err)
+	}
+
+	preview := &Preview{Path: path, Size: info.Size(), Format: options.Format}
+	if preview.Format == "" {
+		preview.Format = detectFormat(path, head)
+	}
+	if preview.Format == FormatCSV {
+		preview.Delimiter = options.Delimiter
+		if preview.Delimiter == 0 {
+			preview.Delimiter = detectDelimiter(path, head)
+		}
+	}
+
+	reader, err := newReader(file, preview)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Format == FormatCSV {
+		if err := reader.inspectCSV(preview, options.Header, sampleRows); err != nil {
+			return nil, err
+		}
+	} else if err := reader.inspectJSON(preview, sampleRows); err != nil {
+		return nil, err
+	}
+	if len(preview.Columns) == 0 {
+		return nil, fmt.Errorf("no columns found in %s", filepath.Base(path))
+	}
+	for index := range preview.Columns {
+		values := make([]string, 0, len(preview.Rows))
+		for _, row := range preview.Rows {
+			if index < len(row) && row[index].Valid {
+				values = append(values, row[index].String)
+			}
+		}
+		preview.Columns[index].Kind = InferKind(values)
+	}
+	return preview, nil
+}
+
+// Open starts reading the data rows of a previously inspected file.
+func Open(preview *Preview) (*Reader, error) {
+	file, err := os.Open(preview.Path)
+	if err != nil {
+		return nil, fmt.Errorf("open import file: %w", err)
+	}
+	reader, err := newReader(file, preview)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader.width = len(preview.Columns)
+	reader.columns = make(map[string]int, len(preview.Columns))
+	for index, column := range preview.Columns {
+		reader.columns[column.Name] = index
+	}
+	if preview.Format == FormatCSV && preview.Header {
+		if _, err := reader.csv.Read(); err != nil && !errors.Is(err, io.EOF) {
+			reader.Close()
+			return nil, fmt.Errorf("read CSV header: %w", err)
+		}
+	}
+	return reader, nil
+}
+
+// sniffBytes is how much of a file format and delimiter detection look at.
+const sniffBytes = 64 * 1024
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+func newReader(file *os.File, preview *Preview) (*Reader, error) {
+	// The counter sits under the buffer, so it runs up to 64 KiB ahead of the
+	// parser; that is plenty for a progress bar.
+	counter := &countingReader{reader: file}
+	buffered := bufio.NewReaderSize(counter, 64*1024)
+	if head, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(head, utf8BOM) {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	reader := &Reader{file: file, counter: counter}
+	switch preview.Format {
+	case FormatCSV:
+		reader.csv = csv.NewReader(buffered)
+		reader.csv.Comma = preview.Delimiter
+		reader.csv.FieldsPerRecord = -1
+		reader.csv.LazyQuotes = true
+		reader.csv.ReuseRecord = true
+	case FormatJSON:
+		reader.json = json.NewDecoder(buffered)
+		reader.json.UseNumber()
+		token, err := reader.json.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read JSON: %w", err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("a JSON import file must hold an array of objects")
+		}
+	case FormatNDJSON:
+		reader.lines = buffered
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", preview.Format)
+	}
+	return reader, nil
+}
+
+func (r *Reader) inspectCSV(preview *Preview, mode HeaderMode, sampleRows int) error {
+	var first []string
+	for len(preview.Rows) < sampleRows+1 {
+		record, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read CSV: %w", err)
+		}
+		if first == nil {
+			first = append([]string(nil), record...)
+		}
+		preview.Rows = append(preview.Rows, csvRecord(record, len(record)))
+	}
+	if first == nil {
+		return fmt.Errorf("the file is empty")
+	}
+	switch mode {
+	case HeaderPresent:
+		preview.Header = true
+	case HeaderAbsent:
+		preview.Header = false
+	default:
+		preview.Header = looksLikeHeader(first)
+	}
+	width := len(first)
+	for _, row := range preview.Rows {
+		width = max(width, len(row))
+	}
+	if preview.Header {
+		preview.Rows = preview.Rows[1:]
+	} else if len(preview.Rows) > sampleRows {
+		preview.Rows = preview.Rows[:sampleRows]
+	}
+	for index := 0; index < width; index++ {
+		name := ""
+		if preview.Header && index < len(first) {
+			name = strings.TrimSpace(first[index])
+		}
+		if name == "" {
+			name = fmt.Sprintf("column_%d", index+1)
+		}
+		preview.Columns = append(preview.Columns, Column{Name: name})
+	}
+	for index, row := range preview.Rows {
+		for len(row) < width {
+			row = append(row, sql.NullString{})
+		}
+		preview.Rows[index] = row
+	}
+	return nil
+}
+
+func (r *Reader) inspectJSON(preview *Preview, sampleRows int) error {
+	seen := map[string]bool{}
+	var objects []jsonObject
+	for len(objects) < sampleRows {
+		object, err := r.nextObject()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, field := range object {
+			if !seen[field.key] {
+				seen[field.key] = true
+				preview.Columns = append(preview.Columns, Column{Name: field.key})
+			}
+		}
+		objects = append(objects, object)
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("no JSON objects found")
+	}
+	index := make(map[string]int, len(preview.Columns))
+	for position, column := range preview.Columns {
+		index[column.Name] = position
+	}
+	for _, object := range objects {
+		row := make(Record, len(preview.Columns))
+		for _, field := range object {
+			row[index[field.key]] = field.value
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+	return nil
+}
+
+// Next returns the next data row, io.EOF at the end of the file, or a
+// *RowError for a row that cannot be read.
+func (r *Reader) Next() (Record, error) {
+	if r.csv != nil {
+		fields, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		r.row++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && !errors.Is(parseErr.Err, csv.ErrQuote) {
+				return nil, &RowError{Row: r.row, Message: parseErr.Err.Error()}
+			}
+			return nil, fmt.Errorf("read CSV row %d: %w", r.row, err)
+		}
+		if len(fields) > r.width {
+			return nil, &RowError{Row: r.row, Message: fmt.Sprintf("has %d fields, expected %d", len(fields), r.width)}
+		}
+		return csvRecord(fields, r.width), nil
+	}
+
+	object, err := r.nextObject()
+	if err != nil {
+		return nil, err
+	}
+	record := make(Record, r.width)
+	for _, field := range object {
+		position, ok := r.columns[field.key]
+		if !ok {
+			return nil, &RowError{Row: r.row, Message: fmt.Sprintf("has field %q, which was not in the sampled rows", field.key)}
+		}
+		record[position] = field.value
+	}
+	return record, nil
+}
+
+// Row returns the 1-based number of the data row last returned by Next.
+func (r *Reader) Row() int { return r.row }
+
+// BytesRead approximates how far into the file the reader is.
+func (r *Reader) BytesRead() int64 { return r.counter.count }
+
+// Close releases the file.
+func (r *Reader) Close() error { return r.file.Close() }
+
+type jsonField struct {
+	key   string
+	value sql.NullString
+}
+
+type jsonObject []jsonField
+
+func (r *Reader) nextObject() (jsonObject, error) {
+	if r.json != nil {
+		if !r.json.More() {
+			if _, err := r.json.Token(); err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("read JSON: %w", err)
+			}
+			return nil, io.EOF
+		}
+		r.row++
+		var raw json.RawMessage
+		if err := r.json.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("read JSON row %d: %w", r.row, err)
+		}
+		return r.decodeObject(raw)
+	}
+
+	for {
+		line, err := r.lines.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil, io.EOF
+				}
+				return nil, fmt.Errorf("read NDJSON: %w", err)
+			}
+			continue
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("read NDJSON: %w", err)
+		}
+		r.row++
+		return r.decodeObject(line)
+	}
+}
+
+func (r *Reader) decodeObject(raw []byte) (jsonObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, &RowError{Row: r.row, Message: fmt.Sprintf("is not valid JSON: %v", err)}
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, &RowError{Row: r.row, Message: "is not a JSON object"}
+	}
+	var object jsonObject
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return nil, &RowError{Row: r.row, Message: fmt.Sprintf("is not valid JSON: %v", err)}
+		}
+		key, _ := keyToken.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, &RowError{Row: r.row, Message: fmt.Sprintf("is not valid JSON: %v", err)}
+		}
+		object = append(object, jsonField{key: key, value: jsonValue(value)})
+	}
+	return object, nil
+}
+
+// jsonValue flattens a JSON value to its text: strings are unquoted, null is
+// NULL, and nested objects and arrays keep their compact JSON form.
+func jsonValue(raw json.RawMessage) sql.NullString {
+	trimmed := bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(trimmed, []byte("null")):
+		return sql.NullString{}
+	case len(trimmed) > 0 && trimmed[0] == '"':
+		var text string
+		if err := json.Unmarshal(trimmed, &text); err == nil {
+			return sql.NullString{String: text, Valid: true}
+		}
+	case len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['):
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, trimmed); err == nil {
+			return sql.NullString{String: compacted.String(), Valid: true}
+		}
+	}
+	return sql.NullString{String: string(trimmed), Valid: true}
+}
+
+func csvRecord(fields []string, width int) Record {
+	record := make(Record, width)
+	for index := 0; index < len(fields) && index < width; index++ {
+		if fields[index] != "" {
+			record[index] = sql.NullString{String: fields[index], Valid: true}
+		}
+	}
+	return record
+}
+
+func detectFormat(path string, head []byte) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".tsv", ".tab", ".txt":
+		return FormatCSV
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	}
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		return FormatJSON
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		return FormatNDJSON
+	default:
+		return FormatCSV
+	}
+}
+
+// detectDelimiter picks the separator that splits the leading lines into the
+// same number of fields most often, preferring commas on ties.
+func detectDelimiter(path string, head []byte) rune {
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".tsv" || ext == ".tab" {
+		return '\t'
+	}
+	if cut := bytes.LastIndexByte(head, '\n'); cut > 0 && len(head) >= sniffBytes-len(utf8BOM) {
+		head = head[:cut]
+	}
+	best, bestScore := ',', 0
+	for _, delimiter := range Delimiters {
+		reader := csv.NewReader(bytes.NewReader(head))
+		reader.Comma = delimiter
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		counts := map[int]int{}
+		for lines := 0; lines < 50; lines++ {
+			record, err := reader.Read()
+			if err != nil {
+				break
+			}
+			counts[len(record)]++
+		}
+		for fields, rows := range counts {
+			if fields > 1 && rows*1000+fields > bestScore {
+				best, bestScore = delimiter, rows*1000+fields
+			}
+		}
+	}
+	return best
+}
+
+// looksLikeHeader treats a first row as a header when every field is
+// non-empty, unique, and text rather than a number, date, or boolean.
+func looksLikeHeader(first []string) bool {
+	seen := make(map[string]bool, len(first))
+	for _, field := range first {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] || InferKind([]string{field}) != KindText {
+			return false
+		}
+		seen[field] = true
+	}
+	return true
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
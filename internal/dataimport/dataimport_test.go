@@ -0,0 +1,130 @@
+package dataimport
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeImportFile(t *testing.T, name, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readAll(t *testing.T, preview *Preview) ([]Record, []int) {
+	t.Helper()
+	reader, err := Open(preview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	var records []Record
+	var badRows []int
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records, badRows
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			badRows = append(badRows, rowErr.Row)
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestInspectCSVDetectsDelimiterHeaderAndKinds(t *testing.T) {
+	path := writeImportFile(t, "people.txt", "\ufeffid;name;score;active;born\n1;Ada;9.5;yes;1815-12-10\n2;\"Bob; Jr\";;no;1990-01-02\n3;Cy;7;true;2001-03-04\n")
+	preview, err := Inspect(path, Options{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Format != FormatCSV || preview.Delimiter != ';' || !preview.Header {
+		t.Fatalf("preview = %+v", preview)
+	}
+	want := []Column{{"id", KindInteger}, {"name", KindText}, {"score", KindReal}, {"active", KindBoolean}, {"born", KindDate}}
+	if len(preview.Columns) != len(want) {
+		t.Fatalf("columns = %+v", preview.Columns)
+	}
+	for index, column := range want {
+		if preview.Columns[index] != column {
+			t.Fatalf("column %d = %+v, want %+v", index, preview.Columns[index], column)
+		}
+	}
+
+	records, badRows := readAll(t, preview)
+	if len(records) != 3 || len(badRows) != 0 {
+		t.Fatalf("records = %v, bad rows = %v", records, badRows)
+	}
+	if records[1][1].String != "Bob; Jr" || records[1][2].Valid {
+		t.Fatalf("second record = %+v", records[1])
+	}
+
+	headless, err := Inspect(writeImportFile(t, "numbers.csv", "1,2\n3,4\n"), Options{}, 100)
+	if err != nil || headless.Header || headless.Columns[0].Name != "column_1" {
+		t.Fatalf("headless = %+v, err = %v", headless, err)
+	}
+	forced, err := Inspect(path, Options{Delimiter: ';', Header: HeaderAbsent}, 100)
+	if err != nil || forced.Header || len(forced.Rows) != 4 {
+		t.Fatalf("forced = %+v, err = %v", forced, err)
+	}
+}
+
+func TestJSONAndNDJSONReadObjectsAsRecords(t *testing.T) {
+	array, err := Inspect(writeImportFile(t, "rows.json", `[{"id":1,"tags":["a","b"]},{"id":2,"name":"Bo","tags":null}]`), Options{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if array.Format != FormatJSON || len(array.Columns) != 3 || array.Columns[0].Kind != KindInteger {
+		t.Fatalf("array preview = %+v", array)
+	}
+	records, _ := readAll(t, array)
+	if len(records) != 2 || records[0][1].String != `["a","b"]` || records[0][2].Valid || records[1][2].String != "Bo" {
+		t.Fatalf("array records = %+v", records)
+	}
+
+	lines, err := Inspect(writeImportFile(t, "rows.data", "{\"id\":1}\n\n[1]\n{\"id\":3}\n"), Options{}, 100)
+	if err != nil || lines.Format != FormatNDJSON {
+		t.Fatalf("lines = %+v, err = %v", lines, err)
+	}
+	records, badRows := readAll(t, lines)
+	if len(records) != 2 || len(badRows) != 1 || badRows[0] != 2 {
+		t.Fatalf("records = %v, bad rows = %v", records, badRows)
+	}
+}
+
+func TestInferKindAndSQLTypes(t *testing.T) {
+	for _, test := range []struct {
+		values []string
+		want   Kind
+	}{
+		{[]string{"1", "-20", "300"}, KindInteger},
+		{[]string{"1", "2.5", "1e3"}, KindReal},
+		{[]string{"007", "8"}, KindText},
+		{[]string{"2024-01-02T03:04:05Z", "2024-01-02 03:04:05"}, KindTimestamp},
+		{[]string{"0", "1"}, KindInteger},
+		{nil, KindText},
+	} {
+		if got := InferKind(test.values); got != test.want {
+			t.Fatalf("InferKind(%q) = %s, want %s", test.values, got, test.want)
+		}
+	}
+	for declared, want := range map[string]Kind{
+		"INTEGER": KindInteger, "bigint unsigned": KindInteger, "tinyint(1)": KindBoolean, "numeric(10,2)": KindReal,
+		"timestamp with time zone": KindTimestamp, "DATE": KindDate, "varchar(20)": KindText, "jsonb": KindText,
+	} {
+		if got := KindOfSQLType(declared); got != want {
+			t.Fatalf("KindOfSQLType(%q) = %s, want %s", declared, got, want)
+		}
+	}
+}
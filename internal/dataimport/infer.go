@@ -0,0 +1,136 @@
+package dataimport
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the portable type a column is inferred or declared as.
+type Kind string
+
+const (
+	KindInteger   Kind = "integer"
+	KindReal      Kind = "real"
+	KindBoolean   Kind = "boolean"
+	KindDate      Kind = "date"
+	KindTimestamp Kind = "timestamp"
+	KindText      Kind = "text"
+)
+
+// Kinds lists every kind, from the most to the least specific.
+var Kinds = []Kind{KindInteger, KindReal, KindBoolean, KindDate, KindTimestamp, KindText}
+
+var (
+	integerPattern = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)$`)
+	realPattern    = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+	timestampLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02 15:04",
+	}
+)
+
+// InferKind returns the most specific kind every value matches. Numbers with
+// leading zeros stay text so codes such as ZIPs keep their digits.
+func InferKind(values []string) Kind {
+	if len(values) == 0 {
+		return KindText
+	}
+	for _, kind := range Kinds[:len(Kinds)-1] {
+		matches := true
+		for _, value := range values {
+			if !Matches(kind, value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return kind
+		}
+	}
+	return KindText
+}
+
+// Matches reports whether value is valid input for kind.
+func Matches(kind Kind, value string) bool {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case KindInteger:
+		if !integerPattern.MatchString(value) {
+			return false
+		}
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case KindReal:
+		return realPattern.MatchString(value)
+	case KindBoolean:
+		_, ok := ParseBool(value)
+		return ok
+	case KindDate:
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case KindTimestamp:
+		_, ok := ParseTimestamp(value)
+		return ok
+	default:
+		return true
+	}
+}
+
+// ParseBool accepts true/false, t/f, yes/no, and 1/0 in any case.
+func ParseBool(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "yes", "1":
+		return true, true
+	case "false", "f", "no", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// ParseTimestamp accepts ISO 8601 timestamps with a T or a space, optional
+// fractional seconds, and an optional offset, as well as plain dates.
+func ParseTimestamp(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range append(timestampLayouts, "2006-01-02") {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// KindOfSQLType maps a declared column type to the kind whose values it
+// accepts. Types without a portable equivalent, such as UUID or JSON, are text.
+func KindOfSQLType(declared string) Kind {
+	declared = strings.ToUpper(strings.TrimSpace(declared))
+	if declared == "TINYINT(1)" {
+		// MySQL spells BOOLEAN this way.
+		return KindBoolean
+	}
+	if cut := strings.IndexByte(declared, '('); cut >= 0 {
+		declared = strings.TrimSpace(declared[:cut])
+	}
+	declared = strings.TrimSpace(strings.TrimSuffix(declared, " UNSIGNED"))
+	switch declared {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8",
+		"SERIAL", "BIGSERIAL", "SMALLSERIAL", "HUGEINT", "UBIGINT", "UINTEGER", "USMALLINT", "UTINYINT":
+		return KindInteger
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "DECIMAL", "NUMERIC", "NUMBER":
+		return KindReal
+	case "BOOL", "BOOLEAN":
+		return KindBoolean
+	case "DATE":
+		return KindDate
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE":
+		return KindTimestamp
+	default:
+		return KindText
+	}
+}
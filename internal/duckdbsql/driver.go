@@ -0,0 +1,661 @@
+// Package duckdbsql provides dbterm's database/sql adapter for DuckDB.
+//
+// Release builds are pure Go, so rather than linking DuckDB this adapter runs
+// the duckdb command-line client for each statement batch and decodes its JSON
+// output. Sessions are therefore stateless: SET, temporary tables, and
+// transactions do not carry over between statements.
+package duckdbsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DriverName is the database/sql name registered by this package.
+const DriverName = "dbterm-duckdb"
+
+// Executable names the duckdb client. Tests and packagers may override it.
+var Executable = "duckdb"
+
+func init() {
+	sql.Register(DriverName, &dbDriver{})
+}
+
+// dataFileExtensions are opened as a read-only view over an in-memory
+// database instead of as a DuckDB database file.
+var dataFileExtensions = []string{".parquet", ".csv", ".tsv", ".json", ".ndjson", ".jsonl", ".csv.gz", ".tsv.gz", ".json.gz"}
+
+// IsDataFile reports whether path is a Parquet, CSV, or JSON extract rather
+// than a DuckDB database.
+func IsDataFile(path string) bool {
+	lower := strings.ToLower(strings.TrimSpace(path))
+	for _, extension := range dataFileExtensions {
+		if strings.HasSuffix(lower, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// ViewName returns the view name a data file is exposed under: its base name
+// without extensions, limited to letters, digits, and underscores.
+func ViewName(path string) string {
+	base := filepath.Base(strings.TrimSpace(path))
+	if index := strings.IndexByte(base, '.'); index > 0 {
+		base = base[:index]
+	}
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, base)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "data_" + name
+	}
+	return name
+}
+
+// DSN returns the connection string for a DuckDB database or data file.
+func DSN(path string, readOnly bool) string {
+	values := url.Values{"path": {path}}
+	if readOnly {
+		values.Set("read_only", "true")
+	}
+	return "duckdb:?" + values.Encode()
+}
+
+type dsnConfig struct {
+	path     string
+	readOnly bool
+}
+
+func parseDSN(raw string) (dsnConfig, error) {
+	rest, ok := strings.CutPrefix(raw, "duckdb:?")
+	if !ok {
+		return dsnConfig{}, fmt.Errorf("DuckDB connection string must start with duckdb:?")
+	}
+	values, err := url.ParseQuery(rest)
+	if err != nil {
+		return dsnConfig{}, fmt.Errorf("parse DuckDB connection string: %w", err)
+	}
+	cfg := dsnConfig{path: strings.TrimSpace(values.Get("path")), readOnly: values.Get("read_only") == "true"}
+	if cfg.path == "" {
+		return dsnConfig{}, fmt.Errorf("DuckDB file path is required")
+	}
+	return cfg, nil
+}
+
+// LookPath resolves the duckdb client.
+func LookPath() (string, error) {
+	path, err := exec.LookPath(Executable)
+	if err != nil {
+		return "", fmt.Errorf("the duckdb command-line client was not found; install DuckDB and ensure duckdb is in PATH")
+	}
+	return path, nil
+}
+
+type dbDriver struct{}
+
+func (driverInstance *dbDriver) Open(name string) (driver.Conn, error) {
+	connector, err := driverInstance.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+func (*dbDriver) OpenConnector(name string) (driver.Connector, error) {
+	cfg, err := parseDSN(name)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{cfg: cfg}, nil
+}
+
+type connector struct {
+	cfg dsnConfig
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	executable, err := LookPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(c.cfg.path); err != nil && (IsDataFile(c.cfg.path) || c.cfg.readOnly) {
+		return nil, fmt.Errorf("open DuckDB file: %w", err)
+	}
+	return &connection{executable: executable, cfg: c.cfg}, nil
+}
+
+func (*connector) Driver() driver.Driver { return &dbDriver{} }
+
+type connection struct {
+	executable string
+	cfg        dsnConfig
+	closed     bool
+}
+
+func (c *connection) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *connection) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if !c.IsValid() {
+		return nil, driver.ErrBadConn
+	}
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return &statement{connection: c, query: query}, nil
+}
+
+func (c *connection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (*connection) Begin() (driver.Tx, error) { return nil, errNoTransactions }
+
+func (*connection) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, errNoTransactions
+}
+
+var errNoTransactions = errors.New("DuckDB transactions are not available through the duckdb command-line client; run BEGIN and COMMIT in the same query")
+
+func (c *connection) Ping(ctx context.Context) error {
+	_, err := c.run(ctx, "SELECT 1")
+	return err
+}
+
+func (c *connection) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+	if ctx != nil {
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (c *connection) IsValid() bool {
+	return c != nil && !c.closed && c.executable != ""
+}
+
+func (c *connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	bound, err := bindArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := c.run(ctx, bound)
+	if err != nil {
+		return nil, err
+	}
+	var affected int64
+	for _, set := range sets {
+		// DuckDB reports DML row counts as a one-column "Count" result.
+		if len(set.columns) == 1 && set.columns[0] == "Count" {
+			for _, row := range set.rows {
+				if count, ok := row[0].(int64); ok {
+					affected += count
+				}
+			}
+		}
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+func (c *connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	bound, err := bindArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := c.run(ctx, bound)
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		// The client prints nothing for an empty result, so ask DuckDB for the
+		// column names separately. Statements DESCRIBE rejects keep none.
+		if described, err := c.run(ctx, "DESCRIBE "+strings.TrimRight(strings.TrimSpace(bound), ";")); err == nil && len(described) == 1 {
+			sets = []resultSet{{columns: describedColumns(described[0])}}
+		}
+	}
+	return newRows(sets), nil
+}
+
+func describedColumns(set resultSet) []string {
+	index := -1
+	for position, column := range set.columns {
+		if column == "column_name" {
+			index = position
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+	columns := make([]string, 0, len(set.rows))
+	for _, row := range set.rows {
+		if name, ok := row[index].(string); ok {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// run executes script with the duckdb client and decodes every result set it
+// prints. Data files are exposed through a temporary view created first.
+func (c *connection) run(ctx context.Context, script string) ([]resultSet, error) {
+	if !c.IsValid() {
+		return nil, driver.ErrBadConn
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	args := []string{"-json", "-bail", "-init", os.DevNull}
+	var input strings.Builder
+	if IsDataFile(c.cfg.path) {
+		args = append(args, ":memory:")
+		fmt.Fprintf(&input, "CREATE TEMP VIEW %s AS SELECT * FROM %s;\n", quoteIdentifier(ViewName(c.cfg.path)), quoteLiteral(c.cfg.path))
+	} else {
+		if c.cfg.readOnly {
+			args = append(args, "-readonly")
+		}
+		args = append(args, c.cfg.path)
+	}
+	input.WriteString(script)
+	if !strings.HasSuffix(strings.TrimSpace(script), ";") {
+		input.WriteString(";")
+	}
+	input.WriteString("\n")
+
+	cmd := exec.CommandContext(ctx, c.executable, args...)
+	cmd.Stdin = strings.NewReader(input.String())
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, clientError(stderr.String(), stdout.String(), err)
+	}
+	// The client can report a statement error on stderr yet exit cleanly.
+	if message := strings.TrimSpace(stderr.String()); message != "" && strings.Contains(message, "Error") {
+		return nil, clientError(message, "", nil)
+	}
+	return parseOutput(stdout.Bytes())
+}
+
+func clientError(stderr, stdout string, runErr error) error {
+	message := strings.TrimSpace(stderr)
+	if message == "" {
+		message = strings.TrimSpace(stdout)
+	}
+	if message == "" && runErr != nil {
+		message = runErr.Error()
+	}
+	message = strings.TrimPrefix(message, "Error: ")
+	return fmt.Errorf("DuckDB: %s", message)
+}
+
+type resultSet struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// parseOutput decodes the concatenated JSON arrays the client prints, one per
+// statement that returned rows. Objects are read token by token so column
+// order and duplicate column names survive.
+func parseOutput(data []byte) ([]resultSet, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var sets []resultSet
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return sets, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode DuckDB output: %w", err)
+		}
+		if token != json.Delim('[') {
+			return nil, fmt.Errorf("decode DuckDB output: unexpected %v", token)
+		}
+		var set resultSet
+		for decoder.More() {
+			row, columns, err := decodeRow(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if set.columns == nil {
+				set.columns = columns
+			}
+			if len(row) != len(set.columns) {
+				return nil, fmt.Errorf("DuckDB returned %d values for %d result columns", len(row), len(set.columns))
+			}
+			set.rows = append(set.rows, row)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("decode DuckDB output: %w", err)
+		}
+		if set.columns != nil {
+			sets = append(sets, set)
+		}
+	}
+}
+
+func decodeRow(decoder *json.Decoder) ([]driver.Value, []string, error) {
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("decode DuckDB output: expected a row object")
+	}
+	var (
+		row     []driver.Value
+		columns []string
+	)
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode DuckDB output: %w", err)
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, fmt.Errorf("decode DuckDB output: %w", err)
+		}
+		value, err := rawValue(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("DuckDB column %q: %w", key, err)
+		}
+		columns = append(columns, fmt.Sprint(key))
+		row = append(row, value)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, fmt.Errorf("decode DuckDB output: %w", err)
+	}
+	return row, columns, nil
+}
+
+// rawValue maps a JSON value onto a driver value. Lists and structs keep
+// their JSON text; integers beyond int64 stay exact as text.
+func rawValue(raw json.RawMessage) (driver.Value, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	switch trimmed[0] {
+	case 'n':
+		return nil, nil
+	case 't':
+		return true, nil
+	case 'f':
+		return false, nil
+	case '"':
+		var text string
+		if err := json.Unmarshal(trimmed, &text); err != nil {
+			return nil, err
+		}
+		return text, nil
+	case '[', '{':
+		return string(trimmed), nil
+	}
+	number := json.Number(trimmed)
+	if integer, err := number.Int64(); err == nil {
+		return integer, nil
+	}
+	if !bytes.ContainsAny(trimmed, ".eE") {
+		return string(trimmed), nil
+	}
+	floating, err := number.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid numeric value")
+	}
+	return floating, nil
+}
+
+type statement struct {
+	connection *connection
+	query      string
+}
+
+func (*statement) Close() error  { return nil }
+func (*statement) NumInput() int { return -1 }
+
+func (s *statement) Exec(values []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamed(values))
+}
+
+func (s *statement) Query(values []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamed(values))
+}
+
+func (s *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if s == nil || s.connection == nil {
+		return nil, driver.ErrBadConn
+	}
+	return s.connection.ExecContext(ctx, s.query, args)
+}
+
+func (s *statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if s == nil || s.connection == nil {
+		return nil, driver.ErrBadConn
+	}
+	return s.connection.QueryContext(ctx, s.query, args)
+}
+
+type rows struct {
+	sets     []resultSet
+	setIndex int
+	rowIndex int
+	closed   bool
+}
+
+func newRows(sets []resultSet) *rows {
+	return &rows{sets: sets}
+}
+
+func (r *rows) Columns() []string {
+	if r == nil || r.closed || r.setIndex >= len(r.sets) {
+		return nil
+	}
+	return append([]string(nil), r.sets[r.setIndex].columns...)
+}
+
+func (r *rows) Close() error {
+	if r != nil {
+		r.closed = true
+		r.sets = nil
+	}
+	return nil
+}
+
+func (r *rows) Next(destination []driver.Value) error {
+	if r == nil || r.closed || r.setIndex >= len(r.sets) {
+		return io.EOF
+	}
+	current := r.sets[r.setIndex]
+	if r.rowIndex >= len(current.rows) {
+		return io.EOF
+	}
+	copy(destination, current.rows[r.rowIndex])
+	r.rowIndex++
+	return nil
+}
+
+func (r *rows) HasNextResultSet() bool {
+	return r != nil && !r.closed && r.setIndex+1 < len(r.sets)
+}
+
+func (r *rows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.setIndex++
+	r.rowIndex = 0
+	return nil
+}
+
+// bindArgs substitutes ? and $N placeholders outside quotes and comments with
+// SQL literals, since the client cannot bind parameters.
+func bindArgs(query string, args []driver.NamedValue) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+	for _, arg := range args {
+		if arg.Name != "" {
+			return "", fmt.Errorf("DuckDB named parameters are not supported; use ? or $1 placeholders")
+		}
+	}
+	var out strings.Builder
+	next := 0
+	used := 0
+	for index := 0; index < len(query); index++ {
+		character := query[index]
+		switch {
+		case character == '\'' || character == '"':
+			end := index + 1
+			for end < len(query) {
+				if query[end] == character {
+					if end+1 < len(query) && query[end+1] == character {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			out.WriteString(query[index:min(end+1, len(query))])
+			index = end
+		case character == '-' && index+1 < len(query) && query[index+1] == '-':
+			end := strings.IndexByte(query[index:], '\n')
+			if end < 0 {
+				end = len(query) - index
+			}
+			out.WriteString(query[index : index+end])
+			index += end - 1
+		case character == '/' && index+1 < len(query) && query[index+1] == '*':
+			end := strings.Index(query[index+2:], "*/")
+			if end < 0 {
+				out.WriteString(query[index:])
+				index = len(query)
+				break
+			}
+			out.WriteString(query[index : index+end+4])
+			index += end + 3
+		case character == '?':
+			if next >= len(args) {
+				return "", fmt.Errorf("query has more placeholders than the %d supplied arguments", len(args))
+			}
+			literal, err := quoteValue(args[next].Value)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(literal)
+			next++
+			used = max(used, next)
+		case character == '$' && index+1 < len(query) && query[index+1] >= '0' && query[index+1] <= '9':
+			end := index + 1
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			ordinal, _ := strconv.Atoi(query[index+1 : end])
+			if ordinal < 1 || ordinal > len(args) {
+				return "", fmt.Errorf("placeholder $%d has no matching argument", ordinal)
+			}
+			literal, err := quoteValue(args[ordinal-1].Value)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(literal)
+			used = max(used, ordinal)
+			index = end - 1
+		default:
+			out.WriteByte(character)
+		}
+	}
+	if used != len(args) {
+		return "", fmt.Errorf("query uses %d of the %d supplied arguments", used, len(args))
+	}
+	return out.String(), nil
+}
+
+func quoteValue(value driver.Value) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "NULL", nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			return quoteLiteral(strconv.FormatFloat(typed, 'g', -1, 64)) + "::DOUBLE", nil
+		}
+		return strconv.FormatFloat(typed, 'g', -1, 64), nil
+	case bool:
+		if typed {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case string:
+		return quoteLiteral(typed), nil
+	case []byte:
+		return "from_hex('" + hex.EncodeToString(typed) + "')", nil
+	case time.Time:
+		return "TIMESTAMPTZ " + quoteLiteral(typed.Format(time.RFC3339Nano)), nil
+	default:
+		return "", fmt.Errorf("unsupported DuckDB argument type %T", value)
+	}
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func quoteIdentifier(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+func valuesToNamed(values []driver.Value) []driver.NamedValue {
+	result := make([]driver.NamedValue, len(values))
+	for index, value := range values {
+		result[index] = driver.NamedValue{Ordinal: index + 1, Value: value}
+	}
+	return result
+}
+
+var (
+	_ driver.Driver             = (*dbDriver)(nil)
+	_ driver.DriverContext      = (*dbDriver)(nil)
+	_ driver.Connector          = (*connector)(nil)
+	_ driver.Conn               = (*connection)(nil)
+	_ driver.ConnPrepareContext = (*connection)(nil)
+	_ driver.ConnBeginTx        = (*connection)(nil)
+	_ driver.ExecerContext      = (*connection)(nil)
+	_ driver.QueryerContext     = (*connection)(nil)
+	_ driver.Pinger             = (*connection)(nil)
+	_ driver.SessionResetter    = (*connection)(nil)
+	_ driver.Validator          = (*connection)(nil)
+	_ driver.Stmt               = (*statement)(nil)
+	_ driver.StmtExecContext    = (*statement)(nil)
+	_ driver.StmtQueryContext   = (*statement)(nil)
+	_ driver.Rows               = (*rows)(nil)
+	_ driver.RowsNextResultSet  = (*rows)(nil)
+)
@@ -0,0 +1,127 @@
+package duckdbsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDSNRoundTripAndDataFiles(t *testing.T) {
+	cfg, err := parseDSN(DSN("/data/my events.parquet", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.path != "/data/my events.parquet" || !cfg.readOnly {
+		t.Fatalf("parseDSN = %+v", cfg)
+	}
+	if _, err := parseDSN("duckdb:?read_only=true"); err == nil {
+		t.Fatal("DSN without a path was accepted")
+	}
+	for path, want := range map[string]bool{
+		"events.parquet": true, "export.CSV": true, "logs.ndjson": true, "rows.csv.gz": true,
+		"warehouse.duckdb": false, "notes.txt": false,
+	} {
+		if got := IsDataFile(path); got != want {
+			t.Fatalf("IsDataFile(%q) = %t, want %t", path, got, want)
+		}
+	}
+	if got := ViewName("/tmp/2024 Sales-Q1.csv.gz"); got != "data_2024_sales_q1" {
+		t.Fatalf("ViewName = %q", got)
+	}
+}
+
+func TestParseOutputKeepsColumnOrderAndResultSets(t *testing.T) {
+	output := `[{"z":1,"a":"x","z":2.5,"nested":{"k":[1,2]},"big":18446744073709551615,"flag":true,"none":null}]
+[{"Count":3}]
+[]
+`
+	sets, err := parseOutput([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("sets = %d, want 2", len(sets))
+	}
+	if want := []string{"z", "a", "z", "nested", "big", "flag", "none"}; !reflect.DeepEqual(sets[0].columns, want) {
+		t.Fatalf("columns = %v, want %v", sets[0].columns, want)
+	}
+	want := []driver.Value{int64(1), "x", 2.5, `{"k":[1,2]}`, "18446744073709551615", true, nil}
+	if !reflect.DeepEqual(sets[0].rows[0], want) {
+		t.Fatalf("row = %#v, want %#v", sets[0].rows[0], want)
+	}
+	if _, err := parseOutput([]byte(`[{"a":1},{"a":1,"b":2}]`)); err == nil {
+		t.Fatal("ragged rows were accepted")
+	}
+}
+
+func TestBindArgsSkipsQuotesAndComments(t *testing.T) {
+	stamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	query := `SELECT '?', "$1" -- ?
+FROM t /* $2 */ WHERE a = ? AND b = ? AND c = ? AND d = ?`
+	got, err := bindArgs(query, []driver.NamedValue{
+		{Ordinal: 1, Value: "O'Brien"}, {Ordinal: 2, Value: nil}, {Ordinal: 3, Value: []byte{0xca, 0xfe}}, {Ordinal: 4, Value: stamp},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT '?', "$1" -- ?
+FROM t /* $2 */ WHERE a = 'O''Brien' AND b = NULL AND c = from_hex('cafe') AND d = TIMESTAMPTZ '2024-05-01T12:00:00Z'`
+	if got != want {
+		t.Fatalf("bindArgs =\n%s\nwant\n%s", got, want)
+	}
+	if got, err := bindArgs("SELECT $2, $1, $2", []driver.NamedValue{{Ordinal: 1, Value: int64(7)}, {Ordinal: 2, Value: true}}); err != nil || got != "SELECT TRUE, 7, TRUE" {
+		t.Fatalf("numbered bindArgs = %q, %v", got, err)
+	}
+	if _, err := bindArgs("SELECT ?", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: int64(2)}}); err == nil {
+		t.Fatal("unused argument was accepted")
+	}
+}
+
+func TestQueryRunsClientWithDataFileView(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake client is a shell script")
+	}
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "events.csv")
+	if err := os.WriteFile(dataFile, []byte("id\n1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// The fake client records its arguments and script, then prints one row.
+	script := "#!/bin/sh\necho \"$@\" > \"$0.args\"\ncat > \"$0.sql\"\necho '[{\"id\":1}]'\n"
+	client := filepath.Join(dir, "duckdb")
+	if err := os.WriteFile(client, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	previous := Executable
+	Executable = client
+	t.Cleanup(func() { Executable = previous })
+
+	db, err := sql.Open(DriverName, DSN(dataFile, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var id int
+	if err := db.QueryRowContext(context.Background(), "SELECT id FROM events WHERE id = ?", 1).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Fatalf("id = %d", id)
+	}
+	args, _ := os.ReadFile(client + ".args")
+	if !strings.Contains(string(args), "-json") || !strings.Contains(string(args), ":memory:") {
+		t.Fatalf("client args = %q", args)
+	}
+	input, _ := os.ReadFile(client + ".sql")
+	wantView := `CREATE TEMP VIEW "events" AS SELECT * FROM '` + dataFile + `';`
+	if !strings.Contains(string(input), wantView) || !strings.Contains(string(input), "WHERE id = 1;") {
+		t.Fatalf("client script = %q", input)
+	}
+}
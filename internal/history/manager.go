@@ -13,6 +13,9 @@ import (
 const (
 	DefaultFileName                = "history.json"
 	DefaultMaxEntriesPerConnection = 200
+
+	// maxParamQueries caps how many queries keep their last parameter values.
+	maxParamQueries = 500
 )
 
 // Entry is a single history record.
@@ -21,8 +24,22 @@ type Entry struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ParamValue is the last input for one placeholder of a query: the
+// placeholder name, the chosen type, and the text as typed.
+type ParamValue struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type paramSet struct {
+	Values []ParamValue `json:"values"`
+	UsedAt time.Time    `json:"used_at"`
+}
+
 type fileState struct {
-	Connections map[string][]Entry `json:"connections"`
+	Connections map[string][]Entry  `json:"connections"`
+	Params      map[string]paramSet `json:"params,omitempty"`
 }
 
 // Manager handles query history persistence.
@@ -110,6 +127,51 @@ func (m *Manager) Entries(connectionKey string) []Entry {
 	return cloneEntries(m.state.Connections[key])
 }
 
+// Params returns the values last used for a query. Queries that differ only
+// in whitespace share their values.
+func (m *Manager) Params(query string) []ParamValue {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	set, ok := m.state.Params[paramKey(query)]
+	if !ok {
+		return nil
+	}
+	return append([]ParamValue(nil), set.Values...)
+}
+
+// SaveParams remembers the values used for a query, forgetting the least
+// recently used queries beyond the cap.
+func (m *Manager) SaveParams(query string, values []ParamValue) error {
+	key := paramKey(query)
+	if key == "" || len(values) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state.Params == nil {
+		m.state.Params = map[string]paramSet{}
+	}
+	m.state.Params[key] = paramSet{Values: append([]ParamValue(nil), values...), UsedAt: m.now().UTC()}
+	for len(m.state.Params) > maxParamQueries {
+		oldest := ""
+		for candidate, set := range m.state.Params {
+			if oldest == "" || set.UsedAt.Before(m.state.Params[oldest].UsedAt) {
+				oldest = candidate
+			}
+		}
+		delete(m.state.Params, oldest)
+	}
+
+	return m.saveLocked()
+}
+
+func paramKey(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
 // Load refreshes manager state from disk.
 func (m *Manager) Load() error {
 	m.mu.Lock()
@@ -148,3 +148,30 @@ func TestManagerInputValidation(t *testing.T) {
 		t.Fatalf("Entries(conn-a) len = %d, want 0", len(got))
 	}
 }
+
+func TestManagerRemembersParamsPerQuery(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	m, err := NewManagerAt(path, 10)
+	if err != nil {
+		t.Fatalf("NewManagerAt() error = %v", err)
+	}
+
+	values := []ParamValue{{Name: ":id", Type: "Integer", Value: "42"}}
+	if err := m.SaveParams("SELECT * FROM t\nWHERE id = :id", values); err != nil {
+		t.Fatalf("SaveParams() error = %v", err)
+	}
+
+	reloaded, err := NewManagerAt(path, 10)
+	if err != nil {
+		t.Fatalf("NewManagerAt(reload) error = %v", err)
+	}
+	if got := reloaded.Params("SELECT *  FROM t WHERE id = :id"); len(got) != 1 || got[0] != values[0] {
+		t.Fatalf("Params() = %#v, want %#v", got, values)
+	}
+	if got := reloaded.Params("SELECT 1"); got != nil {
+		t.Fatalf("Params(unknown) = %#v, want nil", got)
+	}
+}
@@ -30,7 +30,7 @@ func (s *service) followRecord(ctx context.Context, input followRecordInput) (fo
 	if err != nil {
 		return followRecordOutput{}, fmt.Errorf("resolve connection [%s]: %w", auditID, err)
 	}
-	db, queryCtx, closeFn, err := s.connect(ctx, cfg)
+	db, queryCtx, closeFn, err := s.connect(ctx, &cfg)
 	if err != nil {
 		return followRecordOutput{}, fmt.Errorf("follow record failed [%s]: %w", auditID, err)
 	}
@@ -241,7 +241,7 @@ func sameTable(cfg config.ConnectionConfig, a, b string) bool {
 		defaultSchema = "public"
 	case config.MySQL:
 		defaultSchema = cfg.Database
-	case config.SQLite, config.Turso:
+	case config.SQLite, config.Turso, config.DuckDB:
 		defaultSchema = "main"
 	}
 	if as == "" {
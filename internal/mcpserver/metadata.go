@@ -19,7 +19,7 @@ func (s *service) inspectDatabase(ctx context.Context, input inspectDatabaseInpu
 	if err != nil {
 		return inspectDatabaseOutput{}, err
 	}
-	db, queryCtx, closeFn, err := s.connect(ctx, cfg)
+	db, queryCtx, closeFn, err := s.connect(ctx, &cfg)
 	if err != nil {
 		return inspectDatabaseOutput{}, err
 	}
@@ -44,7 +44,7 @@ func (s *service) inspectTable(ctx context.Context, input inspectTableInput) (in
 	if _, _, err := splitTableName(input.Table); err != nil {
 		return inspectTableOutput{}, err
 	}
-	db, queryCtx, closeFn, err := s.connect(ctx, cfg)
+	db, queryCtx, closeFn, err := s.connect(ctx, &cfg)
 	if err != nil {
 		return inspectTableOutput{}, err
 	}
@@ -78,6 +78,8 @@ func listSchemas(ctx context.Context, db *sql.DB, dbType config.DBType, max int)
 		query = `SELECT schema_name FROM information_schema.schemata WHERE schema_name NOT IN ('information_schema', 'mysql', 'performance_schema', 'sys') ORDER BY schema_name`
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		query = `PRAGMA database_list`
+	case config.DuckDB:
+		query = `SELECT schema_name FROM information_schema.schemata WHERE catalog_name = current_database() AND schema_name NOT IN ('information_schema', 'pg_catalog') ORDER BY schema_name`
 	default:
 		return nil, false, fmt.Errorf("unsupported database type %q", dbType)
 	}
@@ -184,6 +186,15 @@ FROM information_schema.columns c WHERE c.table_schema=$1 AND c.table_name=$2 OR
 		rows, err := db.QueryContext(ctx, `SELECT column_name, column_type, is_nullable, COALESCE(column_default, ''), column_key='PRI'
 FROM information_schema.columns WHERE table_schema=? AND table_name=? ORDER BY ordinal_position`, schema, tableOnly)
 		return scanInformationSchemaColumns(rows, err)
+	case config.DuckDB:
+		if schema == "" {
+			schema = "main"
+		}
+		rows, err := db.QueryContext(ctx, `SELECT c.column_name, c.data_type, c.is_nullable, COALESCE(c.column_default, ''),
+EXISTS (SELECT 1 FROM duckdb_constraints() k WHERE k.constraint_type='PRIMARY KEY' AND k.schema_name=c.table_schema
+AND k.table_name=c.table_name AND list_contains(k.constraint_column_names, c.column_name))
+FROM information_schema.columns c WHERE c.table_schema=$1 AND c.table_name=$2 ORDER BY c.ordinal_position`, schema, tableOnly)
+		return scanInformationSchemaColumns(rows, err)
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", quoteIdentifier(cfg.Type, tableOnly)))
 		if err != nil {
@@ -277,6 +288,10 @@ ORDER BY constraint_name, ordinal_position`, schema, tableOnly)
 			result = appendFKColumn(result, name, tableOnly, target, sourceCol, targetCol)
 		}
 		return result, rows.Err()
+	case config.DuckDB:
+		// DuckDB's constraint catalog does not pair referenced columns
+		// consistently across releases, so no foreign keys are reported.
+		return nil, nil
 	default:
 		return nil, fmt.Errorf("unsupported database type %q", cfg.Type)
 	}
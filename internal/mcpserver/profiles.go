@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
+	"github.com/shreyam1008/dbterm/internal/secrets"
 )
 
 func (s *service) listConnections() (listConnectionsOutput, error) {
@@ -36,6 +38,11 @@ func (s *service) saveProfile(ctx context.Context, input saveProfileInput) (save
 		s.logAudit(auditID, "save_connection_profile", input.ID, "denied", "profile writes disabled", started)
 		return saveProfileOutput{}, fmt.Errorf("profile writes are disabled [%s]", auditID)
 	}
+	if secrets.Locked() {
+		// Updates would otherwise validate without the sealed credentials.
+		s.logAudit(auditID, "save_connection_profile", input.ID, "denied", "secrets vault locked", started)
+		return saveProfileOutput{}, fmt.Errorf("%w; start the MCP server with %s or %s [%s]", secrets.ErrLocked, secrets.EnvKeyFile, secrets.EnvPassphraseFile, auditID)
+	}
 	store, err := s.options.StoreLoader()
 	if err != nil {
 		return saveProfileOutput{}, fmt.Errorf("load profiles [%s]: %w", auditID, err)
@@ -62,6 +69,17 @@ func (s *service) saveProfile(ctx context.Context, input saveProfileInput) (save
 		}
 	}
 
+	// ${ENV_VAR} references would let an agent read the server's environment.
+	for _, field := range []struct{ label, value, existing string }{
+		{"user", input.User, existing.User}, {"password", input.Password, existing.Password},
+		{"auth_token", input.AuthToken, existing.AuthToken}, {"ssh_password", input.SSHPassword, existing.SSHPassword},
+	} {
+		if config.HasCredentialReference(field.value) && field.value != field.existing {
+			s.logAudit(auditID, "save_connection_profile", input.ID, "denied", "environment reference in "+field.label, started)
+			return saveProfileOutput{}, fmt.Errorf("%s cannot reference environment variables in agent-saved profiles [%s]", field.label, auditID)
+		}
+	}
+
 	candidate := config.ConnectionConfig{
 		ID: input.ID, Name: strings.TrimSpace(input.Name), Type: input.Type,
 		Host: strings.TrimSpace(input.Host), Port: strings.TrimSpace(input.Port), User: input.User,
@@ -69,6 +87,13 @@ func (s *service) saveProfile(ctx context.Context, input saveProfileInput) (save
 		FilePath: strings.TrimSpace(input.FilePath), SSLMode: strings.TrimSpace(input.SSLMode),
 		AccountID: strings.TrimSpace(input.AccountID), DatabaseID: strings.TrimSpace(input.DatabaseID),
 		AuthToken: input.AuthToken,
+		SSHHost:   strings.TrimSpace(input.SSHHost), SSHPort: strings.TrimSpace(input.SSHPort), SSHUser: strings.TrimSpace(input.SSHUser),
+		SSHPassword: input.SSHPassword, SSHKeyFile: strings.TrimSpace(input.SSHKeyFile), SSHUseAgent: input.SSHUseAgent,
+		SSHKnownHosts: strings.TrimSpace(input.SSHKnownHosts), SSHJumpHosts: strings.TrimSpace(input.SSHJumpHosts),
+		TLSCAFile: strings.TrimSpace(input.TLSCAFile), TLSCertFile: strings.TrimSpace(input.TLSCertFile),
+		TLSKeyFile: strings.TrimSpace(input.TLSKeyFile), TLSServerName: strings.TrimSpace(input.TLSServerName),
+		Environment: input.Environment, Color: input.Color,
+		InitSQL: strings.TrimSpace(input.InitSQL), StatementTimeout: strings.TrimSpace(input.StatementTimeout),
 	}
 	if input.ReadOnly == nil {
 		candidate.ReadOnly = true
@@ -76,13 +101,18 @@ func (s *service) saveProfile(ctx context.Context, input saveProfileInput) (save
 		candidate.ReadOnly = *input.ReadOnly
 	}
 	if !created {
+		// Agents cannot set a password command, but keep one the user saved.
 		candidate.Active, candidate.LastUsed = existing.Active, existing.LastUsed
+		candidate.PasswordCommand = existing.PasswordCommand
 		if candidate.Password == "" {
 			candidate.Password = existing.Password
 		}
 		if candidate.AuthToken == "" {
 			candidate.AuthToken = existing.AuthToken
 		}
+		if candidate.SSHPassword == "" && candidate.SSHHost == existing.SSHHost {
+			candidate.SSHPassword = existing.SSHPassword
+		}
 	}
 	applyProfileDefaults(&candidate)
 	if err := validateProfile(candidate); err != nil {
@@ -91,11 +121,19 @@ func (s *service) saveProfile(ctx context.Context, input saveProfileInput) (save
 	}
 
 	// Refuse to persist credentials that do not connect. Connector errors are
-	// scrubbed before returning and the raw input is never logged.
-	db, err := s.options.Connector(&candidate)
+	// scrubbed before returning and the raw input is never logged. A host the
+	// agent chose must not receive the user's ~/.pgpass or ~/.my.cnf password.
+	agentEndpoint := created || candidate.Host != existing.Host || candidate.Port != existing.Port ||
+		candidate.SSHHost != existing.SSHHost
+	resolved, err := candidate.ResolveCredentialsWith(config.CredentialOptions{SkipPasswordFiles: agentEndpoint})
+	if err != nil {
+		s.logAudit(auditID, "save_connection_profile", input.ID, "error", "credential resolution failed", started)
+		return saveProfileOutput{}, fmt.Errorf("connection validation failed [%s]: %w", auditID, err)
+	}
+	db, err := s.options.Connector(resolved)
 	if err != nil {
 		s.logAudit(auditID, "save_connection_profile", input.ID, "error", "connection validation failed", started)
-		return saveProfileOutput{}, fmt.Errorf("connection validation failed [%s]: %s", auditID, redactError(err, candidate))
+		return saveProfileOutput{}, fmt.Errorf("connection validation failed [%s]: %s", auditID, redactError(err, *resolved))
 	}
 	db.Close()
 	if created {
@@ -122,36 +160,65 @@ func applyProfileDefaults(candidate *config.ConnectionConfig) {
 	if candidate == nil {
 		return
 	}
+	if !candidate.UsesSSHTunnel() {
+		clearSSHTunnel(candidate)
+	}
 	switch candidate.Type {
 	case config.PostgreSQL:
 		candidate.FilePath, candidate.AuthToken, candidate.AccountID, candidate.DatabaseID = "", "", "", ""
 		if candidate.Port == "" {
 			candidate.Port = "5432"
 		}
-		if candidate.SSLMode == "" {
+		if candidate.SSLMode == "" && !candidate.HasTLSFiles() {
 			candidate.SSLMode = "require"
 		}
 	case config.MySQL:
-		candidate.FilePath, candidate.SSLMode, candidate.AuthToken, candidate.AccountID, candidate.DatabaseID = "", "", "", "", ""
+		candidate.FilePath, candidate.AuthToken, candidate.AccountID, candidate.DatabaseID = "", "", "", ""
 		if candidate.Port == "" {
 			candidate.Port = "3306"
 		}
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		candidate.Host, candidate.Port, candidate.User, candidate.Password, candidate.Database = "", "", "", "", ""
 		candidate.SSLMode, candidate.AuthToken, candidate.AccountID, candidate.DatabaseID = "", "", "", ""
+		clearTLS(candidate)
+		if candidate.Type == config.DuckDB && duckdbsql.IsDataFile(candidate.FilePath) {
+			candidate.ReadOnly = true
+		}
 	case config.Turso:
 		candidate.Port, candidate.User, candidate.Password, candidate.Database, candidate.FilePath = "", "", "", "", ""
 		candidate.SSLMode, candidate.AccountID, candidate.DatabaseID = "", "", ""
+		clearTLS(candidate)
 	case config.CloudflareD1:
 		candidate.Host, candidate.Port, candidate.User, candidate.Password, candidate.Database = "", "", "", "", ""
 		candidate.FilePath, candidate.SSLMode = "", ""
+		clearTLS(candidate)
 	}
+	candidate.Environment = config.NormalizeEnvironment(candidate.Environment)
+}
+
+func clearTLS(candidate *config.ConnectionConfig) {
+	candidate.TLSCAFile, candidate.TLSCertFile, candidate.TLSKeyFile, candidate.TLSServerName = "", "", "", ""
+}
+
+func clearSSHTunnel(candidate *config.ConnectionConfig) {
+	candidate.SSHHost, candidate.SSHPort, candidate.SSHUser, candidate.SSHPassword = "", "", "", ""
+	candidate.SSHKeyFile, candidate.SSHKnownHosts, candidate.SSHJumpHosts = "", "", ""
+	candidate.SSHUseAgent = false
 }
 
 func validateProfile(candidate config.ConnectionConfig) error {
 	if candidate.Name == "" {
 		return fmt.Errorf("name is required")
 	}
+	if err := candidate.ValidateEnvironment(); err != nil {
+		return err
+	}
+	if err := candidate.ValidateSession(); err != nil {
+		return err
+	}
+	if err := validateSessionSQL(candidate.SessionStatements()); err != nil {
+		return err
+	}
 	switch candidate.Type {
 	case config.PostgreSQL, config.MySQL:
 		if candidate.Host == "" {
@@ -163,16 +230,29 @@ func validateProfile(candidate config.ConnectionConfig) error {
 		if candidate.User == "" {
 			return fmt.Errorf("user is required for %s", candidate.Type)
 		}
+		if candidate.UsesSSHTunnel() && candidate.SSHUser == "" {
+			return fmt.Errorf("ssh_user is required when ssh_host is set")
+		}
+		if strings.TrimSpace(candidate.TLSKeyFile) != "" && strings.TrimSpace(candidate.TLSCertFile) == "" {
+			return fmt.Errorf("tls_cert_file is required when tls_key_file is set")
+		}
+		if err := candidate.ValidateTLS(); err != nil {
+			return err
+		}
 	case config.SQLite:
 		if candidate.FilePath == "" {
 			return fmt.Errorf("file_path is required for sqlite")
 		}
+	case config.DuckDB:
+		if candidate.FilePath == "" {
+			return fmt.Errorf("file_path is required for duckdb")
+		}
 	case config.Turso:
 		if candidate.Host == "" {
 			return fmt.Errorf("host is required for turso")
 		}
 	case config.CloudflareD1:
-		if candidate.AccountID == "" || candidate.DatabaseID == "" || candidate.AuthToken == "" {
+		if candidate.AccountID == "" || candidate.DatabaseID == "" || (candidate.AuthToken == "" && candidate.PasswordCommand == "") {
 			return fmt.Errorf("account_id, database_id, and auth_token are required for d1")
 		}
 	default:
@@ -23,7 +23,7 @@ func (s *service) queryReadOnly(ctx context.Context, input readQueryInput) (quer
 		s.logAudit(auditID, "query_read_only", input.ConnectionID, "denied", err.Error(), started)
 		return queryOutput{}, fmt.Errorf("resolve connection [%s]: %w", auditID, err)
 	}
-	db, queryCtx, closeFn, err := s.connect(ctx, cfg)
+	db, queryCtx, closeFn, err := s.connect(ctx, &cfg)
 	if err != nil {
 		s.logAudit(auditID, "query_read_only", cfg.ID, "error", err.Error(), started)
 		return queryOutput{}, fmt.Errorf("query failed [%s]: %w", auditID, err)
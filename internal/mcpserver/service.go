@@ -125,9 +125,21 @@ func (s *service) resolveConnection(id string) (config.ConnectionConfig, error)
 	return config.ConnectionConfig{}, fmt.Errorf("connection %q is not present in the configured MCP scope", id)
 }
 
-func (s *service) connect(ctx context.Context, cfg config.ConnectionConfig) (*sql.DB, context.Context, context.CancelFunc, error) {
-	ctx, cancel := context.WithTimeout(ctx, s.limits.queryTimeout)
-	connectCfg := cfg
+// connect resolves cfg's credentials in place, so later redactError calls
+// scrub the resolved values, then opens it within the tool timeout.
+func (s *service) connect(ctx context.Context, cfg *config.ConnectionConfig) (*sql.DB, context.Context, context.CancelFunc, error) {
+	resolved, err := cfg.ResolveCredentials()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("connect to profile %q: %w", cfg.ID, err)
+	}
+	*cfg = *resolved
+	// A profile's own statement timeout may tighten the MCP limit, never loosen it.
+	timeout := s.limits.queryTimeout
+	if configured := cfg.StatementTimeoutDuration(); configured > 0 && configured < timeout {
+		timeout = configured
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	connectCfg := *cfg
 	if cfg.Type == config.SQLite {
 		readOnlyPath, err := sqliteReadOnlyDSN(cfg.FilePath)
 		if err != nil {
@@ -137,10 +149,14 @@ func (s *service) connect(ctx context.Context, cfg config.ConnectionConfig) (*sq
 		connectCfg.FilePath = readOnlyPath
 		connectCfg.ReadOnly = true
 	}
+	if cfg.Type == config.DuckDB {
+		// Opens the file with the client's -readonly flag.
+		connectCfg.ReadOnly = true
+	}
 	db, err := s.options.Connector(&connectCfg)
 	if err != nil {
 		cancel()
-		return nil, nil, nil, fmt.Errorf("connect to profile %q: %s", cfg.ID, redactError(err, cfg))
+		return nil, nil, nil, fmt.Errorf("connect to profile %q: %s", cfg.ID, redactError(err, *cfg))
 	}
 	return db, ctx, func() { cancel(); db.Close() }, nil
 }
@@ -179,7 +195,7 @@ func redactError(err error, cfg config.ConnectionConfig) string {
 		return ""
 	}
 	text := err.Error()
-	for _, secret := range []string{cfg.Password, cfg.AuthToken, cfg.BuildConnString()} {
+	for _, secret := range []string{cfg.Password, cfg.AuthToken, cfg.SSHPassword, cfg.BuildConnString()} {
 		if strings.TrimSpace(secret) != "" {
 			text = strings.ReplaceAll(text, secret, "[redacted]")
 		}
@@ -192,7 +208,7 @@ func summary(connection config.ConnectionConfig) connectionSummary {
 	if connection.Port != "" && endpoint != "" {
 		endpoint += ":" + connection.Port
 	}
-	if connection.Type == config.SQLite {
+	if connection.Type == config.SQLite || connection.Type == config.DuckDB {
 		endpoint = connection.FilePath
 	}
 	if connection.Type == config.CloudflareD1 {
@@ -200,8 +216,8 @@ func summary(connection config.ConnectionConfig) connectionSummary {
 	}
 	return connectionSummary{
 		ID: connection.ID, Name: connection.Name, Type: connection.Type,
-		Database: connection.Database, Endpoint: endpoint, ReadOnly: connection.ReadOnly,
-		Active: connection.Active, LastUsed: connection.LastUsed,
+		Database: connection.Database, Endpoint: endpoint, SSHHost: sanitizeEndpoint(connection.SSHHost), SSLMode: connection.EffectiveSSLMode(), Env: connection.EnvironmentName(),
+		ReadOnly: connection.ReadOnly, Active: connection.Active, LastUsed: connection.LastUsed,
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -84,7 +85,7 @@ func TestSQLiteDriverReadOnlyTransactionBlocksWrites(t *testing.T) {
 		t.Fatal(err)
 	}
 	service := newService(Options{AuditWriter: &bytes.Buffer{}})
-	readonlyDB, queryCtx, closeFn, err := service.connect(context.Background(), config.ConnectionConfig{ID: "sqlite", Type: config.SQLite, FilePath: path})
+	readonlyDB, queryCtx, closeFn, err := service.connect(context.Background(), &config.ConnectionConfig{ID: "sqlite", Type: config.SQLite, FilePath: path})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -337,3 +338,83 @@ func TestSaveD1ProfileDefaultsReadOnlyAndKeepsTokenWriteOnly(t *testing.T) {
 		t.Fatalf("D1 token leaked in MCP output: %s", encoded)
 	}
 }
+
+func TestConnectRedactsResolvedCredentials(t *testing.T) {
+	t.Setenv("DBTERM_TEST_MCP_PASSWORD", "resolved-secret")
+	service := newService(Options{AuditWriter: &bytes.Buffer{}, Connector: func(cfg *config.ConnectionConfig) (*sql.DB, error) {
+		return nil, errors.New("authentication failed for password " + cfg.Password)
+	}})
+	_, _, _, err := service.connect(context.Background(), &config.ConnectionConfig{
+		ID: "pg", Type: config.PostgreSQL, Host: "db.example.com", User: "app", Password: "${DBTERM_TEST_MCP_PASSWORD}",
+	})
+	if err == nil || strings.Contains(err.Error(), "resolved-secret") {
+		t.Fatalf("connect error = %v", err)
+	}
+}
+
+func TestSaveProfileSkipsPasswordFilesForAgentChosenHosts(t *testing.T) {
+	t.Setenv("DBTERM_CONFIG_DIR", t.TempDir())
+	t.Setenv("DBTERM_STATE_DIR", t.TempDir())
+	pgpass := filepath.Join(t.TempDir(), "pgpass")
+	if err := os.WriteFile(pgpass, []byte("*:*:*:*:pgpass-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PGPASSFILE", pgpass)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if err := os.WriteFile(filepath.Join(home, ".my.cnf"), []byte("[client]\npassword=mycnf-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var dialed []string
+	store := &config.Store{}
+	service := newService(Options{
+		AllowProfileWrites: true, ConnectionScope: "all", AuditWriter: &bytes.Buffer{},
+		StoreLoader: func() (*config.Store, error) { return store, nil },
+		Connector: func(cfg *config.ConnectionConfig) (*sql.DB, error) {
+			dialed = append(dialed, cfg.Password)
+			return sql.Open("sqlite", ":memory:")
+		},
+	})
+	for _, input := range []saveProfileInput{
+		{Name: "agent-pg", Type: config.PostgreSQL, Host: "attacker.example", User: "app"},
+		{Name: "agent-mysql", Type: config.MySQL, Host: "attacker.example", User: "app"},
+	} {
+		if _, err := service.saveProfile(context.Background(), input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, password := range dialed {
+		if password != "" {
+			t.Fatalf("validation sent a stored password to an agent-chosen host: %q", password)
+		}
+	}
+
+	// Renaming a profile keeps its host, so the user's password file applies.
+	dialed = nil
+	if _, err := service.saveProfile(context.Background(), saveProfileInput{
+		ID: store.Connections[0].ID, Name: "renamed", Type: config.PostgreSQL, Host: "attacker.example", User: "app",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(dialed) != 1 || dialed[0] != "pgpass-secret" {
+		t.Fatalf("unchanged endpoint skipped ~/.pgpass: %q", dialed)
+	}
+}
+
+func TestSaveProfileRejectsEnvironmentReferences(t *testing.T) {
+	t.Setenv("DBTERM_CONFIG_DIR", t.TempDir())
+	t.Setenv("DBTERM_STATE_DIR", t.TempDir())
+	store := &config.Store{}
+	service := newService(Options{
+		AllowProfileWrites: true, ConnectionScope: "all", AuditWriter: &bytes.Buffer{},
+		StoreLoader: func() (*config.Store, error) { return store, nil },
+		Connector:   func(*config.ConnectionConfig) (*sql.DB, error) { return sql.Open("sqlite", ":memory:") },
+	})
+	_, err := service.saveProfile(context.Background(), saveProfileInput{
+		Name: "agent-pg", Type: config.PostgreSQL, Host: "db.example.com", User: "app", Password: "${AWS_SECRET_ACCESS_KEY}",
+	})
+	if err == nil || len(store.Connections) != 0 {
+		t.Fatalf("environment reference was accepted: %v", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+
+	"github.com/shreyam1008/dbterm/internal/sqlfmt"
 )
 
 var forbiddenSQLWords = map[string]struct{}{
@@ -115,101 +117,80 @@ func validatePragma(cleaned string, words []string) error {
 	return nil
 }
 
+// validateSessionSQL limits agent-saved init SQL to session settings. Those
+// statements run outside the read-only transactions used for queries, so
+// anything that could write, or change server-wide state, is rejected.
+func validateSessionSQL(statements []string) error {
+	for _, statement := range statements {
+		cleaned, _, err := lexSQL(statement)
+		if err != nil {
+			return err
+		}
+		words := sqlWords(cleaned)
+		if len(words) == 0 || (words[0] != "SET" && words[0] != "PRAGMA") {
+			return fmt.Errorf("init_sql may only contain SET or PRAGMA statements, got %q", statement)
+		}
+		for _, word := range words[1:] {
+			_, forbidden := forbiddenSQLWords[word]
+			if forbidden || word == "SELECT" || word == "GLOBAL" || word == "PERSIST" || word == "PERSIST_ONLY" {
+				return fmt.Errorf("init_sql keyword %s is not allowed for agent-saved profiles", word)
+			}
+		}
+	}
+	return nil
+}
+
+// guardDialect lexes every engine's comment forms as comments and treats
+// backslashes as escapes, so text is never hidden from the keyword checks
+// by a dialect the policy did not expect.
+var guardDialect = sqlfmt.Dialect{HashComments: true, BackslashEscapes: true}
+
 // lexSQL removes comments and quoted contents while preserving identifiers.
 // It also counts semicolon-separated statements outside quoted regions.
 func lexSQL(input string) (string, int, error) {
+	tokens, err := sqlfmt.Lex(input, guardDialect)
+	if err != nil {
+		return "", 0, err
+	}
 	var out strings.Builder
 	statements := 1
-	hasAfterSemicolon := false
 	semicolonSeen := false
-	for i := 0; i < len(input); {
-		c := input[i]
-		switch {
-		case c == '-' && i+1 < len(input) && input[i+1] == '-':
-			i += 2
-			for i < len(input) && input[i] != '\n' {
-				i++
-			}
-			out.WriteByte(' ')
-		case c == '/' && i+1 < len(input) && input[i+1] == '*':
-			mysqlExecutable := i+2 < len(input) && input[i+2] == '!'
-			mariaDBExecutable := i+3 < len(input) && (input[i+2] == 'M' || input[i+2] == 'm') && input[i+3] == '!'
-			if mysqlExecutable || mariaDBExecutable {
+	for _, token := range tokens {
+		switch token.Kind {
+		case sqlfmt.Comment:
+			if executableComment(token.Text) {
 				return "", 0, fmt.Errorf("MySQL/MariaDB executable comments are not allowed")
 			}
-			i += 2
-			closed := false
-			for i+1 < len(input) {
-				if input[i] == '*' && input[i+1] == '/' {
-					i += 2
-					closed = true
-					break
-				}
-				i++
-			}
-			if !closed {
-				return "", 0, fmt.Errorf("unterminated SQL block comment")
-			}
 			out.WriteByte(' ')
-		case c == '#':
-			for i < len(input) && input[i] != '\n' {
-				i++
-			}
-			out.WriteByte(' ')
-		case c == '\'' || c == '"' || c == '`':
-			quote := c
-			if quote != '\'' {
-				out.WriteByte(quote)
-			}
-			i++
-			closed := false
-			for i < len(input) {
-				if input[i] == quote {
-					if i+1 < len(input) && input[i+1] == quote {
-						i += 2
-						continue
-					}
-					i++
-					closed = true
-					break
-				}
-				if input[i] == '\\' && quote == '\'' && i+1 < len(input) {
-					i += 2
-					continue
-				}
-				if quote != '\'' {
-					out.WriteByte(input[i])
-				}
-				i++
-			}
-			if !closed {
-				return "", 0, fmt.Errorf("unterminated quoted SQL value")
-			}
-			if quote != '\'' {
-				out.WriteByte(quote)
-			} else {
-				out.WriteByte(' ')
-			}
-		case c == ';':
-			semicolonSeen = true
-			i++
-		case unicode.IsSpace(rune(c)):
+		case sqlfmt.Space, sqlfmt.String:
 			out.WriteByte(' ')
-			i++
+		case sqlfmt.QuotedIdent:
+			quote := token.Text[:1]
+			out.WriteString(quote)
+			out.WriteString(strings.ReplaceAll(token.Text[1:len(token.Text)-1], quote+quote, ""))
+			out.WriteString(quote)
 		default:
-			if semicolonSeen && !unicode.IsSpace(rune(c)) {
-				hasAfterSemicolon = true
+			if token.Text == ";" {
+				semicolonSeen = true
+				continue
 			}
-			out.WriteByte(c)
-			i++
+			if semicolonSeen {
+				statements = 2
+			}
+			out.WriteString(token.Text)
 		}
 	}
-	if hasAfterSemicolon {
-		statements = 2
-	}
 	return out.String(), statements, nil
 }
 
+func executableComment(comment string) bool {
+	body, ok := strings.CutPrefix(comment, "/*")
+	if !ok {
+		return false
+	}
+	return strings.HasPrefix(body, "!") || strings.HasPrefix(body, "M!") || strings.HasPrefix(body, "m!")
+}
+
 func sqlWords(cleaned string) []string {
 	return strings.FieldsFunc(strings.ToUpper(cleaned), func(r rune) bool {
 		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
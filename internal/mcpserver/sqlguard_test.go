@@ -50,3 +50,15 @@ func TestValidateExplainableSQLRejectsNonSelect(t *testing.T) {
 		t.Fatal("expected SHOW to be rejected for EXPLAIN")
 	}
 }
+
+func TestValidateSessionSQLAllowsOnlySessionSettings(t *testing.T) {
+	t.Parallel()
+	if err := validateSessionSQL([]string{"SET search_path TO 'app, public'", "SET ROLE reporting", "PRAGMA foreign_keys = ON"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range []string{"DELETE FROM users", "SET GLOBAL max_connections = 1", "SET @id = (SELECT max(id) FROM users)", "SET x = 1; DROP TABLE users"} {
+		if err := validateSessionSQL([]string{statement}); err == nil {
+			t.Fatalf("validateSessionSQL(%q) succeeded", statement)
+		}
+	}
+}
@@ -50,6 +50,9 @@ type connectionSummary struct {
 	Type     config.DBType `json:"type"`
 	Database string        `json:"database,omitempty"`
 	Endpoint string        `json:"endpoint,omitempty"`
+	SSHHost  string        `json:"ssh_host,omitempty"`
+	SSLMode  string        `json:"ssl_mode,omitempty"`
+	Env      string        `json:"environment,omitempty"`
 	ReadOnly bool          `json:"read_only"`
 	Active   bool          `json:"active"`
 	LastUsed string        `json:"last_used,omitempty"`
@@ -156,7 +159,7 @@ type followRecordOutput struct {
 type saveProfileInput struct {
 	ID         string        `json:"id,omitempty" jsonschema:"existing connection ID to update; omit to create"`
 	Name       string        `json:"name"`
-	Type       config.DBType `json:"type" jsonschema:"postgresql, mysql, sqlite, turso, or d1"`
+	Type       config.DBType `json:"type" jsonschema:"postgresql, mysql, sqlite, turso, d1, or duckdb"`
 	Host       string        `json:"host,omitempty"`
 	Port       string        `json:"port,omitempty"`
 	User       string        `json:"user,omitempty"`
@@ -164,10 +167,30 @@ type saveProfileInput struct {
 	Database   string        `json:"database,omitempty"`
 	ReadOnly   *bool         `json:"read_only,omitempty" jsonschema:"whether dbterm should treat this profile as read-only; defaults to true for new agent-created profiles"`
 	FilePath   string        `json:"file_path,omitempty"`
-	SSLMode    string        `json:"ssl_mode,omitempty"`
+	SSLMode    string        `json:"ssl_mode,omitempty" jsonschema:"disable, prefer, require, verify-ca, or verify-full; postgresql and mysql"`
 	AccountID  string        `json:"account_id,omitempty"`
 	DatabaseID string        `json:"database_id,omitempty"`
 	AuthToken  string        `json:"auth_token,omitempty" jsonschema:"write-only token; never returned"`
+
+	SSHHost       string `json:"ssh_host,omitempty" jsonschema:"SSH bastion that can reach host:port; postgresql and mysql only"`
+	SSHPort       string `json:"ssh_port,omitempty"`
+	SSHUser       string `json:"ssh_user,omitempty"`
+	SSHPassword   string `json:"ssh_password,omitempty" jsonschema:"write-only SSH password or key passphrase; never returned"`
+	SSHKeyFile    string `json:"ssh_key_file,omitempty"`
+	SSHUseAgent   bool   `json:"ssh_use_agent,omitempty"`
+	SSHKnownHosts string `json:"ssh_known_hosts,omitempty" jsonschema:"known_hosts file; defaults to ~/.ssh/known_hosts"`
+	SSHJumpHosts  string `json:"ssh_jump_hosts,omitempty" jsonschema:"comma-separated user@host:port hops dialed before ssh_host"`
+
+	TLSCAFile     string `json:"tls_ca_file,omitempty" jsonschema:"PEM CA bundle that must have issued the server certificate"`
+	TLSCertFile   string `json:"tls_cert_file,omitempty" jsonschema:"PEM client certificate for mutual TLS"`
+	TLSKeyFile    string `json:"tls_key_file,omitempty" jsonschema:"PEM client key for tls_cert_file"`
+	TLSServerName string `json:"tls_server_name,omitempty" jsonschema:"name to verify on the server certificate instead of host"`
+
+	Environment string `json:"environment,omitempty" jsonschema:"dev, staging, prod, or a short custom tag"`
+	Color       string `json:"color,omitempty" jsonschema:"tag color as #rrggbb or a name such as red"`
+
+	InitSQL          string `json:"init_sql,omitempty" jsonschema:"SET or PRAGMA statements run on every new session, separated by semicolons"`
+	StatementTimeout string `json:"statement_timeout,omitempty" jsonschema:"per-statement timeout such as 30s; tightens the server's query limit"`
 }
 
 type saveProfileOutput struct {
@@ -61,6 +61,14 @@ func SaveJSON(path string, value any) error {
 		return fmt.Errorf("marshal json: %w", err)
 	}
 	data = append(data, '\n')
+	return SaveFile(path, data)
+}
+
+// SaveFile atomically replaces path with a private 0600 copy of data.
+func SaveFile(path string, data []byte) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("file path is required")
+	}
 
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, defaultDirMode); err != nil {
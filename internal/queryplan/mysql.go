@@ -0,0 +1,221 @@
+package queryplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// mysqlOperations are the wrapper objects of EXPLAIN FORMAT=JSON, in the
+// order a query block applies them, with the label each gets in the tree.
+var mysqlOperations = []struct {
+	key   string
+	label string
+}{
+	{"ordering_operation", "Sort"},
+	{"grouping_operation", "Group"},
+	{"duplicates_removal", "Remove duplicates"},
+	{"windowing", "Window"},
+	{"buffer_result", "Buffer result"},
+}
+
+// mysqlAccessTypes describes the access_type values of MySQL and MariaDB.
+var mysqlAccessTypes = map[string]string{
+	"ALL":             "Full table scan",
+	"index":           "Full index scan",
+	"range":           "Index range scan",
+	"ref":             "Index lookup",
+	"eq_ref":          "Unique index lookup",
+	"ref_or_null":     "Index lookup or NULL",
+	"const":           "Constant row",
+	"system":          "System row",
+	"fulltext":        "Fulltext index",
+	"index_merge":     "Index merge",
+	"unique_subquery": "Unique subquery lookup",
+	"index_subquery":  "Subquery index lookup",
+}
+
+// ParseMySQL reads the output of EXPLAIN FORMAT=JSON from MySQL or MariaDB,
+// including the version 2 format of MySQL 8.3 and later.
+func ParseMySQL(data []byte) (*Plan, error) {
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("parse MySQL plan: %w", err)
+	}
+	plan := &Plan{}
+	switch {
+	case document["query_block"] != nil:
+		block, _ := document["query_block"].(map[string]any)
+		plan.Roots = []*Node{mysqlQueryBlock(block)}
+	case document["operation"] != nil:
+		plan.Roots = []*Node{mysqlIteratorNode(document)}
+	default:
+		return nil, fmt.Errorf("parse MySQL plan: no query_block")
+	}
+	plan.markHot()
+	return plan, nil
+}
+
+func mysqlQueryBlock(block map[string]any) *Node {
+	node := newNode("Query block")
+	if id, ok := number(block["select_id"]); ok {
+		node.Operation = fmt.Sprintf("Query block #%.0f", id)
+	}
+	if cost, ok := mysqlObject(block, "cost_info")["query_cost"]; ok {
+		node.TotalCost, node.HasCost = number(cost)
+	}
+	if message := text(block["message"]); message != "" {
+		node.Details = append(node.Details, message)
+	}
+	node.Children = mysqlChildren(block)
+	return node
+}
+
+// mysqlChildren collects the plan steps nested in one JSON object.
+func mysqlChildren(object map[string]any) []*Node {
+	var children []*Node
+	for _, operation := range mysqlOperations {
+		inner := mysqlObject(object, operation.key)
+		if inner == nil {
+			continue
+		}
+		node := newNode(operation.label)
+		for _, flag := range []string{"using_filesort", "using_temporary_table"} {
+			if used, _ := inner[flag].(bool); used {
+				node.Details = append(node.Details, strings.ReplaceAll(flag, "_", " "))
+			}
+		}
+		if cost, ok := number(mysqlObject(inner, "cost_info")["sort_cost"]); ok {
+			node.SelfCost, node.TotalCost, node.HasCost = cost, cost, true
+		}
+		node.Children = mysqlChildren(inner)
+		children = append(children, node)
+	}
+	if table := mysqlObject(object, "table"); table != nil {
+		children = append(children, mysqlTable(table))
+	}
+	if loop, ok := object["nested_loop"].([]any); ok {
+		node := newNode("Nested loop")
+		for _, item := range loop {
+			if step, ok := item.(map[string]any); ok {
+				node.Children = append(node.Children, mysqlChildren(step)...)
+			}
+		}
+		children = append(children, node)
+	}
+	if union := mysqlObject(object, "union_result"); union != nil {
+		node := newNode("Union")
+		node.Children = mysqlSubqueries(union, "query_specifications")
+		children = append(children, node)
+	}
+	if block := mysqlObject(object, "query_block"); block != nil {
+		children = append(children, mysqlQueryBlock(block))
+	}
+	for _, key := range []string{"attached_subqueries", "optimized_away_subqueries", "order_by_subqueries", "group_by_subqueries", "having_subqueries", "select_list_subqueries"} {
+		children = append(children, mysqlSubqueries(object, key)...)
+	}
+	return children
+}
+
+func mysqlSubqueries(object map[string]any, key string) []*Node {
+	var nodes []*Node
+	items, _ := object[key].([]any)
+	for _, item := range items {
+		if specification, ok := item.(map[string]any); ok {
+			nodes = append(nodes, mysqlChildren(specification)...)
+		}
+	}
+	return nodes
+}
+
+func mysqlTable(table map[string]any) *Node {
+	access := text(table["access_type"])
+	operation := mysqlAccessTypes[access]
+	if operation == "" {
+		operation = strings.TrimSpace("Access " + access)
+	}
+	node := newNode(operation)
+	node.Relation = text(table["table_name"])
+	node.FullScan = access == "ALL"
+	if scanned, ok := number(table["rows_examined_per_scan"]); ok {
+		node.ScannedRows = scanned
+	} else if scanned, ok := number(table["rows"]); ok {
+		// MariaDB reports one row estimate per table.
+		node.ScannedRows = scanned
+	}
+	node.EstimatedRows, node.HasEstimate = number(table["rows_produced_per_join"])
+	if !node.HasEstimate && node.ScannedRows >= 0 {
+		node.EstimatedRows, node.HasEstimate = node.ScannedRows, true
+		if filtered, ok := number(table["filtered"]); ok {
+			node.EstimatedRows = node.ScannedRows * filtered / 100
+		}
+	}
+	costs := mysqlObject(table, "cost_info")
+	read, hasRead := number(costs["read_cost"])
+	eval, hasEval := number(costs["eval_cost"])
+	node.SelfCost = read + eval
+	node.TotalCost, node.HasCost = number(costs["prefix_cost"])
+	if !node.HasCost && (hasRead || hasEval) {
+		node.TotalCost, node.HasCost = node.SelfCost, true
+	}
+	if cost, ok := number(table["cost"]); ok && !node.HasCost {
+		node.SelfCost, node.TotalCost, node.HasCost = cost, cost, true
+	}
+	if key := text(table["key"]); key != "" {
+		node.Details = append(node.Details, "index "+key)
+	} else if possible := text(table["possible_keys"]); possible != "" {
+		node.Details = append(node.Details, "possible indexes: "+possible)
+	}
+	if condition := text(table["attached_condition"]); condition != "" {
+		node.Details = append(node.Details, "condition: "+condition)
+	}
+	if filtered := text(table["filtered"]); filtered != "" && filtered != "100.00" {
+		node.Details = append(node.Details, "filtered: "+filtered+"%")
+	}
+	if materialized := mysqlObject(table, "materialized_from_subquery"); materialized != nil {
+		node.Details = append(node.Details, "materialized from a subquery")
+		node.Children = mysqlChildren(materialized)
+	}
+	node.Children = append(node.Children, mysqlChildren(table)...)
+	return node
+}
+
+// mysqlIteratorNode reads the version 2 format, where every step has an
+// operation and its inputs.
+func mysqlIteratorNode(object map[string]any) *Node {
+	node := newNode(text(object["operation"]))
+	node.Relation = text(object["table_name"])
+	node.Schema = text(object["schema_name"])
+	if node.Relation != "" {
+		// The operation already names the table.
+		node.Operation = strings.TrimSpace(strings.Split(node.Operation, " on ")[0])
+	}
+	node.FullScan = text(object["access_type"]) == "table"
+	node.EstimatedRows, node.HasEstimate = number(object["estimated_rows"])
+	node.TotalCost, node.HasCost = number(object["estimated_total_cost"])
+	if node.FullScan {
+		node.ScannedRows = node.EstimatedRows
+	}
+	if condition := text(object["condition"]); condition != "" {
+		node.Details = append(node.Details, "condition: "+condition)
+	}
+	if index := text(object["index_name"]); index != "" {
+		node.Details = append(node.Details, "index "+index)
+	}
+	var childCost float64
+	inputs, _ := object["inputs"].([]any)
+	for _, input := range inputs {
+		if child, ok := input.(map[string]any); ok {
+			childNode := mysqlIteratorNode(child)
+			node.Children = append(node.Children, childNode)
+			childCost += childNode.TotalCost
+		}
+	}
+	node.SelfCost = max(0, node.TotalCost-childCost)
+	return node
+}
+
+func mysqlObject(object map[string]any, key string) map[string]any {
+	inner, _ := object[key].(map[string]any)
+	return inner
+}
@@ -0,0 +1,133 @@
+package queryplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParsePostgres reads the output of EXPLAIN (FORMAT JSON), with or without
+// ANALYZE.
+func ParsePostgres(data []byte) (*Plan, error) {
+	var documents []map[string]any
+	if err := json.Unmarshal(data, &documents); err != nil {
+		return nil, fmt.Errorf("parse PostgreSQL plan: %w", err)
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("parse PostgreSQL plan: the plan is empty")
+	}
+	document := documents[0]
+	root, ok := document["Plan"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("parse PostgreSQL plan: no Plan object")
+	}
+	plan := &Plan{}
+	plan.PlanningTime, _ = number(document["Planning Time"])
+	var analyzed bool
+	plan.ExecutionTime, analyzed = number(document["Execution Time"])
+	plan.Analyzed = analyzed
+	plan.Roots = []*Node{postgresNode(root)}
+	plan.markHot()
+	return plan, nil
+}
+
+func postgresNode(object map[string]any) *Node {
+	operation := text(object["Node Type"])
+	if join := text(object["Join Type"]); join != "" && join != "Inner" {
+		operation += " (" + join + ")"
+	}
+	if strategy := text(object["Strategy"]); strategy != "" && strategy != "Plain" {
+		operation += " (" + strategy + ")"
+	}
+	if parallel, _ := object["Parallel Aware"].(bool); parallel {
+		operation = "Parallel " + operation
+	}
+	node := newNode(operation)
+	node.Relation = text(object["Relation Name"])
+	node.Schema = text(object["Schema"])
+	if node.Relation == "" {
+		node.Relation = text(object["CTE Name"])
+	}
+	node.StartupCost, _ = number(object["Startup Cost"])
+	node.TotalCost, node.HasCost = number(object["Total Cost"])
+	node.EstimatedRows, node.HasEstimate = number(object["Plan Rows"])
+	if loops, ok := number(object["Actual Loops"]); ok {
+		node.HasActual = true
+		node.Loops = loops
+		node.ActualRows, _ = number(object["Actual Rows"])
+		perLoop, _ := number(object["Actual Total Time"])
+		node.TotalTime = perLoop * loops
+	}
+	node.FullScan = text(object["Node Type"]) == "Seq Scan"
+	removed, hasRemoved := number(object["Rows Removed by Filter"])
+	if node.FullScan && node.HasActual {
+		node.ScannedRows = node.ActualRows + removed
+	}
+
+	if relationship := text(object["Parent Relationship"]); relationship == "InitPlan" || relationship == "SubPlan" {
+		node.Details = append(node.Details, strings.TrimSpace(relationship+" "+text(object["Subplan Name"])))
+	}
+	if alias := text(object["Alias"]); alias != "" && alias != node.Relation {
+		node.Details = append(node.Details, "alias "+alias)
+	}
+	if index := text(object["Index Name"]); index != "" {
+		node.Details = append(node.Details, "index "+index)
+	}
+	for _, key := range []string{"Index Cond", "Recheck Cond", "Hash Cond", "Merge Cond", "Join Filter", "Filter", "Sort Key", "Group Key", "Presorted Key"} {
+		if value := text(object[key]); value != "" {
+			node.Details = append(node.Details, strings.ToLower(key)+": "+value)
+		}
+	}
+	if hasRemoved && removed > 0 {
+		node.Details = append(node.Details, fmt.Sprintf("rows removed by filter: %.0f", removed))
+	}
+	if method := text(object["Sort Method"]); method != "" {
+		used, _ := number(object["Sort Space Used"])
+		node.Details = append(node.Details, fmt.Sprintf("sort: %s, %.0f kB %s", method, used, strings.ToLower(text(object["Sort Space Type"]))))
+	}
+
+	childCost, childTime := 0.0, 0.0
+	children, _ := object["Plans"].([]any)
+	for _, raw := range children {
+		if child, ok := raw.(map[string]any); ok {
+			childNode := postgresNode(child)
+			node.Children = append(node.Children, childNode)
+			childCost += childNode.TotalCost
+			childTime += childNode.TotalTime
+		}
+	}
+	node.SelfCost = max(0, node.TotalCost-childCost)
+	node.SelfTime = max(0, node.TotalTime-childTime)
+	return node
+}
+
+// number reads a JSON number, or a number MySQL wrote as a string.
+func number(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case string:
+		var parsed float64
+		if _, err := fmt.Sscan(value, &parsed); err == nil {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
+
+// text reads a JSON string, or joins an array of them.
+func text(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			if part := text(item); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
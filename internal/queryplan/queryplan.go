@@ -0,0 +1,173 @@
+// Package queryplan turns engine-specific EXPLAIN output into one tree of
+// plan nodes with costs, row estimates, and the scans worth a second look.
+package queryplan
+
+import (
+	"strings"
+)
+
+// HotShare is the fraction of a plan's total self cost, or self time after
+// ANALYZE, from which a node counts as hot.
+const HotShare = 0.3
+
+// Node is one step of a plan. Costs are in the engine's own units; times are
+// milliseconds summed over every loop.
+type Node struct {
+	Operation string
+	Relation  string
+	Schema    string
+	Details   []string
+
+	HasCost     bool
+	StartupCost float64
+	TotalCost   float64
+	SelfCost    float64
+
+	HasEstimate   bool
+	EstimatedRows float64
+
+	HasActual  bool
+	ActualRows float64 // per loop, like the engine reports it
+	Loops      float64
+	TotalTime  float64
+	SelfTime   float64
+
+	// FullScan marks a scan that reads every row of Relation. ScannedRows is
+	// how many rows it reads when the plan says so, and TableRows the size of
+	// the table when the caller looked it up; both are -1 when unknown.
+	FullScan    bool
+	ScannedRows float64
+	TableRows   float64
+
+	Hot      bool
+	Children []*Node
+}
+
+// Plan is a parsed EXPLAIN result. SQLite plans can have several roots.
+type Plan struct {
+	Roots         []*Node
+	Analyzed      bool
+	PlanningTime  float64
+	ExecutionTime float64
+}
+
+func newNode(operation string) *Node {
+	return &Node{Operation: operation, ScannedRows: -1, TableRows: -1}
+}
+
+// Walk visits every node depth first, parents before children.
+func (p *Plan) Walk(visit func(node *Node, depth int)) {
+	var walk func(nodes []*Node, depth int)
+	walk = func(nodes []*Node, depth int) {
+		for _, node := range nodes {
+			visit(node, depth)
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(p.Roots, 0)
+}
+
+// FullScans lists the nodes that read a whole table.
+func (p *Plan) FullScans() []*Node {
+	var scans []*Node
+	p.Walk(func(node *Node, _ int) {
+		if node.FullScan && node.Relation != "" {
+			scans = append(scans, node)
+		}
+	})
+	return scans
+}
+
+// LargeScan reports whether a full scan reads at least threshold rows, by
+// the plan's own count or the table size, whichever is known and larger.
+func (n *Node) LargeScan(threshold float64) bool {
+	return n.FullScan && max(n.ScannedRows, n.TableRows) >= threshold
+}
+
+// Misestimate returns how many times the actual row count is off from the
+// estimate, or 0 when the plan was not analyzed. Estimates of zero or one row
+// are compared as one row.
+func (n *Node) Misestimate() float64 {
+	if !n.HasActual || !n.HasEstimate || n.Loops == 0 {
+		return 0
+	}
+	estimated, actual := max(n.EstimatedRows, 1), max(n.ActualRows, 1)
+	return max(estimated/actual, actual/estimated)
+}
+
+// Label is the operation with its relation, such as "Seq Scan on public.users".
+func (n *Node) Label() string {
+	if n.Relation == "" || strings.Contains(n.Operation, " "+n.Relation) {
+		// SQLite details already name the table.
+		return n.Operation
+	}
+	relation := n.Relation
+	if n.Schema != "" {
+		relation = n.Schema + "." + relation
+	}
+	return n.Operation + " on " + relation
+}
+
+// markHot flags the nodes that account for at least HotShare of the plan's
+// self time when it was analyzed, otherwise of its self cost.
+func (p *Plan) markHot() {
+	metric := func(node *Node) float64 {
+		if p.Analyzed {
+			return node.SelfTime
+		}
+		return node.SelfCost
+	}
+	var total float64
+	p.Walk(func(node *Node, _ int) { total += metric(node) })
+	if total <= 0 {
+		return
+	}
+	p.Walk(func(node *Node, _ int) {
+		node.Hot = metric(node)/total >= HotShare
+	})
+}
+
+// SQLiteStep is one row of EXPLAIN QUERY PLAN.
+type SQLiteStep struct {
+	ID     int
+	Parent int
+	Detail string
+}
+
+// ParseSQLite builds the tree from EXPLAIN QUERY PLAN rows. SQLite reports
+// no costs, so only full scans are flagged.
+func ParseSQLite(steps []SQLiteStep) *Plan {
+	plan := &Plan{}
+	nodes := make(map[int]*Node, len(steps))
+	for _, step := range steps {
+		node := newNode(step.Detail)
+		node.Relation, node.FullScan = sqliteScanTarget(step.Detail)
+		nodes[step.ID] = node
+		if parent, ok := nodes[step.Parent]; ok && step.Parent != step.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			plan.Roots = append(plan.Roots, node)
+		}
+	}
+	return plan
+}
+
+// sqliteScanTarget reads the table from details such as "SCAN users",
+// "SCAN TABLE users AS u", or "SEARCH users USING INDEX ...". Only a SCAN
+// without an index reads the whole table.
+func sqliteScanTarget(detail string) (string, bool) {
+	words := strings.Fields(detail)
+	if len(words) < 2 || (words[0] != "SCAN" && words[0] != "SEARCH") {
+		return "", false
+	}
+	table := words[1]
+	if table == "TABLE" && len(words) > 2 {
+		table = words[2]
+	}
+	switch {
+	case table == "CONSTANT", table == "SUBQUERY", strings.HasPrefix(table, "("):
+		return "", false
+	}
+	fullScan := words[0] == "SCAN" && !strings.Contains(detail, " USING ") && !strings.Contains(detail, " VIRTUAL TABLE")
+	return table, fullScan
+}
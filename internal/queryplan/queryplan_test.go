@@ -0,0 +1,122 @@
+package queryplan
+
+import (
+	"strings"
+	"testing"
+)
+
+const postgresAnalyzedPlan = `[{
+  "Plan": {
+    "Node Type": "Hash Join", "Join Type": "Inner", "Startup Cost": 30.5, "Total Cost": 80.0, "Plan Rows": 100,
+    "Actual Total Time": 9.0, "Actual Rows": 2400, "Actual Loops": 1, "Hash Cond": "(o.user_id = u.id)",
+    "Plans": [
+      {"Node Type": "Seq Scan", "Parent Relationship": "Outer", "Relation Name": "orders", "Schema": "public", "Alias": "o",
+       "Startup Cost": 0, "Total Cost": 40.0, "Plan Rows": 2000, "Actual Total Time": 7.5, "Actual Rows": 2400, "Actual Loops": 1,
+       "Filter": "(total > 10)", "Rows Removed by Filter": 20000},
+      {"Node Type": "Hash", "Parent Relationship": "Inner", "Startup Cost": 20, "Total Cost": 20, "Plan Rows": 500,
+       "Actual Total Time": 0.25, "Actual Rows": 500, "Actual Loops": 1,
+       "Plans": [{"Node Type": "Index Scan", "Relation Name": "users", "Schema": "public", "Alias": "u", "Index Name": "users_pkey",
+                  "Startup Cost": 0, "Total Cost": 20, "Plan Rows": 500, "Actual Total Time": 0.1, "Actual Rows": 500, "Actual Loops": 1}]}
+    ]
+  },
+  "Planning Time": 0.2, "Execution Time": 9.4
+}]`
+
+func TestParsePostgresComputesSelfTimeAndFlagsScans(t *testing.T) {
+	plan, err := ParsePostgres([]byte(postgresAnalyzedPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Analyzed || plan.ExecutionTime != 9.4 || len(plan.Roots) != 1 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	join := plan.Roots[0]
+	scan := join.Children[0]
+	if join.Label() != "Hash Join" || scan.Label() != "Seq Scan on public.orders" {
+		t.Fatalf("labels = %q, %q", join.Label(), scan.Label())
+	}
+	if join.SelfTime != 1.25 || join.SelfCost != 20 {
+		t.Fatalf("join self time = %v, self cost = %v", join.SelfTime, join.SelfCost)
+	}
+	if !scan.Hot || join.Hot || !scan.FullScan || scan.ScannedRows != 22400 || !scan.LargeScan(10000) {
+		t.Fatalf("scan = %+v", scan)
+	}
+	if got := join.Misestimate(); got != 24 {
+		t.Fatalf("join misestimate = %v", got)
+	}
+	if !strings.Contains(strings.Join(scan.Details, "\n"), "filter: (total > 10)") {
+		t.Fatalf("scan details = %q", scan.Details)
+	}
+	if scans := plan.FullScans(); len(scans) != 1 || scans[0] != scan {
+		t.Fatalf("full scans = %+v", scans)
+	}
+
+	estimated, err := ParsePostgres([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "t", "Total Cost": 5, "Plan Rows": 10}}]`))
+	if err != nil || estimated.Analyzed || estimated.Roots[0].HasActual || estimated.Roots[0].ScannedRows != -1 || !estimated.Roots[0].Hot {
+		t.Fatalf("estimated plan = %+v, err = %v", estimated.Roots[0], err)
+	}
+	if _, err := ParsePostgres([]byte(`[]`)); err == nil {
+		t.Fatal("an empty plan was accepted")
+	}
+}
+
+func TestParseMySQLReadsNestedLoopsAndAccessTypes(t *testing.T) {
+	plan, err := ParseMySQL([]byte(`{"query_block": {"select_id": 1, "cost_info": {"query_cost": "1210.50"},
+	  "ordering_operation": {"using_filesort": true, "nested_loop": [
+	    {"table": {"table_name": "orders", "access_type": "ALL", "rows_examined_per_scan": 50000, "rows_produced_per_join": 5000,
+	      "filtered": "10.00", "cost_info": {"read_cost": "900.00", "eval_cost": "100.00", "prefix_cost": "1000.00"},
+	      "attached_condition": "(orders.total > 10)"}},
+	    {"table": {"table_name": "users", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1, "rows_produced_per_join": 5000,
+	      "filtered": "100.00", "cost_info": {"read_cost": "200.00", "eval_cost": "10.50", "prefix_cost": "1210.50"}}}
+	  ]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := plan.Roots[0]
+	if block.Operation != "Query block #1" || block.TotalCost != 1210.5 {
+		t.Fatalf("block = %+v", block)
+	}
+	sort := block.Children[0]
+	if sort.Operation != "Sort" || sort.Details[0] != "using filesort" {
+		t.Fatalf("sort = %+v", sort)
+	}
+	loop := sort.Children[0]
+	orders, users := loop.Children[0], loop.Children[1]
+	if loop.Operation != "Nested loop" || orders.Label() != "Full table scan on orders" || users.Label() != "Unique index lookup on users" {
+		t.Fatalf("loop = %+v", loop)
+	}
+	if !orders.Hot || users.Hot || !orders.LargeScan(10000) || users.FullScan || orders.SelfCost != 1000 {
+		t.Fatalf("orders = %+v, users = %+v", orders, users)
+	}
+
+	iterator, err := ParseMySQL([]byte(`{"operation": "Filter: (t.a > 1)", "estimated_rows": 3, "estimated_total_cost": 2,
+	  "inputs": [{"operation": "Table scan on t", "table_name": "t", "access_type": "table", "estimated_rows": 30, "estimated_total_cost": 1.5}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scan := iterator.Roots[0].Children[0]; scan.Label() != "Table scan on t" || !scan.FullScan || scan.ScannedRows != 30 {
+		t.Fatalf("version 2 scan = %+v", scan)
+	}
+}
+
+func TestParseSQLiteBuildsTreeFromParents(t *testing.T) {
+	plan := ParseSQLite([]SQLiteStep{
+		{ID: 2, Parent: 0, Detail: "SCAN orders"},
+		{ID: 5, Parent: 0, Detail: "SEARCH users USING INTEGER PRIMARY KEY (rowid=?)"},
+		{ID: 9, Parent: 0, Detail: "SCALAR SUBQUERY 1"},
+		{ID: 12, Parent: 9, Detail: "SCAN TABLE items AS i USING COVERING INDEX items_order"},
+		{ID: 20, Parent: 0, Detail: "USE TEMP B-TREE FOR ORDER BY"},
+	})
+	if len(plan.Roots) != 4 || len(plan.Roots[2].Children) != 1 {
+		t.Fatalf("roots = %+v", plan.Roots)
+	}
+	if orders := plan.Roots[0]; orders.Relation != "orders" || !orders.FullScan || orders.Label() != "SCAN orders" {
+		t.Fatalf("orders = %+v", orders)
+	}
+	if items := plan.Roots[2].Children[0]; items.Relation != "items" || items.FullScan {
+		t.Fatalf("items = %+v", items)
+	}
+	if scans := plan.FullScans(); len(scans) != 1 {
+		t.Fatalf("full scans = %+v", scans)
+	}
+}
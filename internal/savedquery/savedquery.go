@@ -0,0 +1,345 @@
+// Package savedquery stores named queries as plain .sql files in a folder
+// tree, so a library can be committed to git and reviewed like code.
+//
+// Each file holds one query. Leading "-- key: value" comment lines carry its
+// metadata; the rest of the file is the SQL:
+//
+//	-- name: Open orders
+//	-- description: Orders nobody has closed yet
+//	-- tags: orders, support
+//	-- engine: postgresql
+//	SELECT * FROM orders WHERE closed_at IS NULL AND customer_id = :customer
+//
+// A query with a connection line applies to that connection only, one with
+// an engine line to every connection of that engine, and one with neither to
+// every connection. The folder is the file's directory within the library.
+package savedquery
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shreyam1008/dbterm/internal/appdirs"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/persist"
+)
+
+// DefaultDirName is the personal library inside the dbterm config directory.
+const DefaultDirName = "queries"
+
+// Scope says which connections a query applies to.
+type Scope string
+
+const (
+	ScopeGlobal     Scope = "global"
+	ScopeEngine     Scope = "engine"
+	ScopeConnection Scope = "connection"
+)
+
+// Query is one saved query.
+type Query struct {
+	Name        string
+	Description string
+	Tags        []string
+	Folder      string        // slash-separated, relative to the library root
+	Engine      config.DBType // limits the query to one engine
+	Connection  string        // limits the query to one connection, by name
+	SQL         string
+
+	// Path is the file the query was loaded from, empty for a new query.
+	Path string
+}
+
+// Scope reports how widely the query applies.
+func (q Query) Scope() Scope {
+	switch {
+	case q.Connection != "":
+		return ScopeConnection
+	case q.Engine != "":
+		return ScopeEngine
+	default:
+		return ScopeGlobal
+	}
+}
+
+// AppliesTo reports whether the query is offered on a connection.
+func (q Query) AppliesTo(dbType config.DBType, connection string) bool {
+	if q.Engine != "" && q.Engine != dbType {
+		return false
+	}
+	return q.Connection == "" || strings.EqualFold(q.Connection, strings.TrimSpace(connection))
+}
+
+// Title is the folder and name, such as "support/Open orders".
+func (q Query) Title() string {
+	if q.Folder == "" {
+		return q.Name
+	}
+	return q.Folder + "/" + q.Name
+}
+
+// DefaultRoot returns the personal library directory.
+func DefaultRoot() (string, error) {
+	dir, err := appdirs.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DefaultDirName), nil
+}
+
+// Load reads every .sql file under root, sorted by folder and name. A
+// missing root is an empty library. Files that cannot be read or parsed are
+// skipped and reported as warnings.
+func Load(root string) ([]Query, []string, error) {
+	if strings.TrimSpace(root) == "" {
+		return nil, nil, nil
+	}
+	var queries []Query
+	var warnings []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			warnings = append(warnings, err.Error())
+			return nil
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".sql") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+			return nil
+		}
+		query, err := Parse(string(data))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+		relative, _ := filepath.Rel(root, filepath.Dir(path))
+		if relative != "." {
+			query.Folder = filepath.ToSlash(relative)
+		}
+		if query.Name == "" {
+			query.Name = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		}
+		query.Path = path
+		queries = append(queries, query)
+		return nil
+	})
+	if err != nil {
+		return nil, warnings, fmt.Errorf("read query library %s: %w", root, err)
+	}
+	sort.SliceStable(queries, func(i, j int) bool {
+		if queries[i].Folder != queries[j].Folder {
+			return queries[i].Folder < queries[j].Folder
+		}
+		return strings.ToLower(queries[i].Name) < strings.ToLower(queries[j].Name)
+	})
+	return queries, warnings, nil
+}
+
+// Parse reads the metadata lines and SQL of one file. Reading stops at the
+// first line that is not a known "-- key: value" comment, so ordinary
+// comments stay part of the SQL.
+func Parse(data string) (Query, error) {
+	var query Query
+	rest := strings.TrimPrefix(data, "\ufeff")
+	for rest != "" {
+		line, next, _ := strings.Cut(rest, "\n")
+		key, value, ok := metadataLine(line)
+		if !ok {
+			break
+		}
+		switch key {
+		case "name":
+			query.Name = value
+		case "description":
+			query.Description = value
+		case "tags":
+			query.Tags = splitTags(value)
+		case "engine":
+			query.Engine = config.DBType(strings.ToLower(value))
+		case "connection":
+			query.Connection = value
+		}
+		rest = next
+	}
+	query.SQL = strings.TrimSpace(rest)
+	if onlyComments(query.SQL) {
+		return Query{}, errors.New("the file has no SQL")
+	}
+	if query.Engine != "" && !knownEngine(query.Engine) {
+		return Query{}, fmt.Errorf("unknown engine %q", query.Engine)
+	}
+	return query, nil
+}
+
+func onlyComments(sql string) bool {
+	for _, line := range strings.Split(sql, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
+
+func metadataLine(line string) (string, string, bool) {
+	comment, ok := strings.CutPrefix(strings.TrimSpace(line), "--")
+	if !ok {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(comment, ":")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	switch key {
+	case "name", "description", "tags", "engine", "connection":
+		return key, strings.TrimSpace(value), true
+	}
+	return "", "", false
+}
+
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func knownEngine(dbType config.DBType) bool {
+	switch dbType {
+	case config.PostgreSQL, config.MySQL, config.SQLite, config.Turso, config.CloudflareD1, config.DuckDB:
+		return true
+	}
+	return false
+}
+
+// Format renders a query in the file format Parse reads.
+func Format(query Query) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "-- name: %s\n", oneLine(query.Name))
+	if query.Description != "" {
+		fmt.Fprintf(&out, "-- description: %s\n", oneLine(query.Description))
+	}
+	if len(query.Tags) > 0 {
+		fmt.Fprintf(&out, "-- tags: %s\n", oneLine(strings.Join(query.Tags, ", ")))
+	}
+	if query.Engine != "" {
+		fmt.Fprintf(&out, "-- engine: %s\n", query.Engine)
+	}
+	if query.Connection != "" {
+		fmt.Fprintf(&out, "-- connection: %s\n", oneLine(query.Connection))
+	}
+	out.WriteString(strings.TrimSpace(query.SQL))
+	out.WriteByte('\n')
+	return out.String()
+}
+
+func oneLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Save writes a query to <root>/<folder>/<file name from its name>.sql and
+// returns the path. A query that was loaded from another path is moved, and
+// an existing file of another query is never overwritten.
+func Save(root string, query Query) (string, error) {
+	if strings.TrimSpace(root) == "" {
+		return "", errors.New("the query library has no directory")
+	}
+	query.Name = oneLine(query.Name)
+	if query.Name == "" {
+		return "", errors.New("a saved query needs a name")
+	}
+	if strings.TrimSpace(query.SQL) == "" {
+		return "", errors.New("a saved query needs SQL")
+	}
+	if query.Engine != "" && !knownEngine(query.Engine) {
+		return "", fmt.Errorf("unknown engine %q", query.Engine)
+	}
+	folder, err := cleanFolder(query.Folder)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(root, filepath.FromSlash(folder), FileName(query.Name))
+	if path != query.Path {
+		if _, err := os.Stat(path); err == nil {
+			return "", fmt.Errorf("%s already exists; choose another name or folder", path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("check %s: %w", path, err)
+		}
+	}
+	if err := persist.SaveFile(path, []byte(Format(query))); err != nil {
+		return "", err
+	}
+	if query.Path != "" && query.Path != path {
+		if err := os.Remove(query.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return path, fmt.Errorf("remove %s: %w", query.Path, err)
+		}
+	}
+	return path, nil
+}
+
+// Delete removes a saved query's file.
+func Delete(query Query) error {
+	if query.Path == "" {
+		return errors.New("the query was never saved")
+	}
+	if err := os.Remove(query.Path); err != nil {
+		return fmt.Errorf("remove %s: %w", query.Path, err)
+	}
+	return nil
+}
+
+// FileName turns a query name into a portable file name: lowercase ASCII
+// letters and digits joined by dashes.
+func FileName(name string) string {
+	var out strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && out.Len() > 0 {
+				out.WriteByte('-')
+			}
+			out.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	if out.Len() == 0 {
+		return "query.sql"
+	}
+	return out.String() + ".sql"
+}
+
+func cleanFolder(folder string) (string, error) {
+	var parts []string
+	for _, part := range strings.Split(filepath.ToSlash(strings.TrimSpace(folder)), "/") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "" || part == ".":
+			continue
+		case part == ".." || strings.HasPrefix(part, "."):
+			return "", fmt.Errorf("folder %q must stay inside the library", folder)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "/"), nil
+}
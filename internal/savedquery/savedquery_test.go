@@ -0,0 +1,110 @@
+package savedquery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestParseReadsMetadataAndKeepsOrdinaryComments(t *testing.T) {
+	query, err := Parse("\ufeff-- name: Open orders\r\n-- Tags: orders, , support\n-- engine: PostgreSQL\n-- pending orders only\nSELECT 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Name != "Open orders" || !reflect.DeepEqual(query.Tags, []string{"orders", "support"}) || query.Engine != config.PostgreSQL {
+		t.Fatalf("query = %+v", query)
+	}
+	if query.SQL != "-- pending orders only\nSELECT 1" || query.Scope() != ScopeEngine {
+		t.Fatalf("sql = %q, scope = %s", query.SQL, query.Scope())
+	}
+	if _, err := Parse("-- name: empty\n"); err == nil {
+		t.Fatal("a file without SQL was accepted")
+	}
+	if _, err := Parse("-- engine: oracle\nSELECT 1"); err == nil {
+		t.Fatal("an unknown engine was accepted")
+	}
+}
+
+func TestQueryAppliesToItsScope(t *testing.T) {
+	global := Query{Name: "a"}
+	engine := Query{Name: "b", Engine: config.MySQL}
+	connection := Query{Name: "c", Engine: config.MySQL, Connection: "Orders"}
+	for _, test := range []struct {
+		query Query
+		want  [3]bool
+	}{
+		{global, [3]bool{true, true, true}},
+		{engine, [3]bool{true, true, false}},
+		{connection, [3]bool{true, false, false}},
+	} {
+		got := [3]bool{
+			test.query.AppliesTo(config.MySQL, "orders"),
+			test.query.AppliesTo(config.MySQL, "billing"),
+			test.query.AppliesTo(config.PostgreSQL, "orders"),
+		}
+		if got != test.want {
+			t.Errorf("%s scope: applies = %v, want %v", test.query.Scope(), got, test.want)
+		}
+	}
+}
+
+func TestSaveLoadMoveAndDelete(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "queries")
+	queries, warnings, err := Load(root)
+	if err != nil || len(queries) != 0 || len(warnings) != 0 {
+		t.Fatalf("missing library = %v, %v, %v", queries, warnings, err)
+	}
+
+	query := Query{Name: "Orders by Customer!", Description: "line one\nline two", Tags: []string{"orders"}, Folder: "support/billing", Connection: "orders", SQL: "SELECT * FROM orders WHERE customer_id = :customer"}
+	path, err := Save(root, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "support", "billing", "orders-by-customer.sql"); path != want {
+		t.Fatalf("path = %q, want %q", path, want)
+	}
+	if _, err := Save(root, query); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("saving over another query: %v", err)
+	}
+	if _, err := Save(root, Query{Name: "x", Folder: "../out", SQL: "SELECT 1"}); err == nil {
+		t.Fatal("a folder outside the library was accepted")
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.sql"), []byte("-- just a comment\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "a.sql"), []byte("SELECT 2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	queries, warnings, err = Load(root)
+	if err != nil || len(queries) != 2 || len(warnings) != 1 {
+		t.Fatalf("queries = %+v, warnings = %v, err = %v", queries, warnings, err)
+	}
+	if queries[0].Name != "a" || queries[0].Scope() != ScopeGlobal {
+		t.Fatalf("unnamed file = %+v", queries[0])
+	}
+	loaded := queries[1]
+	if loaded.Title() != "support/billing/Orders by Customer!" || loaded.Description != "line one line two" || loaded.Scope() != ScopeConnection || loaded.SQL != query.SQL {
+		t.Fatalf("loaded = %+v", loaded)
+	}
+
+	loaded.Folder = "archive"
+	moved, err := Save(root, loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("the old file was kept after a move: %v", err)
+	}
+	loaded.Path = moved
+	if err := Delete(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if queries, _, _ := Load(root); len(queries) != 1 {
+		t.Fatalf("queries after delete = %+v", queries)
+	}
+}
@@ -0,0 +1,130 @@
+// Package scratch persists the query editor tabs of each connection so
+// unsaved buffers survive restarts.
+package scratch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/persist"
+)
+
+const DefaultFileName = "editor-tabs.json"
+
+// Tab is one named editor buffer.
+type Tab struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	SQL       string    `json:"sql"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Workspace is the tab set of one connection.
+type Workspace struct {
+	Tabs   []Tab  `json:"tabs"`
+	Active string `json:"active,omitempty"`
+}
+
+type fileState struct {
+	Connections map[string]Workspace `json:"connections"`
+}
+
+// Manager handles editor tab persistence.
+type Manager struct {
+	mu sync.RWMutex
+
+	path  string
+	state fileState
+}
+
+// NewManager creates a manager backed by editor-tabs.json in dbterm's
+// per-user configuration directory.
+func NewManager() (*Manager, error) {
+	path, err := persist.DefaultConfigFile(DefaultFileName)
+	if err != nil {
+		return nil, err
+	}
+	return NewManagerAt(path)
+}
+
+// NewManagerAt creates a manager backed by the given file path.
+func NewManagerAt(path string) (*Manager, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("editor tabs path is required")
+	}
+	m := &Manager{path: path, state: fileState{Connections: map[string]Workspace{}}}
+	if err := m.loadLocked(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Workspace returns a copy of the tabs saved for a connection.
+func (m *Manager) Workspace(connectionKey string) Workspace {
+	key := strings.TrimSpace(connectionKey)
+	if key == "" {
+		return Workspace{}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return cloneWorkspace(m.state.Connections[key])
+}
+
+// SetWorkspace replaces and persists the tabs of a connection. A workspace
+// whose tabs are all empty is removed.
+func (m *Manager) SetWorkspace(connectionKey string, workspace Workspace) error {
+	key := strings.TrimSpace(connectionKey)
+	if key == "" {
+		return errors.New("connection key is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if workspace.blank() {
+		if _, ok := m.state.Connections[key]; !ok {
+			return nil
+		}
+		delete(m.state.Connections, key)
+	} else {
+		m.state.Connections[key] = cloneWorkspace(workspace)
+	}
+	return persist.SaveJSON(m.path, m.state)
+}
+
+func (m *Manager) loadLocked() error {
+	var loaded fileState
+	if err := persist.LoadJSON(m.path, &loaded); err != nil {
+		return fmt.Errorf("load editor tabs: %w", err)
+	}
+	if loaded.Connections == nil {
+		loaded.Connections = map[string]Workspace{}
+	}
+	m.state = loaded
+	return nil
+}
+
+// blank reports whether the workspace is a single untouched default tab or
+// nothing at all, which is not worth a file entry.
+func (w Workspace) blank() bool {
+	for _, tab := range w.Tabs {
+		if strings.TrimSpace(tab.SQL) != "" {
+			return false
+		}
+	}
+	return len(w.Tabs) <= 1
+}
+
+func cloneWorkspace(workspace Workspace) Workspace {
+	cloned := Workspace{Active: workspace.Active}
+	if len(workspace.Tabs) > 0 {
+		cloned.Tabs = make([]Tab, len(workspace.Tabs))
+		copy(cloned.Tabs, workspace.Tabs)
+	}
+	return cloned
+}
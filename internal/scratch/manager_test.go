@@ -0,0 +1,64 @@
+package scratch
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManagerPersistsWorkspacesPerConnection(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	m, err := NewManagerAt(path)
+	if err != nil {
+		t.Fatalf("NewManagerAt() error = %v", err)
+	}
+
+	workspace := Workspace{
+		Tabs: []Tab{
+			{ID: "1", Name: "orders", SQL: "SELECT * FROM orders", UpdatedAt: time.Unix(10, 0).UTC()},
+			{ID: "2", Name: "scratch", SQL: ""},
+		},
+		Active: "2",
+	}
+	if err := m.SetWorkspace("conn-a", workspace); err != nil {
+		t.Fatalf("SetWorkspace() error = %v", err)
+	}
+
+	reloaded, err := NewManagerAt(path)
+	if err != nil {
+		t.Fatalf("NewManagerAt(reload) error = %v", err)
+	}
+	if got := reloaded.Workspace("conn-a"); !reflect.DeepEqual(got, workspace) {
+		t.Fatalf("Workspace() = %+v, want %+v", got, workspace)
+	}
+	if got := reloaded.Workspace("conn-b"); len(got.Tabs) != 0 {
+		t.Fatalf("Workspace(conn-b) = %+v, want empty", got)
+	}
+}
+
+func TestManagerDropsBlankWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	m, err := NewManagerAt(path)
+	if err != nil {
+		t.Fatalf("NewManagerAt() error = %v", err)
+	}
+	if err := m.SetWorkspace("conn-a", Workspace{Tabs: []Tab{{ID: "1", SQL: "SELECT 1"}}}); err != nil {
+		t.Fatalf("SetWorkspace() error = %v", err)
+	}
+	if err := m.SetWorkspace("conn-a", Workspace{Tabs: []Tab{{ID: "1", SQL: "  "}}}); err != nil {
+		t.Fatalf("SetWorkspace(blank) error = %v", err)
+	}
+
+	reloaded, err := NewManagerAt(path)
+	if err != nil {
+		t.Fatalf("NewManagerAt(reload) error = %v", err)
+	}
+	if got := reloaded.Workspace("conn-a"); len(got.Tabs) != 0 {
+		t.Fatalf("blank workspace was kept: %+v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Environment variables read by UnlockNonInteractive. The backup agent and
+// MCP server run without a terminal, so they use these or a key-file vault.
+const (
+	EnvKeyFile        = "DBTERM_VAULT_KEY_FILE"
+	EnvPassphraseFile = "DBTERM_VAULT_PASSPHRASE_FILE"
+	EnvPassphrase     = "DBTERM_VAULT_PASSPHRASE"
+)
+
+var (
+	sessionMu    sync.Mutex
+	sessionVault *Vault
+)
+
+// Unlocked returns the process's unlocked default vault, or nil when no vault
+// is enabled or it is still locked.
+func Unlocked() *Vault {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	if sessionVault == nil || sessionVault.path != filepath.Clean(path) {
+		return nil
+	}
+	return sessionVault
+}
+
+// Locked reports whether a vault exists that this process cannot read yet.
+func Locked() bool {
+	return Enabled() && Unlocked() == nil
+}
+
+// Unlock opens the default vault with key and keeps it for this process.
+func Unlock(key Key) (*Vault, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	vault, err := Open(path, key)
+	if err != nil {
+		return nil, err
+	}
+	setSession(vault)
+	return vault, nil
+}
+
+// Initialize creates the default vault and keeps it unlocked.
+func Initialize(key Key) (*Vault, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	vault, err := Create(path, key)
+	if err != nil {
+		return nil, err
+	}
+	setSession(vault)
+	return vault, nil
+}
+
+// Lock forgets the unlocked vault.
+func Lock() {
+	setSession(nil)
+}
+
+func setSession(vault *Vault) {
+	sessionMu.Lock()
+	sessionVault = vault
+	sessionMu.Unlock()
+}
+
+// UnlockNonInteractive unlocks the default vault without prompting. It tries
+// DBTERM_VAULT_KEY_FILE, DBTERM_VAULT_PASSPHRASE_FILE, DBTERM_VAULT_PASSPHRASE,
+// and finally the key file recorded when a key-file vault was created. It
+// returns false without error when no vault exists or no source applies.
+func UnlockNonInteractive() (bool, error) {
+	if !Enabled() {
+		return false, nil
+	}
+	if Unlocked() != nil {
+		return true, nil
+	}
+	key, ok, err := nonInteractiveKey()
+	if err != nil || !ok {
+		return false, err
+	}
+	if _, err := Unlock(key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func nonInteractiveKey() (Key, bool, error) {
+	if path := strings.TrimSpace(os.Getenv(EnvKeyFile)); path != "" {
+		return Key{IdentityFile: path}, true, nil
+	}
+	if path := strings.TrimSpace(os.Getenv(EnvPassphraseFile)); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Key{}, false, fmt.Errorf("read %s: %w", EnvPassphraseFile, err)
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return Key{}, false, fmt.Errorf("%s points at an empty file", EnvPassphraseFile)
+		}
+		return Key{Passphrase: passphrase}, true, nil
+	}
+	if passphrase := os.Getenv(EnvPassphrase); passphrase != "" {
+		return Key{Passphrase: passphrase}, true, nil
+	}
+	path, err := DefaultPath()
+	if err != nil {
+		return Key{}, false, err
+	}
+	metadata, err := LoadMetadata(path)
+	if err != nil {
+		return Key{}, false, err
+	}
+	if metadata.Mode == ModeKeyFile && metadata.KeyFile != "" {
+		return Key{IdentityFile: metadata.KeyFile}, true, nil
+	}
+	return Key{}, false, nil
+}
@@ -0,0 +1,278 @@
+// Package secrets keeps connection and notification credentials in an
+// age-encrypted vault instead of the plaintext profile files.
+//
+// The vault is optional. Until `dbterm connections migrate-secrets` creates
+// one, every store keeps its historical plaintext behavior.
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/shreyam1008/dbterm/internal/persist"
+)
+
+const (
+	vaultFileName    = "secrets.age"
+	metadataFileName = "secrets.json"
+	vaultVersion     = 1
+
+	// ModePassphrase vaults are unlocked with a typed passphrase.
+	ModePassphrase = "passphrase"
+	// ModeKeyFile vaults are unlocked with an age X25519 identity file.
+	ModeKeyFile = "key_file"
+)
+
+// ErrLocked reports that a vault exists but has not been unlocked in this
+// process, so secrets can be neither read nor written.
+var ErrLocked = errors.New("secrets vault is locked; unlock it first")
+
+// scryptWorkFactor overrides age's default passphrase cost in tests.
+var scryptWorkFactor int
+
+// Key unlocks a vault. Exactly one of Passphrase or IdentityFile is used.
+type Key struct {
+	Passphrase   string
+	IdentityFile string
+}
+
+// Metadata is the unencrypted description stored beside the vault. It names
+// how to unlock the vault and never contains secret material.
+type Metadata struct {
+	Mode    string `json:"mode"`
+	KeyFile string `json:"key_file,omitempty"`
+}
+
+type vaultPayload struct {
+	Version int               `json:"version"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+// Vault is an unlocked, in-memory view of the encrypted secret map.
+type Vault struct {
+	mu        sync.Mutex
+	path      string
+	recipient age.Recipient
+	values    map[string]string
+	dirty     bool
+}
+
+// DefaultPath returns <config dir>/secrets.age.
+func DefaultPath() (string, error) {
+	return persist.DefaultConfigFile(vaultFileName)
+}
+
+func metadataPath(vaultPath string) string {
+	return filepath.Join(filepath.Dir(vaultPath), metadataFileName)
+}
+
+// Enabled reports whether the default vault has been created.
+func Enabled() bool {
+	path, err := DefaultPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// LoadMetadata reads how the vault at vaultPath expects to be unlocked.
+func LoadMetadata(vaultPath string) (Metadata, error) {
+	var metadata Metadata
+	if err := persist.LoadJSON(metadataPath(vaultPath), &metadata); err != nil {
+		return Metadata{}, err
+	}
+	if metadata.Mode == "" {
+		metadata.Mode = ModePassphrase
+	}
+	return metadata, nil
+}
+
+// Create writes a new empty vault at path. It refuses to replace an existing
+// vault because that would orphan every secret sealed inside it.
+func Create(path string, key Key) (*Vault, error) {
+	path = filepath.Clean(path)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("secrets vault already exists: %s", path)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("inspect secrets vault: %w", err)
+	}
+	recipient, _, metadata, err := resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	vault := &Vault{path: path, recipient: recipient, values: map[string]string{}, dirty: true}
+	if err := vault.Save(); err != nil {
+		return nil, err
+	}
+	if err := persist.SaveJSON(metadataPath(path), metadata); err != nil {
+		return nil, fmt.Errorf("write secrets vault metadata: %w", err)
+	}
+	return vault, nil
+}
+
+// Open decrypts the vault at path with key.
+func Open(path string, key Key) (*Vault, error) {
+	path = filepath.Clean(path)
+	recipient, identity, _, err := resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open secrets vault: %w", err)
+	}
+	defer file.Close()
+	reader, err := age.Decrypt(file, identity)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("wrong passphrase or key file for the secrets vault")
+		}
+		return nil, fmt.Errorf("decrypt secrets vault: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt secrets vault: %w", err)
+	}
+	var payload vaultPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("decode secrets vault: %w", err)
+	}
+	if payload.Version != vaultVersion {
+		return nil, fmt.Errorf("unsupported secrets vault version %d", payload.Version)
+	}
+	if payload.Secrets == nil {
+		payload.Secrets = map[string]string{}
+	}
+	return &Vault{path: path, recipient: recipient, values: payload.Secrets}, nil
+}
+
+func resolveKey(key Key) (age.Recipient, age.Identity, Metadata, error) {
+	switch {
+	case strings.TrimSpace(key.IdentityFile) != "":
+		path := filepath.Clean(key.IdentityFile)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, Metadata{}, fmt.Errorf("read vault key file: %w", err)
+		}
+		identities, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, Metadata{}, fmt.Errorf("parse vault key file: %w", err)
+		}
+		for _, identity := range identities {
+			if x25519, ok := identity.(*age.X25519Identity); ok {
+				absolute, absErr := filepath.Abs(path)
+				if absErr != nil {
+					absolute = path
+				}
+				return x25519.Recipient(), x25519, Metadata{Mode: ModeKeyFile, KeyFile: absolute}, nil
+			}
+		}
+		return nil, nil, Metadata{}, fmt.Errorf("vault key file has no age X25519 identity")
+	case key.Passphrase != "":
+		recipient, err := age.NewScryptRecipient(key.Passphrase)
+		if err != nil {
+			return nil, nil, Metadata{}, fmt.Errorf("prepare vault passphrase: %w", err)
+		}
+		if scryptWorkFactor > 0 {
+			recipient.SetWorkFactor(scryptWorkFactor)
+		}
+		identity, err := age.NewScryptIdentity(key.Passphrase)
+		if err != nil {
+			return nil, nil, Metadata{}, fmt.Errorf("prepare vault passphrase: %w", err)
+		}
+		return recipient, identity, Metadata{Mode: ModePassphrase}, nil
+	default:
+		return nil, nil, Metadata{}, fmt.Errorf("a vault passphrase or key file is required")
+	}
+}
+
+// Path returns the vault file location.
+func (v *Vault) Path() string {
+	return v.path
+}
+
+// Get returns the secret stored under ref.
+func (v *Vault) Get(ref string) (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	value, ok := v.values[ref]
+	return value, ok
+}
+
+// Set stores value under ref. An empty value removes the entry.
+func (v *Vault) Set(ref, value string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	current, ok := v.values[ref]
+	if value == "" {
+		if ok {
+			delete(v.values, ref)
+			v.dirty = true
+		}
+		return
+	}
+	if !ok || current != value {
+		v.values[ref] = value
+		v.dirty = true
+	}
+}
+
+// Refs lists stored references that start with prefix, in sorted order.
+func (v *Vault) Refs(prefix string) []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	refs := make([]string, 0, len(v.values))
+	for ref := range v.values {
+		if strings.HasPrefix(ref, prefix) {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// Save re-encrypts the vault when it has changed. The previous ciphertext is
+// kept beside it as .bak so an interrupted write never loses every secret.
+func (v *Vault) Save() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.dirty {
+		return nil
+	}
+	plain, err := json.Marshal(vaultPayload{Version: vaultVersion, Secrets: v.values})
+	if err != nil {
+		return fmt.Errorf("encode secrets vault: %w", err)
+	}
+	var sealed bytes.Buffer
+	writer, err := age.Encrypt(&sealed, v.recipient)
+	if err != nil {
+		return fmt.Errorf("encrypt secrets vault: %w", err)
+	}
+	if _, err := writer.Write(plain); err != nil {
+		return fmt.Errorf("encrypt secrets vault: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("encrypt secrets vault: %w", err)
+	}
+	if previous, readErr := os.ReadFile(v.path); readErr == nil {
+		if err := persist.SaveFile(v.path+".bak", previous); err != nil {
+			return fmt.Errorf("keep previous secrets vault: %w", err)
+		}
+	}
+	if err := persist.SaveFile(v.path, sealed.Bytes()); err != nil {
+		return fmt.Errorf("write secrets vault: %w", err)
+	}
+	v.dirty = false
+	return nil
+}
@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func writeIdentity(t *testing.T) string {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "vault-key.txt")
+	if err := os.WriteFile(path, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func useTestConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "config")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DBTERM_CONFIG_DIR", dir)
+	for _, name := range []string{EnvKeyFile, EnvPassphraseFile, EnvPassphrase} {
+		t.Setenv(name, "")
+	}
+	t.Cleanup(Lock)
+	return dir
+}
+
+func TestVaultRoundTripsWithKeyFileAndNeverStoresPlaintext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.age")
+	keyFile := writeIdentity(t)
+	vault, err := Create(path, Key{IdentityFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vault.Set("connection/a/password", "hunter2-correct-horse")
+	if err := vault.Save(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "hunter2") {
+		t.Fatal("vault file contains the plaintext secret")
+	}
+
+	reopened, err := Open(path, Key{IdentityFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := reopened.Get("connection/a/password"); !ok || got != "hunter2-correct-horse" {
+		t.Fatalf("reopened secret = %q, %t", got, ok)
+	}
+	if _, err := Open(path, Key{IdentityFile: writeIdentity(t)}); err == nil || !strings.Contains(err.Error(), "wrong passphrase or key file") {
+		t.Fatalf("foreign key error = %v", err)
+	}
+	if _, err := Create(path, Key{IdentityFile: keyFile}); err == nil {
+		t.Fatal("Create replaced an existing vault")
+	}
+}
+
+func TestVaultPassphraseUnlock(t *testing.T) {
+	scryptWorkFactor = 10
+	t.Cleanup(func() { scryptWorkFactor = 0 })
+	path := filepath.Join(t.TempDir(), "secrets.age")
+	vault, err := Create(path, Key{Passphrase: "long enough passphrase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vault.Set("backup-job/j1/smtp_password", "app-password")
+	if err := vault.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, Key{Passphrase: "wrong passphrase"}); err == nil {
+		t.Fatal("wrong passphrase unlocked the vault")
+	}
+	reopened, err := Open(path, Key{Passphrase: "long enough passphrase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reopened.Get("backup-job/j1/smtp_password"); got != "app-password" {
+		t.Fatalf("secret = %q", got)
+	}
+	metadata, err := LoadMetadata(path)
+	if err != nil || metadata.Mode != ModePassphrase {
+		t.Fatalf("metadata = %#v, %v", metadata, err)
+	}
+}
+
+func TestUnlockNonInteractiveUsesRecordedKeyFile(t *testing.T) {
+	useTestConfigDir(t)
+	keyFile := writeIdentity(t)
+	if _, err := Initialize(Key{IdentityFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	Lock()
+	if !Locked() {
+		t.Fatal("vault should be locked after Lock")
+	}
+	unlocked, err := UnlockNonInteractive()
+	if err != nil || !unlocked {
+		t.Fatalf("UnlockNonInteractive = %t, %v", unlocked, err)
+	}
+	if Unlocked() == nil {
+		t.Fatal("session vault was not kept")
+	}
+}
+
+func TestUnlockNonInteractiveWithoutVaultIsANoop(t *testing.T) {
+	useTestConfigDir(t)
+	unlocked, err := UnlockNonInteractive()
+	if err != nil || unlocked || Locked() {
+		t.Fatalf("no vault: unlocked=%t locked=%t err=%v", unlocked, Locked(), err)
+	}
+}
@@ -0,0 +1,267 @@
+package sqlfmt
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// Kind classifies a token.
+type Kind int
+
+const (
+	Space       Kind = iota // whitespace
+	Comment                 // -- line, # line, or /* block */ comment
+	String                  // quoted or dollar-quoted string literal
+	QuotedIdent             // "name", `name`, or [name]
+	Word                    // keyword, identifier, or number
+	Param                   // :name, @var, $1, ?, ?1
+	Operator                // run of operator characters, such as <= or @>
+	Punct                   // any other single character, or ::
+)
+
+// Token is one lexeme. Text is the exact source, so joining every token's
+// Text reproduces the input.
+type Token struct {
+	Kind Kind
+	Text string
+}
+
+// Dialect selects the lexical rules of one engine.
+type Dialect struct {
+	HashComments        bool // # starts a line comment
+	DashCommentSpace    bool // -- starts a comment only when followed by whitespace
+	NestedComments      bool // block comments nest
+	DollarQuotes        bool // $tag$ ... $tag$ strings
+	BracketIdents       bool // [name] identifiers
+	BackslashEscapes    bool // backslash escapes the next byte in '...' strings
+	DoubleQuotedStrings bool // "..." is a string with the same escapes as '...'
+}
+
+// DialectFor returns the lexical rules of an engine.
+func DialectFor(dbType config.DBType) Dialect {
+	switch dbType {
+	case config.MySQL:
+		return Dialect{HashComments: true, DashCommentSpace: true, BackslashEscapes: true, DoubleQuotedStrings: true}
+	case config.PostgreSQL:
+		return Dialect{NestedComments: true, DollarQuotes: true}
+	case config.DuckDB:
+		return Dialect{DollarQuotes: true}
+	case config.SQLite, config.Turso, config.CloudflareD1:
+		return Dialect{BracketIdents: true}
+	default:
+		return Dialect{}
+	}
+}
+
+var (
+	errUnterminatedComment = errors.New("unterminated SQL block comment")
+	errUnterminatedQuote   = errors.New("unterminated quoted SQL value")
+	errUnterminatedDollar  = errors.New("unterminated dollar-quoted SQL string")
+)
+
+// Lex splits input into tokens. Quoted regions and comments are returned
+// whole, so callers that only rearrange whitespace never alter them.
+func Lex(input string, dialect Dialect) ([]Token, error) {
+	tokens := make([]Token, 0, len(input)/4+1)
+	for i := 0; i < len(input); {
+		end, kind, err := lexOne(input, i, dialect)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: input[i:end]})
+		i = end
+	}
+	return tokens, nil
+}
+
+func lexOne(input string, i int, dialect Dialect) (int, Kind, error) {
+	c := input[i]
+	r, size := utf8.DecodeRuneInString(input[i:])
+	switch {
+	case unicode.IsSpace(r):
+		end := i + size
+		for end < len(input) {
+			next, nextSize := utf8.DecodeRuneInString(input[end:])
+			if !unicode.IsSpace(next) {
+				break
+			}
+			end += nextSize
+		}
+		return end, Space, nil
+	case c == '-' && strings.HasPrefix(input[i:], "--") && (!dialect.DashCommentSpace || i+2 == len(input) || isSpaceByte(input[i+2])):
+		return lineEnd(input, i), Comment, nil
+	case c == '#' && dialect.HashComments:
+		return lineEnd(input, i), Comment, nil
+	case c == '/' && strings.HasPrefix(input[i:], "/*"):
+		end, ok := blockCommentEnd(input, i, dialect.NestedComments)
+		if !ok {
+			return 0, 0, errUnterminatedComment
+		}
+		return end, Comment, nil
+	case c == '\'':
+		end, ok := quotedEnd(input, i, dialect.BackslashEscapes || escapeStringPrefix(input, i))
+		if !ok {
+			return 0, 0, errUnterminatedQuote
+		}
+		return end, String, nil
+	case c == '"' && dialect.DoubleQuotedStrings:
+		end, ok := quotedEnd(input, i, dialect.BackslashEscapes)
+		if !ok {
+			return 0, 0, errUnterminatedQuote
+		}
+		return end, String, nil
+	case c == '"' || c == '`':
+		end, ok := quotedEnd(input, i, false)
+		if !ok {
+			return 0, 0, errUnterminatedQuote
+		}
+		return end, QuotedIdent, nil
+	case c == '[' && dialect.BracketIdents:
+		closeAt := strings.IndexByte(input[i+1:], ']')
+		if closeAt < 0 {
+			return 0, 0, errUnterminatedQuote
+		}
+		return i + 1 + closeAt + 1, QuotedIdent, nil
+	case c == '$' && dialect.DollarQuotes:
+		if tag, ok := dollarTag(input, i); ok {
+			closeAt := strings.Index(input[i+len(tag):], tag)
+			if closeAt < 0 {
+				return 0, 0, errUnterminatedDollar
+			}
+			return i + len(tag) + closeAt + len(tag), String, nil
+		}
+		return paramEnd(input, i+1), Param, nil
+	case c == '$' || c == '?':
+		return paramEnd(input, i+1), Param, nil
+	case c == ':' && i+1 < len(input) && input[i+1] == ':':
+		return i + 2, Punct, nil
+	case c == ':' && i+1 < len(input) && input[i+1] == '=':
+		return i + 2, Operator, nil
+	case (c == ':' || c == '@') && i+1 < len(input) && (isWordRune(rune(input[i+1])) || input[i+1] == '@' || input[i+1] >= utf8.RuneSelf):
+		end := i + 1
+		if c == '@' && input[end] == '@' {
+			end++
+		}
+		return paramEnd(input, end), Param, nil
+	case isWordRune(r):
+		end := i + size
+		for end < len(input) {
+			next, nextSize := utf8.DecodeRuneInString(input[end:])
+			if !isWordRune(next) {
+				break
+			}
+			end += nextSize
+		}
+		return end, Word, nil
+	case strings.IndexByte(operatorChars, c) >= 0:
+		end := i + 1
+		for end < len(input) && strings.IndexByte(operatorChars, input[end]) >= 0 &&
+			!strings.HasPrefix(input[end:], "--") && !strings.HasPrefix(input[end:], "/*") {
+			end++
+		}
+		return end, Operator, nil
+	default:
+		return i + size, Punct, nil
+	}
+}
+
+// operatorChars make up operators. A run of them is one token, so
+// PostgreSQL operators such as @> or ->> are never split apart.
+const operatorChars = "+-*/<>=~!@%^&|"
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func lineEnd(input string, i int) int {
+	if newline := strings.IndexByte(input[i:], '\n'); newline >= 0 {
+		return i + newline
+	}
+	return len(input)
+}
+
+func blockCommentEnd(input string, i int, nested bool) (int, bool) {
+	depth := 0
+	for j := i; j+1 < len(input); {
+		switch {
+		case input[j] == '/' && input[j+1] == '*' && (nested || depth == 0):
+			depth++
+			j += 2
+		case input[j] == '*' && input[j+1] == '/':
+			depth--
+			j += 2
+			if depth == 0 {
+				return j, true
+			}
+		default:
+			j++
+		}
+	}
+	return 0, false
+}
+
+// quotedEnd returns the offset after the quote that closes the region
+// starting at i. A doubled quote is part of the value.
+func quotedEnd(input string, i int, backslash bool) (int, bool) {
+	quote := input[i]
+	for j := i + 1; j < len(input); j++ {
+		switch {
+		case input[j] == quote:
+			if j+1 < len(input) && input[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1, true
+		case input[j] == '\\' && backslash:
+			j++
+		}
+	}
+	return 0, false
+}
+
+// escapeStringPrefix reports a PostgreSQL E'...' string, where backslashes
+// escape even though ordinary strings treat them literally.
+func escapeStringPrefix(input string, quote int) bool {
+	if quote == 0 || (input[quote-1] != 'E' && input[quote-1] != 'e') {
+		return false
+	}
+	if quote == 1 {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(input[:quote-1])
+	return !isWordRune(before)
+}
+
+func dollarTag(input string, i int) (string, bool) {
+	for j := i + 1; j < len(input); j++ {
+		c := input[j]
+		switch {
+		case c == '$':
+			return input[i : j+1], true
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= utf8.RuneSelf:
+		case c >= '0' && c <= '9' && j > i+1:
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func paramEnd(input string, i int) int {
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if !isWordRune(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
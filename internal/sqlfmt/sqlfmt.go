@@ -0,0 +1,491 @@
+// Package sqlfmt lexes SQL and pretty-prints it for the query editor.
+//
+// The printer only changes whitespace between tokens and the case of
+// keywords. Strings, dollar-quoted bodies, quoted identifiers, and comments
+// are copied byte for byte, and tokens that touch in the input are only
+// separated where that can never change how an engine reads them.
+package sqlfmt
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// Case is how keywords are written.
+type Case string
+
+const (
+	CaseUpper    Case = "upper"
+	CaseLower    Case = "lower"
+	CasePreserve Case = "preserve"
+)
+
+// DefaultWidth is the column long lines wrap at.
+const DefaultWidth = 80
+
+const indentUnit = "  "
+
+// Options control the layout.
+type Options struct {
+	Case    Case
+	Compact bool // one line per statement instead of a line per clause
+	Width   int  // wrap column; zero means DefaultWidth
+}
+
+// Format pretty-prints one or more statements in the dialect of dbType.
+func Format(dbType config.DBType, sql string, options Options) (string, error) {
+	tokens, err := Lex(sql, DialectFor(dbType))
+	if err != nil {
+		return "", err
+	}
+	items := significant(tokens)
+	if dbType == config.MySQL && hasDelimiterCommand(items) {
+		return "", errors.New("scripts that change the DELIMITER cannot be formatted")
+	}
+	if options.Width <= 0 {
+		options.Width = DefaultWidth
+	}
+	p := &printer{options: options, mysql: dbType == config.MySQL, items: items, frames: []frame{{block: true}}}
+	for i := range items {
+		p.print(i)
+	}
+	return p.finish(), nil
+}
+
+// Compact joins each statement onto one line.
+func Compact(dbType config.DBType, sql string, keywordCase Case) (string, error) {
+	return Format(dbType, sql, Options{Case: keywordCase, Compact: true})
+}
+
+// item is a token other than whitespace, with what separated it from the
+// previous token.
+type item struct {
+	Token
+	spaced  bool // whitespace or a comment came before it
+	newline bool // a line break came before it
+}
+
+func significant(tokens []Token) []item {
+	items := make([]item, 0, len(tokens))
+	spaced, newline := false, false
+	for _, token := range tokens {
+		if token.Kind == Space {
+			spaced = true
+			newline = newline || strings.Contains(token.Text, "\n")
+			continue
+		}
+		items = append(items, item{Token: token, spaced: spaced || token.Kind == Comment, newline: newline})
+		spaced, newline = token.Kind == Comment, false
+	}
+	return items
+}
+
+func hasDelimiterCommand(items []item) bool {
+	for i, it := range items {
+		if it.Kind == Word && strings.EqualFold(it.Text, "DELIMITER") && (i == 0 || it.newline) {
+			return true
+		}
+	}
+	return false
+}
+
+// frame is one level of parentheses. Block frames hold a statement or
+// subquery and get a line per clause; inline frames hold function arguments
+// and lists and stay on one line.
+type frame struct {
+	block   bool
+	indent  int    // indent level of the block's clauses
+	outer   int    // indent level of the line that opened it
+	verb    string // first keyword of the statement or subquery
+	clause  string // keyword of the clause being printed
+	between bool   // the next AND belongs to BETWEEN
+}
+
+type printer struct {
+	options Options
+	mysql   bool
+	items   []item
+	frames  []frame
+
+	lines      []string
+	line       strings.Builder
+	lineIndent int  // indent level of the current line
+	lineWidth  int  // runes on the current line
+	wrapped    bool // the current line continues a wrapped one
+	breakNext  int  // line breaks owed before the next token
+	prev       *item
+	spacedPrev bool // prev was written with a space before it
+}
+
+func (p *printer) top() *frame { return &p.frames[len(p.frames)-1] }
+
+// depth is the indent level of clauses in the innermost block.
+func (p *printer) depth() int {
+	for i := len(p.frames) - 1; i >= 0; i-- {
+		if p.frames[i].block {
+			return p.frames[i].indent
+		}
+	}
+	return 0
+}
+
+func (p *printer) print(i int) {
+	it := p.items[i]
+	if it.Kind == Comment {
+		p.printComment(it)
+		return
+	}
+	text := it.Text
+	keyword := ""
+	if it.Kind == Word {
+		keyword = p.keywordAt(i)
+		if keyword != "" {
+			text = p.caseKeyword(it.Text)
+		}
+	}
+	f := p.top()
+	switch {
+	case keyword == "BETWEEN":
+		f.between = true
+	case keyword == "AND" && f.between:
+		f.between = false
+	case !f.block || p.options.Compact || keyword == "":
+	case keyword == "AND" || keyword == "OR":
+		if f.clause == "WHERE" || f.clause == "HAVING" || f.clause == "QUALIFY" {
+			p.newline(p.depth() + 1)
+		}
+	default:
+		if clause, breaks := p.clauseBreak(i, keyword); breaks {
+			p.newline(p.depth())
+			f.clause = clause
+		}
+	}
+	if f.verb == "" && keyword != "" {
+		f.verb = keyword
+	}
+
+	switch {
+	case text == "(":
+		p.write(it, text)
+		if p.opensSubquery(i) && !p.options.Compact {
+			p.frames = append(p.frames, frame{block: true, indent: p.lineIndent + 1, outer: p.lineIndent})
+			p.breakNext = max(p.breakNext, 1)
+			return
+		}
+		p.frames = append(p.frames, frame{})
+		return
+	case text == ")":
+		closing := *p.top()
+		if len(p.frames) > 1 {
+			p.frames = p.frames[:len(p.frames)-1]
+		}
+		if closing.block {
+			p.newline(closing.outer)
+		}
+	case text == ";":
+		p.write(it, text)
+		if len(p.frames) == 1 {
+			p.frames[0] = frame{block: true}
+			p.breakNext = 2
+			if p.options.Compact {
+				p.breakNext = 1
+			}
+		} else if !p.options.Compact {
+			p.breakNext = max(p.breakNext, 1)
+		}
+		return
+	case text == ",":
+		p.write(it, text)
+		if f.block && f.clause == "WITH" && !p.options.Compact {
+			p.breakNext = max(p.breakNext, 1)
+		}
+		return
+	}
+	p.write(it, text)
+}
+
+func (p *printer) printComment(it item) {
+	owed := 0
+	if it.newline {
+		p.newline(p.depth())
+	} else {
+		// A comment on the same line as the code before it stays there.
+		owed, p.breakNext = p.breakNext, 0
+	}
+	p.write(it, it.Text)
+	p.breakNext = owed
+	if !strings.HasPrefix(it.Text, "/*") {
+		p.breakNext = max(p.breakNext, 1)
+	}
+}
+
+// write appends a token, with the space or line break owed before it.
+func (p *printer) write(it item, text string) {
+	if p.breakNext > 0 && p.lineWidth > 0 {
+		p.newline(p.depth())
+	}
+	p.breakNext = 0
+	spaced := false
+	if p.lineWidth > 0 && p.spaceBefore(it) {
+		spaced = true
+		width := utf8.RuneCountInString(firstLine(text))
+		if !p.options.Compact && p.lineWidth+1+width > p.options.Width && p.canWrap(it) {
+			indent := p.lineIndent
+			if !p.wrapped {
+				indent++
+			}
+			p.newline(indent)
+			p.wrapped = true
+		} else {
+			p.line.WriteByte(' ')
+			p.lineWidth++
+		}
+	}
+	if p.lineWidth == 0 {
+		p.line.WriteString(strings.Repeat(indentUnit, p.lineIndent))
+		p.lineWidth = p.lineIndent * len(indentUnit)
+	}
+	p.line.WriteString(text)
+	if newline := strings.LastIndexByte(text, '\n'); newline >= 0 {
+		p.lineWidth = utf8.RuneCountInString(text[newline+1:])
+	} else {
+		p.lineWidth += utf8.RuneCountInString(text)
+	}
+	stored := it
+	p.prev, p.spacedPrev = &stored, spaced
+}
+
+// spaceBefore decides the separator between the previous token and it on
+// one line. Tokens that touch in the input stay touching unless a space can
+// never change their meaning.
+func (p *printer) spaceBefore(it item) bool {
+	prev := p.prev
+	if prev == nil {
+		return false
+	}
+	if prev.Kind == Comment || it.Kind == Comment {
+		return true
+	}
+	switch it.Text {
+	case ",", ";", ")":
+		return false
+	}
+	switch prev.Text {
+	case "(":
+		return false
+	case ",":
+		return true
+	case ".":
+		return it.spaced
+	}
+	if it.Text == "." {
+		return it.spaced
+	}
+	if paddedOperator(it) && operand(*prev, "(") || paddedOperator(*prev) && p.spacedPrev && operand(it, ")") {
+		return true
+	}
+	return it.spaced
+}
+
+// paddedOperator reports an operator written with a space on each side.
+func paddedOperator(it item) bool {
+	if it.Kind != Operator {
+		return false
+	}
+	switch it.Text {
+	case "=", "<>", "!=", "<", ">", "<=", ">=", "||", ":=":
+		return true
+	}
+	return false
+}
+
+// operand reports a token a padded operator may be separated from; except
+// names the parenthesis that cannot be.
+func operand(it item, except string) bool {
+	switch it.Kind {
+	case Word, QuotedIdent, String, Param:
+		return true
+	case Punct:
+		return (it.Text == "(" || it.Text == ")") && it.Text != except
+	}
+	return false
+}
+
+// canWrap reports whether a long line may break before it.
+func (p *printer) canWrap(it item) bool {
+	if p.prev != nil && p.prev.Text == "," {
+		return true
+	}
+	return it.Kind == Word && keywords[strings.ToUpper(it.Text)]
+}
+
+// newline ends the current line, adding any blank lines still owed.
+func (p *printer) newline(indent int) {
+	p.flush()
+	for ; p.breakNext > 1 && len(p.lines) > 0; p.breakNext-- {
+		p.lines = append(p.lines, "")
+	}
+	p.breakNext = 0
+	p.lineIndent = indent
+	p.wrapped = false
+}
+
+func (p *printer) flush() {
+	if p.lineWidth > 0 {
+		p.lines = append(p.lines, strings.TrimRight(p.line.String(), " "))
+	}
+	p.line.Reset()
+	p.lineWidth = 0
+}
+
+func (p *printer) finish() string {
+	p.flush()
+	return strings.Join(p.lines, "\n")
+}
+
+// keywordAt returns the upper-case keyword at i, or "" when the word is an
+// identifier: not a keyword, or part of a qualified name.
+func (p *printer) keywordAt(i int) string {
+	word := strings.ToUpper(p.items[i].Text)
+	if !keywords[word] || (p.mysql && unreservedInMySQL[word]) {
+		return ""
+	}
+	if i > 0 && p.items[i-1].Text == "." && !p.items[i].spaced {
+		return ""
+	}
+	if i+1 < len(p.items) && p.items[i+1].Text == "." && !p.items[i+1].spaced {
+		return ""
+	}
+	return word
+}
+
+func (p *printer) caseKeyword(text string) string {
+	switch p.options.Case {
+	case CaseLower:
+		return strings.ToLower(text)
+	case CasePreserve:
+		return text
+	default:
+		return strings.ToUpper(text)
+	}
+}
+
+// next returns the upper-case text of the n-th token after i, skipping
+// comments.
+func (p *printer) next(i, n int) string {
+	for j := i + 1; j < len(p.items); j++ {
+		if p.items[j].Kind == Comment {
+			continue
+		}
+		if n--; n == 0 {
+			return strings.ToUpper(p.items[j].Text)
+		}
+	}
+	return ""
+}
+
+// previous returns the upper-case text of the n-th token before i, skipping
+// comments.
+func (p *printer) previous(i, n int) string {
+	for j := i - 1; j >= 0; j-- {
+		if p.items[j].Kind == Comment {
+			continue
+		}
+		if n--; n == 0 {
+			return strings.ToUpper(p.items[j].Text)
+		}
+	}
+	return ""
+}
+
+// clauseBreak reports whether keyword at i starts a clause on a new line,
+// and the clause it starts.
+func (p *printer) clauseBreak(i int, keyword string) (string, bool) {
+	f := p.top()
+	next := p.next(i, 1)
+	previous := p.previous(i, 1)
+	switch keyword {
+	case "SELECT", "WHERE", "HAVING", "LIMIT", "FETCH", "QUALIFY", "RETURNING", "WINDOW":
+		return keyword, true
+	case "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE":
+		return keyword, f.clause == "" || f.clause == "WITH"
+	case "WITH":
+		return keyword, f.clause == ""
+	case "FROM":
+		return keyword, previous != "DELETE" && !(previous == "DISTINCT" && (p.previous(i, 2) == "IS" || p.previous(i, 2) == "NOT"))
+	case "OFFSET":
+		return keyword, f.clause != "LIMIT"
+	case "GROUP", "ORDER":
+		return keyword, next == "BY"
+	case "UNION", "INTERSECT":
+		return keyword, true
+	case "EXCEPT":
+		return keyword, next != "(" || p.next(i, 2) == "SELECT"
+	case "VALUES":
+		return keyword, f.clause == "" || f.clause == "INSERT" || f.clause == "REPLACE"
+	case "SET":
+		return keyword, f.verb == "UPDATE"
+	case "ON":
+		return "ON " + next, next == "CONFLICT" || next == "DUPLICATE"
+	case "JOIN", "STRAIGHT_JOIN":
+		switch previous {
+		case "LEFT", "RIGHT", "FULL", "INNER", "CROSS", "NATURAL", "OUTER", "ANTI", "SEMI", "ASOF", "POSITIONAL":
+			return "", false
+		}
+		return "JOIN", true
+	case "LEFT", "RIGHT", "FULL":
+		return "JOIN", next == "JOIN" || next == "OUTER" || next == "ANTI" || next == "SEMI"
+	case "INNER", "CROSS", "NATURAL":
+		return "JOIN", next == "JOIN" || next == "LEFT" || next == "RIGHT" || next == "FULL" || next == "INNER" || next == "APPLY"
+	}
+	return "", false
+}
+
+// opensSubquery reports whether the parenthesis at i holds a query.
+func (p *printer) opensSubquery(i int) bool {
+	switch p.next(i, 1) {
+	case "SELECT", "WITH", "VALUES":
+		return true
+	}
+	return false
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// keywords are cased and may start clauses. Words that are common table or
+// column names, such as NAME or STATUS, are left out: MySQL table names and
+// aliases are case sensitive on most servers.
+var keywords = map[string]bool{}
+
+// unreservedInMySQL are keywords MySQL also accepts as unquoted names, so
+// they are left as typed there.
+var unreservedInMySQL = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		ADD ALL ALTER AND ANTI ANY APPLY AS ASC ASOF BEGIN BETWEEN BOTH BY CASCADE
+		CASE CAST CHECK COLLATE COLUMN COMMIT CONFLICT CONSTRAINT CREATE CROSS
+		CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP DATABASE DEFAULT DELETE DESC
+		DISTINCT DO DROP DUPLICATE ELSE END ESCAPE EXCEPT EXISTS EXPLAIN FALSE
+		FETCH FILTER FOR FOREIGN FROM FULL GRANT GROUP HAVING IF ILIKE IN INDEX
+		INNER INSERT INTERSECT INTERVAL INTO IS JOIN KEY LATERAL LEADING LEFT LIKE
+		LIMIT MATERIALIZED MERGE NATURAL NOT NOTHING NULL NULLS OFFSET ON OR ORDER
+		OUTER OVER PARTITION PRAGMA PRIMARY QUALIFY RECURSIVE REFERENCES REPLACE
+		RETURNING REVOKE RIGHT ROLLBACK SELECT SEMI SET SHOW STRAIGHT_JOIN TABLE
+		THEN TO TRAILING TRIGGER TRUE TRUNCATE UNION UNIQUE UPDATE USING VACUUM
+		VALUES VIEW WHEN WHERE WINDOW WITH`) {
+		keywords[word] = true
+	}
+	for _, word := range strings.Fields(`
+		ANTI ANY APPLY ASOF BEGIN COMMIT CONFLICT DO DUPLICATE END ESCAPE FILTER
+		FULL ILIKE MATERIALIZED MERGE NOTHING NULLS OFFSET PRAGMA QUALIFY
+		RETURNING ROLLBACK SEMI TRUNCATE VACUUM VIEW`) {
+		unreservedInMySQL[word] = true
+	}
+}
@@ -0,0 +1,148 @@
+package sqlfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestLexKeepsQuotedRegionsAndCommentsWhole(t *testing.T) {
+	for _, test := range []struct {
+		dbType config.DBType
+		input  string
+		want   []Token
+	}{
+		{config.PostgreSQL, "$fn$ a; 'b' $fn$ $1 E'\\'' /* x /* y */ z */", []Token{
+			{String, "$fn$ a; 'b' $fn$"}, {Param, "$1"}, {Word, "E"}, {String, "E'\\''"[1:]}, {Comment, "/* x /* y */ z */"},
+		}},
+		{config.MySQL, "`a``b` \"it\\\"s\" --x -- c\n@@session.x", []Token{
+			{QuotedIdent, "`a``b`"}, {String, "\"it\\\"s\""}, {Operator, "--"}, {Word, "x"}, {Comment, "-- c"}, {Param, "@@session"}, {Punct, "."}, {Word, "x"},
+		}},
+		{config.SQLite, "[order by] = 'a\\' x::int @> ?2", []Token{
+			{QuotedIdent, "[order by]"}, {Operator, "="}, {String, "'a\\'"}, {Word, "x"}, {Punct, "::"}, {Word, "int"}, {Operator, "@>"}, {Param, "?2"},
+		}},
+	} {
+		tokens, err := Lex(test.input, DialectFor(test.dbType))
+		if err != nil {
+			t.Fatalf("%s: %v", test.dbType, err)
+		}
+		var joined strings.Builder
+		var got []Token
+		for _, token := range tokens {
+			joined.WriteString(token.Text)
+			if token.Kind != Space {
+				got = append(got, token)
+			}
+		}
+		if joined.String() != test.input {
+			t.Errorf("%s: tokens join to %q", test.dbType, joined.String())
+		}
+		if len(got) != len(test.want) {
+			t.Fatalf("%s: tokens = %+v, want %+v", test.dbType, got, test.want)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: token %d = %+v, want %+v", test.dbType, i, got[i], test.want[i])
+			}
+		}
+	}
+	if _, err := Lex("SELECT $$ open", DialectFor(config.PostgreSQL)); err == nil {
+		t.Fatal("an unterminated dollar quote was accepted")
+	}
+}
+
+func TestFormatLaysOutClausesSubqueriesAndCTEs(t *testing.T) {
+	input := "with recent as (select * from orders where created_at > now() - interval '1 day') select u.id,count(o.id) as n from users u left join recent o on o.user_id=u.id where u.active and o.total between 1 and 10 or u.id in (select user_id from vip) group by u.id order by n desc limit 5; -- done\nselect 1"
+	want := `WITH recent AS (
+  SELECT *
+  FROM orders
+  WHERE created_at > now() - INTERVAL '1 day'
+)
+SELECT u.id, count(o.id) AS n
+FROM users u
+LEFT JOIN recent o ON o.user_id = u.id
+WHERE u.active
+  AND o.total BETWEEN 1 AND 10
+  OR u.id IN (
+    SELECT user_id
+    FROM vip
+  )
+GROUP BY u.id
+ORDER BY n DESC
+LIMIT 5; -- done
+
+SELECT 1`
+	got, err := Format(config.PostgreSQL, input, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("Format() =\n%s\n\nwant\n%s", got, want)
+	}
+	compact, err := Compact(config.PostgreSQL, got, CaseLower)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(compact, "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "with recent as (select * from orders where") || lines[1] != "select 1" {
+		t.Fatalf("Compact() =\n%s", compact)
+	}
+}
+
+func TestFormatOnlyChangesWhitespaceAndKeywordCase(t *testing.T) {
+	for _, test := range []struct {
+		dbType config.DBType
+		input  string
+	}{
+		{config.PostgreSQL, "SELECT $body$ select  from\n where $body$, E'a\\'b', \"Mixed Case\", data->>'k', a@>b, x::date FROM t WHERE a<>b /* keep  this */"},
+		{config.MySQL, "select `from`,@from:=1, \"where x\" from filters filter # trailing note\nwhere filter.id=-1 on duplicate key update a=values(a)"},
+		{config.SQLite, "update t set [select]=1,b=:b where id=?1--no comment here?\n and c='it''s'"},
+		{config.DuckDB, "select * exclude (a) from t union all select * from (values (1),(2)) v(x)"},
+	} {
+		for _, options := range []Options{{}, {Case: CaseLower, Width: 20}, {Compact: true, Case: CasePreserve}} {
+			got, err := Format(test.dbType, test.input, options)
+			if err != nil {
+				t.Fatalf("%s: %v", test.dbType, err)
+			}
+			assertSameTokens(t, test.dbType, test.input, got)
+			again, err := Format(test.dbType, got, options)
+			if err != nil || again != got {
+				t.Errorf("%s %+v: formatting is not stable:\n%s\n---\n%s", test.dbType, options, got, again)
+			}
+		}
+	}
+	if _, err := Format(config.MySQL, "DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//", Options{}); err == nil {
+		t.Fatal("a DELIMITER script was formatted")
+	}
+}
+
+// assertSameTokens checks that formatting kept every token, changing only
+// the case of unquoted words.
+func assertSameTokens(t *testing.T, dbType config.DBType, input, output string) {
+	t.Helper()
+	before, _ := Lex(input, DialectFor(dbType))
+	after, err := Lex(output, DialectFor(dbType))
+	if err != nil {
+		t.Fatalf("%s: formatted SQL does not lex: %v\n%s", dbType, err, output)
+	}
+	before, after = significantTokens(before), significantTokens(after)
+	if len(before) != len(after) {
+		t.Fatalf("%s: %d tokens became %d:\n%s", dbType, len(before), len(after), output)
+	}
+	for i := range before {
+		same := before[i] == after[i] || before[i].Kind == Word && after[i].Kind == Word && strings.EqualFold(before[i].Text, after[i].Text)
+		if !same {
+			t.Fatalf("%s: token %+v became %+v:\n%s", dbType, before[i], after[i], output)
+		}
+	}
+}
+
+func significantTokens(tokens []Token) []Token {
+	kept := tokens[:0:0]
+	for _, token := range tokens {
+		if token.Kind != Space {
+			kept = append(kept, token)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,456 @@
+// Package sshtunnel forwards a local loopback port to a database host through
+// one or more SSH servers. Every hop verifies its host key against a
+// known_hosts file; there is no trust-on-first-use fallback.
+package sshtunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultSSHPort    = "22"
+	defaultDialTime   = 10 * time.Second
+	keepAliveInterval = 30 * time.Second
+)
+
+// Hop is one SSH server on the path to the database. Hops are dialed in
+// order; each one after the first is reached through the previous hop.
+type Hop struct {
+	Host string
+	Port string
+	User string
+}
+
+// Address returns the hop as host:port with the default SSH port applied.
+func (h Hop) Address() string {
+	port := strings.TrimSpace(h.Port)
+	if port == "" {
+		port = defaultSSHPort
+	}
+	return net.JoinHostPort(strings.TrimSpace(h.Host), port)
+}
+
+// Options describes a complete tunnel. Authentication settings are shared by
+// every hop, matching how OpenSSH applies -i and the agent to ProxyJump hosts.
+type Options struct {
+	Hops           []Hop
+	KeyFile        string
+	Password       string // password auth, or the passphrase of an encrypted key
+	UseAgent       bool
+	KnownHostsFile string
+	DialTimeout    time.Duration
+}
+
+// Tunnel is a running local forward. Close releases the listener, every
+// forwarded connection, and every SSH session in the chain.
+type Tunnel struct {
+	listener  net.Listener
+	clients   []*ssh.Client
+	target    string
+	done      chan struct{}
+	closeOnce sync.Once
+	wait      sync.WaitGroup
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+// Open connects every hop and starts forwarding 127.0.0.1:<random> to target.
+func Open(ctx context.Context, options Options, target string) (*Tunnel, error) {
+	if len(options.Hops) == 0 {
+		return nil, fmt.Errorf("SSH tunnel needs at least one SSH host")
+	}
+	if strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("SSH tunnel target address is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := options.DialTimeout
+	if timeout <= 0 {
+		timeout = defaultDialTime
+	}
+	auth, closeAuth, err := authMethods(options)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAuth()
+	hostKeys, err := knownHostsCallback(options.KnownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*ssh.Client, 0, len(options.Hops))
+	closeClients := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			_ = clients[i].Close()
+		}
+	}
+	for index, hop := range options.Hops {
+		if strings.TrimSpace(hop.Host) == "" {
+			closeClients()
+			return nil, fmt.Errorf("SSH hop %d is missing a host", index+1)
+		}
+		if strings.TrimSpace(hop.User) == "" {
+			closeClients()
+			return nil, fmt.Errorf("SSH hop %s is missing a user", hop.Address())
+		}
+		clientConfig := &ssh.ClientConfig{
+			User:              strings.TrimSpace(hop.User),
+			Auth:              auth,
+			HostKeyCallback:   hostKeys,
+			HostKeyAlgorithms: knownHostKeyAlgorithms(hostKeys, hop.Address()),
+			Timeout:           timeout,
+		}
+		client, err := dialHop(ctx, clients, hop.Address(), clientConfig, timeout)
+		if err != nil {
+			closeClients()
+			return nil, describeHopError(hop, err)
+		}
+		clients = append(clients, client)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		closeClients()
+		return nil, fmt.Errorf("open local SSH forward: %w", err)
+	}
+	tunnel := &Tunnel{
+		listener: listener,
+		clients:  clients,
+		target:   target,
+		done:     make(chan struct{}),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	tunnel.wait.Add(2)
+	go tunnel.acceptLoop()
+	go tunnel.keepAlive()
+	return tunnel, nil
+}
+
+// LocalAddress returns the loopback host and port that reach the target.
+func (t *Tunnel) LocalAddress() (string, string) {
+	host, port, _ := net.SplitHostPort(t.listener.Addr().String())
+	return host, port
+}
+
+// Close stops forwarding and disconnects every hop, innermost first.
+func (t *Tunnel) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.closeOnce.Do(func() {
+		close(t.done)
+		_ = t.listener.Close()
+		t.mu.Lock()
+		for conn := range t.conns {
+			_ = conn.Close()
+		}
+		t.mu.Unlock()
+		for i := len(t.clients) - 1; i >= 0; i-- {
+			_ = t.clients[i].Close()
+		}
+		t.wait.Wait()
+	})
+	return nil
+}
+
+func (t *Tunnel) acceptLoop() {
+	defer t.wait.Done()
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		t.wait.Add(1)
+		go t.forward(local)
+	}
+}
+
+func (t *Tunnel) forward(local net.Conn) {
+	defer t.wait.Done()
+	if !t.track(local) {
+		_ = local.Close()
+		return
+	}
+	defer t.untrack(local)
+	defer local.Close()
+
+	last := t.clients[len(t.clients)-1]
+	remote, err := last.Dial("tcp", t.target)
+	if err != nil {
+		return
+	}
+	if !t.track(remote) {
+		_ = remote.Close()
+		return
+	}
+	defer t.untrack(remote)
+	defer remote.Close()
+
+	copied := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		if closer, ok := dst.(interface{ CloseWrite() error }); ok {
+			_ = closer.CloseWrite()
+		}
+		copied <- struct{}{}
+	}
+	go pipe(remote, local)
+	go pipe(local, remote)
+	// Either side finishing its stream ends the forwarded session once the
+	// other direction drains; Close interrupts both copies on shutdown.
+	<-copied
+	select {
+	case <-copied:
+	case <-t.done:
+	}
+}
+
+func (t *Tunnel) track(conn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	select {
+	case <-t.done:
+		return false
+	default:
+	}
+	t.conns[conn] = struct{}{}
+	return true
+}
+
+func (t *Tunnel) untrack(conn net.Conn) {
+	t.mu.Lock()
+	delete(t.conns, conn)
+	t.mu.Unlock()
+}
+
+// keepAlive stops idle bastions from dropping the session between queries.
+func (t *Tunnel) keepAlive() {
+	defer t.wait.Done()
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			for _, client := range t.clients {
+				_, _, _ = client.SendRequest("keepalive@openssh.com", true, nil)
+			}
+		}
+	}
+}
+
+func dialHop(ctx context.Context, previous []*ssh.Client, address string, clientConfig *ssh.ClientConfig, timeout time.Duration) (*ssh.Client, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var (
+		conn net.Conn
+		err  error
+	)
+	if len(previous) == 0 {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(dialCtx, "tcp", address)
+	} else {
+		conn, err = previous[len(previous)-1].DialContext(dialCtx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// ssh.NewClientConn has no context; a deadline bounds the handshake and
+	// is cleared once the session is established.
+	if deadline, ok := dialCtx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, address, clientConfig)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ssh.NewClient(clientConn, channels, requests), nil
+}
+
+func authMethods(options Options) ([]ssh.AuthMethod, func(), error) {
+	var methods []ssh.AuthMethod
+	cleanup := func() {}
+	if keyFile := strings.TrimSpace(options.KeyFile); keyFile != "" {
+		signer, err := loadSigner(keyFile, options.Password)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if options.UseAgent {
+		socket := strings.TrimSpace(os.Getenv("SSH_AUTH_SOCK"))
+		if socket == "" {
+			return nil, cleanup, fmt.Errorf("SSH agent authentication is enabled, but SSH_AUTH_SOCK is not set")
+		}
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("connect to SSH agent: %w", err)
+		}
+		// The agent socket is only needed while hops authenticate.
+		cleanup = func() { _ = conn.Close() }
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+	}
+	if options.Password != "" && strings.TrimSpace(options.KeyFile) == "" {
+		methods = append(methods, ssh.Password(options.Password))
+	}
+	if len(methods) == 0 {
+		return nil, cleanup, fmt.Errorf("SSH tunnel needs a key file, the SSH agent, or a password")
+	}
+	return methods, cleanup, nil
+}
+
+func loadSigner(keyFile, passphrase string) (ssh.Signer, error) {
+	path, err := ExpandHome(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read SSH key %s: %w", path, err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("SSH key %s is encrypted; enter its passphrase in the SSH password field", path)
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse SSH key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+func knownHostsCallback(file string) (ssh.HostKeyCallback, error) {
+	file = strings.TrimSpace(file)
+	if file == "" {
+		file = "~/.ssh/known_hosts"
+	}
+	path, err := ExpandHome(file)
+	if err != nil {
+		return nil, err
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("load SSH known_hosts %s: %w", path, err)
+	}
+	return callback, nil
+}
+
+// knownHostKeyAlgorithms lists the host key algorithms of the keys known_hosts
+// pins for address, as OpenSSH does. Without it the server may present a key
+// type the file does not record, which looks like a changed key. Nil keeps
+// the library defaults for hosts that are not pinned yet.
+func knownHostKeyAlgorithms(callback ssh.HostKeyCallback, address string) []string {
+	// A key that matches nothing makes the callback report every pinned key.
+	err := callback(address, &net.TCPAddr{IP: net.IPv4zero}, placeholderKey{})
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return nil
+	}
+	var algorithms []string
+	seen := map[string]bool{}
+	for _, known := range keyErr.Want {
+		keyType := known.Key.Type()
+		candidates := []string{keyType}
+		if keyType == ssh.KeyAlgoRSA {
+			candidates = []string{ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSA}
+		}
+		for _, algorithm := range candidates {
+			if !seen[algorithm] {
+				seen[algorithm] = true
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	return algorithms
+}
+
+// placeholderKey is a public key no known_hosts entry can match.
+type placeholderKey struct{}
+
+func (placeholderKey) Type() string    { return "dbterm-placeholder" }
+func (placeholderKey) Marshal() []byte { return []byte("dbterm-placeholder") }
+func (placeholderKey) Verify([]byte, *ssh.Signature) error {
+	return errors.New("placeholder key cannot verify signatures")
+}
+
+func describeHopError(hop Hop, err error) error {
+	var keyErr *knownhosts.KeyError
+	if errors.As(err, &keyErr) {
+		if len(keyErr.Want) == 0 {
+			return fmt.Errorf("SSH host %s is not in known_hosts; verify its fingerprint and add it with ssh-keyscan -p %s %s >> ~/.ssh/known_hosts",
+				hop.Address(), nonEmpty(hop.Port, defaultSSHPort), strings.TrimSpace(hop.Host))
+		}
+		return fmt.Errorf("SSH host key for %s does not match known_hosts; refusing a possible man-in-the-middle", hop.Address())
+	}
+	return fmt.Errorf("SSH %s@%s: %w", strings.TrimSpace(hop.User), hop.Address(), err)
+}
+
+// ExpandHome resolves a leading ~ to the current user's home directory.
+func ExpandHome(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory for %s: %w", path, err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// ParseJumpHosts reads an OpenSSH ProxyJump-style list such as
+// "ops@bastion:2222,jump2". Entries without a user inherit defaultUser.
+func ParseJumpHosts(value, defaultUser string) ([]Hop, error) {
+	var hops []Hop
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		hop := Hop{User: defaultUser}
+		if user, rest, found := strings.Cut(entry, "@"); found {
+			hop.User = strings.TrimSpace(user)
+			entry = rest
+		}
+		if host, port, err := net.SplitHostPort(entry); err == nil {
+			hop.Host, hop.Port = host, port
+		} else if strings.Count(entry, ":") == 0 {
+			hop.Host = entry
+		} else {
+			return nil, fmt.Errorf("invalid SSH jump host %q; use user@host:port", entry)
+		}
+		if strings.TrimSpace(hop.Host) == "" {
+			return nil, fmt.Errorf("invalid SSH jump host %q; host is required", entry)
+		}
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
+
+func nonEmpty(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return strings.TrimSpace(value)
+}
@@ -16,7 +16,11 @@ import (
 	backupcore "github.com/shreyam1008/dbterm/internal/backup"
 	profiler "github.com/shreyam1008/dbterm/internal/changeprofiler"
 	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
 	"github.com/shreyam1008/dbterm/internal/history"
+	"github.com/shreyam1008/dbterm/internal/savedquery"
+	"github.com/shreyam1008/dbterm/internal/scratch"
+	"github.com/shreyam1008/dbterm/internal/secrets"
 )
 
 // ── Catppuccin Mocha ──────────────────────────────────────────────────
@@ -40,6 +44,7 @@ var (
 	updateRowBG  = tcell.NewRGBColor(61, 55, 31)
 	updateCellBG = tcell.NewRGBColor(91, 75, 31)
 	deleteRowBG  = tcell.NewRGBColor(63, 34, 43)
+	stagedCellBG = tcell.NewRGBColor(38, 52, 86)
 )
 
 // App holds all TUI state for the dbterm application
@@ -51,6 +56,8 @@ type App struct {
 	settings               *config.Settings
 	keymap                 *actionKeymap
 	historyMgr             *history.Manager
+	scratchMgr             *scratch.Manager
+	queryLibraryDir        string // personal saved-query library
 	backupStore            *backupcore.Store
 	profilerStore          *profiler.Store
 	buildInfo              BuildInfo
@@ -72,6 +79,7 @@ type App struct {
 	dbType                  config.DBType
 	dbName                  string // name of current connection (from config)
 	activeConn              *config.ConnectionConfig
+	projectWorkspace        *config.Workspace // .dbterm.json found at startup, if any
 
 	// Main UI components
 	tables                *tview.List
@@ -96,6 +104,7 @@ type App struct {
 	resultColumnSearch    string                          // type-ahead search while the result header row is active
 	resultFilter          *resultValueFilter
 	resultFilters         map[string]*resultValueFilter // remembered per table for the active connection
+	resultDiffBaseline    *resultDiffSnapshot           // pinned result; kept across connections
 	copiedCellValue       string
 	hasCopiedCellValue    bool
 	copiedCellSystem      bool
@@ -106,7 +115,17 @@ type App struct {
 	loadingMu             sync.Mutex
 	loadingReturns        map[uint64]loadingReturnState
 	results               *tview.Table
+	scriptTabs            *tview.TextView
+	script                *scriptRun // per-statement tabs of the last script
+	cellEdits             *cellEditSession
+	transaction           *database.Transaction // explicit transaction editor statements run in
 	queryInput            *tview.TextArea
+	editorTabs            []*editorTab // named buffers of the active connection
+	editorTabIndex        int
+	editorTabsKey         string // connection key the tabs are saved under
+	editorSaveMu          sync.Mutex
+	editorSaveTimer       *time.Timer
+	editorSavePending     *pendingEditorTabsSave
 	sqlCompletionView     *tview.Table
 	sqlCompletionState    sqlCompletionState
 	sqlCompletionCatalog  sqlCompletionCatalog
@@ -123,11 +142,17 @@ type App struct {
 	resultExportMu        sync.Mutex
 	resultExportRunning   bool
 	resultExportCancel    context.CancelFunc
+	// resultExportFormatIndex remembers the last export format this session.
+	resultExportFormatIndex int
 
 	// Pagination state
-	pageOffset              int           // current OFFSET for paginated table browsing
-	pageSize                int           // actual rows shown per page after safety limits
-	totalRowCount           int           // cached COUNT(*) for the selected table (-1 = unknown)
+	pageOffset              int  // current OFFSET for paginated table browsing
+	pageSize                int  // actual rows shown per page after safety limits
+	totalRowCount           int  // cached COUNT(*) for the selected table (-1 = unknown)
+	totalRowCountApprox     bool // totalRowCount is a catalog estimate
+	pageCursor              *resultPageCursor
+	tableSeekKeys           map[string]*tableSeekKey // keyset pagination keys per table
+	tableSeekKeyLoads       map[string]bool
 	resultGeneration        atomic.Uint64 // invalidates async result metadata updates
 	sqlCompletionGeneration atomic.Uint64 // invalidates async autocomplete metadata
 	sidebarSearchGeneration atomic.Uint64 // debounces lazy metadata while type-ahead changes
@@ -182,6 +207,9 @@ func NewApp() *App {
 // NewAppWithBuildInfo creates an application with release metadata supplied by
 // the main package, which owns the embedded release manifest and linker values.
 func NewAppWithBuildInfo(buildInfo BuildInfo) *App {
+	// Key-file vaults and environment-provided keys unlock before the store
+	// loads; failures are reported by the unlock prompt once the UI is up.
+	_, _ = secrets.UnlockNonInteractive()
 	store, err := config.LoadStore()
 	if store == nil {
 		store = &config.Store{}
@@ -199,6 +227,16 @@ func NewAppWithBuildInfo(buildInfo BuildInfo) *App {
 		fmt.Printf("⚠ Warning: query history disabled: %v\n", historyErr)
 	}
 
+	scratchMgr, scratchErr := scratch.NewManager()
+	if scratchErr != nil {
+		fmt.Printf("⚠ Warning: editor tabs will not be saved: %v\n", scratchErr)
+	}
+
+	queryLibraryDir, queryLibraryErr := savedquery.DefaultRoot()
+	if queryLibraryErr != nil {
+		fmt.Printf("⚠ Warning: saved queries disabled: %v\n", queryLibraryErr)
+	}
+
 	settings, settingsErr := config.LoadSettings()
 	if settingsErr != nil {
 		fmt.Printf("⚠ Warning: settings required attention: %v\n", settingsErr)
@@ -223,6 +261,8 @@ func NewAppWithBuildInfo(buildInfo BuildInfo) *App {
 		settings:               settings,
 		keymap:                 keymap,
 		historyMgr:             historyMgr,
+		scratchMgr:             scratchMgr,
+		queryLibraryDir:        queryLibraryDir,
 		resultLimit:            defaultTablePreviewLimit,
 		totalRowCount:          -1,
 		buildInfo:              normalizeBuildInfo(buildInfo),
@@ -269,6 +309,12 @@ func (a *App) setupUI() {
 		SetTitleColor(peach)
 	a.sqlCompletionView = newSQLCompletionView()
 
+	// ── Script Tabs ──
+	a.scriptTabs = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	a.scriptTabs.SetBackgroundColor(mantle)
+
 	// ── Status Bar ──
 	a.statusBar = tview.NewTextView().
 		SetDynamicColors(true).
@@ -283,6 +329,7 @@ func (a *App) setupUI() {
 		SetDirection(tview.FlexRow).
 		AddItem(a.queryInput, 0, 1, false).
 		AddItem(a.sqlCompletionView, 0, 0, false).
+		AddItem(a.scriptTabs, 0, 0, false).
 		AddItem(a.results, 0, 4, false) // Results get 80% vertical space
 
 	a.mainFlex = tview.NewFlex().
@@ -317,6 +364,30 @@ func (a *App) setupUI() {
 			case 'f':
 				a.exploreSelectedRelationships()
 				return nil
+			case 'p':
+				a.profileSelectedResultColumn()
+				return nil
+			case 'b':
+				a.pinOrDiffResultBaseline()
+				return nil
+			case '#':
+				a.countRowsExactly()
+				return nil
+			case 'e':
+				a.editSelectedResultCell()
+				return nil
+			case 'w':
+				a.showCellEditReview()
+				return nil
+			case 'i':
+				a.showRowInsert(false)
+				return nil
+			case 'd':
+				a.showRowInsert(true)
+				return nil
+			case 'x':
+				a.deleteSelectedResultRows()
+				return nil
 			case 's':
 				// Sort by current column.
 				row, col := a.results.GetSelection()
@@ -335,11 +406,22 @@ func (a *App) setupUI() {
 			case '[':
 				a.prevPage()
 				return nil
+			case '}':
+				if a.moveScriptTab(1) {
+					return nil
+				}
+			case '{':
+				if a.moveScriptTab(-1) {
+					return nil
+				}
 			}
 		}
 
 		// Pagination: PgDn/PgUp for next/prev page, Home/End for first/last page
 		switch event.Key() {
+		case tcell.KeyDelete:
+			a.deleteSelectedResultRows()
+			return nil
 		case tcell.KeyPgDn:
 			a.nextPage()
 			return nil
@@ -410,7 +492,10 @@ func (a *App) setupUI() {
 		}
 		return event
 	})
-	a.queryInput.SetChangedFunc(func() { a.refreshSQLCompletions(false) })
+	a.queryInput.SetChangedFunc(func() {
+		a.editorTextChanged()
+		a.refreshSQLCompletions(false)
+	})
 	a.queryInput.SetMovedFunc(func() { a.refreshSQLCompletions(false) })
 }
 
@@ -419,6 +504,8 @@ func (a *App) updateStatusBar(extra string, rowCount int) {
 	width, _ := a.getScreenSize()
 	actionText := a.statusActionText(width)
 	selectedCount := a.selectedResultRowCount()
+	// Script tabs go away as soon as another result owns the grid.
+	a.refreshScriptTabs()
 
 	if running := a.queryRunningStatus(width, time.Now()); running != "" {
 		a.statusBar.SetText("  " + running)
@@ -450,6 +537,9 @@ func (a *App) updateStatusBar(extra string, rowCount int) {
 	case config.SQLite:
 		dbIcon = "[#a6e3a1]◆ SQLite[-]"
 		dbShort = "[#a6e3a1]SL[-]"
+	case config.DuckDB:
+		dbIcon = "[#f9e2af]◇ DuckDB[-]"
+		dbShort = "[#f9e2af]DK[-]"
 	default:
 		dbShort = "[#6c7086]DB[-]"
 	}
@@ -469,6 +559,11 @@ func (a *App) updateStatusBar(extra string, rowCount int) {
 	if width < 90 {
 		parts[0] = fmt.Sprintf("%s [green]●[-] %s [white]%s[-]", dbShort, iconConnect, truncateForDisplay(a.dbName, nameMax))
 	}
+	if a.activeConn != nil {
+		if tag := environmentTag(*a.activeConn); tag != "" {
+			parts[0] += " " + tag
+		}
+	}
 
 	if width >= 90 {
 		parts = append(parts, fmt.Sprintf("[gray]%d tables[-]", a.tableCount))
@@ -483,6 +578,12 @@ func (a *App) updateStatusBar(extra string, rowCount int) {
 			parts = append(parts, fmt.Sprintf("[yellow]%d selected[-]", selectedCount))
 		}
 	}
+	if a.transaction != nil {
+		parts = append(parts, fmt.Sprintf("[#fab387]TX open · %s commit/rollback[-]", a.escapedActionShortcut(actionTransaction)))
+	}
+	if staged := a.pendingCellEditCount(); staged > 0 {
+		parts = append(parts, fmt.Sprintf("[#89b4fa]%d staged · W review[-]", staged))
+	}
 	if width >= 84 {
 		parts = append(parts, a.resultLimitStatus(width))
 	}
@@ -506,7 +607,7 @@ func (a *App) updateStatusBar(extra string, rowCount int) {
 func (a *App) setFocusWithColor(target tview.Primitive) {
 	// Reset all panel borders to inactive color
 	a.tables.SetBorderColor(surface1)
-	a.queryInput.SetBorderColor(surface1)
+	a.queryInput.SetBorderColor(a.queryBorderColor(false))
 	a.results.SetBorderColor(surface1)
 
 	// Set the focused panel border to its accent color
@@ -514,7 +615,7 @@ func (a *App) setFocusWithColor(target tview.Primitive) {
 	case a.tables:
 		a.tables.SetBorderColor(mauve)
 	case a.queryInput:
-		a.queryInput.SetBorderColor(blue)
+		a.queryInput.SetBorderColor(a.queryBorderColor(true))
 	case a.results:
 		a.results.SetBorderColor(green)
 	}
@@ -662,12 +763,12 @@ func (a *App) setResultLimit(limit int) {
 	prevLimit := a.resultLimit
 	prevOffset := a.pageOffset
 	prevPageSize := a.pageSize
-	prevTotal := a.totalRowCount
+	prevTotal, prevApprox := a.totalRowCount, a.totalRowCountApprox
 	restorePrevious := func() {
 		a.resultLimit = prevLimit
 		a.pageOffset = prevOffset
 		a.pageSize = prevPageSize
-		a.totalRowCount = prevTotal
+		a.totalRowCount, a.totalRowCountApprox = prevTotal, prevApprox
 	}
 	a.resultLimit = limit
 	a.pageOffset = 0 // reset to first page when page size changes
@@ -796,10 +897,14 @@ func (a *App) paginationStatus(width int) string {
 		if totalPages < 1 {
 			totalPages = 1
 		}
+		approx := ""
+		if a.totalRowCountApprox {
+			approx = "~"
+		}
 		if width < 120 {
-			return fmt.Sprintf("[#a6adc8]pg[-]:[yellow]%d/%d[-]", page, totalPages)
+			return fmt.Sprintf("[#a6adc8]pg[-]:[yellow]%d/%s%d[-]", page, approx, totalPages)
 		}
-		return fmt.Sprintf("[#a6adc8]page[-] [yellow]%d/%d[-]", page, totalPages)
+		return fmt.Sprintf("[#a6adc8]page[-] [yellow]%d/%s%d[-]", page, approx, totalPages)
 	}
 	if a.pageOffset > 0 {
 		if width < 120 {
@@ -990,8 +1095,13 @@ func (a *App) setupKeyBindings() {
 				}
 			}
 
-			a.cleanup()
-			a.app.Stop()
+			quit := func() {
+				a.cleanup()
+				a.app.Stop()
+			}
+			if !a.confirmEndTransaction("Quitting", quit) {
+				quit()
+			}
 			return nil
 		}
 
@@ -1207,6 +1317,21 @@ func (a *App) setupKeyBindings() {
 			case actionImportDump:
 				a.showImportModal()
 				return nil
+			case actionImportData:
+				a.showDataImport()
+				return nil
+			case actionExplainQuery:
+				a.explainQuery()
+				return nil
+			case actionFormatSQL:
+				a.formatEditorSQL(false)
+				return nil
+			case actionTransaction:
+				a.toggleTransaction()
+				return nil
+			case actionQueryLibrary:
+				a.showSavedQueries()
+				return nil
 			case actionInspectSchema:
 				if a.app.GetFocus() == a.queryInput {
 					return event
@@ -1229,11 +1354,25 @@ func (a *App) setupKeyBindings() {
 				if a.app.GetFocus() == a.queryInput {
 					return event
 				}
-				a.exportCurrentResultsToCSV()
+				a.exportCurrentResults()
 				return nil
 			case actionHistory:
 				a.showHistoryModal()
 				return nil
+			case actionNewEditorTab:
+				if a.openEditorTab("") {
+					a.setFocusWithColor(a.queryInput)
+				}
+				return nil
+			case actionCloseEditorTab:
+				a.closeEditorTab()
+				return nil
+			case actionNextEditorTab:
+				a.cycleEditorTab(1)
+				return nil
+			case actionPrevEditorTab:
+				a.cycleEditorTab(-1)
+				return nil
 			}
 		}
 
@@ -1516,13 +1655,16 @@ func (a *App) cleanup() {
 	a.resetSQLCompletionCatalog()
 	a.requestActiveQueryCancel()
 	a.cancelActiveResultExport()
+	a.abandonTransaction()
 	a.clearTableSessionState()
 	a.resultNavStack = nil
+	a.flushEditorTabs()
 	if a.db != nil {
 		a.db.Close()
 		a.db = nil
 	}
 	a.activeConn = nil
+	a.refreshEnvironmentChrome()
 }
 
 // clearTableSessionState drops visual browsing history and remembered filters.
@@ -1534,6 +1676,7 @@ func (a *App) clearTableSessionState() {
 	}
 	a.activeTable = ""
 	a.visitedTables = nil
+	a.cellEdits = nil
 	a.selectedTable = ""
 	a.tableSearch = ""
 	a.sidebarSearchGeneration.Add(1)
@@ -1548,6 +1691,9 @@ func (a *App) clearTableSessionState() {
 	a.resultColumnSearch = ""
 	a.resultFilter = nil
 	a.resultFilters = nil
+	a.pageCursor = nil
+	a.tableSeekKeys = nil
+	a.tableSeekKeyLoads = nil
 	a.refreshTableSidebarState()
 }
 
@@ -1590,6 +1736,7 @@ func (a *App) Run() error {
 		a.ShowAlert(fmt.Sprintf("%s %s%s", icon, tview.Escape(a.startupNotice), suffix), "dashboard")
 		a.startupNotice = ""
 	}
+	a.showVaultUnlockIfLocked()
 
 	a.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
 		w, h := screen.Size()
@@ -1639,6 +1786,7 @@ func (a *App) applyResponsiveLayout(width, height int) {
 		completionHeight = a.sqlCompletionPopupHeight()
 	}
 	a.rightFlex.AddItem(a.sqlCompletionView, completionHeight, 0, false)
+	a.rightFlex.AddItem(a.scriptTabs, a.scriptTabsHeight(), 0, false)
 	a.rightFlex.AddItem(a.results, 0, 1, false)
 
 	if width < 110 {
@@ -1710,20 +1858,20 @@ func (a *App) statusActionText(width int) string {
 			if filterActive {
 				return "[yellow]Esc[-] Clear filter  │  [yellow]C[-] Copy  │  [yellow]/[-] Find"
 			}
-			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  %s CSV", exportKey)
+			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  %s Export", exportKey)
 		}
-		return fmt.Sprintf("[yellow]Space[-] Select  │  %s CSV  │  [yellow]Esc[-] Back", exportKey)
+		return fmt.Sprintf("[yellow]Space[-] Select  │  %s Export  │  [yellow]Esc[-] Back", exportKey)
 	case width < 90:
 		if inQuery {
 			return fmt.Sprintf("[yellow]Enter[-] Run ▶  │  [yellow]Shift+Enter[-] Newline  │  [yellow]Esc[-] Back  │  %s Guide %s", helpKey, iconHelp)
 		}
 		if inResults {
 			if filterActive {
-				return fmt.Sprintf("[yellow]Esc[-] Clear filter  │  [yellow]/[-] Change  │  %s CSV", exportKey)
+				return fmt.Sprintf("[yellow]Esc[-] Clear filter  │  [yellow]/[-] Change  │  %s Export", exportKey)
 			}
-			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  %s CSV", exportKey)
+			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  %s Export", exportKey)
 		}
-		return fmt.Sprintf("[yellow]Space[-] Select  │  %s/%s All/Clear  │  %s CSV  │  %s %s", selectAllKey, clearSelectionKey, exportKey, helpKey, iconHelp)
+		return fmt.Sprintf("[yellow]Space[-] Select  │  %s/%s All/Clear  │  %s Export  │  %s %s", selectAllKey, clearSelectionKey, exportKey, helpKey, iconHelp)
 	case width < 120:
 		if inQuery {
 			return fmt.Sprintf("[yellow]Enter[-] Run ▶  │  [yellow]Shift+Enter[-] Newline  │  [yellow]F5[-] %s  │  %s/[yellow]Esc[-] Dash %s",
@@ -1731,14 +1879,14 @@ func (a *App) statusActionText(width int) string {
 		}
 		if inResults {
 			if filterActive {
-				return fmt.Sprintf("[yellow]Esc[-] Clear filter  │  [yellow]/[-] Change  │  [yellow]C[-] Copy  │  %s CSV  │  %s %s", exportKey, helpKey, iconHelp)
+				return fmt.Sprintf("[yellow]Esc[-] Clear filter  │  [yellow]/[-] Change  │  [yellow]C[-] Copy  │  %s Export  │  %s %s", exportKey, helpKey, iconHelp)
 			}
 			if tableActive {
-				return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s CSV", exportKey)
+				return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s Export", exportKey)
 			}
-			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]Enter[-] Detail  │  %s CSV  │  [yellow]%s[-] Palette", exportKey, paletteKey)
+			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]Enter[-] Detail  │  %s Export  │  [yellow]%s[-] Palette", exportKey, paletteKey)
 		}
-		return fmt.Sprintf("[yellow]F5[-] %s  │  [yellow]Space[-] Toggle Sel  │  %s/%s/%s All/Clear/CSV  │  [yellow]Enter[-] Detail  │  %s/[yellow]Esc[-] Dash %s",
+		return fmt.Sprintf("[yellow]F5[-] %s  │  [yellow]Space[-] Toggle Sel  │  %s/%s/%s All/Clear/Export  │  [yellow]Enter[-] Detail  │  %s/[yellow]Esc[-] Dash %s",
 			iconRefresh, selectAllKey, clearSelectionKey, exportKey, dashboardKey, iconDashboard)
 	default:
 		if inQuery {
@@ -1747,14 +1895,14 @@ func (a *App) statusActionText(width int) string {
 		}
 		if inResults {
 			if filterActive {
-				return fmt.Sprintf("[yellow]Esc[-] Clear filters  │  [yellow]/[-] Change  │  [yellow]C[-] Copy  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s CSV  │  [yellow]%s[-] Palette", exportKey, paletteKey)
+				return fmt.Sprintf("[yellow]Esc[-] Clear filters  │  [yellow]/[-] Change  │  [yellow]C[-] Copy  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s Export  │  [yellow]%s[-] Palette", exportKey, paletteKey)
 			}
 			if tableActive {
-				return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s CSV  │  [yellow]Enter[-] Detail  │  [yellow]F5[-] %s  │  [yellow]%s[-] Palette", exportKey, iconRefresh, paletteKey)
+				return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]/[-] Filter  │  [yellow]V[-] Clipboard  │  [yellow]F[-] Follow FK  │  %s Export  │  [yellow]Enter[-] Detail  │  [yellow]F5[-] %s  │  [yellow]%s[-] Palette", exportKey, iconRefresh, paletteKey)
 			}
-			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]Space[-] Select  │  [yellow]Enter[-] Detail  │  %s CSV  │  [yellow]%s[-] Palette  │  %s %s", exportKey, paletteKey, helpKey, iconHelp)
+			return fmt.Sprintf("[yellow]C[-] Copy  │  [yellow]Space[-] Select  │  [yellow]Enter[-] Detail  │  %s Export  │  [yellow]%s[-] Palette  │  %s %s", exportKey, paletteKey, helpKey, iconHelp)
 		}
-		return fmt.Sprintf("[yellow]F5[-] %s  │  [yellow]Space[-] Toggle Sel  │  %s All  │  %s Clear  │  %s CSV  │  [yellow]Enter[-] Detail  │  %s Guide %s  │  [yellow]Esc/Bksp[-] Dashboard %s",
+		return fmt.Sprintf("[yellow]F5[-] %s  │  [yellow]Space[-] Toggle Sel  │  %s All  │  %s Clear  │  %s Export  │  [yellow]Enter[-] Detail  │  %s Guide %s  │  [yellow]Esc/Bksp[-] Dashboard %s",
 			iconRefresh, selectAllKey, clearSelectionKey, exportKey, helpKey, iconHelp, iconDashboard)
 	}
 }
@@ -436,7 +436,7 @@ func backupBaseName(cfg *config.ConnectionConfig) string {
 		return "database"
 	}
 	switch cfg.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		if strings.TrimSpace(cfg.FilePath) != "" {
 			return strings.TrimSuffix(filepath.Base(cfg.FilePath), filepath.Ext(cfg.FilePath))
 		}
@@ -453,7 +453,7 @@ func backupTargetLabel(cfg *config.ConnectionConfig) string {
 		return "database"
 	}
 	switch cfg.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		return nonEmptyOr(cfg.FilePath, cfg.Name)
 	case config.Turso:
 		return nonEmptyOr(cfg.Host, cfg.Name)
@@ -517,7 +517,7 @@ func (a *App) showBackupConnectionPicker() {
 func backupConnectionSummary(connection config.ConnectionConfig) string {
 	typeLabel := connection.TypeLabel()
 	switch connection.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		return fmt.Sprintf("%s · %s", typeLabel, nonEmptyOr(connection.FilePath, "path not set"))
 	case config.Turso:
 		return fmt.Sprintf("%s · %s", typeLabel, nonEmptyOr(connection.Host, "URL not set"))
@@ -1837,6 +1837,7 @@ func (a *App) runRestoreAsync(plan *backupcore.RestorePlan) {
 				a.dbType = reconnect.Type
 				a.dbName = reconnect.Name
 				a.activeConn = cloneConnectionConfig(reconnect)
+				a.refreshEnvironmentChrome()
 			}
 			if canceled.Load() {
 				note := "The restore was canceled."
@@ -1877,21 +1878,23 @@ func (a *App) detachActiveSQLiteRestoreTarget(target config.ConnectionConfig) *c
 		return nil
 	}
 	reconnect := cloneConnectionConfig(a.activeConn)
+	a.abandonTransaction()
 	_ = a.db.Close()
 	a.db = nil
 	a.activeConn = nil
+	a.refreshEnvironmentChrome()
 	return reconnect
 }
 
 func restoreTargetLabel(target config.ConnectionConfig) string {
-	if target.Type == config.SQLite {
+	if target.Type == config.SQLite || target.Type == config.DuckDB {
 		return target.FilePath
 	}
 	return fmt.Sprintf("%s@%s:%s/%s", nonEmptyOr(target.User, "user"), nonEmptyOr(target.Host, "localhost"), defaultPortFor(&target), target.Database)
 }
 
 func restoreConfirmationValue(target config.ConnectionConfig) string {
-	if target.Type == config.SQLite {
+	if target.Type == config.SQLite || target.Type == config.DuckDB {
 		return filepath.Base(target.FilePath)
 	}
 	return target.Database
@@ -0,0 +1,551 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+const (
+	pageCellEdit = "cellEdit"
+
+	paletteActionEditCell         keymapAction = "palette_edit_cell"
+	paletteActionReviewCellEdits  keymapAction = "palette_review_cell_edits"
+	paletteActionDiscardCellEdits keymapAction = "palette_discard_cell_edits"
+
+	cellEditKeyPrimary           = "primary key"
+	cellEditKeyUnique            = "unique key"
+	cellEditRowIdentitySeparator = "\x1f"
+)
+
+// cellEditSession holds the staged edits of one table. Key columns are
+// resolved once and identify rows across pages and refreshes.
+type cellEditSession struct {
+	table      string
+	keyColumns []string
+	keyKind    string
+	edits      []stagedCellEdit
+}
+
+// stagedCellEdit is one pending value. A nil value stages SQL NULL.
+type stagedCellEdit struct {
+	rowKey   string
+	keys     []any
+	column   string
+	original string
+	value    any
+}
+
+func (a *App) pendingCellEditCount() int {
+	if a == nil || a.cellEdits == nil {
+		return 0
+	}
+	return len(a.cellEdits.edits)
+}
+
+// editSelectedResultCell opens the edit form for the selected table cell,
+// resolving the table's key first when this is its first edit.
+func (a *App) editSelectedResultCell() {
+	row, col, column, _, ok := a.currentResultCell()
+	if !ok || !a.isTableResultActive() || a.db == nil {
+		a.flashStatus("[yellow]Select a table data cell to edit[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	if !a.rowWritesAllowed("Cell editing") {
+		return
+	}
+	table := a.selectedTable
+	if session := a.cellEdits; session != nil && session.table != table && len(session.edits) > 0 {
+		a.ShowAlert(fmt.Sprintf("%s %d edit(s) are staged for %s.\n\nCommit or discard them before editing another table.", iconWarn, len(session.edits), tview.Escape(session.table)), "main")
+		return
+	}
+	if session := a.cellEdits; session != nil && session.table == table {
+		a.showCellEditForm(row, col, column)
+		return
+	}
+
+	db := a.db
+	dbType := a.dbType
+	namespace := a.defaultObjectNamespace("")
+	resultGeneration := a.currentResultGeneration()
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Finding the key of %s...", table),
+		withLoadingCancel("Press Esc to cancel.", func() {
+			canceled.Store(true)
+			cancel()
+			a.setFocusWithColor(a.results)
+		}),
+	)
+
+	go func() {
+		defer cancel()
+		keyColumns, keyKind, err := loadCellEditKey(ctx, db, dbType, table, namespace)
+		a.queueUpdateDraw(func() {
+			if canceled.Load() {
+				return
+			}
+			if a.db != db || a.selectedTable != table || a.currentResultGeneration() != resultGeneration {
+				return
+			}
+			if !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			a.setFocusWithColor(a.results)
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not edit %s:\n\n%v", iconWarn, tview.Escape(table), err), "main")
+				return
+			}
+			a.cellEdits = &cellEditSession{table: table, keyColumns: keyColumns, keyKind: keyKind}
+			a.showCellEditForm(row, col, column)
+		})
+	}()
+}
+
+func (a *App) showCellEditForm(row, col int, column string) {
+	session := a.cellEdits
+	cell := a.results.GetCell(row, col)
+	ref, _ := cell.GetReference().(resultCellReference)
+	if bytes, ok := ref.rawValue.([]byte); ok && !ref.isNull && !utf8.Valid(bytes) {
+		a.ShowAlert(fmt.Sprintf("%s %s holds binary data, which cannot be edited as text.", iconWarn, tview.Escape(column)), "main")
+		return
+	}
+	rowKey, keys, err := a.cellEditRowKey(row, session.keyColumns)
+	if err != nil {
+		a.ShowAlert(fmt.Sprintf("%s Could not edit this row:\n\n%v", iconWarn, err), "main")
+		return
+	}
+
+	current, isNull := ref.value, ref.isNull
+	if staged, ok := session.find(rowKey, column); ok {
+		current, isNull = "", staged.value == nil
+		if !isNull {
+			current = fmt.Sprint(staged.value)
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Edit %s ", tview.Escape(column))).SetTitleColor(mauve).SetBorderColor(surface1)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	form.AddTextArea("Value", current, 0, 5, 0, nil)
+	form.AddCheckbox("NULL", isNull, nil)
+	closeForm := func() {
+		a.pages.RemovePage(pageCellEdit)
+		a.pages.SwitchToPage("main")
+		a.setFocusWithColor(a.results)
+	}
+	form.AddButton("Stage", func() {
+		var value any
+		if checkbox, ok := form.GetFormItemByLabel("NULL").(*tview.Checkbox); !ok || !checkbox.IsChecked() {
+			value = form.GetFormItemByLabel("Value").(*tview.TextArea).GetText()
+		}
+		closeForm()
+		a.stageCellEdit(stagedCellEdit{rowKey: rowKey, keys: keys, column: column, original: cellEditOriginalText(ref), value: value}, ref)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+	modalW, modalH := a.modalSize(56, 90, 13, 15)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).AddItem(form, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageCellEdit, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+// stageCellEdit records an edit, or drops it when the value matches the
+// loaded one again, then repaints the visible grid.
+func (a *App) stageCellEdit(edit stagedCellEdit, original resultCellReference) {
+	session := a.cellEdits
+	if session == nil {
+		return
+	}
+	session.remove(edit.rowKey, edit.column)
+	unchanged := (edit.value == nil && original.isNull) ||
+		(edit.value != nil && !original.isNull && fmt.Sprint(edit.value) == original.value)
+	if !unchanged {
+		session.edits = append(session.edits, edit)
+	}
+	a.applyStagedCellEdits()
+	a.updateStatusBar("", a.currentResultRowCount())
+}
+
+func (s *cellEditSession) find(rowKey, column string) (stagedCellEdit, bool) {
+	for _, edit := range s.edits {
+		if edit.rowKey == rowKey && edit.column == column {
+			return edit, true
+		}
+	}
+	return stagedCellEdit{}, false
+}
+
+func (s *cellEditSession) remove(rowKey, column string) {
+	kept := s.edits[:0]
+	for _, edit := range s.edits {
+		if edit.rowKey != rowKey || edit.column != column {
+			kept = append(kept, edit)
+		}
+	}
+	s.edits = kept
+}
+
+// cellEditRowKey captures the key values of a grid row. Every key column
+// must be visible and non-NULL to address exactly one row.
+func (a *App) cellEditRowKey(row int, keyColumns []string) (string, []any, error) {
+	identities := make([]string, 0, len(keyColumns))
+	values := make([]any, 0, len(keyColumns))
+	for _, key := range keyColumns {
+		col := a.resultColumnIndex(key)
+		if col < 0 {
+			return "", nil, fmt.Errorf("key column %s is not part of this result", key)
+		}
+		cell := a.results.GetCell(row, col)
+		value, isNull := resultReferenceQueryValue(cell)
+		if isNull {
+			return "", nil, fmt.Errorf("key column %s is NULL", key)
+		}
+		identities = append(identities, resultCellIdentity(cell))
+		values = append(values, value)
+	}
+	return strings.Join(identities, cellEditRowIdentitySeparator), values, nil
+}
+
+func (a *App) resultColumnIndex(name string) int {
+	for col := 0; col < a.results.GetColumnCount(); col++ {
+		if strings.EqualFold(a.resultColumnName(col), name) {
+			return col
+		}
+	}
+	return -1
+}
+
+// applyStagedCellEdits shows staged values in the grid and restores cells
+// whose edits were committed, discarded, or unstaged. Key values in the cell
+// references stay untouched so rows are still matched by their loaded key.
+func (a *App) applyStagedCellEdits() {
+	if a == nil || a.results == nil {
+		return
+	}
+	session := a.cellEdits
+	active := session != nil && a.isTableResultActive() && session.table == a.activeTable
+	staged := make(map[string]stagedCellEdit)
+	if active {
+		for _, edit := range session.edits {
+			staged[edit.rowKey+cellEditRowIdentitySeparator+strings.ToLower(edit.column)] = edit
+		}
+	}
+	for row := 1; row < a.results.GetRowCount(); row++ {
+		rowKey := ""
+		if active && len(staged) > 0 {
+			rowKey, _, _ = a.cellEditRowKey(row, session.keyColumns)
+		}
+		for col := 0; col < a.results.GetColumnCount(); col++ {
+			cell := a.results.GetCell(row, col)
+			if cell == nil {
+				continue
+			}
+			ref, ok := cell.GetReference().(resultCellReference)
+			if !ok {
+				continue
+			}
+			edit, isStaged := staged[rowKey+cellEditRowIdentitySeparator+strings.ToLower(a.resultColumnName(col))]
+			isStaged = isStaged && rowKey != ""
+			if !isStaged && !ref.staged {
+				continue
+			}
+			display, color := formatCellValueForDatabaseType(ref.rawValue, ref.databaseType)
+			if isStaged {
+				display, color = formatCellValue(edit.value)
+			}
+			ref.staged = isStaged
+			cell.SetText(tview.Escape(display)).SetTextColor(color).SetReference(ref)
+			if ref.rowSelected {
+				continue
+			}
+			a.restoreProfilerCellStyle(cell)
+		}
+	}
+}
+
+// rowWriteStatementsForCellEdits groups the staged edits into one UPDATE
+// per row, in the order rows were first edited.
+func rowWriteStatementsForCellEdits(dbType config.DBType, session *cellEditSession) []rowWriteStatement {
+	if session == nil {
+		return nil
+	}
+	var order []string
+	byRow := make(map[string][]stagedCellEdit)
+	for _, edit := range session.edits {
+		if _, ok := byRow[edit.rowKey]; !ok {
+			order = append(order, edit.rowKey)
+		}
+		byRow[edit.rowKey] = append(byRow[edit.rowKey], edit)
+	}
+
+	table := quoteIdentifier(dbType, session.table)
+	statements := make([]rowWriteStatement, 0, len(order))
+	for _, rowKey := range order {
+		edits := byRow[rowKey]
+		var assignments, previewAssignments, predicates, previewPredicates []string
+		var args []any
+		for _, edit := range edits {
+			args = append(args, edit.value)
+			column := quoteIdentifier(dbType, edit.column)
+			assignments = append(assignments, fmt.Sprintf("%s = %s", column, numberedResultFilterPlaceholder(dbType, len(args))))
+			previewAssignments = append(previewAssignments, fmt.Sprintf("%s = %s", column, cellEditSQLLiteral(edit.value)))
+		}
+		keys := edits[0].keys
+		for index, key := range session.keyColumns {
+			column := quoteIdentifier(dbType, key)
+			predicates = append(predicates, fmt.Sprintf("%s = %s", column, numberedResultFilterPlaceholder(dbType, len(args)+index+1)))
+			previewPredicates = append(previewPredicates, fmt.Sprintf("%s = %s", column, cellEditSQLLiteral(keys[index])))
+		}
+		var countPredicates []string
+		for index, key := range session.keyColumns {
+			countPredicates = append(countPredicates, fmt.Sprintf("%s = %s", quoteIdentifier(dbType, key), numberedResultFilterPlaceholder(dbType, index+1)))
+		}
+		statements = append(statements, rowWriteStatement{
+			sql:       fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, strings.Join(assignments, ", "), strings.Join(predicates, " AND ")),
+			args:      append(args, keys...),
+			countSQL:  fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table, strings.Join(countPredicates, " AND ")),
+			countArgs: append([]any(nil), keys...),
+			preview:   fmt.Sprintf("UPDATE %s SET %s WHERE %s;", table, strings.Join(previewAssignments, ", "), strings.Join(previewPredicates, " AND ")),
+		})
+	}
+	return statements
+}
+
+func (a *App) showCellEditReview() {
+	session := a.cellEdits
+	if session == nil || len(session.edits) == 0 {
+		a.flashStatus("[yellow]No staged cell edits[-]", a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	statements := rowWriteStatementsForCellEdits(a.dbType, session)
+
+	var body strings.Builder
+	for _, edit := range session.edits {
+		fmt.Fprintf(&body, " [yellow]~[-] %s: [#6c7086]%s[-] → %s\n",
+			tview.Escape(edit.column), tview.Escape(truncateForDisplay(edit.original, 40)), tview.Escape(truncateForDisplay(cellEditSQLLiteral(edit.value), 40)))
+	}
+	body.WriteString("\n")
+	body.WriteString(rowWritePreviewText(statements))
+
+	a.showRowWritePreview(rowWritePreview{
+		title:       fmt.Sprintf("Staged edits — %s · %d row(s) by %s", session.table, len(statements), session.keyKind),
+		body:        body.String(),
+		commitLabel: "Commit",
+		tokens:      []string{"UPDATE"},
+		commit:      a.commitCellEdits,
+		discard:     a.discardCellEdits,
+	})
+}
+
+func (a *App) commitCellEdits() {
+	session := a.cellEdits
+	if session == nil || len(session.edits) == 0 {
+		return
+	}
+	statements := rowWriteStatementsForCellEdits(a.dbType, session)
+	a.runRowWrite(session.table, fmt.Sprintf("Committing %d update(s) to %s...", len(statements), session.table), statements, func() {
+		if a.cellEdits == session {
+			session.edits = nil
+		}
+		a.applyStagedCellEdits()
+		a.flashStatus(fmt.Sprintf("[green]%s Committed %d row update(s)[-]", iconSuccess, len(statements)), a.currentResultRowCount(), 1800*time.Millisecond)
+	})
+}
+
+func (a *App) discardCellEdits() {
+	count := a.pendingCellEditCount()
+	if count == 0 {
+		a.flashStatus("[yellow]No staged cell edits[-]", a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	a.cellEdits.edits = nil
+	a.applyStagedCellEdits()
+	a.flashStatus(fmt.Sprintf("[yellow]Discarded %d staged edit(s)[-]", count), a.currentResultRowCount(), 1600*time.Millisecond)
+}
+
+func cellEditOriginalText(ref resultCellReference) string {
+	if ref.isNull {
+		return "NULL"
+	}
+	return ref.value
+}
+
+// cellEditSQLLiteral renders a value for previews and the activity log.
+// Execution always binds values as parameters.
+func cellEditSQLLiteral(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if typed {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		return strconv.FormatFloat(typed, 'g', -1, 64)
+	case []byte:
+		return "'" + strings.ReplaceAll(string(typed), "'", "''") + "'"
+	case time.Time:
+		return "'" + typed.Format(time.RFC3339Nano) + "'"
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(typed), "'", "''") + "'"
+	}
+}
+
+// loadCellEditKey returns the primary key of a table, or failing that its
+// first unique key whose columns are all NOT NULL.
+func loadCellEditKey(ctx context.Context, db *sql.DB, dbType config.DBType, tableName, defaultNamespace string) ([]string, string, error) {
+	columns, err := loadSidebarColumnMetadata(ctx, db, dbType, tableName, defaultNamespace)
+	if err != nil {
+		return nil, "", fmt.Errorf("load columns: %w", err)
+	}
+	var primary []string
+	for _, column := range columns {
+		if column.primaryKey {
+			primary = append(primary, column.name)
+		}
+	}
+	if len(primary) > 0 {
+		return primary, cellEditKeyPrimary, nil
+	}
+
+	namespace, table := splitQualifiedIdentifier(tableName)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	unique, err := loadCellEditUniqueKey(ctx, db, dbType, namespace, table, columns)
+	if err != nil {
+		return nil, "", fmt.Errorf("load unique keys: %w", err)
+	}
+	if len(unique) == 0 {
+		return nil, "", fmt.Errorf("%s has no primary key or NOT NULL unique key to identify rows", tableName)
+	}
+	return unique, cellEditKeyUnique, nil
+}
+
+func loadCellEditUniqueKey(ctx context.Context, db *sql.DB, dbType config.DBType, namespace, table string, columns []sidebarColumnMeta) ([]string, error) {
+	notNull := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		notNull[strings.ToLower(column.name)] = column.notNull
+	}
+	usable := func(names []string) bool {
+		for _, name := range names {
+			if !notNull[strings.ToLower(name)] {
+				return false
+			}
+		}
+		return len(names) > 0
+	}
+
+	switch dbType {
+	case config.PostgreSQL, config.MySQL:
+		query := `SELECT tc.constraint_name, kcu.column_name
+FROM information_schema.table_constraints tc JOIN information_schema.key_column_usage kcu
+ON tc.constraint_schema = kcu.constraint_schema AND tc.table_name = kcu.table_name AND tc.constraint_name = kcu.constraint_name
+WHERE tc.table_schema = $1 AND tc.table_name = $2 AND tc.constraint_type = 'UNIQUE'
+ORDER BY tc.constraint_name, kcu.ordinal_position`
+		if dbType == config.MySQL {
+			query = strings.NewReplacer("$1", "?", "$2", "?").Replace(query)
+		}
+		rows, err := db.QueryContext(ctx, query, namespace, table)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var order []string
+		byName := make(map[string][]string)
+		for rows.Next() {
+			var name, column string
+			if err := rows.Scan(&name, &column); err != nil {
+				return nil, err
+			}
+			if _, ok := byName[name]; !ok {
+				order = append(order, name)
+			}
+			byName[name] = append(byName[name], column)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		for _, name := range order {
+			if usable(byName[name]) {
+				return byName[name], nil
+			}
+		}
+	case config.SQLite, config.Turso:
+		rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA index_list(%s)", quoteIdentifier(dbType, table)))
+		if err != nil {
+			return nil, err
+		}
+		var indexes []string
+		for rows.Next() {
+			var seq, unique, partial int
+			var name, origin string
+			if err := rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			// Partial indexes do not cover every row.
+			if unique != 0 && partial == 0 {
+				indexes = append(indexes, name)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			info, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA index_info(%s)", quoteIdentifier(dbType, index)))
+			if err != nil {
+				return nil, err
+			}
+			var names []string
+			valid := true
+			for info.Next() {
+				var seqno, cid int
+				var name sql.NullString
+				if err := info.Scan(&seqno, &cid, &name); err != nil {
+					info.Close()
+					return nil, err
+				}
+				// Expression columns have no name and cannot be matched.
+				if !name.Valid || cid < 0 {
+					valid = false
+					continue
+				}
+				names = append(names, name.String)
+			}
+			err = info.Err()
+			info.Close()
+			if err != nil {
+				return nil, err
+			}
+			if valid && usable(names) {
+				return names, nil
+			}
+		}
+	}
+	return nil, nil
+}
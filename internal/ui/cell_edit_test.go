@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func testCellEditDB(t *testing.T, statements ...string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "edit.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestCellEditsStageAndCommitInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE users(id INTEGER PRIMARY KEY, name TEXT NOT NULL, note TEXT)`,
+		`INSERT INTO users VALUES(1,'ada','x'),(2,'bob','y')`,
+	)
+	rows, err := db.Query(`SELECT * FROM users ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := newResultTable()
+	if _, err := populateTable(results, rows); err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	keys, kind, err := loadCellEditKey(ctx, db, config.SQLite, "users", "")
+	if err != nil || kind != cellEditKeyPrimary || strings.Join(keys, ",") != "id" {
+		t.Fatalf("key = %v (%s), err = %v", keys, kind, err)
+	}
+	app := &App{
+		app: tview.NewApplication(), results: results, statusBar: tview.NewTextView(),
+		db: db, dbType: config.SQLite, selectedTable: "users", activeTable: "users", tableResultsActive: true,
+		cellEdits: &cellEditSession{table: "users", keyColumns: keys, keyKind: kind},
+	}
+	stage := func(row, col int, value any) {
+		t.Helper()
+		rowKey, keyValues, err := app.cellEditRowKey(row, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := results.GetCell(row, col).GetReference().(resultCellReference)
+		app.stageCellEdit(stagedCellEdit{rowKey: rowKey, keys: keyValues, column: app.resultColumnName(col), original: cellEditOriginalText(ref), value: value}, ref)
+	}
+	stage(1, 1, "ada lovelace")
+	stage(2, 2, nil)
+	stage(2, 1, "bob")
+	if app.pendingCellEditCount() != 2 {
+		t.Fatalf("staged = %d, want 2 (restaging the original unstages)", app.pendingCellEditCount())
+	}
+	cell := results.GetCell(1, 1)
+	if _, background, _ := cell.Style.Decompose(); background != stagedCellBG || cell.Text != "ada lovelace" {
+		t.Fatalf("staged cell = %q with background %v", cell.Text, background)
+	}
+
+	statements := rowWriteStatementsForCellEdits(config.SQLite, app.cellEdits)
+	if len(statements) != 2 || statements[0].preview != `UPDATE "users" SET "name" = 'ada lovelace' WHERE "id" = 1;` ||
+		statements[1].sql != `UPDATE "users" SET "note" = ? WHERE "id" = ?` {
+		t.Fatalf("statements = %+v", statements)
+	}
+	if _, err := commitRowWriteStatements(ctx, db, statements); err != nil {
+		t.Fatal(err)
+	}
+	var name string
+	var note sql.NullString
+	if err := db.QueryRow(`SELECT u1.name, u2.note FROM users u1, users u2 WHERE u1.id = 1 AND u2.id = 2`).Scan(&name, &note); err != nil {
+		t.Fatal(err)
+	}
+	if name != "ada lovelace" || note.Valid {
+		t.Fatalf("committed name = %q, note = %v", name, note)
+	}
+
+	app.discardCellEdits()
+	if cell := results.GetCell(1, 1); cell.Text != "ada" {
+		t.Fatalf("discarded cell still shows %q", cell.Text)
+	}
+}
+
+func TestCellEditCommitRollsBackWhenARowIsGone(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE users(id INTEGER PRIMARY KEY, name TEXT)`,
+		`INSERT INTO users VALUES(1,'ada')`,
+	)
+	session := &cellEditSession{table: "users", keyColumns: []string{"id"}, edits: []stagedCellEdit{
+		{rowKey: "value:1", keys: []any{int64(1)}, column: "name", value: "changed"},
+		{rowKey: "value:2", keys: []any{int64(2)}, column: "name", value: "missing"},
+	}}
+	_, err := commitRowWriteStatements(ctx, db, rowWriteStatementsForCellEdits(config.SQLite, session))
+	if err == nil || !strings.Contains(err.Error(), "no longer exists") {
+		t.Fatalf("commit error = %v", err)
+	}
+	var name string
+	if err := db.QueryRow(`SELECT name FROM users WHERE id = 1`).Scan(&name); err != nil || name != "ada" {
+		t.Fatalf("first update was not rolled back: %q, %v", name, err)
+	}
+}
+
+func TestLoadCellEditKeyFallsBackToNotNullUniqueKey(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE codes(code TEXT NOT NULL UNIQUE, label TEXT)`,
+		`CREATE TABLE loose(code TEXT UNIQUE, label TEXT)`,
+	)
+	keys, kind, err := loadCellEditKey(ctx, db, config.SQLite, "codes", "")
+	if err != nil || kind != cellEditKeyUnique || strings.Join(keys, ",") != "code" {
+		t.Fatalf("key = %v (%s), err = %v", keys, kind, err)
+	}
+	if _, _, err := loadCellEditKey(ctx, db, config.SQLite, "loose", ""); err == nil {
+		t.Fatal("a nullable unique key was accepted")
+	}
+}
@@ -150,6 +150,11 @@ func (a *App) restoreProfilerCellStyle(cell *tview.TableCell) {
 		return
 	}
 	ref, ok := cell.GetReference().(resultCellReference)
+	if ok && ref.staged {
+		cell.SetTransparency(false)
+		cell.SetBackgroundColor(stagedCellBG)
+		return
+	}
 	if !ok || ref.profilerKind == "" {
 		cell.SetBackgroundColor(tcell.ColorDefault)
 		cell.SetTransparency(true)
@@ -159,6 +164,8 @@ func (a *App) restoreProfilerCellStyle(cell *tview.TableCell) {
 	switch profiler.DiffKind(ref.profilerKind) {
 	case profiler.DiffInserted:
 		cell.SetBackgroundColor(insertRowBG)
+	case profiler.DiffDeleted:
+		cell.SetBackgroundColor(deleteRowBG)
 	case profiler.DiffUpdated:
 		if ref.profilerCell {
 			cell.SetBackgroundColor(updateCellBG)
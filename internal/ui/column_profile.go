@@ -0,0 +1,824 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+const (
+	pageColumnProfile = "columnProfile"
+
+	// columnProfileRowLimit bounds every server-side aggregate, so profiling a
+	// large table costs at most one scan of this many rows per query.
+	columnProfileRowLimit   = 100000
+	columnProfileTopValues  = 10
+	columnProfileBuckets    = 10
+	columnProfileTimeout    = 30 * time.Second
+	columnProfileValueAlias = "profiled_value"
+)
+
+type columnProfileKind uint8
+
+const (
+	columnProfileOther columnProfileKind = iota
+	columnProfileText
+	columnProfileNumeric
+	columnProfileTemporal
+)
+
+type columnProfileValue struct {
+	value any // nil is SQL NULL
+	count int64
+}
+
+type columnProfileBucket struct {
+	low, high float64
+	count     int64
+}
+
+// columnProfile summarizes one column of a table or result set.
+type columnProfile struct {
+	column       string
+	databaseType string
+	kind         columnProfileKind
+	rows         int64
+	nulls        int64
+	distinct     int64
+	// bounded is set when the profile stopped at the row limit, which makes
+	// the distinct count an estimate for the whole table.
+	bounded   bool
+	min, max  any
+	lengths   bool
+	minLength int64
+	maxLength int64
+	avgLength float64
+	top       []columnProfileValue
+	histogram []columnProfileBucket
+	err       error
+}
+
+// columnProfileRequest is everything a profile needs from the App, captured
+// before the work leaves the UI goroutine.
+type columnProfileRequest struct {
+	db       *sql.DB
+	dbType   config.DBType
+	table    string
+	filter   *resultValueFilter
+	columns  []string
+	types    []string
+	detail   bool
+	rowLimit int
+}
+
+// profileSelectedResultColumn profiles the focused result column. Table
+// results are profiled on the server; ad-hoc query results are profiled from
+// the rows on screen, as export does.
+func (a *App) profileSelectedResultColumn() {
+	_, col := a.results.GetSelection()
+	column := a.resultColumnName(col)
+	if column == "" || a.results.GetColumnCount() == 0 {
+		a.flashStatus("[yellow]Select a result column to profile[-]", a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	a.showColumnProfiles([]int{col}, true)
+}
+
+// profileAllResultColumns shows a one-line profile of every result column.
+func (a *App) profileAllResultColumns() {
+	count := a.results.GetColumnCount()
+	if count == 0 || a.resultColumnName(0) == "" {
+		a.flashStatus("[yellow]No result columns to profile[-]", a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	columns := make([]int, count)
+	for index := range columns {
+		columns[index] = index
+	}
+	a.showColumnProfiles(columns, false)
+}
+
+func (a *App) showColumnProfiles(columns []int, detail bool) {
+	if !a.isTableResultActive() {
+		profiles := make([]columnProfile, 0, len(columns))
+		for _, col := range columns {
+			profiles = append(profiles, profileDisplayedResultColumn(a.results, col, a.resultColumnName(col), detail))
+		}
+		a.presentColumnProfiles(profiles, false)
+		return
+	}
+	if a.db == nil {
+		a.ShowAlert(fmt.Sprintf("%s Not connected to any database.", iconWarn), "main")
+		return
+	}
+
+	request := columnProfileRequest{
+		db:       a.db,
+		dbType:   a.dbType,
+		table:    a.selectedTable,
+		filter:   a.activeResultFilter(a.selectedTable),
+		detail:   detail,
+		rowLimit: columnProfileRowLimit,
+	}
+	for _, col := range columns {
+		request.columns = append(request.columns, a.resultColumnName(col))
+		request.types = append(request.types, a.resultColumnDatabaseType(col))
+	}
+	label := request.columns[0]
+	if len(request.columns) > 1 {
+		label = fmt.Sprintf("%d columns", len(request.columns))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), columnProfileTimeout)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Profiling %s of %s...", label, request.table),
+		withLoadingCancel("Press Esc to cancel profiling.", func() {
+			canceled.Store(true)
+			cancel()
+			a.setFocusWithColor(a.results)
+			a.flashStatus("[yellow]Column profile canceled[-]", a.currentResultRowCount(), 1500*time.Millisecond)
+		}),
+	)
+
+	go func() {
+		defer cancel()
+		profiles := make([]columnProfile, len(request.columns))
+		for index, column := range request.columns {
+			profiles[index] = loadColumnProfile(ctx, request, column, request.types[index])
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		a.queueUpdateDraw(func() {
+			if canceled.Load() || a.db != request.db || a.selectedTable != request.table {
+				return
+			}
+			if !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not profile %s:\n\n%v", iconWarn, tview.Escape(label), err), "main")
+				return
+			}
+			a.presentColumnProfiles(profiles, true)
+		})
+	}()
+}
+
+func (a *App) presentColumnProfiles(profiles []columnProfile, server bool) {
+	if len(profiles) == 1 {
+		a.showColumnProfileDetail(profiles[0], server)
+		return
+	}
+	a.showColumnProfileSummary(profiles, server)
+}
+
+// resultColumnDatabaseType reads the column's type from its first data cell;
+// the header carries only the name.
+func (a *App) resultColumnDatabaseType(col int) string {
+	for row := 1; row < a.results.GetRowCount(); row++ {
+		if cell := a.results.GetCell(row, col); cell != nil {
+			if reference, ok := cell.GetReference().(resultCellReference); ok {
+				return reference.databaseType
+			}
+		}
+	}
+	return ""
+}
+
+func columnProfileKindFor(databaseType string) columnProfileKind {
+	databaseType = normalizedDatabaseType(databaseType)
+	if open := strings.IndexByte(databaseType, '('); open >= 0 {
+		databaseType = strings.TrimSpace(databaseType[:open])
+	}
+	switch {
+	case resultExportNumericType(databaseType):
+		return columnProfileNumeric
+	case databaseType == "DATE", databaseType == "DATETIME", strings.HasPrefix(databaseType, "TIMESTAMP"):
+		return columnProfileTemporal
+	case strings.Contains(databaseType, "CHAR"), strings.Contains(databaseType, "TEXT"),
+		strings.Contains(databaseType, "CLOB"), databaseType == "STRING", databaseType == "CITEXT", databaseType == "NAME":
+		return columnProfileText
+	default:
+		return columnProfileOther
+	}
+}
+
+// loadColumnProfile runs the bounded aggregates for one column. A failure is
+// kept on the profile so one odd column does not hide the others.
+func loadColumnProfile(ctx context.Context, request columnProfileRequest, column, databaseType string) columnProfile {
+	profile := columnProfile{column: column, databaseType: databaseType, kind: columnProfileKindFor(databaseType)}
+	if profile.kind == columnProfileOther {
+		// Probe the declared type when no row was on screen to read it from.
+		if probed, err := probeColumnDatabaseType(ctx, request, column); err == nil && probed != "" {
+			profile.databaseType = probed
+			profile.kind = columnProfileKindFor(probed)
+		}
+	}
+	source, args := columnProfileSource(request, column, profile.kind)
+	profile.err = loadColumnProfileSummary(ctx, request.db, request.dbType, &profile, source, args, request.rowLimit)
+	if profile.err != nil || !request.detail {
+		return profile
+	}
+	profile.err = loadColumnProfileTopValues(ctx, request.db, &profile, source, args)
+	if profile.err == nil {
+		profile.err = loadColumnProfileHistogram(ctx, request.db, request.dbType, &profile, source, args)
+	}
+	return profile
+}
+
+func probeColumnDatabaseType(ctx context.Context, request columnProfileRequest, column string) (string, error) {
+	rows, err := request.db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", quoteIdentifier(request.dbType, column), quoteIdentifier(request.dbType, request.table)))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	types := resultDatabaseTypes(rows, 1)
+	return types[0], rows.Err()
+}
+
+// columnProfileSource is the bounded, filtered derived table every profile
+// query reads from. Columns without a useful ordering are profiled as text.
+func columnProfileSource(request columnProfileRequest, column string, kind columnProfileKind) (string, []any) {
+	value := quoteIdentifier(request.dbType, column)
+	if kind == columnProfileOther {
+		value = resultFilterTextExpression(request.dbType, value)
+	}
+	clause, args := resultFilterSQL(request.dbType, request.filter)
+	return fmt.Sprintf("(SELECT %s AS %s FROM %s%s LIMIT %d) AS profiled",
+		value, columnProfileValueAlias, quoteIdentifier(request.dbType, request.table), clause, request.rowLimit), args
+}
+
+func loadColumnProfileSummary(ctx context.Context, db *sql.DB, dbType config.DBType, profile *columnProfile, source string, args []any, rowLimit int) error {
+	selects := []string{"COUNT(*)", "COUNT(" + columnProfileValueAlias + ")", "COUNT(DISTINCT " + columnProfileValueAlias + ")",
+		"MIN(" + columnProfileValueAlias + ")", "MAX(" + columnProfileValueAlias + ")"}
+	profile.lengths = profile.kind == columnProfileText || profile.kind == columnProfileOther
+	if profile.lengths {
+		length := columnProfileLengthExpression(dbType)
+		selects = append(selects, "MIN("+length+")", "MAX("+length+")", "AVG("+length+")")
+	}
+	number := columnProfileNumberExpression(dbType, profile.kind)
+	if number != "" {
+		selects = append(selects, "MIN("+number+")", "MAX("+number+")")
+	}
+
+	var (
+		nonNull              int64
+		minValue, maxValue   any
+		minLength, maxLength sql.NullInt64
+		avgLength, low, high sql.NullFloat64
+	)
+	destinations := []any{&profile.rows, &nonNull, &profile.distinct, &minValue, &maxValue}
+	if profile.lengths {
+		destinations = append(destinations, &minLength, &maxLength, &avgLength)
+	}
+	if number != "" {
+		destinations = append(destinations, &low, &high)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selects, ", "), source)
+	if err := db.QueryRowContext(ctx, query, args...).Scan(destinations...); err != nil {
+		return fmt.Errorf("profile %s: %w", profile.column, err)
+	}
+	profile.nulls = profile.rows - nonNull
+	profile.bounded = profile.rows >= int64(rowLimit)
+	profile.min, profile.max = cloneResultRawValue(minValue), cloneResultRawValue(maxValue)
+	profile.minLength, profile.maxLength, profile.avgLength = minLength.Int64, maxLength.Int64, avgLength.Float64
+	if low.Valid && high.Valid {
+		profile.histogram = []columnProfileBucket{{low: low.Float64, high: high.Float64}}
+	}
+	return nil
+}
+
+func loadColumnProfileTopValues(ctx context.Context, db *sql.DB, profile *columnProfile, source string, args []any) error {
+	query := fmt.Sprintf("SELECT %[1]s, COUNT(*) FROM %[2]s GROUP BY %[1]s ORDER BY 2 DESC, 1 LIMIT %[3]d", columnProfileValueAlias, source, columnProfileTopValues)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("top values of %s: %w", profile.column, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var value any
+		var count int64
+		if err := rows.Scan(&value, &count); err != nil {
+			return fmt.Errorf("top values of %s: %w", profile.column, err)
+		}
+		profile.top = append(profile.top, columnProfileValue{value: cloneResultRawValue(value), count: count})
+	}
+	return rows.Err()
+}
+
+// loadColumnProfileHistogram buckets the numeric form of the column between
+// the bounds found by the summary. The bounds are inlined as literals because
+// some engines cannot infer a parameter's type inside arithmetic.
+func loadColumnProfileHistogram(ctx context.Context, db *sql.DB, dbType config.DBType, profile *columnProfile, source string, args []any) error {
+	if len(profile.histogram) != 1 {
+		return nil
+	}
+	low, high := profile.histogram[0].low, profile.histogram[0].high
+	profile.histogram = nil
+	if !(high > low) {
+		return nil
+	}
+	number := columnProfileNumberExpression(dbType, profile.kind)
+	literal := func(value float64) string { return "(" + strconv.FormatFloat(value, 'g', -1, 64) + ")" }
+	scaled := fmt.Sprintf("(%s - %s) * %d.0 / %s", number, literal(low), columnProfileBuckets, literal(high-low))
+	floor := "FLOOR(" + scaled + ")"
+	if usesSQLiteDialect(dbType) {
+		// Core SQLite has no FLOOR; the value is never negative, so
+		// truncation is the same thing.
+		floor = "CAST(" + scaled + " AS INTEGER)"
+	}
+	bucket := fmt.Sprintf("CASE WHEN %s >= %s THEN %d ELSE %s END", number, literal(high), columnProfileBuckets-1, floor)
+	query := fmt.Sprintf("SELECT bucket, COUNT(*) FROM (SELECT %s AS bucket FROM %s WHERE %s IS NOT NULL) AS buckets GROUP BY bucket ORDER BY bucket",
+		bucket, source, columnProfileValueAlias)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("histogram of %s: %w", profile.column, err)
+	}
+	defer rows.Close()
+	buckets := newColumnProfileBuckets(low, high)
+	for rows.Next() {
+		var index sql.NullFloat64
+		var count int64
+		if err := rows.Scan(&index, &count); err != nil {
+			return fmt.Errorf("histogram of %s: %w", profile.column, err)
+		}
+		if index.Valid {
+			buckets[clamp(int(index.Float64), 0, columnProfileBuckets-1)].count += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("histogram of %s: %w", profile.column, err)
+	}
+	profile.histogram = buckets
+	return nil
+}
+
+func newColumnProfileBuckets(low, high float64) []columnProfileBucket {
+	buckets := make([]columnProfileBucket, columnProfileBuckets)
+	width := (high - low) / columnProfileBuckets
+	for index := range buckets {
+		buckets[index].low = low + float64(index)*width
+		buckets[index].high = low + float64(index+1)*width
+	}
+	buckets[len(buckets)-1].high = high
+	return buckets
+}
+
+func usesSQLiteDialect(dbType config.DBType) bool {
+	return dbType == config.SQLite || dbType == config.Turso || dbType == config.CloudflareD1
+}
+
+func columnProfileLengthExpression(dbType config.DBType) string {
+	if dbType == config.MySQL {
+		return "CHAR_LENGTH(" + columnProfileValueAlias + ")"
+	}
+	return "LENGTH(" + columnProfileValueAlias + ")"
+}
+
+// columnProfileNumberExpression maps the profiled value onto a number for the
+// histogram: itself for numeric columns and Unix seconds for dates.
+func columnProfileNumberExpression(dbType config.DBType, kind columnProfileKind) string {
+	value := columnProfileValueAlias
+	switch kind {
+	case columnProfileNumeric:
+		return value
+	case columnProfileTemporal:
+		switch {
+		case dbType == config.PostgreSQL:
+			return "EXTRACT(EPOCH FROM " + value + ")"
+		case dbType == config.MySQL:
+			return "TIMESTAMPDIFF(SECOND, '1970-01-01', " + value + ")"
+		case dbType == config.DuckDB:
+			return "epoch(" + value + ")"
+		case usesSQLiteDialect(dbType):
+			return "(julianday(" + value + ") - 2440587.5) * 86400.0"
+		}
+	}
+	return ""
+}
+
+// profileDisplayedResultColumn computes the same profile from the cells of
+// a result set that is not a table, so nothing is re-run.
+func profileDisplayedResultColumn(results *tview.Table, col int, column string, detail bool) columnProfile {
+	var references []resultCellReference
+	for row := 1; row < results.GetRowCount(); row++ {
+		if cell := results.GetCell(row, col); cell != nil {
+			if reference, ok := cell.GetReference().(resultCellReference); ok {
+				references = append(references, reference)
+			}
+		}
+	}
+	profile := columnProfile{column: column}
+	if len(references) > 0 {
+		profile.databaseType = references[0].databaseType
+	}
+	profile.kind = columnProfileKindFor(profile.databaseType)
+	profile.lengths = profile.kind == columnProfileText || profile.kind == columnProfileOther
+
+	counts := make(map[string]*columnProfileValue)
+	var order []string
+	var numbers []float64
+	var totalLength int64
+	var minText, maxText string
+	var minNumber, maxNumber float64
+	for _, reference := range references {
+		profile.rows++
+		key := "\x00null"
+		if !reference.isNull {
+			key = reference.value
+		}
+		if counts[key] == nil {
+			value := any(nil)
+			if !reference.isNull {
+				value = cloneResultRawValue(reference.rawValue)
+				if value == nil {
+					value = reference.value
+				}
+			}
+			counts[key] = &columnProfileValue{value: value}
+			order = append(order, key)
+		}
+		counts[key].count++
+		if reference.isNull {
+			profile.nulls++
+			continue
+		}
+		nonNull := profile.rows - profile.nulls
+		if number, ok := columnProfileNumber(reference, profile.kind); ok {
+			numbers = append(numbers, number)
+			if len(numbers) == 1 || number < minNumber {
+				minNumber, profile.min = number, counts[key].value
+			}
+			if len(numbers) == 1 || number > maxNumber {
+				maxNumber, profile.max = number, counts[key].value
+			}
+		} else if profile.kind != columnProfileNumeric && profile.kind != columnProfileTemporal {
+			if nonNull == 1 || reference.value < minText {
+				minText, profile.min = reference.value, counts[key].value
+			}
+			if nonNull == 1 || reference.value > maxText {
+				maxText, profile.max = reference.value, counts[key].value
+			}
+		}
+		if profile.lengths {
+			length := int64(utf8.RuneCountInString(reference.value))
+			totalLength += length
+			if nonNull == 1 || length < profile.minLength {
+				profile.minLength = length
+			}
+			if nonNull == 1 || length > profile.maxLength {
+				profile.maxLength = length
+			}
+		}
+	}
+	profile.distinct = int64(len(counts))
+	if _, hasNull := counts["\x00null"]; hasNull {
+		profile.distinct--
+	}
+	if nonNull := profile.rows - profile.nulls; profile.lengths && nonNull > 0 {
+		profile.avgLength = float64(totalLength) / float64(nonNull)
+	}
+	if !detail {
+		return profile
+	}
+
+	profile.top = make([]columnProfileValue, 0, len(order))
+	for _, key := range order {
+		profile.top = append(profile.top, *counts[key])
+	}
+	sort.SliceStable(profile.top, func(i, j int) bool { return profile.top[i].count > profile.top[j].count })
+	profile.top = profile.top[:min(len(profile.top), columnProfileTopValues)]
+	if len(numbers) > 0 && maxNumber > minNumber {
+		profile.histogram = newColumnProfileBuckets(minNumber, maxNumber)
+		for _, number := range numbers {
+			index := int((number - minNumber) * columnProfileBuckets / (maxNumber - minNumber))
+			profile.histogram[clamp(index, 0, columnProfileBuckets-1)].count++
+		}
+	}
+	return profile
+}
+
+func columnProfileNumber(reference resultCellReference, kind columnProfileKind) (float64, bool) {
+	switch kind {
+	case columnProfileNumeric:
+		number, err := strconv.ParseFloat(strings.TrimSpace(reference.value), 64)
+		return number, err == nil && !math.IsNaN(number) && !math.IsInf(number, 0)
+	case columnProfileTemporal:
+		if value, ok := reference.rawValue.(time.Time); ok {
+			return float64(value.Unix()), true
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02"} {
+			if value, err := time.Parse(layout, strings.TrimSpace(reference.value)); err == nil {
+				return float64(value.Unix()), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (a *App) showColumnProfileDetail(profile columnProfile, server bool) {
+	scope := a.columnProfileScope(profile, server)
+	stats := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	stats.SetBackgroundColor(bg)
+	statsText := columnProfileDetailText(profile, scope)
+	stats.SetText(statsText)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).
+		SetTitle(" Top values ").
+		SetTitleColor(subtext0).
+		SetBorderColor(surface1)
+	list.SetBackgroundColor(bg)
+	list.SetMainTextColor(text)
+	list.SetSelectedBackgroundColor(surface0)
+	list.SetSelectedTextColor(green)
+	if len(profile.top) == 0 {
+		list.AddItem("  No values", "", 0, nil)
+	}
+	for _, value := range profile.top {
+		list.AddItem(columnProfileTopValueText(value, profile.rows), "", 0, nil)
+	}
+
+	closeProfile := func() {
+		a.pages.RemovePage(pageColumnProfile)
+		a.setFocusWithColor(a.results)
+	}
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		if index < 0 || index >= len(profile.top) {
+			return
+		}
+		if !server {
+			a.flashStatus("[yellow]Filters apply while browsing a table; this profile is of query results[-]", a.currentResultRowCount(), 2200*time.Millisecond)
+			return
+		}
+		predicate := columnProfileValuePredicate(profile.column, profile.top[index].value)
+		closeProfile()
+		a.applyResultPredicate(predicate.column, predicate.operator, predicate.value)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeProfile()
+			return nil
+		case matchesPlainShortcut(event, 'a'):
+			closeProfile()
+			a.profileAllResultColumns()
+			return nil
+		}
+		return event
+	})
+
+	modalW, modalH := a.modalSize(76, 110, 20, 36)
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter).
+		SetText(columnProfileFooterText(modalW, server))
+	footer.SetBackgroundColor(crust)
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(stats, strings.Count(statsText, "\n")+1, 0, false).
+		AddItem(list, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	container.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s Profile • %s ", iconResults, tview.Escape(profile.column))).
+		SetTitleColor(mauve).
+		SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageColumnProfile, grid, true, true)
+	a.app.SetFocus(list)
+}
+
+func (a *App) showColumnProfileSummary(profiles []columnProfile, server bool) {
+	table := tview.NewTable().SetSelectable(true, false).SetFixed(1, 1)
+	table.SetBackgroundColor(bg)
+	table.SetSelectedStyle(tcell.StyleDefault.Background(surface0).Foreground(green))
+	for col, header := range []string{"Column", "Type", "Nulls", "Distinct", "Min", "Max", "Length"} {
+		table.SetCell(0, col, tview.NewTableCell(header).SetTextColor(mauve).SetSelectable(false).SetAttributes(tcell.AttrBold))
+	}
+	for index, profile := range profiles {
+		row := index + 1
+		cells := []string{profile.column, strings.ToLower(profile.databaseType), "", "", "", "", ""}
+		if profile.err != nil {
+			cells[2] = "error: " + profile.err.Error()
+		} else {
+			cells[2] = columnProfileNullText(profile)
+			cells[3] = columnProfileDistinctText(profile)
+			cells[4] = columnProfileValueText(profile.min, profile.databaseType, 24)
+			cells[5] = columnProfileValueText(profile.max, profile.databaseType, 24)
+			if profile.lengths && profile.rows > profile.nulls {
+				cells[6] = fmt.Sprintf("%d–%d", profile.minLength, profile.maxLength)
+			}
+		}
+		for col, value := range cells {
+			cell := tview.NewTableCell(tview.Escape(value)).SetTextColor(text).SetMaxWidth(40)
+			if profile.err != nil && col == 2 {
+				cell.SetTextColor(red)
+			}
+			table.SetCell(row, col, cell)
+		}
+	}
+
+	closeProfile := func() {
+		a.pages.RemovePage(pageColumnProfile)
+		a.setFocusWithColor(a.results)
+	}
+	table.SetSelectedFunc(func(row, _ int) {
+		if row < 1 || row > len(profiles) {
+			return
+		}
+		column := profiles[row-1].column
+		closeProfile()
+		for col := 0; col < a.results.GetColumnCount(); col++ {
+			if a.resultColumnName(col) == column {
+				selectedRow, _ := a.results.GetSelection()
+				a.results.Select(selectedRow, col)
+				a.showColumnProfiles([]int{col}, true)
+				return
+			}
+		}
+	})
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			closeProfile()
+		}
+	})
+
+	modalW, modalH := a.modalSize(76, 130, 14, 36)
+	scope := a.columnProfileScope(columnProfile{bounded: columnProfilesBounded(profiles)}, server)
+	header := tview.NewTextView().SetDynamicColors(true).SetText(" [#a6adc8]" + tview.Escape(scope) + "[-]")
+	header.SetBackgroundColor(bg)
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter).
+		SetText(footerTextThatFits(modalW,
+			" [yellow]Enter[-] Profile column  │  [yellow]↑/↓[-] Move  │  [yellow]Esc[-] Close ",
+			" [yellow]Enter[-] Profile  │  [yellow]Esc[-] Close ",
+		))
+	footer.SetBackgroundColor(crust)
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	container.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s Profile • %d columns ", iconResults, len(profiles))).
+		SetTitleColor(mauve).
+		SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageColumnProfile, grid, true, true)
+	a.app.SetFocus(table)
+}
+
+func columnProfilesBounded(profiles []columnProfile) bool {
+	for _, profile := range profiles {
+		if profile.bounded {
+			return true
+		}
+	}
+	return false
+}
+
+// columnProfileScope says which rows a profile describes.
+func (a *App) columnProfileScope(profile columnProfile, server bool) string {
+	if !server {
+		return "From the result rows on screen"
+	}
+	scope := "All rows of " + a.selectedTable
+	if profile.bounded {
+		scope = fmt.Sprintf("First %d rows of %s", columnProfileRowLimit, a.selectedTable)
+	}
+	if filter := a.activeResultFilter(a.selectedTable); filter != nil {
+		scope += " matching " + truncateForDisplay(resultFilterExpressionText(filter.orderedPredicates(), 18), 60)
+	}
+	return scope
+}
+
+func columnProfileDetailText(profile columnProfile, scope string) string {
+	lines := []string{" [#a6adc8]" + tview.Escape(scope) + "[-]"}
+	row := func(label, value string) {
+		lines = append(lines, fmt.Sprintf(" [yellow]%-9s[-] %s", label, value))
+	}
+	if profile.databaseType != "" {
+		row("Type", tview.Escape(strings.ToLower(profile.databaseType)))
+	}
+	if profile.err != nil {
+		lines = append(lines, " [red]"+tview.Escape(profile.err.Error())+"[-]")
+		return strings.Join(lines, "\n")
+	}
+	row("Rows", strconv.FormatInt(profile.rows, 10))
+	row("Nulls", columnProfileNullText(profile))
+	row("Distinct", columnProfileDistinctText(profile))
+	row("Min", tview.Escape(columnProfileValueText(profile.min, profile.databaseType, 60)))
+	row("Max", tview.Escape(columnProfileValueText(profile.max, profile.databaseType, 60)))
+	if profile.lengths && profile.rows > profile.nulls {
+		row("Length", fmt.Sprintf("min %d · avg %.1f · max %d", profile.minLength, profile.avgLength, profile.maxLength))
+	}
+	if len(profile.histogram) > 0 {
+		lines = append(lines, " [yellow]Histogram[-]")
+		lines = append(lines, columnProfileHistogramLines(profile)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func columnProfileNullText(profile columnProfile) string {
+	if profile.rows == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%.1f%%)", profile.nulls, float64(profile.nulls)*100/float64(profile.rows))
+}
+
+func columnProfileDistinctText(profile columnProfile) string {
+	if profile.bounded {
+		return fmt.Sprintf("≈%d (exact within the profiled rows)", profile.distinct)
+	}
+	return strconv.FormatInt(profile.distinct, 10)
+}
+
+func columnProfileValueText(value any, databaseType string, maxRunes int) string {
+	if value == nil {
+		return "—"
+	}
+	return resultValuePreview(fullCellValueForDatabaseType(value, databaseType), maxRunes)
+}
+
+func columnProfileTopValueText(value columnProfileValue, rows int64) string {
+	label := "NULL"
+	if value.value != nil {
+		label = resultValuePreview(resultFilterValueString(value.value), 36)
+	}
+	share := 0.0
+	if rows > 0 {
+		share = float64(value.count) * 100 / float64(rows)
+	}
+	return fmt.Sprintf(" %-36s %8d  %5.1f%%  [#89b4fa]%s[-]", tview.Escape(label), value.count, share, columnProfileBar(share/100, 20))
+}
+
+func columnProfileHistogramLines(profile columnProfile) []string {
+	var largest int64
+	for _, bucket := range profile.histogram {
+		if bucket.count > largest {
+			largest = bucket.count
+		}
+	}
+	lines := make([]string, 0, len(profile.histogram))
+	for _, bucket := range profile.histogram {
+		share := 0.0
+		if largest > 0 {
+			share = float64(bucket.count) / float64(largest)
+		}
+		bounds := columnProfileBound(bucket.low, profile) + " – " + columnProfileBound(bucket.high, profile)
+		lines = append(lines, fmt.Sprintf("   %-43s [#89b4fa]%-24s[-] %d", tview.Escape(bounds), columnProfileBar(share, 24), bucket.count))
+	}
+	return lines
+}
+
+func columnProfileBound(value float64, profile columnProfile) string {
+	if profile.kind == columnProfileTemporal {
+		moment := time.Unix(0, int64(value*float64(time.Second))).UTC()
+		span := profile.histogram[len(profile.histogram)-1].high - profile.histogram[0].low
+		if span >= 2*24*60*60 {
+			return moment.Format("2006-01-02")
+		}
+		return moment.Format("2006-01-02 15:04:05")
+	}
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+func columnProfileBar(share float64, width int) string {
+	return strings.Repeat("█", clamp(int(math.Round(share*float64(width))), 0, width))
+}
+
+// columnProfileValuePredicate is the filter that selecting a top value applies.
+func columnProfileValuePredicate(column string, value any) resultFilterPredicate {
+	if value == nil {
+		return resultFilterPredicate{column: column, operator: resultFilterIsNull}
+	}
+	return resultFilterPredicate{column: column, operator: resultFilterEqual, value: cloneResultRawValue(value)}
+}
+
+func columnProfileFooterText(width int, server bool) string {
+	if !server {
+		return footerTextThatFits(width,
+			" [yellow]A[-] All columns  │  [yellow]↑/↓[-] Move  │  [yellow]Esc[-] Close ",
+			" [yellow]A[-] All columns  │  [yellow]Esc[-] Close ",
+		)
+	}
+	return footerTextThatFits(width,
+		" [yellow]Enter[-] Filter by value  │  [yellow]A[-] All columns  │  [yellow]↑/↓[-] Move  │  [yellow]Esc[-] Close ",
+		" [yellow]Enter[-] Filter  │  [yellow]A[-] All  │  [yellow]Esc[-] Close ",
+	)
+}
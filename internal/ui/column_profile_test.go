@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+func TestLoadColumnProfileRunsBoundedAggregatesOnTheServer(t *testing.T) {
+	db, err := database.Connect(&config.ConnectionConfig{Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	for _, statement := range []string{
+		"CREATE TABLE jobs (id INTEGER, status TEXT, created DATE)",
+		`INSERT INTO jobs VALUES (1, 'failed', '2026-01-01'), (2, 'failed', '2026-01-02'), (3, 'ok', '2026-01-03'),
+			(4, 'failed', '2026-01-04'), (5, 'ok', '2026-01-05'), (10, NULL, '2026-01-11')`,
+	} {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := columnProfileRequest{db: db, dbType: config.SQLite, table: "jobs", detail: true, rowLimit: 100}
+
+	status := loadColumnProfile(ctx, request, "status", "")
+	if status.err != nil {
+		t.Fatal(status.err)
+	}
+	if status.rows != 6 || status.nulls != 1 || status.distinct != 2 || status.bounded {
+		t.Fatalf("status counts = rows %d, nulls %d, distinct %d, bounded %v", status.rows, status.nulls, status.distinct, status.bounded)
+	}
+	if fmt.Sprint(status.min) != "failed" || fmt.Sprint(status.max) != "ok" || status.minLength != 2 || status.maxLength != 6 {
+		t.Fatalf("status min/max = %v/%v, lengths %d–%d", status.min, status.max, status.minLength, status.maxLength)
+	}
+	if len(status.top) != 3 || fmt.Sprint(status.top[0].value) != "failed" || status.top[0].count != 3 || len(status.histogram) != 0 {
+		t.Fatalf("status top values = %#v, histogram %#v", status.top, status.histogram)
+	}
+
+	for _, column := range []string{"id", "created"} {
+		profile := loadColumnProfile(ctx, request, column, "")
+		if profile.err != nil {
+			t.Fatalf("%s: %v", column, profile.err)
+		}
+		if len(profile.histogram) != columnProfileBuckets {
+			t.Fatalf("%s histogram = %#v", column, profile.histogram)
+		}
+		var total int64
+		for _, bucket := range profile.histogram {
+			total += bucket.count
+		}
+		// Both columns span ten units with one outlier at the top.
+		if first, gap, last := profile.histogram[0].count, profile.histogram[5].count, profile.histogram[columnProfileBuckets-1].count; total != 6 || first != 1 || gap != 0 || last != 1 {
+			t.Fatalf("%s histogram holds %d rows, buckets 0/5/9 = %d/%d/%d", column, total, first, gap, last)
+		}
+	}
+
+	request.rowLimit = 4
+	request.filter = newResultValueFilter("jobs", []resultFilterPredicate{{column: "status", operator: resultFilterIn, value: "failed, ok"}})
+	bounded := loadColumnProfile(ctx, request, "status", "TEXT")
+	if bounded.err != nil || bounded.rows != 4 || bounded.nulls != 0 || !bounded.bounded {
+		t.Fatalf("bounded filtered profile = rows %d, nulls %d, bounded %v, err %v", bounded.rows, bounded.nulls, bounded.bounded, bounded.err)
+	}
+}
+
+func TestProfileDisplayedResultColumnUsesTheRowsOnScreen(t *testing.T) {
+	results := tview.NewTable()
+	results.SetCell(0, 0, tview.NewTableCell("TOTAL").SetReference("total"))
+	for row, value := range []any{int64(5), int64(5), nil, int64(25), int64(45)} {
+		display, _ := formatCellValue(value)
+		results.SetCell(row+1, 0, tview.NewTableCell(display).SetReference(newResultCellReferenceForDatabaseType(value, display, "INT8")))
+	}
+
+	profile := profileDisplayedResultColumn(results, 0, "total", true)
+	if profile.kind != columnProfileNumeric || profile.rows != 5 || profile.nulls != 1 || profile.distinct != 3 {
+		t.Fatalf("profile = kind %d, rows %d, nulls %d, distinct %d", profile.kind, profile.rows, profile.nulls, profile.distinct)
+	}
+	if profile.min != int64(5) || profile.max != int64(45) || profile.top[0].value != int64(5) || profile.top[0].count != 2 {
+		t.Fatalf("min/max = %v/%v, top = %#v", profile.min, profile.max, profile.top)
+	}
+	if len(profile.histogram) != columnProfileBuckets || profile.histogram[0].count != 2 || profile.histogram[5].count != 1 || profile.histogram[9].count != 1 {
+		t.Fatalf("histogram = %#v", profile.histogram)
+	}
+	if predicate := columnProfileValuePredicate("total", nil); predicate.operator != resultFilterIsNull {
+		t.Fatalf("NULL top value filters with %q", predicate.operator)
+	}
+
+	app := &App{
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		tables:     tview.NewList(),
+		queryInput: tview.NewTextArea(),
+		results:    results,
+		statusBar:  tview.NewTextView(),
+	}
+	app.pages.AddPage("main", results, true, true)
+	results.Select(1, 0)
+	app.profileSelectedResultColumn()
+	if page, _ := app.pages.GetFrontPage(); page != pageColumnProfile {
+		t.Fatalf("front page = %q, want the column profile", page)
+	}
+}
@@ -19,6 +19,10 @@ const (
 	commandPaletteQueryLimit = 24
 
 	paletteActionRunQuery             keymapAction = "palette_run_query"
+	paletteActionSaveQuery            keymapAction = "palette_save_query"
+	paletteActionCompactSQL           keymapAction = "palette_compact_sql"
+	paletteActionCommitTransaction    keymapAction = "palette_commit_transaction"
+	paletteActionRollbackTransaction  keymapAction = "palette_rollback_transaction"
 	paletteActionSQLSuggestions       keymapAction = "palette_sql_suggestions"
 	paletteActionRefreshTable         keymapAction = "palette_refresh_table"
 	paletteActionRefreshDatabase      keymapAction = "palette_refresh_database"
@@ -29,12 +33,17 @@ const (
 	paletteActionFindResultColumn     keymapAction = "palette_find_result_column"
 	paletteActionCopyColumnName       keymapAction = "palette_copy_column_name"
 	paletteActionExploreRelationships keymapAction = "palette_explore_relationships"
+	paletteActionProfileColumn        keymapAction = "palette_profile_column"
+	paletteActionProfileAllColumns    keymapAction = "palette_profile_all_columns"
+	paletteActionPinDiffBaseline      keymapAction = "palette_pin_diff_baseline"
+	paletteActionDiffResults          keymapAction = "palette_diff_results"
 	paletteActionSortColumn           keymapAction = "palette_sort_column"
 	paletteActionOpenRowDetail        keymapAction = "palette_open_row_detail"
 	paletteActionNextPage             keymapAction = "palette_next_page"
 	paletteActionPreviousPage         keymapAction = "palette_previous_page"
 	paletteActionFirstPage            keymapAction = "palette_first_page"
 	paletteActionLastPage             keymapAction = "palette_last_page"
+	paletteActionCountRows            keymapAction = "palette_count_rows"
 	paletteActionToggleTablePin       keymapAction = "palette_toggle_table_pin"
 	paletteActionCopyTableName        keymapAction = "palette_copy_table_name"
 	paletteActionUpdates              keymapAction = "palette_updates"
@@ -51,6 +60,9 @@ const (
 	commandPaletteProcedure commandPaletteItemKind = "procedure"
 	commandPaletteTrigger   commandPaletteItemKind = "trigger"
 	commandPaletteQuery     commandPaletteItemKind = "recent query"
+	commandPaletteProject   commandPaletteItemKind = "project query"
+	commandPaletteSaved     commandPaletteItemKind = "saved query"
+	commandPaletteEditorTab commandPaletteItemKind = "editor tab"
 	commandPaletteBackupJob commandPaletteItemKind = "backup job"
 )
 
@@ -94,14 +106,30 @@ var commandPaletteActionSpecs = []commandPaletteActionSpec{
 	{actionBackupCenter, "Open Backup Center", "Create schedules, run or prune backups, restore artifacts, and manage the agent. N chooses a saved database or adds one; Ctrl+N adds a database from the plan form. Dashboard Ctrl+B starts preselected.", "new saved database connection scheduled automatic restore agent history retention encryption zstd zip ctrl n", ""},
 	{actionChangeProfiler, "Open Change Profiler", "Create named anchors, scan for row and schema changes, and inspect saved before/after reports.", "diff snapshot anchor track changes inserted updated deleted audit", ""},
 	{actionFullscreen, "Toggle Fullscreen Results", "Expand the result grid to the full workspace or restore the normal layout.", "maximize expand data grid", ""},
+	{actionExplainQuery, "Explain Query Plan", "Show the plan of the statement under the editor cursor as a collapsible tree with costs, row estimates, hot nodes, and full scans of large tables. PostgreSQL can re-run it with ANALYZE.", "explain analyze plan cost slow performance index seq scan full table scan optimizer", ""},
+	{actionFormatSQL, "Format SQL", "Pretty-print the selected SQL, or the whole Query editor, with a line per clause, indented subqueries and CTEs, and keywords cased as Settings chooses. Strings, quoted names, and comments are never changed.", "pretty print beautify indent reformat tidy layout uppercase keywords", ""},
+	{paletteActionCompactSQL, "Compact SQL", "Join the selected SQL, or the whole Query editor, onto one line per statement.", "minify single line one line collapse unformat", ""},
+	{actionTransaction, "Begin Transaction", "Pin one session and run every statement from Query inside a transaction until it is committed or rolled back. A badge shows its age, and idle transactions roll back after the Settings timeout.", "begin start transaction session pinned uncommitted atomic tx", ""},
+	{paletteActionCommitTransaction, "Commit Transaction", "Commit every change made in the open transaction.", "save apply end finish tx", ""},
+	{paletteActionRollbackTransaction, "Roll Back Transaction", "Discard every change made in the open transaction.", "rollback undo abort cancel end tx", ""},
 	{actionInspectSchema, "Inspect Selected Table Schema", "Show columns, keys, foreign keys, and indexes for the selected table.", "metadata structure columns constraints indexes foreign keys", ""},
+	{actionNewEditorTab, "New Editor Tab", "Open an empty query editor tab; every tab keeps its own SQL and results and is saved for this connection.", "buffer scratch add open", ""},
+	{actionCloseEditorTab, "Close Editor Tab", "Close the active query editor tab, asking first when it still holds SQL.", "buffer scratch remove discard", ""},
+	{actionNextEditorTab, "Next Editor Tab", "Switch to the next query editor tab with its own results.", "buffer scratch switch cycle", ""},
+	{actionPrevEditorTab, "Previous Editor Tab", "Switch to the previous query editor tab with its own results.", "buffer scratch switch cycle back", ""},
+	{paletteActionRenameEditorTab, "Rename Editor Tab", "Give the active query editor tab a name that the palette can find.", "buffer scratch title label", ""},
+	{actionQueryLibrary, "Open Saved Queries", "Browse, run, and organize named queries saved as plain .sql files, with folders, tags, and connection, engine, or global scope.", "library saved named favorite snippets folder tags investigation bookmark sql files git", ""},
+	{paletteActionSaveQuery, "Save Query to Library", "Save the SQL in the Query editor as a named query with a description, tags, folder, and scope.", "library save name bookmark favorite snippet store sql file", ""},
 	{actionHistory, "Open Query History", "Browse successful queries saved for the active connection and load one into the editor.", "recent sql previous statements", ""},
-	{actionExportCSV, "Export Results to CSV", "Choose selected rows, the current page, or all table rows matching the active filters and stream them safely to CSV.", "download save spreadsheet comma separated all filtered matching stream", ""},
+	{actionExportCSV, "Export Results", "Choose selected rows, the current page, or all table rows matching the active filters and stream them safely to CSV, JSON, NDJSON, Markdown, SQL INSERTs, or XLSX.", "download save spreadsheet csv comma separated json ndjson jsonl markdown table sql insert statements xlsx excel all filtered matching stream", ""},
 	{actionBackup, "Back Up Current Database", "From any workspace panel, create an engine-appropriate backup of the active database. F2 chooses a folder and F3 refreshes destination and staging capacity.", "dump snapshot save restore folder chooser destination staging capacity disk f2 f3", ""},
 	{actionImportDump, "Import SQL Dump", "Import a supported PostgreSQL or MySQL dump into the active connection.", "restore upload sql file", ""},
+	{actionImportData, "Import Data File", "Load a CSV, JSON, or NDJSON file into an existing or new table with column mapping, type inference, and an optional dry run.", "csv tsv json ndjson jsonl spreadsheet load upload rows table create mapping dry run", ""},
 	{actionSelectAll, "Select All Displayed Rows", "Select every currently displayed data row for a bulk result action.", "mark rows bulk csv", ""},
 	{actionClearSelection, "Clear Result Row Selection", "Remove the selection marker from all currently displayed result rows.", "unselect deselect rows bulk", ""},
 	{actionSettings, "Open Settings", "Configure effective keyboard shortcuts and dashboard health-check behavior.", "preferences keymap bindings configuration", ""},
+	{paletteActionImportConnections, "Import Connections from Another Tool", "Preview and import connections from DBeaver, pgAdmin, TablePlus, or docker-compose files.", "migrate dbeaver pgadmin tableplus docker compose servers json plist", "M (Dashboard)"},
+	{paletteActionUnlockVault, "Unlock Secrets Vault", "Unlock the encrypted vault that holds saved connection passwords, tokens, and SMTP credentials for this session.", "password passphrase key file encrypted credentials age locked", ""},
 	{paletteActionUpdates, "Version & Update", "Show the current build, check the latest GitHub release, and install it with checksum verification while preserving the user profile.", "about upgrade latest release current version", "U (Dashboard)"},
 	{paletteActionSQLSuggestions, "Open Smart SQL Suggestions", "Focus Query and show context-ranked SQL, typo-tolerant tables, selected-table columns, and ready read-only query templates.", "autocomplete completion template preview count columns typo", "Ctrl+Space"},
 	{paletteActionRunQuery, "Run Current SQL", "Execute the SQL currently in the Query editor against the active connection.", "execute statement editor", "Enter"},
@@ -111,17 +139,28 @@ var commandPaletteActionSpecs = []commandPaletteActionSpec{
 	{paletteActionCopyTableName, "Copy Selected Table Name", "Copy the complete selected table identifier without opening it.", "clipboard relation identifier sidebar", "Shift+C / Right-click (Tables)"},
 	{paletteActionFindResultColumn, "Find a Result Column", "Focus the selectable header row; type to find and highlight a column, then press Down or Enter for its data.", "header field name jump search highlight columns", "↑ from first data row"},
 	{paletteActionCopyColumnName, "Copy Selected Column Name", "Copy the complete name of the selected result column.", "clipboard header field identifier", "Shift+C (Headers)"},
-	{paletteActionFilterColumn, "Filter Selected Column", "Open the typed filter builder for the selected result column; Apply updates and Add AND composes.", "where search operator contains starts null", "/"},
+	{paletteActionFilterColumn, "Filter Selected Column", "Open the typed filter builder for the selected result column; Apply updates, Add AND and Add OR compose.", "where search operator contains starts null in between not or", "/"},
 	{paletteActionFilterClipboard, "Filter Column by Clipboard", "Apply or update equality on the selected column using the copied value; SQL NULL becomes IS NULL.", "paste value cross table lookup", "V"},
 	{paletteActionClearFilters, "Clear All Active Filters", "Remove every active table predicate and reload the first page.", "reset where predicates", "Esc"},
 	{paletteActionCopyCell, "Copy Selected Cell", "Copy the complete selected cell value, even when its visible preview is shortened.", "clipboard full raw value", "C"},
 	{paletteActionExploreRelationships, "Explore Related Rows", "Open parent or child rows using every component of a declared key; repeat across a chain and use Backspace to return.", "relationship parent child join reference navigation composite chain", "F"},
+	{paletteActionProfileColumn, "Profile Selected Column", "Show nulls, distinct values, min/max, top values, text lengths, and a histogram; Enter on a top value filters by it.", "statistics stats distribution frequency histogram summary explore", "P"},
+	{paletteActionProfileAllColumns, "Profile All Columns", "Summarize nulls, distinct values, min/max, and lengths for every result column, then open any one in detail.", "statistics stats distribution summary overview table", "A (Profile)"},
+	{paletteActionPinDiffBaseline, "Pin Result as Diff Baseline", "Keep a copy of the displayed rows so a later result from any connection can be diffed against it.", "compare baseline snapshot before reference pin", "B"},
+	{paletteActionDiffResults, "Diff Result Against Baseline", "Compare the displayed rows with the pinned baseline by chosen key columns, or by full row, and highlight added, removed, and changed cells; export the diff like any result.", "compare diff baseline before after added removed changed environments", "B (then Diff)"},
+	{paletteActionEditCell, "Edit Selected Cell", "Stage a new value or NULL for the selected cell of a table with a primary or NOT NULL unique key.", "update change modify inline write value", "E"},
+	{paletteActionReviewCellEdits, "Review & Commit Staged Edits", "Preview the generated UPDATE statements, then commit them in one transaction or discard them.", "save apply write transaction pending changes sql", "W"},
+	{paletteActionDiscardCellEdits, "Discard Staged Edits", "Drop every staged cell edit without touching the database.", "revert undo cancel pending changes", ""},
+	{paletteActionInsertRow, "Insert Row", "Add a row to the active table through a form built from its column types, defaults, and nullability.", "add new record create insert", "I"},
+	{paletteActionDuplicateRow, "Duplicate Selected Row", "Open the insert form prefilled from the selected row; generated keys are left for the database.", "copy clone record insert", "D"},
+	{paletteActionDeleteRows, "Delete Selected Rows", "Preview DELETE statements for the selected rows, with cascading or blocking foreign keys, then run them in one transaction.", "remove drop record cascade", "X / Delete"},
 	{paletteActionSortColumn, "Sort by Selected Column", "Toggle ascending or descending server-side sorting for the active table.", "order ascending descending", "S"},
 	{paletteActionOpenRowDetail, "Open Selected Row Details", "Inspect every full value in the selected row in a vertical detail view.", "inspect record full json", "Enter"},
 	{paletteActionNextPage, "Go to Next Result Page", "Load the next bounded page of the active table with cancellable progress.", "pagination forward", "PgDn / ]"},
 	{paletteActionPreviousPage, "Go to Previous Result Page", "Return to the previous bounded page of the active table.", "pagination back", "PgUp / ["},
 	{paletteActionFirstPage, "Go to First Result Page", "Jump to the first page of the active table.", "pagination beginning", "Home"},
 	{paletteActionLastPage, "Go to Last Result Page", "Jump to the final page once the matching row count is known.", "pagination end", "End"},
+	{paletteActionCountRows, "Count Rows Exactly", "Run a cancellable COUNT(*) over the browsed table and its filters, replacing the approximate total shown for large tables.", "total count estimate approximate pagination", "#"},
 }
 
 func (a *App) showCommandPalette() {
@@ -439,6 +478,40 @@ func (a *App) buildCommandPaletteItems() []commandPaletteItem {
 		})
 	}
 
+	if a.db != nil {
+		entries, _ := a.savedQueries()
+		for index, entry := range entries {
+			description := entry.query.Description
+			if description != "" {
+				description += "\n\n"
+			}
+			items = append(items, commandPaletteItem{
+				id:          "saved-query:" + entry.query.Path,
+				kind:        commandPaletteSaved,
+				title:       entry.query.Title(),
+				description: description + "Saved in " + entry.query.Path + ". Load this SQL into the Query editor:\n\n" + entry.query.SQL,
+				keywords:    "saved library sql statement " + strings.Join(entry.query.Tags, " ") + " " + entry.query.Folder + " " + compactSQL(entry.query.SQL),
+				query:       entry.query.SQL,
+				sortOrder:   240 + index,
+			})
+		}
+		for index, query := range a.projectQueries() {
+			items = append(items, commandPaletteItem{
+				id:          "project-query:" + query.Name,
+				kind:        commandPaletteProject,
+				title:       query.Name,
+				description: projectQueryDescription(query, a.projectWorkspacePath()),
+				keywords:    "project workspace shared saved sql statement " + compactSQL(query.SQL),
+				query:       query.SQL,
+				sortOrder:   250 + index,
+			})
+		}
+	}
+
+	if a.db != nil {
+		items = append(items, a.editorTabPaletteItems()...)
+	}
+
 	if a.historyMgr != nil {
 		if connectionKey, ok := a.activeConnectionKey(); ok {
 			entries := a.historyMgr.Entries(connectionKey)
@@ -998,6 +1071,10 @@ func commandPaletteCategoryTag(kind commandPaletteItemKind) string {
 		return "[#ffb496]TRIGGER[-]"
 	case commandPaletteQuery:
 		return "[#a6adc8]RECENT SQL[-]"
+	case commandPaletteProject:
+		return "[#f5c2e7]PROJECT SQL[-]"
+	case commandPaletteSaved:
+		return "[#89dceb]SAVED SQL[-]"
 	case commandPaletteBackupJob:
 		return "[#a6e3a1]BACKUP[-]"
 	default:
@@ -1016,7 +1093,13 @@ func (a *App) executeCommandPaletteItem(item commandPaletteItem) {
 	case commandPaletteView, commandPaletteFunction, commandPaletteProcedure, commandPaletteTrigger:
 		a.openCommandPaletteDatabaseObject(item.objectType, item.objectName)
 	case commandPaletteQuery:
-		a.loadCommandPaletteQuery(item.query)
+		a.loadCommandPaletteQuery(item.query, "Recent query")
+	case commandPaletteProject:
+		a.loadCommandPaletteQuery(item.query, "Project query "+item.title)
+	case commandPaletteSaved:
+		a.loadCommandPaletteQuery(item.query, "Saved query "+item.title)
+	case commandPaletteEditorTab:
+		a.openCommandPaletteEditorTab(item.objectName)
 	case commandPaletteBackupJob:
 		a.showBackupCenter()
 		if a.pages.HasPage(pageBackupCenter) {
@@ -1058,17 +1141,67 @@ func (a *App) executeCommandPaletteAction(action keymapAction, title string) {
 		a.showBackupModal()
 	case actionExportCSV:
 		a.pages.SwitchToPage("main")
-		a.exportCurrentResultsToCSV()
+		a.exportCurrentResults()
 	case actionHistory:
 		a.pages.SwitchToPage("main")
 		a.showHistoryModal()
+	case actionQueryLibrary:
+		a.pages.SwitchToPage("main")
+		a.showSavedQueries()
+	case paletteActionSaveQuery:
+		a.pages.SwitchToPage("main")
+		a.saveEditorQuery()
+	case actionNewEditorTab:
+		a.pages.SwitchToPage("main")
+		if a.openEditorTab("") {
+			a.setFocusWithColor(a.queryInput)
+		}
+	case actionCloseEditorTab:
+		a.pages.SwitchToPage("main")
+		a.closeEditorTab()
+	case actionNextEditorTab:
+		a.pages.SwitchToPage("main")
+		a.cycleEditorTab(1)
+	case actionPrevEditorTab:
+		a.pages.SwitchToPage("main")
+		a.cycleEditorTab(-1)
+	case paletteActionRenameEditorTab:
+		a.pages.SwitchToPage("main")
+		a.showEditorTabRename()
 	case actionSettings:
 		a.showSettings()
 	case paletteActionUpdates:
 		a.showUpdates()
+	case paletteActionImportConnections:
+		returnPage, _ := a.pages.GetFrontPage()
+		a.showConnectionImport(returnPage)
+	case paletteActionUnlockVault:
+		returnPage, _ := a.pages.GetFrontPage()
+		a.showVaultUnlock(returnPage)
+	case actionImportData:
+		a.pages.SwitchToPage("main")
+		a.showDataImport()
 	case actionImportDump:
 		a.pages.SwitchToPage("main")
 		a.showImportModal()
+	case actionExplainQuery:
+		a.pages.SwitchToPage("main")
+		a.explainQuery()
+	case actionFormatSQL:
+		a.pages.SwitchToPage("main")
+		a.formatEditorSQL(false)
+	case paletteActionCompactSQL:
+		a.pages.SwitchToPage("main")
+		a.formatEditorSQL(true)
+	case actionTransaction:
+		a.pages.SwitchToPage("main")
+		a.toggleTransaction()
+	case paletteActionCommitTransaction:
+		a.pages.SwitchToPage("main")
+		a.endTransaction(true, nil)
+	case paletteActionRollbackTransaction:
+		a.pages.SwitchToPage("main")
+		a.endTransaction(false, nil)
 	case actionInspectSchema:
 		a.pages.SwitchToPage("main")
 		a.showSelectedTableMetadata()
@@ -1124,6 +1257,36 @@ func (a *App) executeCommandPaletteAction(action keymapAction, title string) {
 	case paletteActionExploreRelationships:
 		a.showCommandPaletteWorkspace(a.results)
 		a.exploreSelectedRelationships()
+	case paletteActionProfileColumn:
+		a.showCommandPaletteWorkspace(a.results)
+		a.profileSelectedResultColumn()
+	case paletteActionProfileAllColumns:
+		a.showCommandPaletteWorkspace(a.results)
+		a.profileAllResultColumns()
+	case paletteActionPinDiffBaseline:
+		a.showCommandPaletteWorkspace(a.results)
+		a.pinResultDiffBaseline()
+	case paletteActionDiffResults:
+		a.showCommandPaletteWorkspace(a.results)
+		a.diffAgainstResultBaseline()
+	case paletteActionEditCell:
+		a.showCommandPaletteWorkspace(a.results)
+		a.editSelectedResultCell()
+	case paletteActionReviewCellEdits:
+		a.showCommandPaletteWorkspace(a.results)
+		a.showCellEditReview()
+	case paletteActionDiscardCellEdits:
+		a.showCommandPaletteWorkspace(a.results)
+		a.discardCellEdits()
+	case paletteActionInsertRow:
+		a.showCommandPaletteWorkspace(a.results)
+		a.showRowInsert(false)
+	case paletteActionDuplicateRow:
+		a.showCommandPaletteWorkspace(a.results)
+		a.showRowInsert(true)
+	case paletteActionDeleteRows:
+		a.showCommandPaletteWorkspace(a.results)
+		a.deleteSelectedResultRows()
 	case paletteActionSortColumn:
 		a.showCommandPaletteWorkspace(a.results)
 		_, column := a.results.GetSelection()
@@ -1148,21 +1311,30 @@ func (a *App) executeCommandPaletteAction(action keymapAction, title string) {
 	case paletteActionLastPage:
 		a.showCommandPaletteWorkspace(a.results)
 		a.lastPage()
+	case paletteActionCountRows:
+		a.showCommandPaletteWorkspace(a.results)
+		a.countRowsExactly()
 	}
 }
 
 func commandPaletteActionNeedsConnection(action keymapAction) bool {
 	switch action {
 	case actionFocusTables, actionFocusQuery, actionFocusResults, actionFullscreen,
-		actionBackup, actionExportCSV, actionHistory, actionImportDump,
-		actionInspectSchema, actionSelectAll, actionClearSelection,
+		actionBackup, actionExportCSV, actionHistory, actionQueryLibrary, paletteActionSaveQuery, actionImportDump, actionImportData,
+		actionNewEditorTab, actionCloseEditorTab, actionNextEditorTab, actionPrevEditorTab, paletteActionRenameEditorTab,
+		actionInspectSchema, actionExplainQuery, actionFormatSQL, paletteActionCompactSQL, actionSelectAll,
+		actionTransaction, paletteActionCommitTransaction, paletteActionRollbackTransaction, actionClearSelection,
 		paletteActionRunQuery, paletteActionSQLSuggestions, paletteActionRefreshTable, paletteActionRefreshDatabase,
 		paletteActionToggleTablePin, paletteActionCopyTableName,
 		paletteActionFindResultColumn, paletteActionCopyColumnName,
 		paletteActionFilterColumn, paletteActionFilterClipboard, paletteActionClearFilters,
 		paletteActionCopyCell, paletteActionExploreRelationships, paletteActionSortColumn,
+		paletteActionProfileColumn, paletteActionProfileAllColumns,
+		paletteActionPinDiffBaseline, paletteActionDiffResults,
+		paletteActionEditCell, paletteActionReviewCellEdits, paletteActionDiscardCellEdits,
+		paletteActionInsertRow, paletteActionDuplicateRow, paletteActionDeleteRows,
 		paletteActionOpenRowDetail, paletteActionNextPage, paletteActionPreviousPage,
-		paletteActionFirstPage, paletteActionLastPage:
+		paletteActionFirstPage, paletteActionLastPage, paletteActionCountRows:
 		return true
 	default:
 		return false
@@ -1231,12 +1403,12 @@ func (a *App) openCommandPaletteDatabaseObject(objectType database.DBObjectType,
 	a.onDatabaseObjectSelected(objectType, objectName)
 }
 
-func (a *App) loadCommandPaletteQuery(query string) {
-	if strings.TrimSpace(query) == "" || !a.commandPaletteRequireConnection("Loading a recent query") {
+func (a *App) loadCommandPaletteQuery(query, label string) {
+	if strings.TrimSpace(query) == "" || !a.commandPaletteRequireConnection("Loading a query") {
 		return
 	}
 	a.pages.SwitchToPage("main")
-	a.queryInput.SetText(query, true)
+	a.loadQueryIntoEditor(query)
 	a.setFocusWithColor(a.queryInput)
-	a.flashStatus(fmt.Sprintf("[green]%s Recent query loaded[-]", iconSuccess), a.currentResultRowCount(), 1400*time.Millisecond)
+	a.flashStatus(fmt.Sprintf("[green]%s %s loaded[-]", iconSuccess, tview.Escape(label)), a.currentResultRowCount(), 1400*time.Millisecond)
 }
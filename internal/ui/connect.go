@@ -13,6 +13,8 @@ import (
 	"github.com/rivo/tview"
 	"github.com/shreyam1008/dbterm/internal/config"
 	"github.com/shreyam1008/dbterm/internal/database"
+	"github.com/shreyam1008/dbterm/internal/duckdbsql"
+	"github.com/shreyam1008/dbterm/internal/sshtunnel"
 )
 
 // DirectConnect connects to a database using provided parameters
@@ -40,6 +42,7 @@ func (a *App) DirectConnect(dbType config.DBType, dsn, name string) error {
 	cfg.Password = parsed.Password
 	cfg.Database = parsed.Database
 	cfg.SSLMode = parsed.SSLMode
+	cfg.TLSCAFile, cfg.TLSCertFile, cfg.TLSKeyFile = parsed.TLSCAFile, parsed.TLSCertFile, parsed.TLSKeyFile
 
 	// Connect
 	db, err := database.Connect(cfg)
@@ -53,6 +56,7 @@ func (a *App) DirectConnect(dbType config.DBType, dsn, name string) error {
 	a.dbType = cfg.Type
 	a.dbName = cfg.Name
 	a.activeConn = cloneConnectionConfig(cfg)
+	a.refreshEnvironmentChrome()
 
 	// Load tables
 	if err := a.LoadTables(); err != nil {
@@ -80,17 +84,34 @@ const (
 	connLabelName       = "Name (*)"
 	connLabelType       = "Type (*) " + iconDropdown
 	connLabelReadOnly   = "Read-Only Guard (not DB-enforced)"
+	connLabelEnv        = "Environment (dev/staging/prod)"
+	connLabelEnvColor   = "Environment Color"
+	connLabelInitSQL    = "Session Init SQL"
+	connLabelTimeout    = "Statement Timeout"
 	connLabelDSN        = "Connection String (Optional)"
 	connLabelHost       = "Host"
 	connLabelPort       = "Port"
 	connLabelUser       = "User"
 	connLabelPassword   = "Password"
+	connLabelPassCmd    = "Password Command (optional)"
 	connLabelDatabase   = "Default Database (optional)"
-	connLabelSSLMode    = "SSL Mode (PostgreSQL)"
-	connLabelFilePath   = "File Path (SQLite)"
+	connLabelSSLMode    = "SSL Mode"
+	connLabelFilePath   = "File Path"
 	connLabelAuthToken  = "Auth Token"
 	connLabelAccountID  = "Account ID"
 	connLabelDatabaseID = "Database ID (UUID)"
+	connLabelSSHHost    = "SSH Host (optional)"
+	connLabelSSHPort    = "SSH Port"
+	connLabelSSHUser    = "SSH User"
+	connLabelSSHKeyFile = "SSH Key File"
+	connLabelSSHPass    = "SSH Password / Passphrase"
+	connLabelSSHAgent   = "Use SSH Agent"
+	connLabelSSHKnown   = "SSH known_hosts"
+	connLabelSSHJump    = "SSH Jump Hosts"
+	connLabelTLSCA      = "TLS CA File"
+	connLabelTLSCert    = "TLS Client Cert"
+	connLabelTLSKey     = "TLS Client Key"
+	connLabelTLSServer  = "TLS Server Name"
 )
 
 type connectFieldKey string
@@ -99,34 +120,68 @@ const (
 	connFieldName       connectFieldKey = "name"
 	connFieldType       connectFieldKey = "type"
 	connFieldReadOnly   connectFieldKey = "read_only"
+	connFieldEnv        connectFieldKey = "environment"
+	connFieldEnvColor   connectFieldKey = "color"
+	connFieldInitSQL    connectFieldKey = "init_sql"
+	connFieldTimeout    connectFieldKey = "statement_timeout"
 	connFieldDSN        connectFieldKey = "dsn"
 	connFieldHost       connectFieldKey = "host"
 	connFieldPort       connectFieldKey = "port"
 	connFieldUser       connectFieldKey = "user"
 	connFieldPassword   connectFieldKey = "password"
+	connFieldPassCmd    connectFieldKey = "password_command"
 	connFieldDatabase   connectFieldKey = "database"
 	connFieldSSLMode    connectFieldKey = "ssl_mode"
 	connFieldFilePath   connectFieldKey = "file_path"
 	connFieldAuthToken  connectFieldKey = "auth_token"
 	connFieldAccountID  connectFieldKey = "account_id"
 	connFieldDatabaseID connectFieldKey = "database_id"
+	connFieldSSHHost    connectFieldKey = "ssh_host"
+	connFieldSSHPort    connectFieldKey = "ssh_port"
+	connFieldSSHUser    connectFieldKey = "ssh_user"
+	connFieldSSHKeyFile connectFieldKey = "ssh_key_file"
+	connFieldSSHPass    connectFieldKey = "ssh_password"
+	connFieldSSHAgent   connectFieldKey = "ssh_use_agent"
+	connFieldSSHKnown   connectFieldKey = "ssh_known_hosts"
+	connFieldSSHJump    connectFieldKey = "ssh_jump_hosts"
+	connFieldTLSCA      connectFieldKey = "tls_ca_file"
+	connFieldTLSCert    connectFieldKey = "tls_cert_file"
+	connFieldTLSKey     connectFieldKey = "tls_key_file"
+	connFieldTLSServer  connectFieldKey = "tls_server_name"
 )
 
 var connectFieldLabels = map[connectFieldKey]string{
 	connFieldName:       connLabelName,
 	connFieldType:       connLabelType,
 	connFieldReadOnly:   connLabelReadOnly,
+	connFieldEnv:        connLabelEnv,
+	connFieldEnvColor:   connLabelEnvColor,
+	connFieldInitSQL:    connLabelInitSQL,
+	connFieldTimeout:    connLabelTimeout,
 	connFieldDSN:        connLabelDSN,
 	connFieldHost:       connLabelHost,
 	connFieldPort:       connLabelPort,
 	connFieldUser:       connLabelUser,
 	connFieldPassword:   connLabelPassword,
+	connFieldPassCmd:    connLabelPassCmd,
 	connFieldDatabase:   connLabelDatabase,
 	connFieldSSLMode:    connLabelSSLMode,
 	connFieldFilePath:   connLabelFilePath,
 	connFieldAuthToken:  connLabelAuthToken,
 	connFieldAccountID:  connLabelAccountID,
 	connFieldDatabaseID: connLabelDatabaseID,
+	connFieldSSHHost:    connLabelSSHHost,
+	connFieldSSHPort:    connLabelSSHPort,
+	connFieldSSHUser:    connLabelSSHUser,
+	connFieldSSHKeyFile: connLabelSSHKeyFile,
+	connFieldSSHPass:    connLabelSSHPass,
+	connFieldSSHAgent:   connLabelSSHAgent,
+	connFieldSSHKnown:   connLabelSSHKnown,
+	connFieldSSHJump:    connLabelSSHJump,
+	connFieldTLSCA:      connLabelTLSCA,
+	connFieldTLSCert:    connLabelTLSCert,
+	connFieldTLSKey:     connLabelTLSKey,
+	connFieldTLSServer:  connLabelTLSServer,
 }
 
 var dynamicConnectFields = []connectFieldKey{
@@ -135,12 +190,25 @@ var dynamicConnectFields = []connectFieldKey{
 	connFieldPort,
 	connFieldUser,
 	connFieldPassword,
+	connFieldPassCmd,
 	connFieldDatabase,
 	connFieldSSLMode,
 	connFieldFilePath,
 	connFieldAuthToken,
 	connFieldAccountID,
 	connFieldDatabaseID,
+	connFieldSSHHost,
+	connFieldSSHPort,
+	connFieldSSHUser,
+	connFieldSSHKeyFile,
+	connFieldSSHPass,
+	connFieldSSHAgent,
+	connFieldSSHKnown,
+	connFieldSSHJump,
+	connFieldTLSCA,
+	connFieldTLSCert,
+	connFieldTLSKey,
+	connFieldTLSServer,
 }
 
 func connectFieldLabel(key connectFieldKey) string {
@@ -193,13 +261,15 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 
 	form := tview.NewForm()
 
-	dbTypes := []string{"PostgreSQL", "MySQL", "SQLite", "Turso", "Cloudflare D1"}
+	dbTypes := []string{"PostgreSQL", "MySQL", "SQLite", "Turso", "Cloudflare D1", "DuckDB"}
 	initialType := 0
 	nameDefault := ""
 	connStringDefault := ""
 	hostDefault, portDefault, userDefault, passDefault, dbDefault, fileDefault := "localhost", "5432", "", "", "", ""
 	sslModeDefault := ""
 	readOnlyDefault := false
+	envDefault, colorDefault := "", ""
+	initSQLDefault, timeoutDefault := "", ""
 	authTokenDefault, accountIDDefault, dbIDDefault := "", "", ""
 	if initialConn != nil {
 		nameDefault = initialConn.Name
@@ -212,6 +282,8 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 			initialType = 3
 		case config.CloudflareD1:
 			initialType = 4
+		case config.DuckDB:
+			initialType = 5
 		}
 		hostDefault = initialConn.Host
 		portDefault = initialConn.Port
@@ -221,14 +293,24 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 		sslModeDefault = initialConn.SSLMode
 		fileDefault = initialConn.FilePath
 		readOnlyDefault = initialConn.ReadOnly
+		envDefault, colorDefault = initialConn.Environment, initialConn.Color
+		initSQLDefault, timeoutDefault = initialConn.InitSQL, initialConn.StatementTimeout
 		authTokenDefault = initialConn.AuthToken
 		accountIDDefault = initialConn.AccountID
 		dbIDDefault = initialConn.DatabaseID
 	}
+	var sshDefault config.ConnectionConfig
+	if initialConn != nil {
+		sshDefault = *initialConn
+	}
 
 	form.AddInputField(connLabelName, nameDefault, 30, nil, nil)
 	form.AddDropDown(connLabelType, dbTypes, initialType, nil)
 	form.AddCheckbox(connLabelReadOnly, readOnlyDefault, nil)
+	form.AddInputField(connLabelEnv, envDefault, 16, nil, nil)
+	form.AddInputField(connLabelEnvColor, colorDefault, 10, nil, nil)
+	form.AddInputField(connLabelInitSQL, initSQLDefault, 72, nil, nil)
+	form.AddInputField(connLabelTimeout, timeoutDefault, 10, nil, nil)
 
 	fieldValues := map[connectFieldKey]string{
 		connFieldDSN:        connStringDefault,
@@ -236,20 +318,38 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 		connFieldPort:       portDefault,
 		connFieldUser:       userDefault,
 		connFieldPassword:   passDefault,
+		connFieldPassCmd:    sshDefault.PasswordCommand,
 		connFieldDatabase:   dbDefault,
 		connFieldSSLMode:    sslModeDefault,
 		connFieldFilePath:   fileDefault,
 		connFieldAuthToken:  authTokenDefault,
 		connFieldAccountID:  accountIDDefault,
 		connFieldDatabaseID: dbIDDefault,
+		connFieldSSHHost:    sshDefault.SSHHost,
+		connFieldSSHPort:    sshDefault.SSHPort,
+		connFieldSSHUser:    sshDefault.SSHUser,
+		connFieldSSHKeyFile: sshDefault.SSHKeyFile,
+		connFieldSSHPass:    sshDefault.SSHPassword,
+		connFieldSSHAgent:   formBoolValue(sshDefault.SSHUseAgent),
+		connFieldSSHKnown:   sshDefault.SSHKnownHosts,
+		connFieldSSHJump:    sshDefault.SSHJumpHosts,
+		connFieldTLSCA:      sshDefault.TLSCAFile,
+		connFieldTLSCert:    sshDefault.TLSCertFile,
+		connFieldTLSKey:     sshDefault.TLSKeyFile,
+		connFieldTLSServer:  sshDefault.TLSServerName,
 	}
 
 	removeDynamicFields := func() {
 		// Preserve latest typed values before rebuilding type-specific fields.
 		for _, key := range dynamicConnectFields {
-			if form.GetFormItemIndex(connectFieldLabel(key)) >= 0 {
-				fieldValues[key] = formInputValue(form, key)
+			if form.GetFormItemIndex(connectFieldLabel(key)) < 0 {
+				continue
+			}
+			if key == connFieldSSHAgent {
+				fieldValues[key] = formBoolValue(formCheckboxChecked(form, key))
+				continue
 			}
+			fieldValues[key] = formInputValue(form, key)
 		}
 		for _, key := range dynamicConnectFields {
 			idx := form.GetFormItemIndex(connectFieldLabel(key))
@@ -265,10 +365,22 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 		form.AddInputField(connLabelPort, fieldValues[connFieldPort], 10, nil, nil)
 		form.AddInputField(connLabelUser, fieldValues[connFieldUser], 30, nil, nil)
 		form.AddPasswordField(connLabelPassword, fieldValues[connFieldPassword], 30, '*', nil)
+		form.AddInputField(connLabelPassCmd, fieldValues[connFieldPassCmd], 48, nil, nil)
 		form.AddInputField(connLabelDatabase, fieldValues[connFieldDatabase], 30, nil, nil)
-		if typeName == "PostgreSQL" {
-			form.AddInputField(connLabelSSLMode, fieldValues[connFieldSSLMode], 18, nil, nil)
-		}
+		form.AddInputField(connLabelSSLMode, fieldValues[connFieldSSLMode], 18, nil, nil)
+		form.AddInputField(connLabelTLSCA, fieldValues[connFieldTLSCA], 48, nil, nil)
+		form.AddInputField(connLabelTLSCert, fieldValues[connFieldTLSCert], 48, nil, nil)
+		form.AddInputField(connLabelTLSKey, fieldValues[connFieldTLSKey], 48, nil, nil)
+		form.AddInputField(connLabelTLSServer, fieldValues[connFieldTLSServer], 30, nil, nil)
+		// Host and Port above are resolved from the SSH host when a tunnel is set.
+		form.AddInputField(connLabelSSHHost, fieldValues[connFieldSSHHost], 30, nil, nil)
+		form.AddInputField(connLabelSSHPort, fieldValues[connFieldSSHPort], 10, nil, nil)
+		form.AddInputField(connLabelSSHUser, fieldValues[connFieldSSHUser], 30, nil, nil)
+		form.AddInputField(connLabelSSHKeyFile, fieldValues[connFieldSSHKeyFile], 48, nil, nil)
+		form.AddPasswordField(connLabelSSHPass, fieldValues[connFieldSSHPass], 30, '*', nil)
+		form.AddCheckbox(connLabelSSHAgent, fieldValues[connFieldSSHAgent] == "true", nil)
+		form.AddInputField(connLabelSSHKnown, fieldValues[connFieldSSHKnown], 48, nil, nil)
+		form.AddInputField(connLabelSSHJump, fieldValues[connFieldSSHJump], 48, nil, nil)
 	}
 
 	addSQLiteFields := func() {
@@ -279,12 +391,14 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 		// Re-use 'Host' for the Database URL
 		form.AddInputField(connLabelHost, fieldValues[connFieldHost], 60, nil, nil)
 		form.AddPasswordField(connLabelAuthToken, fieldValues[connFieldAuthToken], 60, '*', nil)
+		form.AddInputField(connLabelPassCmd, fieldValues[connFieldPassCmd], 48, nil, nil)
 	}
 
 	addD1Fields := func() {
 		form.AddInputField(connLabelAccountID, fieldValues[connFieldAccountID], 40, nil, nil)
 		form.AddInputField(connLabelDatabaseID, fieldValues[connFieldDatabaseID], 40, nil, nil)
 		form.AddPasswordField(connLabelAuthToken, fieldValues[connFieldAuthToken], 60, '*', nil)
+		form.AddInputField(connLabelPassCmd, fieldValues[connFieldPassCmd], 48, nil, nil)
 	}
 
 	_, initialTypeName := form.GetFormItemByLabel(connLabelType).(*tview.DropDown).GetCurrentOption()
@@ -303,7 +417,7 @@ func (a *App) showConnectionFormWithInitial(editConn *config.ConnectionConfig, e
 		removeDynamicFields()
 		currentTypeName = typeName
 		switch typeName {
-		case "SQLite":
+		case "SQLite", "DuckDB":
 			addSQLiteFields()
 		case "Turso":
 			addTursoFields()
@@ -540,10 +654,40 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 		AuthToken:  getText(connFieldAuthToken),
 		AccountID:  getText(connFieldAccountID),
 		DatabaseID: getText(connFieldDatabaseID),
+
+		PasswordCommand: getText(connFieldPassCmd),
+
+		SSHHost:       getText(connFieldSSHHost),
+		SSHPort:       getText(connFieldSSHPort),
+		SSHUser:       getText(connFieldSSHUser),
+		SSHPassword:   getText(connFieldSSHPass),
+		SSHKeyFile:    getText(connFieldSSHKeyFile),
+		SSHUseAgent:   formCheckboxChecked(form, connFieldSSHAgent),
+		SSHKnownHosts: getText(connFieldSSHKnown),
+		SSHJumpHosts:  getText(connFieldSSHJump),
+
+		TLSCAFile:     getText(connFieldTLSCA),
+		TLSCertFile:   getText(connFieldTLSCert),
+		TLSKeyFile:    getText(connFieldTLSKey),
+		TLSServerName: getText(connFieldTLSServer),
+
+		Environment: getText(connFieldEnv),
+		Color:       getText(connFieldEnvColor),
+
+		InitSQL:          getText(connFieldInitSQL),
+		StatementTimeout: getText(connFieldTimeout),
+	}
+	if err := cfg.ValidateEnvironment(); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v\n\nUse dev, staging, prod, or a short custom tag. Colors are #rrggbb or a name such as red.", iconWarn, err), "connectModal")
+		return nil
+	}
+	if err := cfg.ValidateSession(); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v\n\nSeparate init statements with semicolons. Timeouts look like 30s or 2m.", iconWarn, err), "connectModal")
+		return nil
 	}
 
 	// Optional network DSN: if present, parse and auto-fill individual fields.
-	if dbType != config.SQLite && dbType != config.Turso && dbType != config.CloudflareD1 {
+	if !isFileOrServiceType(dbType) {
 		if connString := getText(connFieldDSN); connString != "" {
 			parsedCfg, err := parseConnectionString(dbType, connString)
 			if err != nil {
@@ -573,6 +717,7 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 			if parsedCfg.SSLMode != "" {
 				cfg.SSLMode = parsedCfg.SSLMode
 			}
+			applyParsedTLSFields(form, cfg, parsedCfg)
 		}
 	}
 
@@ -591,6 +736,22 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 				return nil
 			}
 		}
+	case config.DuckDB:
+		if cfg.FilePath == "" {
+			a.ShowAlert(fmt.Sprintf("%s File path is required for DuckDB.\n\nExample: /home/user/warehouse.duckdb\nParquet, CSV, and JSON files open as a read-only view.", iconInfo), "connectModal")
+			return nil
+		}
+		if duckdbsql.IsDataFile(cfg.FilePath) {
+			if _, err := os.Stat(cfg.FilePath); err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not open data file:\n%v", iconWarn, err), "connectModal")
+				return nil
+			}
+			cfg.ReadOnly = true
+		}
+		if _, err := duckdbsql.LookPath(); err != nil {
+			a.ShowAlert(fmt.Sprintf("%s DuckDB needs its command-line client:\n\n%v", iconWarn, err), "connectModal")
+			return nil
+		}
 	case config.Turso:
 		if cfg.Host == "" {
 			a.ShowAlert(fmt.Sprintf("%s Database URL is required for Turso.\n\nExample: libsql://mydb-user.turso.io", iconInfo), "connectModal")
@@ -599,8 +760,8 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 		// Auth token is usually required for remote, but maybe not for local dev?
 		// We'll leave it optional in validation but robust in practice.
 	case config.CloudflareD1:
-		if cfg.AccountID == "" || cfg.DatabaseID == "" || cfg.AuthToken == "" {
-			a.ShowAlert(fmt.Sprintf("%s Account ID, Database ID, and API Token are required for D1.", iconInfo), "connectModal")
+		if cfg.AccountID == "" || cfg.DatabaseID == "" || (cfg.AuthToken == "" && cfg.PasswordCommand == "") {
+			a.ShowAlert(fmt.Sprintf("%s Account ID, Database ID, and an API Token or Password Command are required for D1.", iconInfo), "connectModal")
 			return nil
 		}
 	default:
@@ -611,10 +772,23 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 		if cfg.User == "" {
 			missing = append(missing, "User")
 		}
+		if cfg.SSHHost != "" && cfg.SSHUser == "" {
+			missing = append(missing, "SSH User")
+		}
 		if len(missing) > 0 {
 			a.ShowAlert(fmt.Sprintf("%s Required fields missing:\n\n• %s\n\nFill these to connect to %s.", iconInfo, strings.Join(missing, "\n• "), typeName), "connectModal")
 			return nil
 		}
+		if cfg.SSHHost != "" {
+			if _, err := sshtunnel.ParseJumpHosts(cfg.SSHJumpHosts, cfg.SSHUser); err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Invalid SSH jump hosts:\n\n%v", iconWarn, err), "connectModal")
+				return nil
+			}
+		}
+		if err := cfg.ValidateTLS(); err != nil {
+			a.ShowAlert(fmt.Sprintf("%s Invalid TLS settings:\n\n%v", iconWarn, err), "connectModal")
+			return nil
+		}
 		// Default port
 		if cfg.Port == "" {
 			switch dbType {
@@ -629,6 +803,16 @@ func (a *App) buildConfigFromForm(form *tview.Form) *config.ConnectionConfig {
 	return cfg
 }
 
+// isFileOrServiceType reports whether dbType is configured without a network
+// DSN, host, or credentials form.
+func isFileOrServiceType(dbType config.DBType) bool {
+	switch dbType {
+	case config.SQLite, config.Turso, config.CloudflareD1, config.DuckDB:
+		return true
+	}
+	return false
+}
+
 func dbTypeFromName(typeName string) config.DBType {
 	switch typeName {
 	case "PostgreSQL":
@@ -641,6 +825,8 @@ func dbTypeFromName(typeName string) config.DBType {
 		return config.Turso
 	case "Cloudflare D1":
 		return config.CloudflareD1
+	case "DuckDB":
+		return config.DuckDB
 	default:
 		return config.PostgreSQL
 	}
@@ -659,6 +845,8 @@ func connectFooterText(width int, dbType config.DBType) string {
 		return fmt.Sprintf(" [yellow]Tab[-] Navigate  │  [yellow]Esc[-] Back %s  │  [gray]Turso: URL + Auth Token[-]", iconBack)
 	case config.CloudflareD1:
 		return fmt.Sprintf(" [yellow]Tab[-] Navigate  │  [yellow]Esc[-] Back %s  │  [gray]D1: Account ID + DB ID + Token[-]", iconBack)
+	case config.DuckDB:
+		return fmt.Sprintf(" [yellow]Tab[-] Navigate  │  [yellow]Esc[-] Back %s  │  [gray]DuckDB: .duckdb, .parquet, .csv, or .json path[-]", iconBack)
 	default:
 		switch {
 		case width < 78:
@@ -670,7 +858,7 @@ func connectFooterText(width int, dbType config.DBType) string {
 }
 
 func formInputValue(form *tview.Form, key connectFieldKey) string {
-	if key == connFieldPassword {
+	if key == connFieldPassword || key == connFieldSSHPass {
 		item := form.GetFormItemByLabel(connectFieldLabel(key))
 		if input, ok := item.(*tview.InputField); ok {
 			// Passwords are opaque credentials; surrounding spaces may be part of
@@ -690,6 +878,13 @@ func formInputValueByLabel(form *tview.Form, label string) string {
 	return ""
 }
 
+func formBoolValue(value bool) string {
+	if value {
+		return "true"
+	}
+	return ""
+}
+
 func formCheckboxChecked(form *tview.Form, key connectFieldKey) bool {
 	item := form.GetFormItemByLabel(connectFieldLabel(key))
 	if checkbox, ok := item.(*tview.Checkbox); ok {
@@ -723,7 +918,7 @@ func (a *App) applyConnectionStringToForm(form *tview.Form) (*config.ConnectionC
 
 	_, typeName := typeDropDown.GetCurrentOption()
 	dbType := dbTypeFromName(typeName)
-	if dbType == config.SQLite || dbType == config.Turso || dbType == config.CloudflareD1 {
+	if isFileOrServiceType(dbType) {
 		return nil, fmt.Errorf("this database type does not support DSN parsing here")
 	}
 
@@ -738,9 +933,36 @@ func (a *App) applyConnectionStringToForm(form *tview.Form) (*config.ConnectionC
 	setFormInputValue(form, connFieldPassword, parsedCfg.Password)
 	setFormInputValue(form, connFieldDatabase, parsedCfg.Database)
 	setFormInputValue(form, connFieldSSLMode, parsedCfg.SSLMode)
+	applyParsedTLSFields(form, nil, parsedCfg)
 	return parsedCfg, nil
 }
 
+// applyParsedTLSFields copies certificate paths found in a DSN into the form
+// and, when cfg is set, into the profile being built.
+func applyParsedTLSFields(form *tview.Form, cfg, parsed *config.ConnectionConfig) {
+	var target config.ConnectionConfig
+	if cfg != nil {
+		target = *cfg
+	}
+	for _, field := range []struct {
+		key   connectFieldKey
+		value string
+		dst   *string
+	}{
+		{connFieldTLSCA, parsed.TLSCAFile, &target.TLSCAFile},
+		{connFieldTLSCert, parsed.TLSCertFile, &target.TLSCertFile},
+		{connFieldTLSKey, parsed.TLSKeyFile, &target.TLSKeyFile},
+	} {
+		if field.value != "" {
+			setFormInputValue(form, field.key, field.value)
+			*field.dst = field.value
+		}
+	}
+	if cfg != nil {
+		*cfg = target
+	}
+}
+
 func parseConnectionString(dbType config.DBType, connString string) (*config.ConnectionConfig, error) {
 	switch dbType {
 	case config.PostgreSQL:
@@ -810,6 +1032,10 @@ func parsePostgresURL(connString string) (*config.ConnectionConfig, error) {
 		Password: password,
 		Database: database,
 		SSLMode:  u.Query().Get("sslmode"),
+
+		TLSCAFile:   u.Query().Get("sslrootcert"),
+		TLSCertFile: u.Query().Get("sslcert"),
+		TLSKeyFile:  u.Query().Get("sslkey"),
 	}, nil
 }
 
@@ -853,6 +1079,10 @@ func parsePostgresKeyValueDSN(connString string) (*config.ConnectionConfig, erro
 		Password: values["password"],
 		Database: database,
 		SSLMode:  values["sslmode"],
+
+		TLSCAFile:   values["sslrootcert"],
+		TLSCertFile: values["sslcert"],
+		TLSKeyFile:  values["sslkey"],
 	}, nil
 }
 
@@ -937,6 +1167,9 @@ func (a *App) connectWithConfig(cfg *config.ConnectionConfig, storeIndex int) {
 		a.browseDatabasesForSavedConnection(storeIndex)
 		return
 	}
+	if a.confirmEndTransaction("Switching connections", func() { a.connectWithConfig(cfg, storeIndex) }) {
+		return
+	}
 	loadingToken := a.showLoadingModal(fmt.Sprintf("%s Connecting to %s...", iconConnect, cfg.Name))
 	failureReturnPage := "dashboard"
 	if a.pages.HasPage("connectModal") {
@@ -974,6 +1207,7 @@ func (a *App) connectWithConfig(cfg *config.ConnectionConfig, storeIndex int) {
 			a.dbType = cfg.Type
 			a.dbName = cfg.Name
 			a.activeConn = cloneConnectionConfig(cfg)
+			a.refreshEnvironmentChrome()
 
 			if storeIndex >= 0 {
 				if err := a.store.MarkUsed(storeIndex); err != nil {
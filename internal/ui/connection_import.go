@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/connimport"
+)
+
+const (
+	pageConnectionImport        = "connectionImport"
+	pageConnectionImportPreview = "connectionImportPreview"
+
+	paletteActionImportConnections keymapAction = "palette_import_connections"
+
+	connectionImportLabelSource = "Import From"
+	connectionImportLabelFile   = "File"
+)
+
+// showConnectionImport asks which tool and file to import connections from.
+// Nothing is saved until the preview is confirmed.
+func (a *App) showConnectionImport(returnPage string) {
+	labels := make([]string, 0, len(connimport.Sources))
+	for _, source := range connimport.Sources {
+		labels = append(labels, source.Label())
+	}
+	source := connimport.Sources[0]
+	suggested := connimport.DefaultPath(source)
+
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(" Import Connections ").
+		SetTitleColor(mauve).
+		SetBorderColor(surface1)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(green).
+		SetLabelColor(text).
+		SetFieldTextColor(text)
+
+	hint := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	hint.SetBackgroundColor(crust)
+	showHint := func() {
+		hint.SetText(fmt.Sprintf(" [#a6adc8]Expects %s[-]\n [#6c7086]Passwords are imported only when the file contains them.[-]", tview.Escape(source.FileHint())))
+	}
+	showHint()
+
+	form.AddDropDown(connectionImportLabelSource, labels, 0, func(_ string, index int) {
+		if index < 0 || index >= len(connimport.Sources) {
+			return
+		}
+		source = connimport.Sources[index]
+		showHint()
+		// Follow the selected tool's default unless a path was typed.
+		if input, ok := form.GetFormItemByLabel(connectionImportLabelFile).(*tview.InputField); ok && input.GetText() == suggested {
+			suggested = connimport.DefaultPath(source)
+			input.SetText(suggested)
+		}
+	})
+	form.AddInputField(connectionImportLabelFile, suggested, 72, nil, nil)
+
+	closeForm := func() {
+		a.pages.RemovePage(pageConnectionImport)
+		a.pages.ShowPage(returnPage)
+	}
+	form.AddButton("Preview", func() {
+		path, err := expandHomePath(strings.TrimSpace(formInputValueByLabel(form, connectionImportLabelFile)))
+		if err != nil || path == "" {
+			a.ShowAlert(fmt.Sprintf("%s Enter the %s file to import.", iconInfo, source.Label()), pageConnectionImport)
+			return
+		}
+		result, err := connimport.ReadFile(source, filepath.Clean(path))
+		if err != nil {
+			a.ShowAlert(fmt.Sprintf("%s %s", iconWarn, tview.Escape(err.Error())), pageConnectionImport)
+			return
+		}
+		a.showConnectionImportPreview(result, returnPage)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(hint, 2, 0, false)
+	modalW, modalH := a.modalSize(64, 100, 11, 13)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+
+	a.pages.AddPage(pageConnectionImport, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+// showConnectionImportPreview lists what the import would add and skip and
+// saves the new profiles only after Import is chosen.
+func (a *App) showConnectionImportPreview(result connimport.Result, returnPage string) {
+	added, skipped := connimport.Plan(a.store.Connections, result.Connections)
+
+	preview := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	preview.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s Preview: %d new, %d already saved ", tview.Escape(result.Source.Label()), len(added), len(skipped))).
+		SetTitleColor(mauve).
+		SetBorderColor(surface1)
+	preview.SetBackgroundColor(bg)
+	preview.SetText(connectionImportPreviewText(added, skipped, result.Warnings))
+
+	closePreview := func() {
+		a.pages.RemovePage(pageConnectionImportPreview)
+		a.pages.ShowPage(pageConnectionImport)
+	}
+	buttons := tview.NewForm()
+	buttons.SetBackgroundColor(bg)
+	buttons.SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	buttons.SetButtonsAlign(tview.AlignCenter)
+	if len(added) > 0 {
+		buttons.AddButton(fmt.Sprintf("Import %d", len(added)), func() {
+			if err := a.store.AddAll(added); err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not save imported connections:\n\n%v", iconFail, err), pageConnectionImportPreview)
+				return
+			}
+			a.pages.RemovePage(pageConnectionImportPreview)
+			a.pages.RemovePage(pageConnectionImport)
+			if returnPage == "dashboard" {
+				a.pages.RemovePage("dashboard")
+				a.showDashboard()
+			}
+			a.ShowAlert(fmt.Sprintf("%s Imported %d connection(s) from %s.\n\nPress E on the Dashboard to add any missing passwords.", iconSuccess, len(added), result.Source.Label()), returnPage)
+		})
+	}
+	buttons.AddButton("Back", closePreview)
+
+	capture := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closePreview()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if preview.HasFocus() {
+				a.app.SetFocus(buttons)
+			} else {
+				a.app.SetFocus(preview)
+			}
+			return nil
+		}
+		return event
+	}
+	preview.SetInputCapture(capture)
+	buttons.SetInputCapture(capture)
+
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(preview, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	modalW, modalH := a.modalSize(72, 120, 14, 32)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+
+	a.pages.AddPage(pageConnectionImportPreview, grid, true, true)
+	a.app.SetFocus(buttons)
+}
+
+func connectionImportPreviewText(added, skipped []config.ConnectionConfig, warnings []string) string {
+	var text strings.Builder
+	for _, connection := range added {
+		fmt.Fprintf(&text, " [green]+[-] %-28s [#a6adc8]%-10s %s[-]\n",
+			tview.Escape(connection.Name), connection.TypeLabel(), tview.Escape(connectionImportEndpoint(connection)))
+	}
+	for _, connection := range skipped {
+		fmt.Fprintf(&text, " [#6c7086]= %-28s already saved[-]\n", tview.Escape(connection.Name))
+	}
+	if len(added) == 0 && len(skipped) == 0 {
+		text.WriteString(" [#6c7086]No supported connections were found in this file.[-]\n")
+	}
+	if len(warnings) > 0 {
+		text.WriteString("\n")
+		for _, warning := range warnings {
+			fmt.Fprintf(&text, " [yellow]![-] %s\n", tview.Escape(warning))
+		}
+	}
+	return text.String()
+}
+
+func connectionImportEndpoint(cfg config.ConnectionConfig) string {
+	switch cfg.Type {
+	case config.SQLite, config.DuckDB:
+		return cfg.FilePath
+	case config.Turso:
+		return cfg.Host
+	}
+	endpoint := cfg.Host + ":" + cfg.Port
+	if cfg.Database != "" {
+		endpoint += "/" + cfg.Database
+	}
+	if cfg.User != "" {
+		endpoint = cfg.User + "@" + endpoint
+	}
+	if cfg.SSHHost != "" {
+		endpoint += " via " + cfg.SSHHost
+	}
+	return endpoint
+}
@@ -2,7 +2,6 @@ package ui
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"strings"
@@ -12,6 +11,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
 )
 
 // showDashboard displays the saved connections landing page
@@ -26,8 +26,11 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 		SetTextAlign(tview.AlignCenter)
 	header.SetBackgroundColor(bg)
 
-	connCount := len(a.store.Connections)
-	connections := append([]config.ConnectionConfig(nil), a.store.Connections...)
+	// Project workspace connections follow the saved ones; indexes at or
+	// past savedCount are read-only and never reach the store.
+	savedCount := len(a.store.Connections)
+	connections := append(append([]config.ConnectionConfig(nil), a.store.Connections...), a.projectConnections()...)
+	connCount := len(connections)
 
 	// Get quick service status for the header
 	mysqlStatus := getQuickStatus("mysql")
@@ -54,7 +57,11 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 
 	baseDetails := make([]string, connCount)
 	if connCount > 0 {
-		connList.SetTitle(fmt.Sprintf(" %s Saved Connections (%d) ", iconDashboard, connCount))
+		connList.SetTitle(fmt.Sprintf(" %s Saved Connections (%d) ", iconDashboard, savedCount))
+		if connCount > savedCount {
+			connList.SetTitle(fmt.Sprintf(" %s Saved Connections (%d) · %s (%d) ", iconDashboard, savedCount,
+				tview.Escape(a.projectWorkspace.DisplayName()), connCount-savedCount))
+		}
 
 		healthStatusText := "[#6c7086]" + iconRefresh + " checking[-]"
 		if !forceHealthChecks && a.settings != nil && a.settings.DashboardHealthChecks == "manual" {
@@ -63,8 +70,15 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 
 		for i, conn := range connections {
 			label := dashboardConnectionLabel(conn)
+			if i >= savedCount {
+				label += "  [#f5c2e7]project[-]"
+			}
 			detail := dashboardConnectionDetail(conn)
 			baseDetails[i] = detail
+			status := healthStatusText
+			if i >= savedCount && !forceHealthChecks && !a.projectWorkspaceTrusted() {
+				status = "[#6c7086]press R to check[-]"
+			}
 
 			// Shortcut key: 1-9 for first 9, then 0 for 10th, then none
 			var shortcut rune
@@ -74,12 +88,12 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 				shortcut = '0'
 			}
 
-			connList.AddItem(label, detail+"  │  "+healthStatusText, shortcut, nil)
+			connList.AddItem(label, detail+"  │  "+status, shortcut, nil)
 		}
-		a.runDashboardConnectionChecks(connList, connections, baseDetails, forceHealthChecks)
+		a.runDashboardConnectionChecks(connList, connections, savedCount, baseDetails, forceHealthChecks)
 	} else {
 		connList.SetTitle(fmt.Sprintf(" %s Saved Connections ", iconDashboard))
-		connList.AddItem(fmt.Sprintf("  [#6c7086]%s No saved connections yet[-]", iconInfo), "       Press [green]N[-] to add your first database "+iconConnect, 0, nil)
+		connList.AddItem(fmt.Sprintf("  [#6c7086]%s No saved connections yet[-]", iconInfo), "       Press [green]N[-] to add your first database "+iconConnect+" or [green]M[-] to import them from DBeaver, pgAdmin, TablePlus, or docker-compose", 0, nil)
 	}
 
 	// ── Footer Actions ──
@@ -135,6 +149,10 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 				a.ShowAlert(fmt.Sprintf("%s Highlight a saved connection first.", iconInfo), "dashboard")
 				return
 			}
+			if index >= savedCount {
+				a.showProjectConnectionReadOnly(connections[index], "scheduled")
+				return
+			}
 			connection := a.store.Connections[index]
 			a.showBackupCenter()
 			if a.pages.HasPage(pageBackupCenter) {
@@ -154,7 +172,7 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 				return
 			}
 
-			cfg := a.store.Connections[idx]
+			cfg := connections[idx]
 			a.showImportModalForConnection(&cfg, "dashboard")
 		}
 
@@ -188,14 +206,18 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 					return nil
 				}
 				idx := connList.GetCurrentItem()
-				if idx >= 0 && idx < connCount {
+				if idx >= savedCount && idx < connCount {
+					a.showProjectConnectionReadOnly(connections[idx], "extended")
+				} else if idx >= 0 && idx < connCount {
 					a.browseDatabasesForSavedConnection(idx)
 				}
 				return nil
 			case 'e', 'E':
 				if connCount > 0 {
 					idx := connList.GetCurrentItem()
-					if idx >= 0 && idx < connCount {
+					if idx >= savedCount && idx < connCount {
+						a.showProjectConnectionReadOnly(connections[idx], "edited")
+					} else if idx >= 0 && idx < connCount {
 						conn := a.store.Connections[idx]
 						a.showConnectionForm(&conn, idx)
 					}
@@ -206,14 +228,21 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 			case 'd', 'D':
 				if connCount > 0 {
 					idx := connList.GetCurrentItem()
-					if idx >= 0 && idx < connCount {
+					if idx >= savedCount && idx < connCount {
+						a.showProjectConnectionReadOnly(connections[idx], "deleted")
+					} else if idx >= 0 && idx < connCount {
 						a.confirmDelete(idx)
 					}
 				}
 				return nil
 			case 'q', 'Q':
-				a.cleanup()
-				a.app.Stop()
+				quit := func() {
+					a.cleanup()
+					a.app.Stop()
+				}
+				if !a.confirmEndTransaction("Quitting", quit) {
+					quit()
+				}
 				return nil
 			case 'h', 'H':
 				a.showHelp()
@@ -243,12 +272,18 @@ func (a *App) showDashboardWithHealthChecks(forceHealthChecks bool) {
 			case 'i', 'I':
 				importSelectedConnection()
 				return nil
+			case 'm', 'M':
+				a.showConnectionImport("dashboard")
+				return nil
 			}
 		}
 
 		if event.Key() == tcell.KeyEnter && connCount > 0 {
 			idx := connList.GetCurrentItem()
-			if idx >= 0 && idx < connCount {
+			if idx >= savedCount && idx < connCount {
+				conn := connections[idx]
+				a.connectWithConfig(&conn, -1)
+			} else if idx >= 0 && idx < connCount {
 				a.connectToSaved(idx)
 			}
 			return nil
@@ -361,10 +396,15 @@ func dashboardConnectionLabel(conn config.ConnectionConfig) string {
 		typeTag = "[#f9e2af]MY[-]"
 	case config.SQLite:
 		typeTag = "[#a6e3a1]SL[-]"
+	case config.DuckDB:
+		typeTag = "[#f9e2af]DK[-]"
 	default:
 		typeTag = "[#6c7086]DB[-]"
 	}
 
+	if tag := environmentTag(conn); tag != "" {
+		typeTag += " " + tag
+	}
 	return fmt.Sprintf(" %s  %s  %s %s  %s", statusIcon, typeTag, iconConnect, tview.Escape(conn.Name), activity)
 }
 
@@ -373,6 +413,8 @@ func dashboardConnectionDetail(conn config.ConnectionConfig) string {
 	switch conn.Type {
 	case config.SQLite:
 		detail = fmt.Sprintf("       Local file  ›  %s", tview.Escape(conn.FilePath))
+	case config.DuckDB:
+		detail = fmt.Sprintf("       DuckDB file  ›  %s", tview.Escape(conn.FilePath))
 	case config.Turso:
 		detail = fmt.Sprintf("       Turso cloud  ›  %s", tview.Escape(conn.Host))
 	case config.CloudflareD1:
@@ -432,16 +474,15 @@ func dashboardConnectionReachableCached(conn config.ConnectionConfig, timeout ti
 	return reachable
 }
 
-func (a *App) runDashboardConnectionChecks(connList *tview.List, conns []config.ConnectionConfig, baseDetails []string, force bool) {
-	if len(conns) == 0 {
+func (a *App) runDashboardConnectionChecks(connList *tview.List, conns []config.ConnectionConfig, savedCount int, baseDetails []string, force bool) {
+	if !force && a.settings != nil && a.settings.DashboardHealthChecks == "manual" {
 		return
 	}
 
-	if !force && a.settings != nil && a.settings.DashboardHealthChecks == "manual" {
+	checkLimit := dashboardAutoCheckCount(len(conns), savedCount, force || a.projectWorkspaceTrusted())
+	if checkLimit == 0 {
 		return
 	}
-
-	checkLimit := len(conns)
 	if !force && len(conns) > 50 {
 		_, screenH := a.getScreenSize()
 		visibleRows := screenH - 10
@@ -506,9 +547,20 @@ func (a *App) runDashboardConnectionChecks(connList *tview.List, conns []config.
 	}()
 }
 
+// dashboardAutoCheckCount returns how many leading connections may be
+// dialed. Workspace connections follow the saved ones and are only checked
+// automatically once the workspace is trusted; a discovered file could point
+// them at any host.
+func dashboardAutoCheckCount(total, savedCount int, projectTrusted bool) int {
+	if projectTrusted {
+		return total
+	}
+	return min(total, savedCount)
+}
+
 func dashboardConnectionReachable(conn config.ConnectionConfig, timeout time.Duration) bool {
 	switch conn.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		if conn.FilePath == "" {
 			return false
 		}
@@ -517,13 +569,11 @@ func dashboardConnectionReachable(conn config.ConnectionConfig, timeout time.Dur
 		}
 		return true
 	default:
-		driver := conn.DriverName()
-		dsn := conn.BuildConnString()
-		if driver == "" || dsn == "" {
+		if conn.DriverName() == "" || conn.BuildConnString() == "" {
 			return false
 		}
 
-		db, err := sql.Open(driver, dsn)
+		db, err := database.Open(&conn)
 		if err != nil {
 			return false
 		}
@@ -561,6 +611,6 @@ func dashboardFooterText(hasConnections, hasWorkspace bool, width int, paletteSh
 		return footerTextThatFits(width, full, short, minimal)
 	}
 	short := fmt.Sprintf("  [yellow]N[-] New  │  [green]B[-] Backups  │  [teal]H[-] Guide %s  │  [#cba6f7]Q[-] Quit", iconHelp)
-	full := fmt.Sprintf("  [yellow]N[-] New Connection  │  [green]B[-] Backups  │  [#94e2d5]S[-] Services %s  │  [yellow]%s[-] Palette  │  [yellow]G[-] Settings  │  [yellow]U[-] Update  │  [teal]H[-] Guide %s  │  [#cba6f7]Q[-] Quit", iconServices, paletteShortcut, iconHelp)
+	full := fmt.Sprintf("  [yellow]N[-] New Connection  │  [yellow]M[-] Import from tools  │  [green]B[-] Backups  │  [#94e2d5]S[-] Services %s  │  [yellow]%s[-] Palette  │  [yellow]G[-] Settings  │  [yellow]U[-] Update  │  [teal]H[-] Guide %s  │  [#cba6f7]Q[-] Quit", iconServices, paletteShortcut, iconHelp)
 	return footerTextThatFits(width, full, short, minimal)
 }
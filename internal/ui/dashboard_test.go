@@ -51,3 +51,17 @@ func TestDashboardConnectionDetailShowsDatabaseInsideScope(t *testing.T) {
 		t.Fatalf("server-only detail does not explain database choice: %q", server)
 	}
 }
+
+func TestDashboardSkipsAutoChecksForUntrustedWorkspace(t *testing.T) {
+	discovered := &App{projectWorkspace: &config.Workspace{}}
+	if got := dashboardAutoCheckCount(5, 3, discovered.projectWorkspaceTrusted()); got != 3 {
+		t.Fatalf("discovered workspace checks %d connections, want only the 3 saved ones", got)
+	}
+	if got := dashboardAutoCheckCount(5, 3, true); got != 5 {
+		t.Fatalf("forced or trusted checks %d connections, want 5", got)
+	}
+	explicit := &App{projectWorkspace: &config.Workspace{Trusted: true}}
+	if !explicit.projectWorkspaceTrusted() || !(&App{}).projectWorkspaceTrusted() {
+		t.Fatal("a --workspace file or no workspace should allow automatic checks")
+	}
+}
@@ -0,0 +1,1007 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/dataimport"
+)
+
+const (
+	pageDataImport         = "dataImport"
+	pageDataImportMapping  = "dataImportMapping"
+	pageDataImportProgress = "dataImportProgress"
+	pageDataImportReport   = "dataImportReport"
+
+	dataImportSampleRows    = 500
+	dataImportMaxBatchRows  = 500
+	dataImportProgressStep  = 250
+	dataImportFailureLimit  = 50
+	dataImportSkipColumn    = "(skip)"
+	dataImportNewTable      = "New table"
+	dataImportSavepointName = "dbterm_import_row"
+)
+
+var (
+	dataImportFormatOptions = []struct {
+		label  string
+		format dataimport.Format
+	}{{"Detect", ""}, {"CSV", dataimport.FormatCSV}, {"JSON array", dataimport.FormatJSON}, {"NDJSON / JSON lines", dataimport.FormatNDJSON}}
+	dataImportDelimiterOptions = []struct {
+		label     string
+		delimiter rune
+	}{{"Detect", 0}, {"Comma ,", ','}, {"Semicolon ;", ';'}, {"Tab", '\t'}, {"Pipe |", '|'}}
+	dataImportHeaderOptions = []struct {
+		label string
+		mode  dataimport.HeaderMode
+	}{{"Detect", dataimport.HeaderAuto}, {"First row is a header", dataimport.HeaderPresent}, {"No header row", dataimport.HeaderAbsent}}
+)
+
+// dataImportColumn maps one file column onto a table column.
+type dataImportColumn struct {
+	source  int
+	name    string
+	kind    dataimport.Kind
+	notNull bool
+}
+
+// dataImportPlan is everything a run needs; create adds the table first.
+type dataImportPlan struct {
+	preview *dataimport.Preview
+	dbType  config.DBType
+	table   string
+	create  bool
+	columns []dataImportColumn
+}
+
+type dataImportFailure struct {
+	row     int
+	message string
+}
+
+// dataImportOutcome counts rows read and rows imported, or for a dry run the
+// rows that would import. imported stays zero after a rollback.
+type dataImportOutcome struct {
+	rows          int
+	imported      int
+	failed        int
+	failures      []dataImportFailure
+	databaseCheck bool
+}
+
+// showDataImport opens the first wizard step: the file, how to read it, and
+// the target table.
+func (a *App) showDataImport() {
+	if a.db == nil {
+		a.ShowAlert(fmt.Sprintf("%s Connect to a database before importing a data file.", iconInfo), "main")
+		return
+	}
+	if a.activeConn != nil && a.activeConn.ReadOnly {
+		a.ShowAlert(fmt.Sprintf("%s Read-Only Guard is on for \"%s\".\n\nImporting data is disabled for read-only profiles.", iconWarn, tview.Escape(a.dbName)), "main")
+		return
+	}
+	if a.isImportRunning() {
+		a.ShowAlert(fmt.Sprintf("%s Another import is already running.\n\nWait for it to finish or cancel it first.", iconInfo), "main")
+		return
+	}
+	if a.transactionBlocksWrite("importing data") {
+		return
+	}
+
+	returnFocus := a.app.GetFocus()
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(" Import Data File ").SetTitleColor(mauve).SetBorderColor(surface1)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+
+	pathInput := tview.NewInputField().SetLabel("File").SetFieldWidth(72).
+		SetPlaceholder("/path/to/data.csv, .json, .ndjson").SetPlaceholderTextColor(overlay0)
+	formatInput := tview.NewDropDown().SetLabel("Format")
+	for _, option := range dataImportFormatOptions {
+		formatInput.AddOption(option.label, nil)
+	}
+	formatInput.SetCurrentOption(0)
+	delimiterInput := tview.NewDropDown().SetLabel("CSV delimiter")
+	for _, option := range dataImportDelimiterOptions {
+		delimiterInput.AddOption(option.label, nil)
+	}
+	delimiterInput.SetCurrentOption(0)
+	headerInput := tview.NewDropDown().SetLabel("CSV header")
+	for _, option := range dataImportHeaderOptions {
+		headerInput.AddOption(option.label, nil)
+	}
+	headerInput.SetCurrentOption(0)
+
+	targets := append([]string{dataImportNewTable}, a.tableOrder...)
+	targetIndex := 0
+	if a.isTableResultActive() {
+		for index, table := range targets {
+			if index > 0 && table == a.selectedTable {
+				targetIndex = index
+			}
+		}
+	}
+	targetInput := tview.NewDropDown().SetLabel("Target table").SetOptions(targets, nil).SetCurrentOption(targetIndex)
+	nameInput := tview.NewInputField().SetLabel("New table name").SetFieldWidth(40).
+		SetPlaceholder("defaults to the file name").SetPlaceholderTextColor(overlay0)
+	form.AddFormItem(pathInput).AddFormItem(formatInput).AddFormItem(delimiterInput).AddFormItem(headerInput).
+		AddFormItem(targetInput).AddFormItem(nameInput)
+
+	closeForm := func() {
+		a.pages.RemovePage(pageDataImport)
+		a.restoreResultExportFocus(returnFocus)
+	}
+	next := func() {
+		path, err := expandHomePath(strings.TrimSpace(pathInput.GetText()))
+		if err == nil && path == "" {
+			err = fmt.Errorf("a file path is required")
+		}
+		if err != nil {
+			a.ShowAlert(fmt.Sprintf("%s %v", iconWarn, err), pageDataImport)
+			return
+		}
+		formatIndex, _ := formatInput.GetCurrentOption()
+		delimiterIndex, _ := delimiterInput.GetCurrentOption()
+		headerIndex, _ := headerInput.GetCurrentOption()
+		options := dataimport.Options{
+			Format:    dataImportFormatOptions[max(0, formatIndex)].format,
+			Delimiter: dataImportDelimiterOptions[max(0, delimiterIndex)].delimiter,
+			Header:    dataImportHeaderOptions[max(0, headerIndex)].mode,
+		}
+		_, target := targetInput.GetCurrentOption()
+		create := target == dataImportNewTable
+		if create {
+			target = strings.TrimSpace(nameInput.GetText())
+			if target == "" {
+				target = dataImportTableName(path)
+			}
+			for _, existing := range a.tableOrder {
+				if strings.EqualFold(existing, target) {
+					a.ShowAlert(fmt.Sprintf("%s A table named %s already exists.\n\nChoose it as the target table, or enter another name.", iconWarn, tview.Escape(existing)), pageDataImport)
+					return
+				}
+			}
+		}
+		a.pages.RemovePage(pageDataImport)
+		a.inspectDataImport(path, options, target, create, returnFocus)
+	}
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			next()
+		}
+	})
+	form.AddButton("Next", next)
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	modalW, modalH := a.modalSize(72, 100, 17, 19)
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter).
+		SetText(footerTextThatFits(modalW,
+			" [yellow]Tab / Shift+Tab[-] Move  │  [yellow]Enter[-] Choose / next  │  [yellow]Esc[-] Cancel ",
+			" [yellow]Enter[-] Next  │  [yellow]Esc[-] Cancel ",
+		))
+	footer.SetBackgroundColor(crust)
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageDataImport, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+// inspectDataImport samples the file and, for an existing table, loads its
+// columns before showing the mapping step.
+func (a *App) inspectDataImport(path string, options dataimport.Options, table string, create bool, returnFocus tview.Primitive) {
+	db := a.db
+	dbType := a.dbType
+	namespace := a.defaultObjectNamespace("")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Reading %s...", filepath.Base(path)),
+		withLoadingCancel("Press Esc to cancel.", func() {
+			canceled.Store(true)
+			cancel()
+		}),
+	)
+
+	go func() {
+		defer cancel()
+		preview, err := dataimport.Inspect(path, options, dataImportSampleRows)
+		var columns []sidebarColumnMeta
+		if err == nil && !create {
+			columns, err = loadSidebarColumnMetadata(ctx, db, dbType, table, namespace)
+			if err == nil && len(columns) == 0 {
+				err = fmt.Errorf("no columns found in %s", table)
+			}
+		}
+		a.queueUpdateDraw(func() {
+			if canceled.Load() || a.db != db || !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not prepare the import:\n\n%v", iconWarn, err), "main")
+				return
+			}
+			a.showDataImportMapping(dataImportPlan{preview: preview, dbType: dbType, table: table, create: create}, columns, returnFocus)
+		})
+	}()
+}
+
+// showDataImportMapping lets the user map file columns to table columns, or
+// name and type the columns of a new table.
+func (a *App) showDataImportMapping(plan dataImportPlan, tableColumns []sidebarColumnMeta, returnFocus tview.Primitive) {
+	form := tview.NewForm()
+	form.SetItemPadding(0)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+
+	kindLabels := make([]string, len(dataimport.Kinds))
+	for index, kind := range dataimport.Kinds {
+		kindLabels[index] = string(kind)
+	}
+	targetLabels := []string{dataImportSkipColumn}
+	for _, column := range tableColumns {
+		targetLabels = append(targetLabels, column.name)
+	}
+	targetInputs := make([]*tview.DropDown, len(plan.preview.Columns))
+	nameInputs := make([]*tview.InputField, len(plan.preview.Columns))
+	kindInputs := make([]*tview.DropDown, len(plan.preview.Columns))
+	for index, column := range plan.preview.Columns {
+		label := truncateForDisplay(column.Name, 24)
+		if plan.create {
+			nameInputs[index] = tview.NewInputField().SetLabel(label).SetText(dataImportColumnName(column.Name)).SetFieldWidth(32)
+			kindInputs[index] = tview.NewDropDown().SetLabel("  type").SetOptions(kindLabels, nil).
+				SetCurrentOption(dataImportKindIndex(column.Kind))
+			form.AddFormItem(nameInputs[index]).AddFormItem(kindInputs[index])
+			continue
+		}
+		targetInputs[index] = tview.NewDropDown().SetLabel(fmt.Sprintf("%s (%s)", label, column.Kind)).
+			SetOptions(targetLabels, nil).SetCurrentOption(dataImportMatchColumn(column.Name, tableColumns) + 1)
+		form.AddFormItem(targetInputs[index])
+	}
+
+	closeMapping := func() {
+		a.pages.RemovePage(pageDataImportMapping)
+		a.restoreResultExportFocus(returnFocus)
+	}
+	buildPlan := func() (dataImportPlan, bool) {
+		mapped := plan
+		mapped.columns = nil
+		var err error
+		if plan.create {
+			for index := range plan.preview.Columns {
+				kindIndex, _ := kindInputs[index].GetCurrentOption()
+				mapped.columns = append(mapped.columns, dataImportColumn{
+					source: index,
+					name:   strings.TrimSpace(nameInputs[index].GetText()),
+					kind:   dataimport.Kinds[max(0, kindIndex)],
+				})
+			}
+		} else {
+			for index := range plan.preview.Columns {
+				targetIndex, _ := targetInputs[index].GetCurrentOption()
+				if targetIndex <= 0 {
+					continue
+				}
+				column := tableColumns[targetIndex-1]
+				mapped.columns = append(mapped.columns, dataImportColumn{
+					source:  index,
+					name:    column.name,
+					kind:    dataimport.KindOfSQLType(column.dataType),
+					notNull: column.notNull,
+				})
+			}
+		}
+		err = validateDataImportMapping(mapped, tableColumns)
+		if err != nil {
+			a.ShowAlert(fmt.Sprintf("%s %v", iconWarn, err), pageDataImportMapping)
+			return dataImportPlan{}, false
+		}
+		return mapped, true
+	}
+	form.AddButton("Dry run", func() {
+		if mapped, ok := buildPlan(); ok {
+			a.pages.HidePage(pageDataImportMapping)
+			a.runDataImport(mapped, true, tableColumns, returnFocus)
+		}
+	})
+	form.AddButton("Import", func() {
+		mapped, ok := buildPlan()
+		if !ok {
+			return
+		}
+		a.pages.HidePage(pageDataImportMapping)
+		a.confirmDataImport(mapped, tableColumns, returnFocus)
+	})
+	form.AddButton("Cancel", closeMapping)
+	form.SetCancelFunc(closeMapping)
+
+	summary := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	summary.SetBackgroundColor(crust)
+	summary.SetText(dataImportSummaryText(plan))
+
+	title := fmt.Sprintf(" Map columns into %s ", tview.Escape(plan.table))
+	if plan.create {
+		title = fmt.Sprintf(" Columns of new table %s ", tview.Escape(plan.table))
+	}
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 3, 0, false).
+		AddItem(form, 0, 1, true)
+	container.SetBorder(true).SetTitle(title).SetTitleColor(mauve).SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	modalW, modalH := a.modalSize(72, 110, 14, 40)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageDataImportMapping, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+func dataImportSummaryText(plan dataImportPlan) string {
+	preview := plan.preview
+	layout := preview.Format.Label()
+	if preview.Format == dataimport.FormatCSV {
+		header := "no header row"
+		if preview.Header {
+			header = "header row"
+		}
+		layout = fmt.Sprintf("CSV · delimiter %s · %s", dataImportDelimiterLabel(preview.Delimiter), header)
+	}
+	target := "Empty fields and JSON nulls import as NULL."
+	if plan.create {
+		target = "The table is created on import. " + target
+	}
+	return fmt.Sprintf(" [#a6adc8]%s[-] · %s · %d columns\n Types inferred from the first %d rows. %s",
+		tview.Escape(filepath.Base(preview.Path)), tview.Escape(layout), len(preview.Columns), len(preview.Rows), target)
+}
+
+func dataImportDelimiterLabel(delimiter rune) string {
+	for _, option := range dataImportDelimiterOptions {
+		if option.delimiter == delimiter {
+			return strings.ToLower(option.label)
+		}
+	}
+	return fmt.Sprintf("%q", delimiter)
+}
+
+func dataImportKindIndex(kind dataimport.Kind) int {
+	for index, candidate := range dataimport.Kinds {
+		if candidate == kind {
+			return index
+		}
+	}
+	return len(dataimport.Kinds) - 1
+}
+
+// dataImportMatchColumn finds the table column a file column most likely
+// fills, ignoring case, spaces, and punctuation; -1 skips it.
+func dataImportMatchColumn(name string, columns []sidebarColumnMeta) int {
+	normalize := func(value string) string {
+		var builder strings.Builder
+		for _, character := range strings.ToLower(value) {
+			if (character >= 'a' && character <= 'z') || (character >= '0' && character <= '9') || character > 127 {
+				builder.WriteRune(character)
+			}
+		}
+		return builder.String()
+	}
+	for index, column := range columns {
+		if column.name == name {
+			return index
+		}
+	}
+	wanted := normalize(name)
+	for index, column := range columns {
+		if wanted != "" && normalize(column.name) == wanted {
+			return index
+		}
+	}
+	return -1
+}
+
+// dataImportTableName derives a table name from a file name.
+func dataImportTableName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return dataImportColumnName(base)
+}
+
+// dataImportColumnName turns a header into a lower-case ASCII identifier so
+// the new table is easy to query on every engine.
+func dataImportColumnName(header string) string {
+	var builder strings.Builder
+	for _, character := range strings.ToLower(strings.TrimSpace(header)) {
+		switch {
+		case character >= 'a' && character <= 'z', character >= '0' && character <= '9':
+			builder.WriteRune(character)
+		case builder.Len() > 0 && !strings.HasSuffix(builder.String(), "_"):
+			builder.WriteByte('_')
+		}
+	}
+	name := strings.TrimSuffix(builder.String(), "_")
+	if name == "" {
+		return "column"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "c_" + name
+	}
+	return name
+}
+
+func validateDataImportMapping(plan dataImportPlan, tableColumns []sidebarColumnMeta) error {
+	if len(plan.columns) == 0 {
+		return fmt.Errorf("map at least one file column to a table column")
+	}
+	if strings.TrimSpace(plan.table) == "" {
+		return fmt.Errorf("a table name is required")
+	}
+	seen := map[string]string{}
+	for _, column := range plan.columns {
+		if column.name == "" {
+			return fmt.Errorf("file column %s needs a table column name", plan.preview.Columns[column.source].Name)
+		}
+		key := strings.ToLower(column.name)
+		if source, ok := seen[key]; ok {
+			return fmt.Errorf("%s and %s both map to column %s", source, plan.preview.Columns[column.source].Name, column.name)
+		}
+		seen[key] = plan.preview.Columns[column.source].Name
+	}
+	auto := rowInsertAutoColumns(plan.dbType, tableColumns)
+	var missing []string
+	for _, column := range tableColumns {
+		key := strings.ToLower(column.name)
+		if _, mapped := seen[key]; !mapped && column.notNull && column.defaultValue == "" && !auto[key] {
+			missing = append(missing, column.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("these columns are NOT NULL without a default and must be mapped: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// confirmDataImport asks for the connection name on production connections
+// before a real import.
+func (a *App) confirmDataImport(plan dataImportPlan, tableColumns []sidebarColumnMeta, returnFocus tview.Primitive) {
+	run := func() { a.runDataImport(plan, false, tableColumns, returnFocus) }
+	if conn := a.activeConn; conn != nil && conn.IsProduction() {
+		tokens := []string{"INSERT"}
+		if plan.create {
+			tokens = append(tokens, "CREATE")
+		}
+		a.pages.RemovePage(pageDataImportMapping)
+		a.confirmProductionWrite(tokens, run)
+		return
+	}
+	run()
+}
+
+// runDataImport streams the file into the database behind a cancellable
+// progress modal, then reports the outcome.
+func (a *App) runDataImport(plan dataImportPlan, dryRun bool, tableColumns []sidebarColumnMeta, returnFocus tview.Primitive) {
+	db := a.db
+	if db == nil {
+		return
+	}
+	// The wizard may have been open when the transaction began.
+	if a.transaction != nil {
+		a.pages.RemovePage(pageDataImportMapping)
+		a.transactionBlocksWrite("importing data")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), importCommandTimeout)
+	var canceling atomic.Bool
+	verb := "Importing"
+	if dryRun {
+		verb = "Checking"
+	}
+	modal := tview.NewModal().
+		AddButtons([]string{" Cancel "}).
+		SetBackgroundColor(bg).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(yellow).
+		SetTextColor(text)
+	progressText := func(rows int, bytesRead int64) string {
+		percent := ""
+		if plan.preview.Size > 0 {
+			percent = fmt.Sprintf(" (%d%%)", min(100, int(bytesRead*100/plan.preview.Size)))
+		}
+		return fmt.Sprintf("\n%s %s %s into %s\n\nRows read: %d%s\n\nPress Esc or Cancel to stop safely.",
+			iconRefresh, verb, tview.Escape(filepath.Base(plan.preview.Path)), tview.Escape(plan.table), rows, percent)
+	}
+	modal.SetText(progressText(0, 0))
+	notifyCancel := func() {
+		canceling.Store(true)
+		modal.SetText(fmt.Sprintf("\n%s Canceling...\n\nRolling back. Please wait.", iconRefresh))
+	}
+	if !a.beginImportRun(cancel, notifyCancel) {
+		cancel()
+		a.pages.RemovePage(pageDataImportMapping)
+		a.ShowAlert(fmt.Sprintf("%s Another import is already running.", iconInfo), "main")
+		return
+	}
+	modal.SetDoneFunc(func(_ int, _ string) { a.requestImportCancel() })
+	a.pages.AddPage(pageDataImportProgress, modal, true, true)
+	a.app.SetFocus(modal)
+
+	go func() {
+		defer cancel()
+		defer a.finishImportRun()
+		progress := func(rows int, bytesRead int64) {
+			a.queueUpdateDraw(func() {
+				if !canceling.Load() {
+					modal.SetText(progressText(rows, bytesRead))
+				}
+			})
+		}
+		outcome, err := importDataFile(ctx, db, plan, dryRun, progress)
+		a.queueUpdateDraw(func() {
+			a.pages.RemovePage(pageDataImportProgress)
+			if a.db != db {
+				return
+			}
+			if dryRun && err == nil {
+				a.showDataImportReport(plan, outcome, tableColumns, returnFocus)
+				return
+			}
+			a.pages.RemovePage(pageDataImportMapping)
+			a.restoreResultExportFocus(returnFocus)
+			if err != nil {
+				a.ShowAlert(dataImportFailureMessage(plan, outcome, err, dryRun), "main")
+				return
+			}
+			a.recordProfilerActivity(fmt.Sprintf("-- import %s\nINSERT INTO %s", filepath.Base(plan.preview.Path), quoteIdentifier(plan.dbType, plan.table)), int64(outcome.imported))
+			a.ShowAlert(fmt.Sprintf("%s Imported %d rows into %s.", iconSuccess, outcome.imported, tview.Escape(plan.table)), "main")
+			if plan.create {
+				a.refreshDataAsync()
+			} else if a.selectedTable == plan.table {
+				a.refreshCurrentTableAsync()
+			}
+		})
+	}()
+}
+
+func dataImportFailureMessage(plan dataImportPlan, outcome dataImportOutcome, err error, dryRun bool) string {
+	if errors.Is(err, context.Canceled) {
+		if dryRun {
+			return fmt.Sprintf("%s Dry run canceled after %d rows. Nothing was changed.", iconInfo, outcome.rows)
+		}
+		err = fmt.Errorf("canceled after %d rows", outcome.rows)
+	}
+	kept := "Nothing was imported."
+	if outcome.imported > 0 {
+		kept = fmt.Sprintf("%d rows were already committed; %s cannot roll an import back.", outcome.imported, plan.dbType)
+	} else if plan.create {
+		kept = fmt.Sprintf("Nothing was imported and %s was not kept.", tview.Escape(plan.table))
+	}
+	return fmt.Sprintf("%s Import into %s stopped.\n\n%s\n\n%s\n\nRun a dry run to list every row that would fail.",
+		iconWarn, tview.Escape(plan.table), tview.Escape(err.Error()), kept)
+}
+
+// showDataImportReport lists the dry-run result and offers the real import.
+func (a *App) showDataImportReport(plan dataImportPlan, outcome dataImportOutcome, tableColumns []sidebarColumnMeta, returnFocus tview.Primitive) {
+	report := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetWordWrap(true).SetScrollable(true)
+	report.SetBackgroundColor(bg)
+	report.SetBorder(true).SetTitle(" Dry run ").SetTitleColor(mauve).SetBorderColor(surface1)
+	report.SetText(dataImportReportText(plan, outcome))
+
+	buttons := tview.NewForm()
+	buttons.SetBackgroundColor(bg)
+	buttons.SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	closeReport := func() {
+		a.pages.RemovePage(pageDataImportReport)
+		a.pages.RemovePage(pageDataImportMapping)
+		a.restoreResultExportFocus(returnFocus)
+	}
+	backToMapping := func() {
+		a.pages.RemovePage(pageDataImportReport)
+		a.pages.ShowPage(pageDataImportMapping)
+		if _, mapping := a.pages.GetFrontPage(); mapping != nil {
+			a.app.SetFocus(mapping)
+		}
+	}
+	if outcome.failed == 0 {
+		buttons.AddButton("Import", func() {
+			a.pages.RemovePage(pageDataImportReport)
+			a.confirmDataImport(plan, tableColumns, returnFocus)
+		})
+	}
+	buttons.AddButton("Back to mapping", backToMapping)
+	buttons.AddButton("Close", closeReport)
+
+	capture := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			backToMapping()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if report.HasFocus() {
+				a.app.SetFocus(buttons)
+			} else {
+				a.app.SetFocus(report)
+			}
+			return nil
+		}
+		return event
+	}
+	report.SetInputCapture(capture)
+	buttons.SetInputCapture(capture)
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(report, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	modalW, modalH := a.modalSize(72, 120, 14, 32)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageDataImportReport, grid, true, true)
+	a.app.SetFocus(buttons)
+}
+
+func dataImportReportText(plan dataImportPlan, outcome dataImportOutcome) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, " [#a6adc8]Rows read:[-] %d   [green]would import:[-] %d   [red]would fail:[-] %d\n\n",
+		outcome.rows, outcome.imported, outcome.failed)
+	switch {
+	case plan.create:
+		fmt.Fprintf(&body, " Every row was checked against the column types. %s does not exist yet, so nothing was written.\n", tview.Escape(plan.table))
+	case outcome.databaseCheck:
+		body.WriteString(" Every row was inserted inside a transaction that was rolled back, so constraints were checked too.\n")
+	default:
+		fmt.Fprintf(&body, " Every row was checked against the column types. %s connections cannot roll back, so constraints were not checked.\n", plan.dbType)
+	}
+	if outcome.failed == 0 {
+		body.WriteString("\n [green]No row would fail.[-]\n")
+		return body.String()
+	}
+	body.WriteString("\n")
+	for _, failure := range outcome.failures {
+		fmt.Fprintf(&body, " [yellow]row %d[-]  %s\n", failure.row, tview.Escape(failure.message))
+	}
+	if hidden := outcome.failed - len(outcome.failures); hidden > 0 {
+		fmt.Fprintf(&body, "\n [#a6adc8]…and %d more.[-]\n", hidden)
+	}
+	return body.String()
+}
+
+// importDataFile reads every row of the file and inserts it in batches. On
+// engines with transactions the whole import commits at once; a new table is
+// dropped again when the import fails. A dry run writes nothing.
+func importDataFile(ctx context.Context, db *sql.DB, plan dataImportPlan, dryRun bool, progress func(int, int64)) (outcome dataImportOutcome, returnErr error) {
+	reader, err := dataimport.Open(plan.preview)
+	if err != nil {
+		return outcome, err
+	}
+	defer reader.Close()
+	report := func(force bool) {
+		if progress != nil && (force || outcome.rows%dataImportProgressStep == 0) {
+			progress(outcome.rows, reader.BytesRead())
+		}
+	}
+	if dryRun {
+		return dryRunDataImport(ctx, db, plan, reader, report)
+	}
+
+	if plan.create {
+		if _, err := db.ExecContext(ctx, dataImportCreateTableSQL(plan.dbType, plan.table, plan.columns)); err != nil {
+			return outcome, fmt.Errorf("create table %s: %w", plan.table, err)
+		}
+		defer func() {
+			if returnErr == nil {
+				return
+			}
+			dropCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			if _, err := db.ExecContext(dropCtx, "DROP TABLE "+quoteIdentifier(plan.dbType, plan.table)); err != nil {
+				returnErr = fmt.Errorf("%w (the new table %s could not be dropped: %v)", returnErr, plan.table, err)
+				return
+			}
+			outcome.imported = 0
+		}()
+	}
+
+	type execer interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	}
+	var target execer = db
+	var tx *sql.Tx
+	if rowWriteEngineSupported(plan.dbType) {
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return outcome, fmt.Errorf("begin transaction: %w", err)
+		}
+		defer func() {
+			if returnErr != nil {
+				_ = tx.Rollback()
+				outcome.imported = 0
+			}
+		}()
+		target = tx
+	}
+
+	batchRows := dataImportBatchRows(plan.dbType, len(plan.columns))
+	batch := make([]any, 0, batchRows*len(plan.columns))
+	batchStart := 1
+	flush := func() error {
+		rows := len(batch) / len(plan.columns)
+		if rows == 0 {
+			return nil
+		}
+		if _, err := target.ExecContext(ctx, dataImportInsertSQL(plan.dbType, plan.table, plan.columns, rows), batch...); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("rows %d-%d: %w", batchStart, batchStart+rows-1, err)
+		}
+		outcome.imported += rows
+		batchStart += rows
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return outcome, err
+		}
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return outcome, err
+		}
+		outcome.rows++
+		values, err := dataImportRowValues(plan, record)
+		if err != nil {
+			return outcome, fmt.Errorf("row %d: %w", reader.Row(), err)
+		}
+		batch = append(batch, values...)
+		if len(batch) == cap(batch) {
+			if err := flush(); err != nil {
+				return outcome, err
+			}
+		}
+		report(false)
+	}
+	if err := flush(); err != nil {
+		return outcome, err
+	}
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return outcome, fmt.Errorf("commit: %w", err)
+		}
+	}
+	report(true)
+	return outcome, nil
+}
+
+// dryRunDataImport checks every row. When the table exists and the engine
+// has transactions, each row is also inserted under a savepoint inside a
+// transaction that is always rolled back, so constraint failures show up.
+func dryRunDataImport(ctx context.Context, db *sql.DB, plan dataImportPlan, reader *dataimport.Reader, report func(bool)) (dataImportOutcome, error) {
+	outcome := dataImportOutcome{databaseCheck: !plan.create && rowWriteEngineSupported(plan.dbType)}
+	fail := func(row int, err error) {
+		outcome.failed++
+		if len(outcome.failures) < dataImportFailureLimit {
+			outcome.failures = append(outcome.failures, dataImportFailure{row: row, message: err.Error()})
+		}
+	}
+	var tx *sql.Tx
+	if outcome.databaseCheck {
+		var err error
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return outcome, fmt.Errorf("begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+	}
+	insert := dataImportInsertSQL(plan.dbType, plan.table, plan.columns, 1)
+	for {
+		if err := ctx.Err(); err != nil {
+			return outcome, err
+		}
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *dataimport.RowError
+		if errors.As(err, &rowErr) {
+			outcome.rows++
+			fail(rowErr.Row, errors.New(rowErr.Message))
+			continue
+		}
+		if err != nil {
+			return outcome, err
+		}
+		outcome.rows++
+		report(false)
+		values, err := dataImportRowValues(plan, record)
+		if err != nil {
+			fail(reader.Row(), err)
+			continue
+		}
+		if tx != nil {
+			inserted, err := dryRunDataImportRow(ctx, tx, insert, values)
+			if err != nil {
+				return outcome, err
+			}
+			if inserted != nil {
+				fail(reader.Row(), inserted)
+				continue
+			}
+		}
+		outcome.imported++
+	}
+	report(true)
+	return outcome, nil
+}
+
+// dryRunDataImportRow inserts one row under a savepoint and undoes it when
+// the database rejects it. The rejection is returned separately from errors
+// that end the dry run.
+func dryRunDataImportRow(ctx context.Context, tx *sql.Tx, insert string, values []any) (rejected error, err error) {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+dataImportSavepointName); err != nil {
+		return nil, fmt.Errorf("savepoint: %w", err)
+	}
+	if _, rejected = tx.ExecContext(ctx, insert, values...); rejected != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+dataImportSavepointName); err != nil {
+			return nil, fmt.Errorf("roll back to savepoint: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+dataImportSavepointName); err != nil {
+		return nil, fmt.Errorf("release savepoint: %w", err)
+	}
+	return rejected, nil
+}
+
+func dataImportRowValues(plan dataImportPlan, record dataimport.Record) ([]any, error) {
+	values := make([]any, len(plan.columns))
+	for index, column := range plan.columns {
+		var field sql.NullString
+		if column.source < len(record) {
+			field = record[column.source]
+		}
+		value, err := dataImportValue(plan.dbType, column, field)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column.name, err)
+		}
+		values[index] = value
+	}
+	return values, nil
+}
+
+// dataImportValue validates a field against its column kind and converts it
+// where a driver would otherwise reject the text form: booleans become
+// numbers outside PostgreSQL and DuckDB, and MySQL gets timestamps without
+// the ISO T or offset (converted to UTC).
+func dataImportValue(dbType config.DBType, column dataImportColumn, field sql.NullString) (any, error) {
+	if !field.Valid {
+		if column.notNull {
+			return nil, fmt.Errorf("a value is required")
+		}
+		return nil, nil
+	}
+	value := strings.TrimSpace(field.String)
+	switch column.kind {
+	case dataimport.KindInteger:
+		if !dataimport.Matches(column.kind, value) {
+			return nil, fmt.Errorf("%q is not an integer", truncateForDisplay(field.String, 40))
+		}
+		return value, nil
+	case dataimport.KindReal:
+		if !dataimport.Matches(column.kind, value) {
+			return nil, fmt.Errorf("%q is not a number", truncateForDisplay(field.String, 40))
+		}
+		return value, nil
+	case dataimport.KindBoolean:
+		flag, ok := dataimport.ParseBool(value)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a boolean", truncateForDisplay(field.String, 40))
+		}
+		if dbType == config.PostgreSQL || dbType == config.DuckDB {
+			return flag, nil
+		}
+		if flag {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case dataimport.KindDate:
+		if !dataimport.Matches(column.kind, value) {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", truncateForDisplay(field.String, 40))
+		}
+		return value, nil
+	case dataimport.KindTimestamp:
+		parsed, ok := dataimport.ParseTimestamp(value)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a timestamp", truncateForDisplay(field.String, 40))
+		}
+		if dbType == config.MySQL {
+			return parsed.UTC().Format("2006-01-02 15:04:05.999999"), nil
+		}
+		return value, nil
+	default:
+		return field.String, nil
+	}
+}
+
+// dataImportBatchRows keeps each multi-row INSERT under the engine's bound
+// parameter limit; D1 allows only 100 per statement.
+func dataImportBatchRows(dbType config.DBType, columns int) int {
+	limit := 30000
+	if dbType == config.CloudflareD1 {
+		limit = 100
+	}
+	return max(1, min(dataImportMaxBatchRows, limit/max(1, columns)))
+}
+
+func dataImportInsertSQL(dbType config.DBType, table string, columns []dataImportColumn, rows int) string {
+	names := make([]string, len(columns))
+	for index, column := range columns {
+		names[index] = quoteIdentifier(dbType, column.name)
+	}
+	var statement strings.Builder
+	fmt.Fprintf(&statement, "INSERT INTO %s (%s) VALUES ", quoteIdentifier(dbType, table), strings.Join(names, ", "))
+	position := 0
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			statement.WriteString(", ")
+		}
+		statement.WriteByte('(')
+		for index := range columns {
+			if index > 0 {
+				statement.WriteString(", ")
+			}
+			position++
+			statement.WriteString(numberedResultFilterPlaceholder(dbType, position))
+		}
+		statement.WriteByte(')')
+	}
+	return statement.String()
+}
+
+func dataImportCreateTableSQL(dbType config.DBType, table string, columns []dataImportColumn) string {
+	definitions := make([]string, len(columns))
+	for index, column := range columns {
+		definitions[index] = quoteIdentifier(dbType, column.name) + " " + dataImportSQLType(dbType, column.kind)
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdentifier(dbType, table), strings.Join(definitions, ", "))
+}
+
+// dataImportSQLType is the column type a new table uses for a kind. Decimals
+// stay exact as NUMERIC on PostgreSQL; MySQL's DECIMAL needs a precision, so
+// it gets DOUBLE.
+func dataImportSQLType(dbType config.DBType, kind dataimport.Kind) string {
+	switch dbType {
+	case config.PostgreSQL:
+		return map[dataimport.Kind]string{
+			dataimport.KindInteger: "BIGINT", dataimport.KindReal: "NUMERIC", dataimport.KindBoolean: "BOOLEAN",
+			dataimport.KindDate: "DATE", dataimport.KindTimestamp: "TIMESTAMP", dataimport.KindText: "TEXT",
+		}[kind]
+	case config.MySQL:
+		return map[dataimport.Kind]string{
+			dataimport.KindInteger: "BIGINT", dataimport.KindReal: "DOUBLE", dataimport.KindBoolean: "BOOLEAN",
+			dataimport.KindDate: "DATE", dataimport.KindTimestamp: "DATETIME(6)", dataimport.KindText: "TEXT",
+		}[kind]
+	case config.DuckDB:
+		return map[dataimport.Kind]string{
+			dataimport.KindInteger: "BIGINT", dataimport.KindReal: "DOUBLE", dataimport.KindBoolean: "BOOLEAN",
+			dataimport.KindDate: "DATE", dataimport.KindTimestamp: "TIMESTAMP", dataimport.KindText: "VARCHAR",
+		}[kind]
+	default:
+		return map[dataimport.Kind]string{
+			dataimport.KindInteger: "INTEGER", dataimport.KindReal: "REAL", dataimport.KindBoolean: "BOOLEAN",
+			dataimport.KindDate: "DATE", dataimport.KindTimestamp: "TIMESTAMP", dataimport.KindText: "TEXT",
+		}[kind]
+	}
+}
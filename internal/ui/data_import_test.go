@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/dataimport"
+)
+
+func testDataImportPreview(t *testing.T, body string) *dataimport.Preview {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "people.csv")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	preview, err := dataimport.Inspect(path, dataimport.Options{}, dataImportSampleRows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return preview
+}
+
+func TestDataImportCreatesTableAndInsertsBatches(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t)
+	preview := testDataImportPreview(t, "ID,Full Name,Active,Joined At\n1,Ada,yes,2024-01-02T03:04:05Z\n2,,no,2024-02-03 04:05:06\n")
+	plan := dataImportPlan{preview: preview, dbType: config.SQLite, table: dataImportTableName(preview.Path), create: true}
+	for index, column := range preview.Columns {
+		plan.columns = append(plan.columns, dataImportColumn{source: index, name: dataImportColumnName(column.Name), kind: column.Kind})
+	}
+	if plan.table != "people" || plan.columns[1].name != "full_name" || plan.columns[2].kind != dataimport.KindBoolean {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if got := dataImportCreateTableSQL(config.SQLite, plan.table, plan.columns); got != `CREATE TABLE "people" ("id" INTEGER, "full_name" TEXT, "active" BOOLEAN, "joined_at" TIMESTAMP)` {
+		t.Fatalf("create table = %q", got)
+	}
+
+	var progressed int
+	outcome, err := importDataFile(ctx, db, plan, false, func(rows int, _ int64) { progressed = rows })
+	if err != nil || outcome.rows != 2 || outcome.imported != 2 || progressed != 2 {
+		t.Fatalf("outcome = %+v, progress = %d, err = %v", outcome, progressed, err)
+	}
+	var name sql.NullString
+	var active int
+	if err := db.QueryRow(`SELECT full_name, active FROM people WHERE id = 2`).Scan(&name, &active); err != nil || name.Valid || active != 0 {
+		t.Fatalf("row 2 = %v, %d, %v", name, active, err)
+	}
+
+	broken := plan
+	broken.table = "broken"
+	broken.columns = append([]dataImportColumn(nil), plan.columns...)
+	broken.columns[1].notNull = true
+	if _, err := importDataFile(ctx, db, broken, false, nil); err == nil || !strings.Contains(err.Error(), "row 2: column full_name: a value is required") {
+		t.Fatalf("import with a bad row: err = %v", err)
+	}
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'broken'`).Scan(&tables); err != nil || tables != 0 {
+		t.Fatalf("failed import left its table behind: %d, %v", tables, err)
+	}
+}
+
+func TestDataImportDryRunReportsFailingRowsWithoutWriting(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE people(id INTEGER PRIMARY KEY, name TEXT NOT NULL, age INTEGER CHECK (age >= 0))`,
+		`INSERT INTO people VALUES(1,'ada',36)`,
+	)
+	preview := testDataImportPreview(t, "id,name,age\n1,dup,1\n2,bob,x\n3,cy,-4\n4,,5\n5,di,9\n")
+	columns, err := loadSidebarColumnMetadata(ctx, db, config.SQLite, "people", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := dataImportPlan{preview: preview, dbType: config.SQLite, table: "people"}
+	for index, column := range preview.Columns {
+		target := columns[dataImportMatchColumn(strings.ToUpper(column.Name), columns)]
+		plan.columns = append(plan.columns, dataImportColumn{source: index, name: target.name, kind: dataimport.KindOfSQLType(target.dataType), notNull: target.notNull})
+	}
+	if err := validateDataImportMapping(dataImportPlan{preview: preview, dbType: config.SQLite, table: "people", columns: plan.columns[:1]}, columns); err == nil || !strings.Contains(err.Error(), "name") {
+		t.Fatalf("unmapped required column: err = %v", err)
+	}
+	if err := validateDataImportMapping(plan, columns); err != nil {
+		t.Fatal(err)
+	}
+
+	outcome, err := importDataFile(ctx, db, plan, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !outcome.databaseCheck || outcome.rows != 5 || outcome.imported != 1 || outcome.failed != 4 {
+		t.Fatalf("outcome = %+v", outcome)
+	}
+	for index, want := range []string{"UNIQUE", "not an integer", "CHECK", "a value is required"} {
+		if outcome.failures[index].row != index+1 || !strings.Contains(outcome.failures[index].message, want) {
+			t.Fatalf("failure %d = %+v, want %q", index, outcome.failures[index], want)
+		}
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM people`).Scan(&count); err != nil || count != 1 {
+		t.Fatalf("dry run wrote rows: %d, %v", count, err)
+	}
+	if text := dataImportReportText(plan, outcome); !strings.Contains(text, "rolled back") || !strings.Contains(text, "row 3") {
+		t.Fatalf("report = %q", text)
+	}
+}
+
+func TestDataImportStatementsRespectEngineLimits(t *testing.T) {
+	columns := []dataImportColumn{{name: "a"}, {name: "b"}, {name: "c"}}
+	if got := dataImportBatchRows(config.CloudflareD1, len(columns)); got != 33 {
+		t.Fatalf("D1 batch rows = %d", got)
+	}
+	if got := dataImportBatchRows(config.CloudflareD1, 150); got != 1 {
+		t.Fatalf("D1 wide batch rows = %d", got)
+	}
+	if got := dataImportBatchRows(config.PostgreSQL, 100); got != 300 {
+		t.Fatalf("PostgreSQL batch rows = %d", got)
+	}
+	if got := dataImportInsertSQL(config.PostgreSQL, "public.items", columns[:2], 2); got != `INSERT INTO "public"."items" ("a", "b") VALUES ($1, $2), ($3, $4)` {
+		t.Fatalf("insert = %q", got)
+	}
+	if got := dataImportSQLType(config.MySQL, dataimport.KindTimestamp); got != "DATETIME(6)" {
+		t.Fatalf("MySQL timestamp type = %q", got)
+	}
+	value, err := dataImportValue(config.MySQL, dataImportColumn{kind: dataimport.KindTimestamp}, sql.NullString{String: "2024-01-02T05:04:05+02:00", Valid: true})
+	if err != nil || value != "2024-01-02 03:04:05" {
+		t.Fatalf("MySQL timestamp = %v, %v", value, err)
+	}
+	if value, _ := dataImportValue(config.PostgreSQL, dataImportColumn{kind: dataimport.KindBoolean}, sql.NullString{String: "Yes", Valid: true}); value != true {
+		t.Fatalf("PostgreSQL boolean = %v", value)
+	}
+	if got := dataImportColumnName("2024 Sales (€)"); got != "c_2024_sales" {
+		t.Fatalf("column name = %q", got)
+	}
+}
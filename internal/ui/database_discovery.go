@@ -45,6 +45,22 @@ func buildDatabaseDiscoveryConfig(form *tview.Form) (*config.ConnectionConfig, e
 		Password: formInputValue(form, connFieldPassword),
 		Database: formInputValue(form, connFieldDatabase),
 		SSLMode:  formInputValue(form, connFieldSSLMode),
+
+		PasswordCommand: formInputValue(form, connFieldPassCmd),
+
+		SSHHost:       formInputValue(form, connFieldSSHHost),
+		SSHPort:       formInputValue(form, connFieldSSHPort),
+		SSHUser:       formInputValue(form, connFieldSSHUser),
+		SSHPassword:   formInputValue(form, connFieldSSHPass),
+		SSHKeyFile:    formInputValue(form, connFieldSSHKeyFile),
+		SSHUseAgent:   formCheckboxChecked(form, connFieldSSHAgent),
+		SSHKnownHosts: formInputValue(form, connFieldSSHKnown),
+		SSHJumpHosts:  formInputValue(form, connFieldSSHJump),
+
+		TLSCAFile:     formInputValue(form, connFieldTLSCA),
+		TLSCertFile:   formInputValue(form, connFieldTLSCert),
+		TLSKeyFile:    formInputValue(form, connFieldTLSKey),
+		TLSServerName: formInputValue(form, connFieldTLSServer),
 	}
 
 	if dsn := formInputValue(form, connFieldDSN); dsn != "" {
@@ -70,6 +86,15 @@ func buildDatabaseDiscoveryConfig(form *tview.Form) (*config.ConnectionConfig, e
 		if parsed.SSLMode != "" {
 			cfg.SSLMode = parsed.SSLMode
 		}
+		if parsed.TLSCAFile != "" {
+			cfg.TLSCAFile = parsed.TLSCAFile
+		}
+		if parsed.TLSCertFile != "" {
+			cfg.TLSCertFile = parsed.TLSCertFile
+		}
+		if parsed.TLSKeyFile != "" {
+			cfg.TLSKeyFile = parsed.TLSKeyFile
+		}
 	}
 
 	if cfg.Host == "" {
@@ -78,6 +103,9 @@ func buildDatabaseDiscoveryConfig(form *tview.Form) (*config.ConnectionConfig, e
 	if cfg.User == "" {
 		return nil, fmt.Errorf("user is required before finding databases")
 	}
+	if err := cfg.ValidateTLS(); err != nil {
+		return nil, err
+	}
 	if cfg.Port == "" {
 		if dbType == config.MySQL {
 			cfg.Port = "3306"
@@ -495,7 +523,7 @@ func newConnectionInSameScope(base config.ConnectionConfig) config.ConnectionCon
 	connection.LastUsed = ""
 	connection.Active = false
 	switch connection.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		if directory := filepath.Dir(connection.FilePath); directory != "." {
 			connection.FilePath = directory + string(filepath.Separator)
 		} else {
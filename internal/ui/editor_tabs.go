@@ -0,0 +1,467 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/scratch"
+)
+
+const (
+	pageEditorTabRename = "editorTabRename"
+	pageEditorTabClose  = "editorTabClose"
+
+	paletteActionRenameEditorTab keymapAction = "palette_rename_editor_tab"
+
+	editorTabSaveDelay  = 750 * time.Millisecond
+	editorTabNamePrefix = "Query "
+)
+
+// editorTab is one named query buffer. Each tab keeps the results it last
+// showed so switching back restores the grid and cursor.
+type editorTab struct {
+	id      string
+	name    string
+	text    string
+	updated time.Time
+	result  *editorTabResult
+}
+
+type editorTabResult struct {
+	cells              [][]*tview.TableCell
+	title              string
+	rowCount           int
+	navigation         resultNavigationState
+	selection          resultSelectionState
+	navStack           []resultNavigationState
+	tableResultsActive bool
+	activeTable        string
+	colWidths          map[string]int
+	script             *scriptRun
+}
+
+type pendingEditorTabsSave struct {
+	key       string
+	workspace scratch.Workspace
+}
+
+func newEditorTab(name, text string) *editorTab {
+	return &editorTab{
+		id:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		name:    name,
+		text:    text,
+		updated: time.Now().UTC(),
+	}
+}
+
+func (a *App) currentEditorTab() *editorTab {
+	if len(a.editorTabs) == 0 {
+		a.editorTabs = []*editorTab{newEditorTab(editorTabNamePrefix+"1", "")}
+		a.editorTabIndex = 0
+	}
+	a.editorTabIndex = clamp(a.editorTabIndex, 0, len(a.editorTabs)-1)
+	return a.editorTabs[a.editorTabIndex]
+}
+
+// nextEditorTabName returns the first unused "Query N" name.
+func nextEditorTabName(tabs []*editorTab) string {
+	used := make(map[string]bool, len(tabs))
+	for _, tab := range tabs {
+		used[tab.name] = true
+	}
+	for n := 1; ; n++ {
+		if name := editorTabNamePrefix + strconv.Itoa(n); !used[name] {
+			return name
+		}
+	}
+}
+
+// editorTabsTitle is the Query panel suffix naming the active tab once there
+// is more than one or the only tab was renamed.
+func (a *App) editorTabsTitle() string {
+	if len(a.editorTabs) == 0 {
+		return ""
+	}
+	tab := a.currentEditorTab()
+	if len(a.editorTabs) == 1 && strings.HasPrefix(tab.name, editorTabNamePrefix) {
+		return ""
+	}
+	return fmt.Sprintf(" — [::b]%s[::-] [gray]%d/%d[-]", tview.Escape(tab.name), a.editorTabIndex+1, len(a.editorTabs))
+}
+
+// editorTextChanged mirrors the editor into the active tab and schedules the
+// auto-save.
+func (a *App) editorTextChanged() {
+	if a.queryInput == nil {
+		return
+	}
+	tab := a.currentEditorTab()
+	if current := a.queryInput.GetText(); current != tab.text {
+		tab.text = current
+		tab.updated = time.Now().UTC()
+		a.scheduleEditorTabsSave()
+	}
+}
+
+// syncEditorTabs loads the saved tabs of the active connection. Buffers typed
+// before the first connection are adopted by it when it has none saved; after
+// a disconnect the last connection's tabs stay in place until another one
+// takes over.
+func (a *App) syncEditorTabs() {
+	key, ok := a.activeConnectionKey()
+	if !ok || key == a.editorTabsKey {
+		return
+	}
+	a.flushEditorTabs()
+	previous := a.editorTabsKey
+	a.editorTabsKey = key
+
+	var workspace scratch.Workspace
+	if a.scratchMgr != nil {
+		workspace = a.scratchMgr.Workspace(key)
+	}
+	for _, tab := range a.editorTabs {
+		tab.result = nil
+	}
+	if len(workspace.Tabs) == 0 && previous == "" {
+		a.scheduleEditorTabsSave()
+		return
+	}
+
+	a.editorTabs = a.editorTabs[:0]
+	a.editorTabIndex = 0
+	for _, saved := range workspace.Tabs {
+		if saved.Name == "" {
+			saved.Name = nextEditorTabName(a.editorTabs)
+		}
+		if saved.ID == workspace.Active {
+			a.editorTabIndex = len(a.editorTabs)
+		}
+		a.editorTabs = append(a.editorTabs, &editorTab{id: saved.ID, name: saved.Name, text: saved.SQL, updated: saved.UpdatedAt})
+	}
+	a.showEditorTabBuffer()
+}
+
+func (a *App) showEditorTabBuffer() {
+	if a.queryInput == nil {
+		return
+	}
+	a.hideSQLCompletions()
+	a.queryInput.SetText(a.currentEditorTab().text, true)
+}
+
+func (a *App) editorTabsWorkspace() scratch.Workspace {
+	workspace := scratch.Workspace{Active: a.currentEditorTab().id}
+	for _, tab := range a.editorTabs {
+		workspace.Tabs = append(workspace.Tabs, scratch.Tab{ID: tab.id, Name: tab.name, SQL: tab.text, UpdatedAt: tab.updated})
+	}
+	return workspace
+}
+
+// scheduleEditorTabsSave snapshots the tabs on the UI thread and writes them
+// shortly after typing pauses.
+func (a *App) scheduleEditorTabsSave() {
+	if a.scratchMgr == nil || a.editorTabsKey == "" {
+		return
+	}
+	pending := &pendingEditorTabsSave{key: a.editorTabsKey, workspace: a.editorTabsWorkspace()}
+	a.editorSaveMu.Lock()
+	defer a.editorSaveMu.Unlock()
+	a.editorSavePending = pending
+	if a.editorSaveTimer == nil {
+		a.editorSaveTimer = time.AfterFunc(editorTabSaveDelay, a.flushEditorTabs)
+		return
+	}
+	a.editorSaveTimer.Reset(editorTabSaveDelay)
+}
+
+// flushEditorTabs writes any pending tab snapshot immediately.
+func (a *App) flushEditorTabs() {
+	if a == nil || a.scratchMgr == nil {
+		return
+	}
+	a.editorSaveMu.Lock()
+	defer a.editorSaveMu.Unlock()
+	pending := a.editorSavePending
+	a.editorSavePending = nil
+	if pending == nil {
+		return
+	}
+	if err := a.scratchMgr.SetWorkspace(pending.key, pending.workspace); err != nil {
+		fmt.Printf("⚠ Warning: failed to persist editor tabs: %v\n", err)
+	}
+}
+
+// openEditorTab opens a tab holding text, which may be empty, and switches
+// to it.
+func (a *App) openEditorTab(text string) bool {
+	if !a.canSwitchEditorTab() {
+		return false
+	}
+	a.currentEditorTab()
+	a.stashEditorTabResult()
+	a.editorTabs = append(a.editorTabs, newEditorTab(nextEditorTabName(a.editorTabs), text))
+	a.editorTabIndex = len(a.editorTabs) - 1
+	a.showEditorTabBuffer()
+	a.restoreEditorTabResult()
+	a.refreshEnvironmentChrome()
+	a.scheduleEditorTabsSave()
+	return true
+}
+
+func (a *App) switchEditorTab(index int) {
+	if index < 0 || index >= len(a.editorTabs) || index == a.editorTabIndex || !a.canSwitchEditorTab() {
+		return
+	}
+	a.stashEditorTabResult()
+	a.editorTabIndex = index
+	a.showEditorTabBuffer()
+	a.restoreEditorTabResult()
+	a.refreshEnvironmentChrome()
+	a.scheduleEditorTabsSave()
+}
+
+func (a *App) cycleEditorTab(delta int) {
+	if len(a.editorTabs) < 2 {
+		a.flashStatus(fmt.Sprintf("[yellow]%s Only one editor tab is open; %s opens another[-]", iconInfo, a.effectiveActionShortcut(actionNewEditorTab)), a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	a.switchEditorTab((a.editorTabIndex + delta + len(a.editorTabs)) % len(a.editorTabs))
+}
+
+// closeEditorTab asks before discarding a tab that still holds SQL. Closing
+// the last tab leaves an empty one behind.
+func (a *App) closeEditorTab() {
+	if !a.canSwitchEditorTab() {
+		return
+	}
+	tab := a.currentEditorTab()
+	if strings.TrimSpace(tab.text) == "" {
+		a.removeEditorTab(tab.id)
+		return
+	}
+	returnFocus := a.focusedPanel
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s Close editor tab %q?\n\nIts SQL will be discarded.", iconWarn, tab.name)).
+		AddButtons([]string{" Close tab ", " Cancel "}).
+		SetDoneFunc(func(index int, _ string) {
+			a.pages.RemovePage(pageEditorTabClose)
+			a.pages.SwitchToPage("main")
+			if index == 0 {
+				a.removeEditorTab(tab.id)
+			}
+			if returnFocus != nil {
+				a.setFocusWithColor(returnFocus)
+			}
+		})
+	modal.SetBackgroundColor(bg).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(red).
+		SetTextColor(text)
+	a.pages.AddPage(pageEditorTabClose, modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+func (a *App) removeEditorTab(id string) {
+	index := -1
+	for i, tab := range a.editorTabs {
+		if tab.id == id {
+			index = i
+		}
+	}
+	if index < 0 || !a.canSwitchEditorTab() {
+		return
+	}
+	name := a.editorTabs[index].name
+	a.editorTabs = append(a.editorTabs[:index], a.editorTabs[index+1:]...)
+	if len(a.editorTabs) == 0 {
+		a.editorTabs = []*editorTab{newEditorTab(editorTabNamePrefix+"1", "")}
+	}
+	a.editorTabIndex = clamp(index, 0, len(a.editorTabs)-1)
+	a.showEditorTabBuffer()
+	a.restoreEditorTabResult()
+	a.refreshEnvironmentChrome()
+	a.scheduleEditorTabsSave()
+	a.flashStatus(fmt.Sprintf("[green]%s Closed editor tab %s[-]", iconSuccess, tview.Escape(name)), a.currentResultRowCount(), 1400*time.Millisecond)
+}
+
+func (a *App) canSwitchEditorTab() bool {
+	if a.isQueryRunning() {
+		a.flashStatus(fmt.Sprintf("[yellow]%s Wait for the running query to finish or cancel it before changing editor tabs[-]", iconWarn), a.currentResultRowCount(), 2*time.Second)
+		return false
+	}
+	return true
+}
+
+// loadQueryIntoEditor puts SQL into the editor without losing a buffer: a
+// non-empty tab keeps its text and the SQL opens in a new tab.
+func (a *App) loadQueryIntoEditor(query string) {
+	if strings.TrimSpace(a.queryInput.GetText()) == "" || strings.TrimSpace(a.queryInput.GetText()) == strings.TrimSpace(query) {
+		a.queryInput.SetText(query, true)
+		return
+	}
+	if !a.openEditorTab(query) {
+		a.queryInput.SetText(query, true)
+	}
+}
+
+func (a *App) showEditorTabRename() {
+	tab := a.currentEditorTab()
+	returnFocus := a.focusedPanel
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(" Rename Editor Tab ").SetTitleColor(mauve).SetBorderColor(surface1)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	form.AddInputField("Name", tab.name, 40, nil, nil)
+	closeForm := func() {
+		a.pages.RemovePage(pageEditorTabRename)
+		a.pages.SwitchToPage("main")
+		if returnFocus != nil {
+			a.setFocusWithColor(returnFocus)
+		}
+	}
+	form.AddButton("Save", func() {
+		name := strings.TrimSpace(formInputValueByLabel(form, "Name"))
+		if name == "" {
+			a.ShowAlert(fmt.Sprintf("%s Tab name is required.", iconWarn), pageEditorTabRename)
+			return
+		}
+		tab.name = name
+		tab.updated = time.Now().UTC()
+		closeForm()
+		a.refreshEnvironmentChrome()
+		a.scheduleEditorTabsSave()
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+	modalW, modalH := a.modalSize(50, 70, 7, 9)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).AddItem(form, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageEditorTabRename, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+// stashEditorTabResult keeps the grid, position, and browsing state of the
+// active tab so switching back restores them.
+func (a *App) stashEditorTabResult() {
+	tab := a.currentEditorTab()
+	tab.result = nil
+	if a.results == nil || a.results.GetRowCount() == 0 {
+		return
+	}
+	rows, cols := a.results.GetRowCount(), a.results.GetColumnCount()
+	cells := make([][]*tview.TableCell, rows)
+	for r := range cells {
+		cells[r] = make([]*tview.TableCell, cols)
+		for c := range cells[r] {
+			cells[r][c] = a.results.GetCell(r, c)
+		}
+	}
+	tab.result = &editorTabResult{
+		cells:              cells,
+		title:              a.results.GetTitle(),
+		rowCount:           a.currentResultRowCount(),
+		navigation:         a.captureResultNavigationState(),
+		selection:          a.captureResultSelection(),
+		navStack:           append([]resultNavigationState(nil), a.resultNavStack...),
+		tableResultsActive: a.tableResultsActive,
+		activeTable:        a.activeTable,
+		colWidths:          a.colWidthOverrides,
+		script:             a.activeScriptRun(),
+	}
+}
+
+// restoreEditorTabResult shows the active tab's stashed results, or an empty
+// grid for a tab that has not run anything yet.
+func (a *App) restoreEditorTabResult() {
+	if a.results == nil {
+		return
+	}
+	generation := a.advanceResultGeneration()
+	a.resultColumnSearch = ""
+	a.results.Clear()
+	state := a.currentEditorTab().result
+	if state == nil {
+		a.clearResultNavigation()
+		a.resetSort()
+		a.resultFilter = nil
+		a.pageOffset = 0
+		a.totalRowCount = -1
+		a.tableResultsActive = false
+		a.activeTable = ""
+		a.clearColumnOverrides()
+		a.script = nil
+		a.results.SetTitle(a.workspacePanelTitle(iconResults, "Results", actionFocusResults, ""))
+		a.results.Select(0, 0)
+		a.results.SetOffset(0, 0)
+		a.refreshTableSidebarState()
+		a.updateStatusBar("", 0)
+		return
+	}
+
+	for r, row := range state.cells {
+		for c, cell := range row {
+			a.results.SetCell(r, c, cell)
+		}
+	}
+	a.restoreResultNavigationState(state.navigation)
+	a.resultNavStack = state.navStack
+	a.tableResultsActive = state.tableResultsActive
+	a.activeTable = state.activeTable
+	a.colWidthOverrides = state.colWidths
+	a.script = state.script
+	if a.script != nil {
+		a.script.generation = generation
+	}
+	a.results.SetTitle(state.title)
+	a.applyColumnWidths()
+	a.applyStagedCellEdits()
+	a.restoreResultSelection(state.selection, state.rowCount)
+	a.refreshTableSidebarState()
+	a.updateStatusBar("", state.rowCount)
+}
+
+// editorTabPaletteItems lets the palette find a tab by name or SQL.
+func (a *App) editorTabPaletteItems() []commandPaletteItem {
+	items := make([]commandPaletteItem, 0, len(a.editorTabs))
+	for index, tab := range a.editorTabs {
+		description := "Empty editor tab."
+		if sql := strings.TrimSpace(tab.text); sql != "" {
+			description = "Switch to this editor tab:\n\n" + sql
+		}
+		if index == a.editorTabIndex {
+			description = "Active editor tab. " + description
+		}
+		items = append(items, commandPaletteItem{
+			id:          "editor-tab:" + tab.id,
+			kind:        commandPaletteEditorTab,
+			title:       tab.name,
+			description: description,
+			keywords:    "editor tab buffer scratch " + compactSQL(tab.text),
+			objectName:  tab.id,
+			sortOrder:   230 + index,
+		})
+	}
+	return items
+}
+
+func (a *App) openCommandPaletteEditorTab(id string) {
+	a.pages.SwitchToPage("main")
+	for index, tab := range a.editorTabs {
+		if tab.id == id {
+			a.switchEditorTab(index)
+			break
+		}
+	}
+	a.setFocusWithColor(a.queryInput)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/scratch"
+)
+
+func testEditorTabsApp(t *testing.T, path string) *App {
+	t.Helper()
+	manager, err := scratch.NewManagerAt(path)
+	if err != nil {
+		t.Fatalf("NewManagerAt() error = %v", err)
+	}
+	app := &App{
+		app: tview.NewApplication(), pages: tview.NewPages(), store: &config.Store{},
+		queryInput: tview.NewTextArea(), results: newResultTable(), statusBar: tview.NewTextView(),
+		scratchMgr: manager,
+	}
+	app.queryInput.SetChangedFunc(app.editorTextChanged)
+	return app
+}
+
+func TestLoadingQueryKeepsEditorBufferAndPerTabResults(t *testing.T) {
+	app := testEditorTabsApp(t, filepath.Join(t.TempDir(), scratch.DefaultFileName))
+	app.queryInput.SetText("SELECT * FROM orders", true)
+	app.results.SetCell(0, 0, tview.NewTableCell("ID"))
+	app.results.SetCell(1, 0, tview.NewTableCell("42"))
+	app.results.Select(1, 0)
+
+	app.loadQueryIntoEditor("SELECT 1")
+	if len(app.editorTabs) != 2 || app.editorTabIndex != 1 || app.queryInput.GetText() != "SELECT 1" {
+		t.Fatalf("tabs = %d, index = %d, text = %q", len(app.editorTabs), app.editorTabIndex, app.queryInput.GetText())
+	}
+	if app.editorTabs[0].text != "SELECT * FROM orders" || app.results.GetRowCount() != 0 {
+		t.Fatalf("first buffer = %q, new tab rows = %d", app.editorTabs[0].text, app.results.GetRowCount())
+	}
+
+	app.cycleEditorTab(1)
+	if app.queryInput.GetText() != "SELECT * FROM orders" || app.results.GetCell(1, 0).Text != "42" {
+		t.Fatalf("switching back lost the buffer or results: %q", app.queryInput.GetText())
+	}
+	if row, _ := app.results.GetSelection(); row != 1 {
+		t.Fatalf("selected row = %d, want 1", row)
+	}
+}
+
+func TestEditorTabsPersistPerConnection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), scratch.DefaultFileName)
+	app := testEditorTabsApp(t, path)
+	app.activeConn = &config.ConnectionConfig{Name: "local", Type: config.SQLite, FilePath: "/tmp/a.db"}
+	app.syncEditorTabs()
+	app.queryInput.SetText("SELECT 'kept'", true)
+	app.openEditorTab("SELECT 2")
+	app.editorTabs[1].name = "counts"
+	app.scheduleEditorTabsSave()
+	app.flushEditorTabs()
+
+	app.activeConn = &config.ConnectionConfig{Name: "other", Type: config.SQLite, FilePath: "/tmp/b.db"}
+	app.syncEditorTabs()
+	if len(app.editorTabs) != 1 || app.queryInput.GetText() != "" {
+		t.Fatalf("another connection saw the first one's tabs: %d, %q", len(app.editorTabs), app.queryInput.GetText())
+	}
+
+	restarted := testEditorTabsApp(t, path)
+	restarted.activeConn = &config.ConnectionConfig{Name: "local", Type: config.SQLite, FilePath: "/tmp/a.db"}
+	restarted.syncEditorTabs()
+	if len(restarted.editorTabs) != 2 || restarted.editorTabIndex != 1 || restarted.queryInput.GetText() != "SELECT 2" {
+		t.Fatalf("restored tabs = %d, index = %d, text = %q", len(restarted.editorTabs), restarted.editorTabIndex, restarted.queryInput.GetText())
+	}
+	if restarted.editorTabs[0].text != "SELECT 'kept'" || restarted.editorTabs[1].name != "counts" {
+		t.Fatalf("restored tabs = %+v", restarted.editorTabs)
+	}
+}
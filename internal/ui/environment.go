@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+const pageProductionConfirm = "productionConfirm"
+
+// environmentTag renders a connection's environment as a colored badge, or ""
+// for untagged connections.
+func environmentTag(conn config.ConnectionConfig) string {
+	name := conn.EnvironmentName()
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("[%s::b]%s[-:-:-]", conn.EnvironmentColor(), tview.Escape(strings.ToUpper(name)))
+}
+
+// activeEnvironmentColor returns the active connection's tag color, if any.
+func (a *App) activeEnvironmentColor() (tcell.Color, bool) {
+	if a.activeConn == nil || a.activeConn.EnvironmentColor() == "" {
+		return 0, false
+	}
+	return tcell.GetColor(a.activeConn.EnvironmentColor()), true
+}
+
+// queryBorderColor keeps the editor outlined in the environment color so a
+// prod session is recognizable even when the Query panel is not focused.
+func (a *App) queryBorderColor(focused bool) tcell.Color {
+	if color, ok := a.activeEnvironmentColor(); ok {
+		return color
+	}
+	if focused {
+		return blue
+	}
+	return surface1
+}
+
+// refreshEnvironmentChrome reapplies environment styling after the active
+// connection changes.
+func (a *App) refreshEnvironmentChrome() {
+	if a.queryInput == nil {
+		return
+	}
+	a.syncEditorTabs()
+	a.queryInput.SetBorderColor(a.queryBorderColor(a.focusedPanel == a.queryInput))
+	a.queryInput.SetTitle(a.queryPanelTitle())
+}
+
+func (a *App) queryPanelTitle() string {
+	suffix := ""
+	if a.activeConn != nil {
+		if tag := environmentTag(*a.activeConn); tag != "" {
+			suffix = " " + tag
+		}
+	}
+	if badge := a.transactionBadge(); badge != "" {
+		suffix += " " + badge
+	}
+	return a.workspacePanelTitle(iconQuery, "Query", actionFocusQuery, a.editorTabsTitle()+suffix)
+}
+
+// productionWriteStatements returns the leading keyword of every statement
+// that the read-only token check would treat as a write. Unlike the
+// Read-Only Guard, every statement is inspected, split exactly as it will
+// run, and a WITH or EXPLAIN ANALYZE that wraps a write reports that write.
+func productionWriteStatements(dbType config.DBType, query string) []string {
+	var writes []string
+	for _, statement := range database.SplitScript(dbType, query) {
+		token := firstSQLToken(statement.SQL)
+		switch {
+		case token == "":
+		case !isReadSQLToken(token):
+			writes = append(writes, token)
+		case token == "WITH" || token == "EXPLAIN":
+			if write := wrappedWriteKeyword(database.ScriptWords(dbType, statement.SQL)); write != "" {
+				writes = append(writes, write)
+			}
+		}
+	}
+	return writes
+}
+
+// wrappedWriteKeyword finds a data-modifying CTE or the statement an EXPLAIN
+// ANALYZE executes. Plain EXPLAIN only plans, and FOR UPDATE locks rows
+// without changing them.
+func wrappedWriteKeyword(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	if words[0] == "EXPLAIN" && !slices.Contains(words, "ANALYZE") && !slices.Contains(words, "ANALYSE") {
+		return ""
+	}
+	for index, word := range words {
+		switch word {
+		case "INSERT", "DELETE", "MERGE", "TRUNCATE", "DROP", "ALTER", "CREATE":
+			return word
+		case "UPDATE":
+			if index > 0 && (words[index-1] == "FOR" || words[index-1] == "KEY") {
+				continue
+			}
+			return word
+		}
+	}
+	return ""
+}
+
+// confirmProductionWrite asks for the connection name before statements that
+// can change a prod-tagged database run.
+func (a *App) confirmProductionWrite(tokens []string, run func()) {
+	conn := a.activeConn
+	if conn == nil {
+		return
+	}
+	expected := conn.Name
+	message := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetWordWrap(true)
+	message.SetBackgroundColor(bg)
+	message.SetText(fmt.Sprintf(
+		"%s %s is tagged %s.\n\nThis SQL contains %s, which can change data.\n\nType the connection name [yellow]%s[-] to run it.",
+		iconWarn, tview.Escape(conn.Name), environmentTag(*conn), tview.Escape(strings.Join(uniqueStrings(tokens), ", ")), tview.Escape(expected),
+	))
+
+	form := tview.NewForm()
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(red)
+	form.AddInputField("Connection name", "", 32, nil, nil)
+	closeConfirm := func() {
+		a.pages.RemovePage(pageProductionConfirm)
+		a.pages.SwitchToPage("main")
+		a.setFocusWithColor(a.queryInput)
+	}
+	form.AddButton("Run on prod", func() {
+		if formInputValueByLabel(form, "Connection name") != expected {
+			a.ShowAlert(fmt.Sprintf("%s The name did not match.\n\nType exactly: %s", iconWarn, tview.Escape(expected)), pageProductionConfirm)
+			return
+		}
+		closeConfirm()
+		// The confirmation belongs to the connection it was shown for.
+		if a.activeConn == nil || a.activeConn.ID != conn.ID || a.activeConn.Name != conn.Name {
+			a.ShowAlert(fmt.Sprintf("%s The active connection changed; the statement was not run.", iconWarn), "main")
+			return
+		}
+		run()
+	})
+	form.AddButton("Cancel", closeConfirm)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeConfirm()
+			return nil
+		}
+		return event
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(message, 0, 1, false).
+		AddItem(form, 5, 0, true)
+	container.SetBorder(true).SetTitle(fmt.Sprintf(" %s Confirm production change ", iconWarn)).
+		SetTitleColor(red).SetBorderColor(tcell.GetColor(conn.EnvironmentColor()))
+	container.SetBackgroundColor(bg)
+	w, h := a.modalSize(60, 84, 13, 16)
+	grid := tview.NewGrid().SetColumns(0, w, 0).SetRows(0, h, 0).AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageProductionConfirm, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
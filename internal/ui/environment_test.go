@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestProductionWriteStatementsInspectsEveryStatement(t *testing.T) {
+	for query, want := range map[string][]string{
+		"SELECT 1": nil,
+		"SELECT ';DELETE' AS x; -- ; DROP\nSHOW x":   nil,
+		"SELECT 1; delete from orders":               {"DELETE"},
+		"/* ; */ UPDATE t SET a = 1; INSERT INTO t ": {"UPDATE", "INSERT"},
+		"WITH x AS (SELECT 1) SELECT * FROM x;;":     nil,
+	} {
+		if got := productionWriteStatements(config.MySQL, query); !reflect.DeepEqual(got, want) {
+			t.Fatalf("productionWriteStatements(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestProductionWriteStatementsSeesWritesTheExecutorRuns(t *testing.T) {
+	for query, want := range map[string][]string{
+		"SELECT $$ ' $$; DELETE FROM t":                                 {"DELETE"},
+		"SELECT $tag$ ; DROP $tag$":                                     nil,
+		"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d":         {"DELETE"},
+		"with moved as (update t set a = 1 returning a) select 1":       {"UPDATE"},
+		"WITH x AS (SELECT 'DELETE' AS \"insert\") SELECT * FROM x":     nil,
+		"WITH x AS (SELECT * FROM t FOR NO KEY UPDATE) SELECT * FROM x": nil,
+		"EXPLAIN ANALYZE DELETE FROM t":                                 {"DELETE"},
+		"EXPLAIN (ANALYZE, BUFFERS) INSERT INTO t SELECT * FROM s":      {"INSERT"},
+		"EXPLAIN DELETE FROM t":                                         nil,
+		"EXPLAIN ANALYZE SELECT * FROM t /* DELETE */ WHERE a = 'DROP'": nil,
+	} {
+		if got := productionWriteStatements(config.PostgreSQL, query); !reflect.DeepEqual(got, want) {
+			t.Errorf("productionWriteStatements(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestEnvironmentTagsAppearOnDashboardAndQueryPanel(t *testing.T) {
+	prod := config.ConnectionConfig{Name: "orders", Type: config.PostgreSQL, Environment: "prod"}
+	if label := dashboardConnectionLabel(prod); !strings.Contains(label, "[#f38ba8::b]PROD[-:-:-]") {
+		t.Fatalf("dashboard label = %q", label)
+	}
+	if label := dashboardConnectionLabel(config.ConnectionConfig{Name: "scratch", Type: config.SQLite}); strings.Contains(label, "::b]") {
+		t.Fatalf("untagged dashboard label = %q", label)
+	}
+
+	app := &App{activeConn: &prod}
+	if title := app.queryPanelTitle(); !strings.Contains(title, "PROD") {
+		t.Fatalf("query title = %q", title)
+	}
+	if app.queryBorderColor(false) == surface1 || app.queryBorderColor(true) != app.queryBorderColor(false) {
+		t.Fatal("prod query border does not use the environment color")
+	}
+	app.activeConn = nil
+	if app.queryBorderColor(true) != blue || app.queryBorderColor(false) != surface1 {
+		t.Fatal("untagged query border changed")
+	}
+}
+
+func TestExecuteQueryAsksBeforeProductionWrites(t *testing.T) {
+	application := tview.NewApplication()
+	pages := tview.NewPages()
+	pages.AddPage("main", tview.NewBox(), true, true)
+	application.SetRoot(pages, true)
+	app := &App{app: application, pages: pages}
+	app.activeConn = &config.ConnectionConfig{ID: "c1", Name: "orders", Type: config.PostgreSQL, Environment: "prod"}
+	app.ExecuteQuery("UPDATE orders SET status = 'void'")
+	if page, _ := app.pages.GetFrontPage(); page != pageProductionConfirm {
+		t.Fatalf("front page = %q, want the production confirmation", page)
+	}
+	if app.queryRunning {
+		t.Fatal("query started before confirmation")
+	}
+}
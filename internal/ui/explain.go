@@ -0,0 +1,476 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+	"github.com/shreyam1008/dbterm/internal/queryplan"
+)
+
+const (
+	pageQueryPlan        = "queryPlan"
+	pageQueryPlanAnalyze = "queryPlanAnalyze"
+
+	// explainLargeTableRows is the table size from which a full scan is
+	// flagged.
+	explainLargeTableRows = 10000
+	// explainMisestimateFactor is how far actual rows may drift from the
+	// estimate before the node calls it out.
+	explainMisestimateFactor = 10
+)
+
+// explainQuery shows the plan of the statement under the editor cursor, or
+// of the selected text.
+func (a *App) explainQuery() {
+	if a.db == nil {
+		a.ShowAlert(fmt.Sprintf("%s Connect to a database before explaining a query.", iconInfo), "main")
+		return
+	}
+	if !explainSupported(a.dbType) {
+		a.ShowAlert(fmt.Sprintf("%s The plan viewer supports PostgreSQL, MySQL/MariaDB, and SQLite-compatible engines.\n\nRun EXPLAIN in the Query panel to see the %s plan as rows.", iconInfo, a.dbType), "main")
+		return
+	}
+	selection, _, _ := a.queryInput.GetSelection()
+	row, _, _, _ := a.queryInput.GetCursor()
+	statement, err := explainTargetStatement(a.dbType, a.queryInput.GetText(), selection, row)
+	if err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v", iconInfo, err), "main")
+		return
+	}
+	a.loadQueryPlanAsync(statement, false)
+}
+
+func explainSupported(dbType config.DBType) bool {
+	switch dbType {
+	case config.PostgreSQL, config.MySQL, config.SQLite, config.Turso, config.CloudflareD1:
+		return true
+	}
+	return false
+}
+
+// explainTargetStatement picks what to explain: the selection when there is
+// one, otherwise the statement the cursor row falls in.
+func explainTargetStatement(dbType config.DBType, buffer, selection string, cursorRow int) (string, error) {
+	source := buffer
+	if strings.TrimSpace(selection) != "" {
+		source = selection
+		cursorRow = 0
+	}
+	statements := database.SplitScript(dbType, source)
+	if len(statements) == 0 {
+		return "", fmt.Errorf("no query to explain.\n\nType a statement in the Query panel first")
+	}
+	statement := statements[0].SQL
+	for _, candidate := range statements[1:] {
+		if candidate.Line-1 > cursorRow {
+			break
+		}
+		statement = candidate.SQL
+	}
+	statement = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+	if first := strings.Fields(statement); len(first) > 0 && strings.EqualFold(first[0], "EXPLAIN") {
+		return "", fmt.Errorf("the statement already starts with EXPLAIN.\n\nRemove it and the plan viewer adds the right form for %s", dbType)
+	}
+	return statement, nil
+}
+
+// explainSQL wraps a statement in the EXPLAIN form the engine can return as
+// a structured plan.
+func explainSQL(dbType config.DBType, statement string, analyze bool) string {
+	switch dbType {
+	case config.PostgreSQL:
+		if analyze {
+			return "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) " + statement
+		}
+		return "EXPLAIN (FORMAT JSON) " + statement
+	case config.MySQL:
+		return "EXPLAIN FORMAT=JSON " + statement
+	default:
+		return "EXPLAIN QUERY PLAN " + statement
+	}
+}
+
+func (a *App) loadQueryPlanAsync(statement string, analyze bool) {
+	db := a.db
+	dbType := a.dbType
+	readOnly := a.activeConn != nil && a.activeConn.ReadOnly
+	timeout := manualQueryTimeout
+	if a.activeConn != nil {
+		if configured := a.activeConn.StatementTimeoutDuration(); configured > 0 {
+			timeout = configured
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	var canceled atomic.Bool
+	message := "Explaining query..."
+	if analyze {
+		message = "Running EXPLAIN ANALYZE..."
+	}
+	loadingToken := a.showLoadingModal(fmt.Sprintf("%s %s", iconRefresh, message),
+		withLoadingCancel("Press Esc to cancel.", func() {
+			canceled.Store(true)
+			cancel()
+		}))
+
+	go func() {
+		defer cancel()
+		plan, err := loadQueryPlan(ctx, db, dbType, statement, analyze, readOnly)
+		if err == nil {
+			loadExplainTableSizes(ctx, db, dbType, statement, plan)
+		}
+		a.queueUpdateDraw(func() {
+			if a.db != db || !a.finishLoadingModal(loadingToken) || canceled.Load() {
+				return
+			}
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not explain the query:\n\n%s", iconWarn, tview.Escape(err.Error())), "main")
+				return
+			}
+			a.showQueryPlan(statement, plan)
+		})
+	}()
+}
+
+// loadQueryPlan runs EXPLAIN and parses its output. EXPLAIN ANALYZE executes
+// the statement, so it runs in a transaction that is always rolled back, and
+// a read-only one for Read-Only Guard profiles.
+func loadQueryPlan(ctx context.Context, db *sql.DB, dbType config.DBType, statement string, analyze bool, readOnly bool) (*queryplan.Plan, error) {
+	query := explainSQL(dbType, statement, analyze && dbType == config.PostgreSQL)
+	switch dbType {
+	case config.PostgreSQL, config.MySQL:
+		var output []byte
+		if analyze && dbType == config.PostgreSQL {
+			tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: readOnly})
+			if err != nil {
+				return nil, fmt.Errorf("begin transaction: %w", err)
+			}
+			defer tx.Rollback()
+			if err := tx.QueryRowContext(ctx, query).Scan(&output); err != nil {
+				return nil, err
+			}
+		} else if err := db.QueryRowContext(ctx, query).Scan(&output); err != nil {
+			return nil, err
+		}
+		if dbType == config.MySQL {
+			return queryplan.ParseMySQL(output)
+		}
+		return queryplan.ParsePostgres(output)
+	default:
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		columns, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) < 4 {
+			return nil, fmt.Errorf("EXPLAIN QUERY PLAN returned %d columns, expected 4", len(columns))
+		}
+		var steps []queryplan.SQLiteStep
+		for rows.Next() {
+			var step queryplan.SQLiteStep
+			var unused any
+			if err := rows.Scan(&step.ID, &step.Parent, &unused, &step.Detail); err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return queryplan.ParseSQLite(steps), nil
+	}
+}
+
+// loadExplainTableSizes looks up the size of fully scanned tables the plan
+// does not size itself. PostgreSQL uses the planner's statistics; SQLite
+// engines count, but stop once a table is known to be large. Failed lookups
+// leave the size unknown.
+func loadExplainTableSizes(ctx context.Context, db *sql.DB, dbType config.DBType, statement string, plan *queryplan.Plan) {
+	sizes := map[string]float64{}
+	aliases := explainTableAliases(statement)
+	for _, node := range plan.FullScans() {
+		if node.ScannedRows >= 0 {
+			continue
+		}
+		key := node.Schema + "." + node.Relation
+		if size, ok := sizes[key]; ok {
+			node.TableRows = size
+			continue
+		}
+		var size sql.NullFloat64
+		var err error
+		switch dbType {
+		case config.PostgreSQL:
+			// Plans name the schema only with VERBOSE, so the search path
+			// resolves unqualified names.
+			name := quoteIdentifier(dbType, node.Relation)
+			if node.Schema != "" {
+				name = quoteIdentifier(dbType, node.Schema) + "." + name
+			}
+			err = db.QueryRowContext(ctx, `SELECT reltuples::float8 FROM pg_class WHERE oid = to_regclass($1)`, name).Scan(&size)
+		case config.SQLite, config.Turso, config.CloudflareD1:
+			table := node.Relation
+			if aliased, ok := aliases[strings.ToLower(table)]; ok {
+				table = aliased
+			}
+			err = db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 FROM %s LIMIT %d)", quoteIdentifier(dbType, table), explainLargeTableRows)).Scan(&size)
+		default:
+			continue
+		}
+		if err != nil || !size.Valid || size.Float64 < 0 {
+			// PostgreSQL reports -1 for tables that were never analyzed.
+			sizes[key] = -1
+			continue
+		}
+		sizes[key] = size.Float64
+		node.TableRows = size.Float64
+	}
+}
+
+// explainTableAliasPattern finds "FROM table alias" and "JOIN table AS alias".
+var explainTableAliasPattern = regexp.MustCompile(`(?i)\b(?:from|join)\s+([\w."]+)(?:\s+(?:as\s+)?([\w"]+))?`)
+
+// explainTableAliases maps the aliases of a statement to their tables, since
+// SQLite plans name aliased tables by alias only.
+func explainTableAliases(statement string) map[string]string {
+	aliases := map[string]string{}
+	for _, match := range explainTableAliasPattern.FindAllStringSubmatch(statement, -1) {
+		alias := strings.ToLower(strings.Trim(match[2], `"`))
+		switch alias {
+		case "", "where", "join", "inner", "left", "right", "full", "cross", "natural", "on", "using",
+			"group", "order", "limit", "union", "except", "intersect", "window", "having":
+			continue
+		}
+		aliases[alias] = strings.Trim(match[1], `"`)
+	}
+	return aliases
+}
+
+// showQueryPlan opens the collapsible plan tree.
+func (a *App) showQueryPlan(statement string, plan *queryplan.Plan) {
+	returnFocus := a.app.GetFocus()
+	root := tview.NewTreeNode(fmt.Sprintf("[#a6adc8]%s[-]", tview.Escape(truncateForDisplay(strings.Join(strings.Fields(statement), " "), 120)))).
+		SetSelectable(false)
+	var build func(parent *tview.TreeNode, nodes []*queryplan.Node)
+	build = func(parent *tview.TreeNode, nodes []*queryplan.Node) {
+		for _, node := range nodes {
+			item := tview.NewTreeNode(queryPlanNodeText(node, a.dbType)).SetReference(node)
+			for _, detail := range node.Details {
+				item.AddChild(tview.NewTreeNode(fmt.Sprintf("[#6c7086]%s[-]", tview.Escape(detail))).SetSelectable(false))
+			}
+			build(item, node.Children)
+			parent.AddChild(item)
+		}
+	}
+	build(root, plan.Roots)
+
+	tree := tview.NewTreeView().SetRoot(root).SetTopLevel(1).SetGraphicsColor(surface1)
+	tree.SetBackgroundColor(mantle)
+	tree.SetBorder(true).SetBorderColor(surface1).SetTitleColor(mauve).SetTitle(fmt.Sprintf(" %s Query plan ", iconQuery))
+	if children := root.GetChildren(); len(children) > 0 {
+		tree.SetCurrentNode(children[0])
+	}
+	tree.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+
+	summary := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetText(queryPlanSummaryText(plan, a.dbType))
+	summary.SetBackgroundColor(crust)
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	footer.SetBackgroundColor(crust)
+	analyzeHint := ""
+	if a.dbType == config.PostgreSQL && !plan.Analyzed {
+		analyzeHint = "  │  [yellow]A[-] Run ANALYZE"
+	}
+	footer.SetText(" [yellow]Enter[-] Expand / collapse  │  [yellow]E / C[-] Expand / collapse all" + analyzeHint + "  │  [yellow]Esc[-] Close ")
+
+	closePlan := func() {
+		a.pages.RemovePage(pageQueryPlan)
+		a.restoreResultExportFocus(returnFocus)
+	}
+	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closePlan()
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'e' || event.Rune() == 'E'):
+			root.ExpandAll()
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'c' || event.Rune() == 'C'):
+			for _, child := range root.GetChildren() {
+				child.CollapseAll()
+			}
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'a' || event.Rune() == 'A') && analyzeHint != "":
+			a.confirmExplainAnalyze(statement, closePlan)
+			return nil
+		}
+		return event
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 3, 0, false).
+		AddItem(tree, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	modalW, modalH := a.modalSize(80, 160, 16, 48)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageQueryPlan, grid, true, true)
+	a.app.SetFocus(tree)
+}
+
+// confirmExplainAnalyze warns that ANALYZE executes the statement before
+// running it.
+func (a *App) confirmExplainAnalyze(statement string, closePlan func()) {
+	message := fmt.Sprintf("%s EXPLAIN ANALYZE executes the statement to measure it.\n\nIt runs inside a transaction that is rolled back, so row changes are undone, but it takes as long as the query and can still call functions with side effects.", iconWarn)
+	if conn := a.activeConn; conn != nil && conn.IsProduction() {
+		message += fmt.Sprintf("\n\n[red]%s is a production connection.[-]", tview.Escape(a.dbName))
+	}
+	modal := tview.NewModal().SetText(message).AddButtons([]string{"Run ANALYZE", "Cancel"})
+	modal.SetBackgroundColor(bg).SetTextColor(text).SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	modal.SetDoneFunc(func(index int, _ string) {
+		a.pages.RemovePage(pageQueryPlanAnalyze)
+		if index != 0 {
+			if _, front := a.pages.GetFrontPage(); front != nil {
+				a.app.SetFocus(front)
+			}
+			return
+		}
+		closePlan()
+		a.loadQueryPlanAsync(statement, true)
+	})
+	a.pages.AddPage(pageQueryPlanAnalyze, modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+func queryPlanSummaryText(plan *queryplan.Plan, dbType config.DBType) string {
+	var hot, large, misestimated int
+	plan.Walk(func(node *queryplan.Node, _ int) {
+		if node.Hot {
+			hot++
+		}
+		if node.LargeScan(explainLargeTableRows) {
+			large++
+		}
+		if node.Misestimate() >= explainMisestimateFactor {
+			misestimated++
+		}
+	})
+	mode := "estimated plan"
+	if plan.Analyzed {
+		mode = fmt.Sprintf("analyzed · planning %s · execution %s", formatPlanMillis(plan.PlanningTime), formatPlanMillis(plan.ExecutionTime))
+	}
+	var flags []string
+	if hot > 0 {
+		flags = append(flags, fmt.Sprintf("[red]%d hot[-]", hot))
+	}
+	if large > 0 {
+		flags = append(flags, fmt.Sprintf("[yellow]%d full scan(s) of large tables[-]", large))
+	}
+	if misestimated > 0 {
+		flags = append(flags, fmt.Sprintf("[#ffb496]%d row misestimate(s)[-]", misestimated))
+	}
+	if len(flags) == 0 {
+		flags = append(flags, "[green]nothing flagged[-]")
+	}
+	legend := fmt.Sprintf("[red]●[-] hot: at least %.0f%% of the plan's ", queryplan.HotShare*100)
+	switch {
+	case plan.Analyzed:
+		legend += "time"
+	case dbType == config.PostgreSQL || dbType == config.MySQL:
+		legend += "cost"
+	default:
+		legend = "SQLite plans carry no costs or row estimates"
+	}
+	return fmt.Sprintf(" [#a6adc8]%s[-] · %s\n %s · [yellow]⚠[-] full scan of %s+ rows", mode, strings.Join(flags, " · "), legend, formatPlanRows(explainLargeTableRows))
+}
+
+// queryPlanNodeText is one tree line: the operation, its cost and share, row
+// estimate against actual rows, time, and any warning.
+func queryPlanNodeText(node *queryplan.Node, dbType config.DBType) string {
+	var line strings.Builder
+	label := tview.Escape(node.Label())
+	switch {
+	case node.Hot:
+		fmt.Fprintf(&line, "[red]● %s[-]", label)
+	case node.LargeScan(explainLargeTableRows):
+		fmt.Fprintf(&line, "[yellow]%s[-]", label)
+	default:
+		line.WriteString(label)
+	}
+	var metrics []string
+	if node.HasCost {
+		cost := formatPlanNumber(node.TotalCost)
+		if dbType == config.PostgreSQL {
+			cost = formatPlanNumber(node.StartupCost) + ".." + cost
+		}
+		metrics = append(metrics, "cost "+cost)
+	}
+	if node.HasEstimate || node.HasActual {
+		rows := "rows"
+		if node.HasEstimate {
+			rows += " " + formatPlanRows(node.EstimatedRows) + " est"
+		}
+		if node.HasActual {
+			if node.Loops == 0 {
+				rows += " · never executed"
+			} else {
+				rows += fmt.Sprintf(" → %s actual", formatPlanRows(node.ActualRows))
+				if node.Loops > 1 {
+					rows += fmt.Sprintf(" ×%s loops", formatPlanRows(node.Loops))
+				}
+			}
+		}
+		metrics = append(metrics, rows)
+	}
+	if node.HasActual && node.Loops > 0 {
+		metrics = append(metrics, formatPlanMillis(node.TotalTime))
+	}
+	if len(metrics) > 0 {
+		fmt.Fprintf(&line, "  [#a6adc8]%s[-]", strings.Join(metrics, " · "))
+	}
+	if factor := node.Misestimate(); factor >= explainMisestimateFactor {
+		fmt.Fprintf(&line, "  [#ffb496]estimate off ×%s[-]", formatPlanRows(factor))
+	}
+	if node.LargeScan(explainLargeTableRows) {
+		fmt.Fprintf(&line, "  [yellow]⚠ full scan of ~%s rows[-]", formatPlanRows(math.Max(node.ScannedRows, node.TableRows)))
+	}
+	return line.String()
+}
+
+func formatPlanNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func formatPlanMillis(value float64) string {
+	if value >= 1000 {
+		return strconv.FormatFloat(value/1000, 'f', 2, 64) + " s"
+	}
+	return strconv.FormatFloat(value, 'f', 3, 64) + " ms"
+}
+
+// formatPlanRows rounds a row count and groups its digits.
+func formatPlanRows(value float64) string {
+	digits := strconv.FormatFloat(value, 'f', 0, 64)
+	var grouped strings.Builder
+	for index, digit := range digits {
+		if index > 0 && (len(digits)-index)%3 == 0 && digits[index-1] != '-' {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return grouped.String()
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/queryplan"
+)
+
+func TestExplainTargetStatementFollowsCursorAndSelection(t *testing.T) {
+	buffer := "SELECT 1;\n\nSELECT *\nFROM users\nWHERE id = 2;\nSELECT 3;"
+	for _, test := range []struct {
+		selection string
+		row       int
+		want      string
+	}{
+		{"", 0, "SELECT 1"},
+		{"", 3, "SELECT *\nFROM users\nWHERE id = 2"},
+		{"", 5, "SELECT 3"},
+		{"SELECT 3;", 0, "SELECT 3"},
+	} {
+		got, err := explainTargetStatement(config.SQLite, buffer, test.selection, test.row)
+		if err != nil || got != test.want {
+			t.Fatalf("row %d, selection %q: got %q, err = %v", test.row, test.selection, got, err)
+		}
+	}
+	if _, err := explainTargetStatement(config.PostgreSQL, "explain select 1", "", 0); err == nil {
+		t.Fatal("an EXPLAIN statement was wrapped again")
+	}
+	if _, err := explainTargetStatement(config.PostgreSQL, "  -- nothing\n", "", 0); err == nil {
+		t.Fatal("an empty buffer was explained")
+	}
+	if got := explainSQL(config.PostgreSQL, "SELECT 1", true); got != "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) SELECT 1" {
+		t.Fatalf("PostgreSQL ANALYZE = %q", got)
+	}
+	if got := explainSQL(config.MySQL, "SELECT 1", true); got != "EXPLAIN FORMAT=JSON SELECT 1" {
+		t.Fatalf("MySQL = %q", got)
+	}
+}
+
+func TestSQLiteQueryPlanFlagsFullScansOfLargeTables(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE events(id INTEGER PRIMARY KEY, kind TEXT)`,
+		`CREATE TABLE kinds(name TEXT)`,
+		`WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 10000) INSERT INTO events SELECT i, 'k' FROM n`,
+		`INSERT INTO kinds VALUES('k')`,
+	)
+	plan, err := loadQueryPlan(ctx, db, config.SQLite, "SELECT * FROM events WHERE id > 5", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scans := plan.FullScans(); len(scans) != 0 {
+		t.Fatalf("an indexed range search was flagged: %+v", scans[0])
+	}
+	plan, err = loadQueryPlan(ctx, db, config.SQLite, "SELECT * FROM kinds", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadExplainTableSizes(ctx, db, config.SQLite, "SELECT * FROM kinds", plan)
+	if scans := plan.FullScans(); len(scans) != 1 || scans[0].TableRows != 1 || scans[0].LargeScan(explainLargeTableRows) {
+		t.Fatalf("kinds scan = %+v", plan.Roots)
+	}
+
+	statement := "SELECT * FROM events AS e WHERE e.kind = 'k'"
+	plan, err = loadQueryPlan(ctx, db, config.SQLite, statement, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadExplainTableSizes(ctx, db, config.SQLite, statement, plan)
+	scans := plan.FullScans()
+	if len(scans) != 1 || !scans[0].LargeScan(explainLargeTableRows) {
+		t.Fatalf("full scan of events was not flagged: %+v", plan.Roots)
+	}
+	if text := queryPlanNodeText(scans[0], config.SQLite); !strings.Contains(text, "full scan of ~10,000 rows") {
+		t.Fatalf("large scan text = %q", text)
+	}
+	if summary := queryPlanSummaryText(plan, config.SQLite); !strings.Contains(summary, "1 full scan(s) of large tables") {
+		t.Fatalf("summary = %q", summary)
+	}
+}
+
+func TestQueryPlanNodeTextShowsCostsRowsAndHotNodes(t *testing.T) {
+	plan, err := queryplan.ParsePostgres([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "orders", "Schema": "public",
+		"Startup Cost": 0, "Total Cost": 1234.5, "Plan Rows": 10, "Actual Total Time": 2.5, "Actual Rows": 1500, "Actual Loops": 2},
+		"Execution Time": 5.1}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := queryPlanNodeText(plan.Roots[0], config.PostgreSQL)
+	for _, want := range []string{"[red]● Seq Scan on public.orders[-]", "cost 0.00..1234.50", "rows 10 est → 1,500 actual ×2 loops", "5.000 ms", "estimate off ×150"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("node text %q is missing %q", got, want)
+		}
+	}
+	if got := formatPlanRows(1234567); got != "1,234,567" {
+		t.Fatalf("formatPlanRows = %q", got)
+	}
+}
@@ -26,6 +26,9 @@ type foreignKeyReference struct {
 	sourceTable string
 	targetTable string
 	columns     []foreignKeyColumnReference
+	// onDelete is the referential action of incoming references, such as
+	// CASCADE or NO ACTION. Outgoing lookups leave it empty.
+	onDelete string
 }
 
 type foreignKeyRowValue struct {
@@ -41,6 +44,7 @@ type resultNavigationState struct {
 	pageOffset    int
 	pageSize      int
 	totalRowCount int
+	totalApprox   bool
 	sortColumn    int
 	sortAsc       bool
 	sortMode      string
@@ -351,6 +355,7 @@ func (a *App) captureResultNavigationState() resultNavigationState {
 		pageOffset:    a.pageOffset,
 		pageSize:      a.pageSize,
 		totalRowCount: a.totalRowCount,
+		totalApprox:   a.totalRowCountApprox,
 		sortColumn:    a.sortColumn,
 		sortAsc:       a.sortAsc,
 		sortMode:      a.sortMode,
@@ -367,6 +372,7 @@ func (a *App) restoreResultNavigationState(state resultNavigationState) {
 	a.pageOffset = state.pageOffset
 	a.pageSize = state.pageSize
 	a.totalRowCount = state.totalRowCount
+	a.totalRowCountApprox = state.totalApprox
 	a.sortColumn = state.sortColumn
 	a.sortAsc = state.sortAsc
 	a.sortMode = state.sortMode
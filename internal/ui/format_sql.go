@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/sqlfmt"
+)
+
+// formatEditorSQL pretty-prints the selection, or the whole buffer when
+// nothing is selected; compact joins each statement onto one line instead.
+// The edit goes through the editor's undo history, so Ctrl+Z restores the
+// original text.
+func (a *App) formatEditorSQL(compact bool) {
+	if a.queryInput == nil {
+		return
+	}
+	source, start, end := a.queryInput.GetSelection()
+	if strings.TrimSpace(source) == "" {
+		source = a.queryInput.GetText()
+		start, end = 0, len(source)
+	}
+	if strings.TrimSpace(source) == "" {
+		a.ShowAlert(fmt.Sprintf("%s No SQL to format.\n\nType or paste a query in the Query panel first.", iconInfo), "main")
+		return
+	}
+	formatted, err := formatSQL(a.dbType, source, a.sqlKeywordCase(), compact)
+	if err != nil {
+		a.ShowAlert(fmt.Sprintf("%s Could not format the SQL: %v\n\nThe editor was left unchanged.", iconWarn, err), "main")
+		return
+	}
+	a.setFocusWithColor(a.queryInput)
+	if formatted == source {
+		a.flashStatus(fmt.Sprintf("[#a6adc8]%s SQL is already formatted[-]", iconInfo), a.currentResultRowCount(), 1400*time.Millisecond)
+		return
+	}
+	a.queryInput.Replace(start, end, formatted)
+	a.flashStatus(fmt.Sprintf("[green]%s SQL formatted — Ctrl+Z undoes it[-]", iconSuccess), a.currentResultRowCount(), 1600*time.Millisecond)
+}
+
+// formatSQL formats source and keeps the whitespace around it, so a
+// formatted selection still lines up with the text beside it.
+func formatSQL(dbType config.DBType, source string, keywordCase sqlfmt.Case, compact bool) (string, error) {
+	formatted, err := sqlfmt.Format(dbType, source, sqlfmt.Options{Case: keywordCase, Compact: compact})
+	if err != nil {
+		return "", err
+	}
+	trimmed := strings.TrimLeft(source, " \t\r\n")
+	leading := source[:len(source)-len(trimmed)]
+	trailing := trimmed[len(strings.TrimRight(trimmed, " \t\r\n")):]
+	return leading + formatted + trailing, nil
+}
+
+func (a *App) sqlKeywordCase() sqlfmt.Case {
+	if a.settings == nil {
+		return sqlfmt.CaseUpper
+	}
+	return sqlfmt.Case(config.NormalizeSQLKeywordCase(a.settings.SQLKeywordCase))
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestFormatEditorSQLFormatsTheSelectionOnly(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.SQLKeywordCase = config.SQLKeywordCaseLower
+	app := &App{
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		tables:     tview.NewList(),
+		queryInput: tview.NewTextArea(),
+		results:    tview.NewTable(),
+		statusBar:  tview.NewTextView(),
+		dbType:     config.PostgreSQL,
+		settings:   settings,
+	}
+	app.pages.AddPage("main", app.results, true, true)
+	first := "-- keep me\nSELECT 1;\n\n"
+	app.queryInput.SetText(first+"SELECT id,'a  b' FROM t WHERE x=1 AND y=2\n", false)
+	app.queryInput.Select(len(first), len(app.queryInput.GetText()))
+
+	app.formatEditorSQL(false)
+	want := first + "select id, 'a  b'\nfrom t\nwhere x = 1\n  and y = 2\n"
+	if got := app.queryInput.GetText(); got != want {
+		t.Fatalf("buffer =\n%q\nwant\n%q", got, want)
+	}
+
+	app.formatEditorSQL(true)
+	want = "-- keep me\nselect 1;\nselect id, 'a  b' from t where x = 1 and y = 2\n"
+	if got := app.queryInput.GetText(); got != want {
+		t.Fatalf("compacted buffer =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -75,13 +75,22 @@ func substituteGuideShortcuts(a *App, markdown string) string {
 		{"Alt+W", actionChangeProfiler},
 		{"Alt+E", actionExportCSV},
 		{"Alt+Y", actionHistory},
+		{"Alt+O", actionQueryLibrary},
 		{"Alt+,", actionSettings},
 		{"Alt+G", actionSettings},
 		{"Alt+I", actionImportDump},
+		{"Alt+L", actionImportData},
 		{"Alt+M", actionInspectSchema},
+		{"Alt+V", actionExplainQuery},
+		{"Alt+J", actionFormatSQL},
+		{"Alt+Z", actionTransaction},
 		{"Alt+A", actionSelectAll},
 		{"Alt+C", actionClearSelection},
 		{"Ctrl+P", actionCommandPalette},
+		{"Alt+N", actionNewEditorTab},
+		{"Alt+X", actionCloseEditorTab},
+		{"Alt+>", actionNextEditorTab},
+		{"Alt+<", actionPrevEditorTab},
 	}
 	settingsToken := "{{dbterm-guide-shortcut-settings}}"
 	markdown = strings.ReplaceAll(markdown, "`Alt+,`, or `Alt+G`", settingsToken)
@@ -287,7 +296,7 @@ func guideSectionSummary(title string) string {
 		"Browse tables, columns, and database objects":   "Schema tree, pins, metadata, and definitions",
 		"Write SQL, use autocomplete, and query history": "Execution, local suggestions, and cancellation",
 		"Work with result rows and columns":              "Filters, relationships, sorting, paging, and size",
-		"Import SQL and export CSV":                      "Supported formats, streaming, and cancellation",
+		"Import and export data":                         "SQL dumps, CSV/JSON files, and export formats",
 		"Compare changes with Change Profiler":           "Anchors, scans, reports, and attribution limits",
 		"Operate local PostgreSQL and MySQL services":    "Status, start/stop, and connect workflows",
 		"Back up and restore databases":                  "Instant backups, plans, agents, and restore",
@@ -19,7 +19,7 @@ func keyboardHelpTextFor(a *App) string {
   [#89b4fa]Find a table[-]       [yellow]{{focus_tables}}[-] → type its name → [yellow]Enter[-]
   [#89b4fa]Find a column[-]      In Results press [yellow]↑[-] from the first row → type its name → [yellow]↓/Enter[-]
   [#89b4fa]Cross-table lookup[-] Select a cell → [yellow]C[-] → open another table/column → [yellow]V[-]
-  [#89b4fa]Filter a column[-]    Select column → [yellow]/[-] → choose operator/value → [yellow]Enter[-]; Add AND composes
+  [#89b4fa]Filter a column[-]    Select column → [yellow]/[-] → choose operator/value → [yellow]Enter[-]; Add AND / Add OR compose
   [#89b4fa]Follow related rows[-] Select a key cell → [yellow]F[-] → choose [#a6e3a1]→ parent[-] or [#89b4fa]← children[-]; repeat for a chain
   [#89b4fa]Find the same value[-] In Related Data press [yellow]V[-] to check same-named columns across tables
   [#89b4fa]Clear a filter[-]     Press [yellow]Esc[-] once to clear and reset position; press again for Dashboard
@@ -54,15 +54,21 @@ func keyboardHelpTextFor(a *App) string {
   [yellow]Tab / Shift+Tab[-]  Hop between column search and table search while both retain their position/text
   [yellow]C[-]                Copy only the selected cell (full value, even if preview is shortened)
   [yellow]V[-]                Apply/update equality from the clipboard (real NULL uses IS NULL)
-  [yellow]/[-]                Open filters; Enter applies, Add AND composes, Tab / Shift+Tab moves; remembered per table
+  [yellow]/[-]                Open filters; Enter applies, Add AND / Add OR compose, Tab / Shift+Tab moves; remembered per table
   [yellow]F[-]                Explore declared relationships in both directions; Enter opens related rows
   [yellow]V (Related Data)[-] Find the exact value in same-named columns across tables
+  [yellow]P[-]                Profile the column: nulls, distinct, min/max, top values, lengths, histogram; A for all columns
+  [yellow]B[-]                Pin the result as a diff baseline; on a later result, diff it by key columns or full row
   [yellow]Backspace[-]        Return one step through a Person → Visit → Payment-style chain
   [yellow]Esc[-]              Clear filters/reset position first; press again for Dashboard
   [yellow]Enter[-]            Open row details; C copies the selected detail cell
+  [yellow]E[-]                Stage an edit for the selected cell (tables with a primary or NOT NULL unique key)
+  [yellow]W[-]                Review the generated UPDATEs, then commit in one transaction or discard
+  [yellow]I / D[-]            Insert a row from a column form / duplicate the selected row
+  [yellow]X / Delete[-]       Delete the selected rows (or the current one) after a preview with foreign key impact
   [yellow]Space[-]            Toggle current row selection
   [yellow]{{select_all}} / {{clear_selection}}[-]    Select all / clear selected rows
-  [yellow]{{export_csv}}[-]            Export selected, current-page, or all matching rows to CSV
+  [yellow]{{export_csv}}[-]            Export selected, current-page, or all matching rows (CSV, JSON, NDJSON, Markdown, SQL, XLSX)
 
 [#a6e3a1]RESULTS — SIZE, SORT & PAGES[-]
   [yellow]+ / -[-]            Widen / narrow selected column (remembered per table)
@@ -73,6 +79,7 @@ func keyboardHelpTextFor(a *App) string {
   [yellow]S[-]                Sort by the selected column
   [yellow]PgDn / ][-]         Next page        [yellow]PgUp / Left bracket[-] Previous page
   [yellow]Home / End[-]       First / last page
+  [yellow]#[-]                Count rows exactly; large tables show an estimate (~) until then
   [yellow]Alt++ / Alt+-[-]    Increase / decrease preview rows per page
   [yellow]Alt+0[-]            Toggle preview limit between 100 and safe maximum
   [yellow]F5 / Ctrl+F5[-]     Refresh current table / refresh tables and current data
@@ -83,8 +90,14 @@ func keyboardHelpTextFor(a *App) string {
   [yellow]Ctrl+Space[-]        Smart local suggestions plus ready read-only queries for the selected table
   [yellow]↑ / ↓, Tab/Enter[-]  Choose / insert; context ranks typo fixes, tables, columns, clauses, functions, and routines
   [yellow]Esc[-]               Close suggestions without leaving Query; Enter runs when suggestions are closed
-  [yellow]{{history}}[-]            Query history
+  [yellow]:name $1 ?[-]        Placeholders: Enter asks for typed values, remembered per query, and binds them as arguments
+  [yellow]{{history}}[-]            Query history            [yellow]{{query_library}}[-] Saved queries: folders, tags, scopes
+  [yellow]{{explain_query}}[-]            Plan tree for the statement under the cursor; A re-runs it with ANALYZE on PostgreSQL
+  [yellow]{{format_sql}}[-]            Format the selection or the whole buffer; the palette also compacts it onto one line
+  [yellow]{{transaction}}[-]            Begin a transaction; press again to commit or roll it back
+  [yellow]{{new_editor_tab}} / {{close_editor_tab}}[-]    New / close editor tab   [yellow]{{next_editor_tab}} / {{prev_editor_tab}}[-] Next / previous tab
   [yellow]{{import_dump}}[-]            Import SQL dump          [yellow]Esc[-] Cancel a running import
+  [yellow]{{import_data}}[-]            Import a CSV, JSON, or NDJSON file into a table
 
 [#a6e3a1]NAVIGATION & APP[-]
   [yellow]{{command_palette}}[-] Search documented actions, tables, collapsed columns, database objects, and recent queries
@@ -158,10 +171,19 @@ func keyboardHelpTextFor(a *App) string {
 		"{{history}}", shortcut(actionHistory),
 		"{{settings}}", shortcut(actionSettings),
 		"{{import_dump}}", shortcut(actionImportDump),
+		"{{import_data}}", shortcut(actionImportData),
 		"{{inspect_schema}}", shortcut(actionInspectSchema),
+		"{{explain_query}}", shortcut(actionExplainQuery),
+		"{{format_sql}}", shortcut(actionFormatSQL),
+		"{{transaction}}", shortcut(actionTransaction),
+		"{{query_library}}", shortcut(actionQueryLibrary),
 		"{{select_all}}", shortcut(actionSelectAll),
 		"{{clear_selection}}", shortcut(actionClearSelection),
 		"{{command_palette}}", shortcut(actionCommandPalette),
+		"{{new_editor_tab}}", shortcut(actionNewEditorTab),
+		"{{close_editor_tab}}", shortcut(actionCloseEditorTab),
+		"{{next_editor_tab}}", shortcut(actionNextEditorTab),
+		"{{prev_editor_tab}}", shortcut(actionPrevEditorTab),
 	).Replace(template)
 }
 
@@ -296,6 +318,28 @@ func (a *App) showHelp() {
   SELECT COUNT(*) FROM table;
   INSERT INTO t (c1, c2) VALUES ('v1', 'v2');
 
+`
+
+	cheatDuckDB := `[::b][#f9e2af]━━━ DuckDB Cheatsheet ━━━[-][-]
+
+[#f9e2af]Inspect Schema[-]
+  SHOW TABLES;
+  DESCRIBE table_name;
+  SUMMARIZE table_name;
+
+[#f9e2af]Read Files[-]
+  SELECT * FROM 'events.parquet' LIMIT 100;
+  SELECT * FROM read_csv('data/*.csv');
+  CREATE TABLE t AS SELECT * FROM 'events.json';
+
+[#f9e2af]Database Info[-]
+  SELECT version();
+  PRAGMA database_size;
+
+[#f9e2af]Export[-]
+  COPY (SELECT * FROM t) TO 'out.parquet' (FORMAT parquet);
+  EXPORT DATABASE 'backup_dir';
+
 `
 
 	sections := manualGuideSections(a)
@@ -308,6 +352,7 @@ func (a *App) showHelp() {
 		{title: "SQLite SQL reference", summary: "Schema, database, CRUD, and performance queries", body: cheatSQLite},
 		{title: "Turso / LibSQL SQL reference", summary: "Schema, database, and common queries", body: cheatTurso},
 		{title: "Cloudflare D1 SQL reference", summary: "Schema, database, and common queries", body: cheatD1},
+		{title: "DuckDB SQL reference", summary: "Schema, file reading, and export queries", body: cheatDuckDB},
 	}
 	if a.db != nil {
 		preferred := 0
@@ -320,6 +365,8 @@ func (a *App) showHelp() {
 			preferred = 3
 		case config.CloudflareD1:
 			preferred = 4
+		case config.DuckDB:
+			preferred = 5
 		}
 		sqlSections[0], sqlSections[preferred] = sqlSections[preferred], sqlSections[0]
 	}
@@ -102,7 +102,7 @@ func TestManualGuideSectionsAreCompleteAndUnique(t *testing.T) {
 
 	all := content.String()
 	for _, expected := range []string{
-		"PostgreSQL, MySQL/MariaDB, SQLite, Turso/LibSQL, and Cloudflare D1",
+		"PostgreSQL, MySQL/MariaDB, SQLite, DuckDB, Turso/LibSQL, and Cloudflare D1",
 		"Read-Only Guard",
 		"IS NOT NULL",
 		"Change Profiler",
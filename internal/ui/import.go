@@ -19,6 +19,7 @@ import (
 	"github.com/rivo/tview"
 	backupcore "github.com/shreyam1008/dbterm/internal/backup"
 	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
 )
 
 const (
@@ -197,14 +198,24 @@ func (a *App) runSQLImport(cfg *config.ConnectionConfig, sqlPath string, stopOnE
 		defer cancel()
 		defer a.finishImportRun()
 
-		var runErr error
-		switch targetCfg.Type {
-		case config.PostgreSQL:
-			runErr = runPostgresSQLImport(ctx, targetCfg, sqlPath, stopOnError, appendOutput)
-		case config.MySQL:
-			runErr = runMySQLSQLImport(ctx, targetCfg, sqlPath, stopOnError, appendOutput)
-		default:
-			runErr = fmt.Errorf("SQL import is not supported for %s", targetCfg.TypeLabel())
+		// psql and mysql dial Host:Port themselves; tunneled profiles hand
+		// them the local end of the SSH forward instead. Credentials are
+		// resolved here because a password command may take a while.
+		clientCfg, runErr := targetCfg.ResolveCredentials()
+		if runErr == nil {
+			var closeTunnel func()
+			clientCfg, closeTunnel, runErr = database.OpenTunnel(clientCfg)
+			defer closeTunnel()
+		}
+		if runErr == nil {
+			switch targetCfg.Type {
+			case config.PostgreSQL:
+				runErr = runPostgresSQLImport(ctx, clientCfg, sqlPath, stopOnError, appendOutput)
+			case config.MySQL:
+				runErr = runMySQLSQLImport(ctx, clientCfg, sqlPath, stopOnError, appendOutput)
+			default:
+				runErr = fmt.Errorf("SQL import is not supported for %s", targetCfg.TypeLabel())
+			}
 		}
 
 		a.app.QueueUpdateDraw(func() {
@@ -595,6 +606,10 @@ func runPostgresSQLImport(ctx context.Context, cfg *config.ConnectionConfig, sql
 	if err := ensureImportClientAvailable(config.PostgreSQL); err != nil {
 		return err
 	}
+	cfg, tlsEnv, err := libpqImportTarget(cfg)
+	if err != nil {
+		return err
+	}
 
 	database := strings.TrimSpace(cfg.Database)
 	if database == "" {
@@ -624,9 +639,7 @@ func runPostgresSQLImport(ctx context.Context, cfg *config.ConnectionConfig, sql
 	if cfg.Password != "" {
 		cmd.Env = append(cmd.Env, "PGPASSWORD="+cfg.Password)
 	}
-	if cfg.SSLMode != "" {
-		cmd.Env = append(cmd.Env, "PGSSLMODE="+cfg.SSLMode)
-	}
+	cmd.Env = append(cmd.Env, tlsEnv...)
 
 	tail, err := runStreamingCommand(cmd, emit, importTailLineLimit)
 	return mapImportCommandError(ctx, "psql", tail, err)
@@ -636,6 +649,10 @@ func runPostgresArchiveImport(ctx context.Context, cfg *config.ConnectionConfig,
 	if _, err := exec.LookPath("pg_restore"); err != nil {
 		return fmt.Errorf("PostgreSQL restore tool not found in PATH (required binary: pg_restore).\n\n%s", importClientSetupHint("pg_restore"))
 	}
+	cfg, tlsEnv, err := libpqImportTarget(cfg)
+	if err != nil {
+		return err
+	}
 
 	database := strings.TrimSpace(cfg.Database)
 	if database == "" {
@@ -651,14 +668,28 @@ func runPostgresArchiveImport(ctx context.Context, cfg *config.ConnectionConfig,
 	if cfg.Password != "" {
 		cmd.Env = append(cmd.Env, "PGPASSWORD="+cfg.Password)
 	}
-	if cfg.SSLMode != "" {
-		cmd.Env = append(cmd.Env, "PGSSLMODE="+cfg.SSLMode)
-	}
+	cmd.Env = append(cmd.Env, tlsEnv...)
 
 	tail, err := runStreamingCommand(cmd, emit, importTailLineLimit)
 	return mapImportCommandError(ctx, "pg_restore", tail, err)
 }
 
+// libpqImportTarget returns a copy of cfg with the host native PostgreSQL
+// clients should be given, plus the TLS and session environment they need.
+func libpqImportTarget(cfg *config.ConnectionConfig) (*config.ConnectionConfig, []string, error) {
+	host, tlsEnv, err := cfg.TLSEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionEnv, err := cfg.LibpqSessionEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	target := *cfg
+	target.Host = host
+	return &target, append(tlsEnv, sessionEnv...), nil
+}
+
 func postgresArchiveImportArgs(cfg *config.ConnectionConfig, dumpPath string, stopOnError bool) []string {
 	args := []string{
 		"--host", nonEmptyOr(cfg.Host, "localhost"),
@@ -704,6 +735,12 @@ func runMySQLSQLImport(ctx context.Context, cfg *config.ConnectionConfig, sqlPat
 	if user := strings.TrimSpace(cfg.User); user != "" {
 		args = append(args, fmt.Sprintf("--user=%s", user))
 	}
+	tlsArgs, err := cfg.MySQLClientTLSArgs()
+	if err != nil {
+		return err
+	}
+	args = append(args, tlsArgs...)
+	args = append(args, cfg.MySQLClientSessionArgs()...)
 	if !stopOnError {
 		args = append(args, "--force")
 	}
@@ -768,7 +805,7 @@ func inspectPostgresImportFile(ctx context.Context, path string) (bool, error) {
 	switch inspection.Format {
 	case backupcore.FormatPostgresCustom, backupcore.FormatPostgresTar:
 		return true, nil
-	case backupcore.FormatMySQLSQL, backupcore.FormatSQLiteDatabase, backupcore.FormatSQLiteSQL:
+	case backupcore.FormatMySQLSQL, backupcore.FormatSQLiteDatabase, backupcore.FormatSQLiteSQL, backupcore.FormatDuckDBExport:
 		return false, fmt.Errorf("the selected file contains a %s backup, not a PostgreSQL backup", inspection.Engine)
 	default:
 		// Hand-written and dialect-ambiguous SQL remains valid here because the
@@ -26,12 +26,21 @@ const (
 	actionChangeProfiler keymapAction = config.ActionChangeProfiler
 	actionExportCSV      keymapAction = config.ActionExportCSV
 	actionHistory        keymapAction = config.ActionHistory
+	actionQueryLibrary   keymapAction = config.ActionQueryLibrary
 	actionSettings       keymapAction = config.ActionSettings
 	actionImportDump     keymapAction = config.ActionImportDump
+	actionImportData     keymapAction = config.ActionImportData
 	actionInspectSchema  keymapAction = config.ActionInspectSchema
+	actionExplainQuery   keymapAction = config.ActionExplainQuery
+	actionFormatSQL      keymapAction = config.ActionFormatSQL
+	actionTransaction    keymapAction = config.ActionTransaction
 	actionSelectAll      keymapAction = config.ActionSelectAll
 	actionClearSelection keymapAction = config.ActionClearSelection
 	actionCommandPalette keymapAction = config.ActionCommandPalette
+	actionNewEditorTab   keymapAction = config.ActionNewEditorTab
+	actionCloseEditorTab keymapAction = config.ActionCloseEditorTab
+	actionNextEditorTab  keymapAction = config.ActionNextEditorTab
+	actionPrevEditorTab  keymapAction = config.ActionPrevEditorTab
 )
 
 var knownKeymapActions = map[keymapAction]struct{}{
@@ -47,12 +56,21 @@ var knownKeymapActions = map[keymapAction]struct{}{
 	actionChangeProfiler: {},
 	actionExportCSV:      {},
 	actionHistory:        {},
+	actionQueryLibrary:   {},
 	actionSettings:       {},
 	actionImportDump:     {},
+	actionImportData:     {},
 	actionInspectSchema:  {},
+	actionExplainQuery:   {},
+	actionFormatSQL:      {},
+	actionTransaction:    {},
 	actionSelectAll:      {},
 	actionClearSelection: {},
 	actionCommandPalette: {},
+	actionNewEditorTab:   {},
+	actionCloseEditorTab: {},
+	actionNextEditorTab:  {},
+	actionPrevEditorTab:  {},
 }
 
 type actionKeymap struct {
@@ -0,0 +1,302 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// tableSeekKey is what keyset pagination needs to know about a table.
+type tableSeekKey struct {
+	primary []string
+	indexed map[string]bool // lower-cased columns that lead an index
+	notNull map[string]bool // lower-cased
+}
+
+// resultPageCursor records how the page on screen was read. A page read in
+// key order lets the next one seek past its last row instead of counting
+// rows with OFFSET, which is what makes deep pages of huge tables cheap.
+type resultPageCursor struct {
+	table   string
+	offset  int
+	columns []string // seek key; empty when the page was read with OFFSET
+	asc     bool
+	filter  string // the filter the page was read with
+	// anchor is the key the page starts after, or before when backward is
+	// set. Without one a forward page reads from the start, or from offset,
+	// and a backward page is the last page.
+	anchor   []any
+	backward bool
+}
+
+func (c resultPageCursor) sameScope(other resultPageCursor) bool {
+	return c.table == other.table && c.offset == other.offset && c.asc == other.asc &&
+		c.filter == other.filter && slices.Equal(c.columns, other.columns)
+}
+
+// usesOffset reports whether the page is read by skipping rows.
+func (c resultPageCursor) usesOffset() bool {
+	return len(c.columns) == 0 || (c.anchor == nil && !c.backward && c.offset > 0)
+}
+
+// seekColumns picks a unique, NOT NULL key in the order the page is sorted:
+// the primary key, led by the sort column when that column is indexed.
+// Anything else could skip or repeat rows, so it returns nil.
+func (k *tableSeekKey) seekColumns(sortColumn string) []string {
+	if k == nil || len(k.primary) == 0 {
+		return nil
+	}
+	if sortColumn == "" || strings.EqualFold(k.primary[0], sortColumn) {
+		return k.primary
+	}
+	folded := strings.ToLower(sortColumn)
+	if !k.indexed[folded] || !k.notNull[folded] {
+		return nil
+	}
+	columns := []string{sortColumn}
+	for _, column := range k.primary {
+		if !strings.EqualFold(column, sortColumn) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// pageCursorFor describes reading the page at offset with the current
+// table, sort and filter.
+func (a *App) pageCursorFor(offset int) resultPageCursor {
+	cursor := resultPageCursor{table: a.selectedTable, offset: offset, asc: true}
+	if filter := a.activeResultFilter(a.selectedTable); filter != nil {
+		clause, args := resultFilterSQL(a.dbType, filter)
+		cursor.filter = clause + fmt.Sprint(args)
+	}
+	sortColumn := a.serverSortColumnName()
+	if sortColumn != "" {
+		cursor.asc = a.sortAsc
+	}
+	cursor.columns = a.tableSeekKeys[a.selectedTable].seekColumns(sortColumn)
+	return cursor
+}
+
+// seekCursorFrom continues from the key of a row on screen: the last row
+// for the next page, the first for the previous one. It returns nil when
+// the page on screen was not read in key order.
+func (a *App) seekCursorFrom(row, offset int, backward bool) *resultPageCursor {
+	current := a.pageCursor
+	if current == nil || len(current.columns) == 0 || !current.sameScope(a.pageCursorFor(a.pageOffset)) {
+		return nil
+	}
+	anchor, ok := a.resultRowSeekKey(row, current.columns)
+	if !ok {
+		return nil
+	}
+	next := a.pageCursorFor(offset)
+	next.anchor = anchor
+	next.backward = backward
+	return &next
+}
+
+// resultRowSeekKey reads the key columns of a displayed row as query
+// arguments.
+func (a *App) resultRowSeekKey(row int, columns []string) ([]any, bool) {
+	if row < 1 || row > a.currentResultRowCount() {
+		return nil, false
+	}
+	key := make([]any, len(columns))
+	for index, column := range columns {
+		col := a.resultColumnIndex(column)
+		if col < 0 {
+			return nil, false
+		}
+		ref, ok := a.results.GetCell(row, col).GetReference().(resultCellReference)
+		if !ok || ref.isNull || ref.rawValue == nil {
+			return nil, false
+		}
+		value := ref.rawValue
+		switch typed := value.(type) {
+		case []byte:
+			if databaseByteValueIsText(ref.databaseType) {
+				value = string(typed)
+			}
+		case time.Time:
+			// SQLite keeps dates as text, which a bound time would not match.
+			if usesSQLiteDialect(a.dbType) {
+				return nil, false
+			}
+		}
+		key[index] = value
+	}
+	return key, true
+}
+
+// keysetSQL renders the seek condition and ORDER BY for cursor. Arguments
+// are numbered from firstArg so they can follow the filter's.
+func keysetSQL(dbType config.DBType, cursor resultPageCursor, firstArg int) (string, string, []any) {
+	ascending := cursor.asc != cursor.backward
+	direction, comparison := "ASC", ">"
+	if !ascending {
+		direction, comparison = "DESC", "<"
+	}
+	quoted := make([]string, len(cursor.columns))
+	order := make([]string, len(cursor.columns))
+	for index, column := range cursor.columns {
+		quoted[index] = quoteIdentifier(dbType, column)
+		order[index] = quoted[index] + " " + direction
+	}
+	orderBy := " ORDER BY " + strings.Join(order, ", ")
+	if cursor.anchor == nil {
+		return "", orderBy, nil
+	}
+	placeholders := make([]string, len(cursor.anchor))
+	for index := range placeholders {
+		placeholders[index] = numberedResultFilterPlaceholder(dbType, firstArg+index)
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf("%s %s %s", quoted[0], comparison, placeholders[0]), orderBy, cursor.anchor
+	}
+	// Row values compare lexicographically, matching the ORDER BY.
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(quoted, ", "), comparison, strings.Join(placeholders, ", ")), orderBy, cursor.anchor
+}
+
+// reverseResultRows restores key order after a backward page, which is read
+// in reverse.
+func reverseResultRows(results *tview.Table, rowCount int) {
+	columns := results.GetColumnCount()
+	for top, bottom := 1, rowCount; top < bottom; top, bottom = top+1, bottom-1 {
+		for col := 0; col < columns; col++ {
+			upper, lower := results.GetCell(top, col), results.GetCell(bottom, col)
+			results.SetCell(top, col, lower)
+			results.SetCell(bottom, col, upper)
+		}
+	}
+}
+
+// loadTableSeekKey learns a table's seek key in the background, once per
+// connection. Pages read before it arrives use OFFSET.
+func (a *App) loadTableSeekKey(table string) {
+	if a == nil || a.db == nil || table == "" {
+		return
+	}
+	if _, loaded := a.tableSeekKeys[table]; loaded || a.tableSeekKeyLoads[table] {
+		return
+	}
+	if a.tableSeekKeyLoads == nil {
+		a.tableSeekKeyLoads = make(map[string]bool)
+	}
+	a.tableSeekKeyLoads[table] = true
+	db, dbType := a.db, a.dbType
+	namespace := a.defaultObjectNamespace("")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		defer cancel()
+		key, err := loadTableSeekKey(ctx, db, dbType, table, namespace)
+		a.queueUpdateDraw(func() {
+			if a.db != db || a.dbType != dbType {
+				return
+			}
+			delete(a.tableSeekKeyLoads, table)
+			if err != nil {
+				return
+			}
+			if a.tableSeekKeys == nil {
+				a.tableSeekKeys = make(map[string]*tableSeekKey)
+			}
+			a.tableSeekKeys[table] = key
+		})
+	}()
+}
+
+func loadTableSeekKey(ctx context.Context, db *sql.DB, dbType config.DBType, tableName, defaultNamespace string) (*tableSeekKey, error) {
+	columns, err := loadSidebarColumnMetadata(ctx, db, dbType, tableName, defaultNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("load columns: %w", err)
+	}
+	key := &tableSeekKey{notNull: make(map[string]bool, len(columns))}
+	for _, column := range columns {
+		if column.primaryKey {
+			key.primary = append(key.primary, column.name)
+		}
+		key.notNull[strings.ToLower(column.name)] = column.notNull || column.primaryKey
+	}
+	namespace, table := splitQualifiedIdentifier(tableName)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	// Without index metadata only the primary key is used.
+	key.indexed, _ = loadLeadingIndexColumns(ctx, db, dbType, tableName, namespace, table)
+	return key, nil
+}
+
+// loadLeadingIndexColumns lists the columns that lead a full (non-partial)
+// index, which are the ones a seek on the sort column can use.
+func loadLeadingIndexColumns(ctx context.Context, db *sql.DB, dbType config.DBType, tableName, namespace, table string) (map[string]bool, error) {
+	indexed := make(map[string]bool)
+	collect := func(query string, args ...any) error {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var column string
+			if err := rows.Scan(&column); err != nil {
+				return err
+			}
+			indexed[strings.ToLower(column)] = true
+		}
+		return rows.Err()
+	}
+
+	switch dbType {
+	case config.PostgreSQL:
+		return indexed, collect(`SELECT a.attname FROM pg_index i
+JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = i.indkey[0]
+WHERE i.indrelid = to_regclass($1) AND i.indpred IS NULL`, quoteIdentifier(dbType, tableName))
+	case config.MySQL:
+		return indexed, collect(`SELECT COLUMN_NAME FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND SEQ_IN_INDEX = 1 AND COLUMN_NAME IS NOT NULL`, namespace, table)
+	case config.SQLite, config.Turso, config.CloudflareD1:
+		rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA index_list(%s)", quoteIdentifier(dbType, table)))
+		if err != nil {
+			return nil, err
+		}
+		var indexes []string
+		for rows.Next() {
+			var seq, unique, partial int
+			var name, origin string
+			if err := rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if partial == 0 {
+				indexes = append(indexes, name)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			var seqno, cid int
+			var name sql.NullString
+			// The first row of index_info is the leading column.
+			err := db.QueryRowContext(ctx, fmt.Sprintf("PRAGMA index_info(%s)", quoteIdentifier(dbType, index))).Scan(&seqno, &cid, &name)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+			if name.Valid && cid >= 0 {
+				indexed[strings.ToLower(name.String)] = true
+			}
+		}
+		return indexed, nil
+	default:
+		return indexed, nil
+	}
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+func TestKeysetPagesSeekByPrimaryKeyInBothDirections(t *testing.T) {
+	db, err := database.Connect(&config.ConnectionConfig{Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, grp INTEGER NOT NULL, name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "CREATE INDEX items_name ON items (name)"); err != nil {
+		t.Fatal(err)
+	}
+	for id := 1; id <= 30; id++ {
+		if _, err := db.ExecContext(ctx, "INSERT INTO items VALUES (?, ?, ?)", id, id%2, fmt.Sprintf("n%02d", 31-id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	key, err := loadTableSeekKey(ctx, db, config.SQLite, "items", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(key.seekColumns(""), []string{"id"}) || !reflect.DeepEqual(key.seekColumns("name"), []string{"name", "id"}) || key.seekColumns("grp") != nil {
+		t.Fatalf("seek columns = %v / %v / %v", key.seekColumns(""), key.seekColumns("name"), key.seekColumns("grp"))
+	}
+
+	// Odd ids only: 1, 3, ..., 29.
+	app := &App{
+		db:                  db,
+		dbType:              config.SQLite,
+		selectedTable:       "items",
+		tableResultsActive:  true,
+		results:             newResultTable(),
+		statusBar:           tview.NewTextView(),
+		resultLimit:         4,
+		sortColumn:          -1,
+		totalRowCount:       30,
+		totalRowCountApprox: true,
+		tableSeekKeys:       map[string]*tableSeekKey{"items": key},
+		resultFilter:        &resultValueFilter{table: "items", column: "grp", value: "1", operator: resultFilterEqual},
+	}
+	load := func(offset int, cursor *resultPageCursor) []string {
+		t.Helper()
+		app.pageOffset, app.pageCursor = offset, cursor
+		request, err := app.prepareTableResultRequest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cursor != nil && strings.Contains(request.query, "OFFSET") {
+			t.Fatalf("seek page used OFFSET: %s", request.query)
+		}
+		if err := app.LoadResults(); err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for row := 1; row <= app.currentResultRowCount(); row++ {
+			ids = append(ids, app.results.GetCell(row, 0).Text)
+		}
+		return ids
+	}
+
+	if got := load(0, nil); strings.Join(got, ",") != "1,3,5,7" {
+		t.Fatalf("first page = %v", got)
+	}
+	if got := load(4, app.seekCursorFrom(4, 4, false)); strings.Join(got, ",") != "9,11,13,15" {
+		t.Fatalf("next page = %v", got)
+	}
+	load(8, app.seekCursorFrom(4, 8, false))
+	if got := load(4, app.seekCursorFrom(1, 4, true)); strings.Join(got, ",") != "9,11,13,15" {
+		t.Fatalf("previous page = %v", got)
+	}
+	// The estimate of 30 rows is double the real 15, so End lands on page 8
+	// and paging back reaches the start early; the offset then resets.
+	last := app.pageCursorFor(28)
+	last.backward = true
+	if got := load(28, &last); strings.Join(got, ",") != "23,25,27,29" {
+		t.Fatalf("last page by estimate = %v", got)
+	}
+	if got := load(24, app.seekCursorFrom(1, 24, true)); strings.Join(got, ",") != "15,17,19,21" {
+		t.Fatalf("page before last = %v", got)
+	}
+	load(20, app.seekCursorFrom(1, 20, true))
+	if got := load(16, app.seekCursorFrom(1, 16, true)); strings.Join(got, ",") != "1,3,5" || app.pageOffset != 0 || app.pageCursor.backward {
+		t.Fatalf("short backward page = %v at offset %d, cursor %#v", got, app.pageOffset, app.pageCursor)
+	}
+	if got := load(4, app.seekCursorFrom(3, 4, false)); strings.Join(got, ",") != "7,9,11,13" {
+		t.Fatalf("next page after the reset = %v", got)
+	}
+}
+
+func TestKeysetSQLNumbersArgumentsAfterTheFilter(t *testing.T) {
+	cursor := resultPageCursor{columns: []string{"created", "id"}, asc: false, anchor: []any{"2026-01-01", 7}}
+	cond, orderBy, args := keysetSQL(config.PostgreSQL, cursor, 2)
+	if cond != `("created", "id") < ($2, $3)` || orderBy != ` ORDER BY "created" DESC, "id" DESC` || len(args) != 2 {
+		t.Fatalf("forward descending = %q %q %v", cond, orderBy, args)
+	}
+	cursor.backward = true
+	cond, orderBy, _ = keysetSQL(config.PostgreSQL, cursor, 1)
+	if cond != `("created", "id") > ($1, $2)` || orderBy != ` ORDER BY "created" ASC, "id" ASC` {
+		t.Fatalf("backward descending = %q %q", cond, orderBy)
+	}
+	cursor = resultPageCursor{columns: []string{"id"}, asc: true}
+	if cond, orderBy, args = keysetSQL(config.MySQL, cursor, 1); cond != "" || orderBy != " ORDER BY `id` ASC" || args != nil {
+		t.Fatalf("first page = %q %q %v", cond, orderBy, args)
+	}
+}
+
+func TestApproximateRowCountReadsSQLiteStatistics(t *testing.T) {
+	db, err := database.Connect(&config.ConnectionConfig{Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	for _, statement := range []string{
+		"CREATE TABLE events (id INTEGER PRIMARY KEY, kind TEXT)",
+		"CREATE INDEX events_kind ON events (kind)",
+		"INSERT INTO events (kind) VALUES ('a'), ('b'), ('a'), ('c'), ('a')",
+	} {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := approximateRowCount(ctx, db, config.SQLite, "events", ""); ok {
+		t.Fatal("estimate reported before ANALYZE")
+	}
+	if _, err := db.ExecContext(ctx, "ANALYZE"); err != nil {
+		t.Fatal(err)
+	}
+	if estimate, ok := approximateRowCount(ctx, db, config.SQLite, "events", ""); !ok || estimate != 5 {
+		t.Fatalf("estimate = %d, %v", estimate, ok)
+	}
+}
@@ -96,6 +96,8 @@ func (a *App) buildSelectedTableMetadata(ctx context.Context, tableName string)
 		return a.buildMySQLTableMetadata(ctx, tableName)
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		return a.buildSQLiteTableMetadata(ctx, tableName)
+	case config.DuckDB:
+		return a.buildDuckDBTableMetadata(ctx, tableName)
 	default:
 		return "", fmt.Errorf("schema inspection is not supported for %s", a.dbType)
 	}
@@ -364,6 +366,78 @@ func (a *App) buildSQLiteTableMetadata(ctx context.Context, tableName string) (s
 	return strings.TrimSpace(out.String()), nil
 }
 
+func (a *App) buildDuckDBTableMetadata(ctx context.Context, tableName string) (string, error) {
+	schemaName, tableOnly := splitQualifiedIdentifier(tableName)
+	schemaName = a.defaultObjectNamespace(schemaName)
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("[::b][#f9e2af]%s[-][-]\n\n", tableName))
+	if cfg := a.currentConnectionConfig(); cfg != nil {
+		out.WriteString(fmt.Sprintf("[#a6adc8]File:[-] %s\n", nonEmptyOr(cfg.FilePath, cfg.Name)))
+	}
+	out.WriteString(fmt.Sprintf("[#a6adc8]Schema:[-] %s\n\n", schemaName))
+
+	appendSectionTitle(&out, "Columns")
+	colRows, err := a.db.QueryContext(ctx, `SELECT column_name, data_type, is_nullable, COALESCE(column_default, '')
+FROM information_schema.columns
+WHERE table_schema = $1 AND table_name = $2
+ORDER BY ordinal_position`, schemaName, tableOnly)
+	if err != nil {
+		return "", err
+	}
+	defer colRows.Close()
+	for colRows.Next() {
+		var name, dataType, nullable, defaultValue string
+		if err := colRows.Scan(&name, &dataType, &nullable, &defaultValue); err != nil {
+			return "", err
+		}
+		line := fmt.Sprintf("• %s  [%s]", name, dataType)
+		if nullable == "NO" {
+			line += " not null"
+		}
+		if strings.TrimSpace(defaultValue) != "" {
+			line += " default=" + defaultValue
+		}
+		out.WriteString(line + "\n")
+	}
+
+	appendSectionTitle(&out, "Constraints")
+	constraintRows, err := a.db.QueryContext(ctx, `SELECT constraint_type, COALESCE(constraint_text, '')
+FROM duckdb_constraints()
+WHERE schema_name = $1 AND table_name = $2 AND constraint_type <> 'NOT NULL'
+ORDER BY constraint_index`, schemaName, tableOnly)
+	if err != nil {
+		return "", err
+	}
+	defer constraintRows.Close()
+	for constraintRows.Next() {
+		var constraintType, text string
+		if err := constraintRows.Scan(&constraintType, &text); err != nil {
+			return "", err
+		}
+		out.WriteString(fmt.Sprintf("• [%s] %s\n", strings.ToLower(constraintType), text))
+	}
+
+	appendSectionTitle(&out, "Indexes")
+	indexRows, err := a.db.QueryContext(ctx, `SELECT index_name, COALESCE(sql, '')
+FROM duckdb_indexes()
+WHERE schema_name = $1 AND table_name = $2
+ORDER BY index_name`, schemaName, tableOnly)
+	if err != nil {
+		return "", err
+	}
+	defer indexRows.Close()
+	for indexRows.Next() {
+		var name, definition string
+		if err := indexRows.Scan(&name, &definition); err != nil {
+			return "", err
+		}
+		out.WriteString(fmt.Sprintf("• %s\n  %s\n", name, definition))
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
 func appendSectionTitle(out *strings.Builder, title string) {
 	if out == nil {
 		return
@@ -360,6 +360,8 @@ func (a *App) defaultObjectNamespace(namespace string) string {
 			return strings.TrimSpace(cfg.Database)
 		}
 		return strings.TrimSpace(a.dbName)
+	case config.DuckDB:
+		return "main"
 	default:
 		return ""
 	}
@@ -10,15 +10,55 @@ import (
 	"time"
 
 	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/database"
 )
 
+// manualQueryTimeout bounds editor queries on profiles without their own
+// statement timeout.
+const manualQueryTimeout = 30 * time.Second
+
 // ExecuteQuery runs a SQL query and displays results or affected row count.
+// Buffers with several statements run as a script with a tab per statement,
+// and buffers with placeholders first prompt for their values.
 func (a *App) ExecuteQuery(query string) {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return
 	}
+	if names := database.QueryParams(a.dbType, query); len(names) > 0 {
+		a.showQueryParams(query, names, func(values map[string]any) { a.executeQuery(query, values) })
+		return
+	}
+	a.executeQuery(query, nil)
+}
 
+// executeQuery runs the buffer with its placeholder values, if any.
+func (a *App) executeQuery(query string, values map[string]any) {
+	run := func() { a.runQuery(query, values) }
+	if statements, script := scriptStatements(a.dbType, query); script {
+		run = func() { a.runScript(query, statements, values) }
+	}
+	// The Read-Only Guard already blocks writes, so only writable prod
+	// sessions need the typed confirmation.
+	if conn := a.activeConn; conn != nil && conn.IsProduction() && !conn.ReadOnly {
+		if writes := productionWriteStatements(a.dbType, query); len(writes) > 0 {
+			a.confirmProductionWrite(writes, run)
+			return
+		}
+	}
+	run()
+}
+
+func (a *App) runQuery(query string, values map[string]any) {
+	if a.transaction != nil && endsTransaction(query) {
+		a.showTransactionControlBlocked()
+		return
+	}
+	statement, args, err := database.NewParamBinder(a.dbType, values).Bind(query)
+	if err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v", iconWarn, err), "main")
+		return
+	}
 	ctx, finish, ok := a.startQueryLifecycle()
 	if !ok {
 		a.queueUpdateDraw(func() {
@@ -32,17 +72,27 @@ func (a *App) ExecuteQuery(query string) {
 	// or filter action always wins. Do not move this claim into the worker: goroutine
 	// scheduling must never reorder user intent.
 	db := a.db
+	tx := a.transaction
 	resultGeneration := a.advanceResultGeneration()
 	requestedLimit := a.effectiveResultLimit()
 	startedAt := a.queryStartedAt
 	readOnly := a.activeConn != nil && a.activeConn.ReadOnly
 	connectionName := a.dbName
 	connectionKey, _ := a.activeConnectionKey()
+	timeout := manualQueryTimeout
+	if a.activeConn != nil {
+		if configured := a.activeConn.StatementTimeoutDuration(); configured > 0 {
+			timeout = configured
+		}
+	}
 
-	go a.executeQueryWorker(ctx, finish, db, resultGeneration, requestedLimit, startedAt, readOnly, connectionName, connectionKey, query)
+	go a.executeQueryWorker(ctx, finish, db, tx, resultGeneration, requestedLimit, startedAt, readOnly, timeout, connectionName, connectionKey, query, statement, args)
 }
 
-func (a *App) executeQueryWorker(ctx context.Context, finish func(), db *sql.DB, resultGeneration uint64, requestedLimit int, startedAt time.Time, readOnly bool, connectionName, connectionKey, query string) {
+// executeQueryWorker runs statement, the query with its placeholders bound
+// to args; query is the editor text kept for history and error messages.
+// With an open transaction tx, the statement runs in it instead of the pool.
+func (a *App) executeQueryWorker(ctx context.Context, finish func(), db *sql.DB, tx *database.Transaction, resultGeneration uint64, requestedLimit int, startedAt time.Time, readOnly bool, timeout time.Duration, connectionName, connectionKey, query, statement string, args []any) {
 	finishOnReturn := true
 	defer func() {
 		if finishOnReturn {
@@ -58,16 +108,21 @@ func (a *App) executeQueryWorker(ctx context.Context, finish func(), db *sql.DB,
 		return
 	}
 
-	pingCtx, pingCancel := context.WithTimeout(ctx, 5*time.Second)
-	defer pingCancel()
-	if err := db.PingContext(pingCtx); err != nil {
-		if a.handleQueryCancellation(err) {
+	var runner queryRunner = db
+	if tx != nil {
+		runner = tx
+	} else {
+		pingCtx, pingCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer pingCancel()
+		if err := db.PingContext(pingCtx); err != nil {
+			if a.handleQueryCancellation(err) {
+				return
+			}
+			a.queueUpdateDraw(func() {
+				a.ShowAlert(fmt.Sprintf("%s Connection lost: %v\n\nPress %s to reconnect from Dashboard.", iconWarn, err, a.escapedActionShortcut(actionDashboard)), "main")
+			})
 			return
 		}
-		a.queueUpdateDraw(func() {
-			a.ShowAlert(fmt.Sprintf("%s Connection lost: %v\n\nPress %s to reconnect from Dashboard.", iconWarn, err, a.escapedActionShortcut(actionDashboard)), "main")
-		})
-		return
 	}
 
 	firstToken := firstSQLToken(query)
@@ -85,10 +140,10 @@ func (a *App) executeQueryWorker(ctx context.Context, finish func(), db *sql.DB,
 	}
 
 	if isRead {
-		queryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		queryCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
-		rows, err := db.QueryContext(queryCtx, query)
+		rows, err := runner.QueryContext(queryCtx, statement, args...)
 		if err != nil {
 			if a.handleQueryCancellation(err) {
 				return
@@ -158,9 +213,9 @@ func (a *App) executeQueryWorker(ctx context.Context, finish func(), db *sql.DB,
 		return
 	}
 
-	queryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	res, err := db.ExecContext(queryCtx, query)
+	res, err := runner.ExecContext(queryCtx, statement, args...)
 	if err != nil {
 		if a.handleQueryCancellation(err) {
 			return
@@ -251,6 +306,8 @@ func (a *App) showQueryError(err error, query string) {
 	missingRelation := sqlErrorMatchesAnyPattern(errMsg, sqlMissingRelationPatterns)
 
 	switch {
+	case a.transaction != nil && strings.Contains(errLower, "current transaction is aborted"):
+		hint = fmt.Sprintf("\n\n💡 Hint: A statement failed inside the open transaction, so PostgreSQL ignores the rest until it is rolled back. Press %s and choose Roll back.", a.escapedActionShortcut(actionTransaction))
 	case missingColumn:
 		if suggestion, ok := sqlMissingColumnSuggestion(errMsg, query, a.sqlCompletionCatalog, a.selectedTable); ok {
 			hint = fmt.Sprintf("\n\n💡 Did you mean column %s? Correct it, or press Ctrl+Space in this expression for ranked columns.", tview.Escape(suggestion))
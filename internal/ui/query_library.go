@@ -120,7 +120,7 @@ func (a *App) showQueryLibraryListModal(pageName, title, footerText string, item
 			return
 		}
 		a.pages.RemovePage(pageName)
-		a.queryInput.SetText(items[index].sql, true)
+		a.loadQueryIntoEditor(items[index].sql)
 		a.setFocusWithColor(a.queryInput)
 		a.flashStatus(
 			fmt.Sprintf("[green]%s Query loaded[-]", iconSuccess),
@@ -180,7 +180,7 @@ func stableConnectionSignature(cfg *config.ConnectionConfig) string {
 
 	parts := []string{"v1", string(cfg.Type)}
 	switch cfg.Type {
-	case config.SQLite:
+	case config.SQLite, config.DuckDB:
 		path := strings.TrimSpace(cfg.FilePath)
 		if path != "" {
 			path = filepath.Clean(path)
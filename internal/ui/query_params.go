@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+	"github.com/shreyam1008/dbterm/internal/dataimport"
+	"github.com/shreyam1008/dbterm/internal/history"
+)
+
+const pageQueryParams = "queryParams"
+
+// Types offered for a parameter value. Auto reads NULL and plain numbers as
+// such and everything else as text.
+const (
+	queryParamAuto      = "Auto"
+	queryParamText      = "Text"
+	queryParamInteger   = "Integer"
+	queryParamNumber    = "Number"
+	queryParamBoolean   = "Boolean"
+	queryParamDate      = "Date"
+	queryParamTimestamp = "Timestamp"
+	queryParamNull      = "NULL"
+)
+
+var queryParamTypes = []string{
+	queryParamAuto, queryParamText, queryParamInteger, queryParamNumber,
+	queryParamBoolean, queryParamDate, queryParamTimestamp, queryParamNull,
+}
+
+// boundStatement is one script statement ready for the driver.
+type boundStatement struct {
+	sql  string
+	args []any
+}
+
+// showQueryParams asks for the value of every placeholder, prefilled with
+// the values last used for the same query, and runs the query with them.
+func (a *App) showQueryParams(query string, names []string, run func(values map[string]any)) {
+	remembered := make(map[string]history.ParamValue)
+	if a.historyMgr != nil {
+		for _, value := range a.historyMgr.Params(query) {
+			remembered[value.Name] = value
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetItemPadding(0)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+
+	valueInputs := make([]*tview.InputField, len(names))
+	typeInputs := make([]*tview.DropDown, len(names))
+	for index, name := range names {
+		last := remembered[name]
+		valueInputs[index] = tview.NewInputField().SetLabel(truncateForDisplay(name, 24)).SetText(last.Value).SetFieldWidth(40)
+		typeInputs[index] = tview.NewDropDown().SetLabel("  type").SetOptions(queryParamTypes, nil).
+			SetCurrentOption(queryParamTypeIndex(last.Type))
+		form.AddFormItem(valueInputs[index]).AddFormItem(typeInputs[index])
+	}
+
+	closeParams := func() {
+		a.pages.RemovePage(pageQueryParams)
+		a.setFocusWithColor(a.queryInput)
+	}
+	submit := func() {
+		values := make(map[string]any, len(names))
+		inputs := make([]history.ParamValue, len(names))
+		for index, name := range names {
+			_, kind := typeInputs[index].GetCurrentOption()
+			input := valueInputs[index].GetText()
+			value, err := queryParamValue(a.dbType, kind, input)
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Parameter %s: %v", iconWarn, tview.Escape(name), err), pageQueryParams)
+				return
+			}
+			values[name] = value
+			inputs[index] = history.ParamValue{Name: name, Type: kind, Value: input}
+		}
+		if a.historyMgr != nil {
+			if err := a.historyMgr.SaveParams(query, inputs); err != nil {
+				fmt.Printf("⚠ Warning: failed to persist query parameters: %v\n", err)
+			}
+		}
+		closeParams()
+		run(values)
+	}
+	form.AddButton("Run", submit)
+	form.AddButton("Cancel", closeParams)
+	form.SetCancelFunc(closeParams)
+
+	summary := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	summary.SetBackgroundColor(crust)
+	summary.SetText(fmt.Sprintf("[#a6adc8]%d parameter(s), bound as driver arguments. Auto reads NULL and plain numbers as such and anything else as text; pick a type to be explicit.[-]", len(names)))
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 2, 0, false).
+		AddItem(form, 0, 1, true)
+	container.SetBorder(true).SetTitle(" Query parameters ").SetTitleColor(mauve).SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	modalW, modalH := a.modalSize(64, 90, 10, 30)
+	modalH = min(modalH, 2*len(names)+7)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageQueryParams, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+func queryParamTypeIndex(kind string) int {
+	for index, candidate := range queryParamTypes {
+		if candidate == kind {
+			return index
+		}
+	}
+	return 0
+}
+
+// queryParamValue converts typed input to a driver argument. Booleans become
+// numbers outside PostgreSQL and DuckDB, dates and timestamps stay text the
+// engine parses, and MySQL gets timestamps without the ISO T or offset
+// (converted to UTC), like data file imports.
+func queryParamValue(dbType config.DBType, kind, input string) (any, error) {
+	value := strings.TrimSpace(input)
+	switch kind {
+	case queryParamText:
+		return input, nil
+	case queryParamNull:
+		return nil, nil
+	case queryParamAuto:
+		switch {
+		case strings.EqualFold(value, "null"):
+			return nil, nil
+		case dataimport.Matches(dataimport.KindInteger, value):
+			return strconv.ParseInt(value, 10, 64)
+		case value != "" && dataimport.Matches(dataimport.KindReal, value):
+			return strconv.ParseFloat(value, 64)
+		default:
+			return input, nil
+		}
+	case queryParamInteger:
+		if !dataimport.Matches(dataimport.KindInteger, value) {
+			return nil, fmt.Errorf("%q is not an integer", truncateForDisplay(input, 40))
+		}
+		return strconv.ParseInt(value, 10, 64)
+	case queryParamNumber:
+		if !dataimport.Matches(dataimport.KindReal, value) {
+			return nil, fmt.Errorf("%q is not a number", truncateForDisplay(input, 40))
+		}
+		if dataimport.Matches(dataimport.KindInteger, value) {
+			return strconv.ParseInt(value, 10, 64)
+		}
+		return strconv.ParseFloat(value, 64)
+	case queryParamBoolean:
+		flag, ok := dataimport.ParseBool(value)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a boolean", truncateForDisplay(input, 40))
+		}
+		if dbType == config.PostgreSQL || dbType == config.DuckDB {
+			return flag, nil
+		}
+		if flag {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case queryParamDate:
+		if !dataimport.Matches(dataimport.KindDate, value) {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", truncateForDisplay(input, 40))
+		}
+		return value, nil
+	case queryParamTimestamp:
+		parsed, ok := dataimport.ParseTimestamp(value)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a timestamp", truncateForDisplay(input, 40))
+		}
+		if dbType == config.MySQL {
+			return parsed.UTC().Format("2006-01-02 15:04:05.999999"), nil
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", kind)
+	}
+}
+
+// bindScript binds every statement in order through one binder so anonymous
+// ? placeholders get the values the prompt numbered them with.
+func bindScript(dbType config.DBType, statements []database.ScriptStatement, values map[string]any) ([]boundStatement, error) {
+	binder := database.NewParamBinder(dbType, values)
+	bound := make([]boundStatement, len(statements))
+	for index, statement := range statements {
+		sql, args, err := binder.Bind(statement.SQL)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", index+1, err)
+		}
+		bound[index] = boundStatement{sql: sql, args: args}
+	}
+	return bound, nil
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/history"
+)
+
+func TestQueryParamValueConvertsTypedInput(t *testing.T) {
+	for _, test := range []struct {
+		dbType config.DBType
+		kind   string
+		input  string
+		want   any
+	}{
+		{config.PostgreSQL, queryParamAuto, "null", nil},
+		{config.PostgreSQL, queryParamAuto, "42", int64(42)},
+		{config.PostgreSQL, queryParamAuto, "2.5", 2.5},
+		{config.PostgreSQL, queryParamAuto, "007", "007"},
+		{config.PostgreSQL, queryParamAuto, "", ""},
+		{config.PostgreSQL, queryParamText, "NULL", "NULL"},
+		{config.SQLite, queryParamNull, "anything", nil},
+		{config.SQLite, queryParamNumber, "12", int64(12)},
+		{config.PostgreSQL, queryParamBoolean, "yes", true},
+		{config.MySQL, queryParamBoolean, "f", int64(0)},
+		{config.SQLite, queryParamDate, " 2026-02-28 ", "2026-02-28"},
+		{config.MySQL, queryParamTimestamp, "2026-02-28T10:00:00+02:00", "2026-02-28 08:00:00"},
+	} {
+		got, err := queryParamValue(test.dbType, test.kind, test.input)
+		if err != nil || got != test.want {
+			t.Errorf("%s %s %q = %#v, %v; want %#v", test.dbType, test.kind, test.input, got, err, test.want)
+		}
+	}
+	for _, kind := range []string{queryParamInteger, queryParamNumber, queryParamBoolean, queryParamDate, queryParamTimestamp} {
+		if _, err := queryParamValue(config.PostgreSQL, kind, "NaN"); err == nil {
+			t.Errorf("%s accepted NaN", kind)
+		}
+	}
+}
+
+func TestExecuteQueryPromptsWithRememberedValues(t *testing.T) {
+	manager, err := history.NewManagerAt(filepath.Join(t.TempDir(), "history.json"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := "SELECT * FROM t WHERE id = :id"
+	if err := manager.SaveParams(query, []history.ParamValue{{Name: ":id", Type: queryParamInteger, Value: "7"}}); err != nil {
+		t.Fatal(err)
+	}
+	application := tview.NewApplication()
+	pages := tview.NewPages()
+	pages.AddPage("main", tview.NewBox(), true, true)
+	application.SetRoot(pages, true)
+	app := &App{app: application, pages: pages, dbType: config.SQLite, historyMgr: manager, queryInput: tview.NewTextArea()}
+
+	app.ExecuteQuery(query)
+	if page, _ := app.pages.GetFrontPage(); page != pageQueryParams {
+		t.Fatalf("front page = %q, want the parameter prompt", page)
+	}
+	if app.queryRunning {
+		t.Fatal("query started before its parameters were entered")
+	}
+	input, ok := application.GetFocus().(*tview.InputField)
+	if !ok || input.GetText() != "7" {
+		t.Fatalf("focus = %T, want the :id input prefilled with its last value", application.GetFocus())
+	}
+}
+
+func TestRunScriptStatementsBindsArguments(t *testing.T) {
+	db := testCellEditDB(t, `CREATE TABLE t (id INTEGER, name TEXT)`)
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	statements, _ := scriptStatements(config.SQLite, "INSERT INTO t VALUES (:id, ?); SELECT name FROM t WHERE id = :id AND name = ?")
+	bound, err := bindScript(config.SQLite, statements, map[string]any{":id": int64(1), "?1": "it's", "?2": "it's"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := runScriptStatements(context.Background(), conn, scriptJob{statements: statements, bound: bound, timeout: time.Minute, requestedLimit: 100})
+	if results[0].rowsAffected != 1 || results[1].status != scriptStatementOK || results[1].rowCount != 1 {
+		t.Fatalf("results = %+v", results)
+	}
+	if _, err := bindScript(config.SQLite, statements, map[string]any{":id": int64(1)}); err == nil {
+		t.Fatal("a script with unbound placeholders was bound")
+	}
+}
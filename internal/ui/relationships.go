@@ -200,7 +200,10 @@ func loadPostgresIncomingForeignKeyReferences(ctx context.Context, db *sql.DB, s
        source_table.relname,
        source_attribute.attname,
        target_attribute.attname,
-       key_position.ordinal
+       key_position.ordinal,
+       CASE constraint_row.confdeltype
+         WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT'
+         WHEN 'r' THEN 'RESTRICT' ELSE 'NO ACTION' END
 FROM pg_catalog.pg_constraint AS constraint_row
 JOIN pg_catalog.pg_class AS source_table ON source_table.oid = constraint_row.conrelid
 JOIN pg_catalog.pg_namespace AS source_namespace ON source_namespace.oid = source_table.relnamespace
@@ -224,12 +227,12 @@ ORDER BY source_namespace.nspname, source_table.relname, constraint_row.conname,
 
 	refs := make([]foreignKeyReference, 0)
 	for rows.Next() {
-		var name, sourceSchema, sourceTable, localColumn, targetColumn string
+		var name, sourceSchema, sourceTable, localColumn, targetColumn, onDelete string
 		var ordinal int
-		if err := rows.Scan(&name, &sourceSchema, &sourceTable, &localColumn, &targetColumn, &ordinal); err != nil {
+		if err := rows.Scan(&name, &sourceSchema, &sourceTable, &localColumn, &targetColumn, &ordinal, &onDelete); err != nil {
 			return nil, err
 		}
-		refs = appendIncomingForeignKeyComponent(refs, name, qualifiedIdentifier(sourceSchema, sourceTable), tableName, foreignKeyColumnReference{
+		refs = appendIncomingForeignKeyComponent(refs, name, qualifiedIdentifier(sourceSchema, sourceTable), tableName, onDelete, foreignKeyColumnReference{
 			localColumn: localColumn, targetColumn: targetColumn, ordinal: ordinal,
 		})
 	}
@@ -237,16 +240,21 @@ ORDER BY source_namespace.nspname, source_table.relname, constraint_row.conname,
 }
 
 func loadMySQLIncomingForeignKeyReferences(ctx context.Context, db *sql.DB, schemaName, tableName string) ([]foreignKeyReference, error) {
-	rows, err := db.QueryContext(ctx, `SELECT constraint_name,
-       table_name,
-       column_name,
-       referenced_column_name,
-       ordinal_position
-FROM information_schema.key_column_usage
-WHERE table_schema = ?
-  AND referenced_table_schema = ?
-  AND referenced_table_name = ?
-ORDER BY table_name, constraint_name, ordinal_position`, schemaName, schemaName, tableName)
+	rows, err := db.QueryContext(ctx, `SELECT usage_row.constraint_name,
+       usage_row.table_name,
+       usage_row.column_name,
+       usage_row.referenced_column_name,
+       usage_row.ordinal_position,
+       constraint_row.delete_rule
+FROM information_schema.key_column_usage AS usage_row
+JOIN information_schema.referential_constraints AS constraint_row
+  ON constraint_row.constraint_schema = usage_row.constraint_schema
+ AND constraint_row.table_name = usage_row.table_name
+ AND constraint_row.constraint_name = usage_row.constraint_name
+WHERE usage_row.table_schema = ?
+  AND usage_row.referenced_table_schema = ?
+  AND usage_row.referenced_table_name = ?
+ORDER BY usage_row.table_name, usage_row.constraint_name, usage_row.ordinal_position`, schemaName, schemaName, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -254,12 +262,12 @@ ORDER BY table_name, constraint_name, ordinal_position`, schemaName, schemaName,
 
 	refs := make([]foreignKeyReference, 0)
 	for rows.Next() {
-		var name, sourceTable, localColumn, targetColumn string
+		var name, sourceTable, localColumn, targetColumn, onDelete string
 		var ordinal int
-		if err := rows.Scan(&name, &sourceTable, &localColumn, &targetColumn, &ordinal); err != nil {
+		if err := rows.Scan(&name, &sourceTable, &localColumn, &targetColumn, &ordinal, &onDelete); err != nil {
 			return nil, err
 		}
-		refs = appendIncomingForeignKeyComponent(refs, name, sourceTable, tableName, foreignKeyColumnReference{
+		refs = appendIncomingForeignKeyComponent(refs, name, sourceTable, tableName, onDelete, foreignKeyColumnReference{
 			localColumn: localColumn, targetColumn: targetColumn, ordinal: ordinal,
 		})
 	}
@@ -287,7 +295,7 @@ func loadSQLiteIncomingForeignKeyReferences(ctx context.Context, db *sql.DB, dbT
 			if !strings.EqualFold(strings.TrimSpace(declaredTarget), strings.TrimSpace(targetTable)) {
 				continue
 			}
-			refs = appendIncomingForeignKeyComponent(refs, fmt.Sprintf("%s.fk#%d", sourceTable, id), sourceTable, targetTable, foreignKeyColumnReference{
+			refs = appendIncomingForeignKeyComponent(refs, fmt.Sprintf("%s.fk#%d", sourceTable, id), sourceTable, targetTable, onDelete, foreignKeyColumnReference{
 				localColumn: localColumn, targetColumn: targetColumn, ordinal: seq,
 			})
 		}
@@ -300,14 +308,14 @@ func loadSQLiteIncomingForeignKeyReferences(ctx context.Context, db *sql.DB, dbT
 	return sortedForeignKeyReferences(refs), nil
 }
 
-func appendIncomingForeignKeyComponent(refs []foreignKeyReference, name, sourceTable, targetTable string, component foreignKeyColumnReference) []foreignKeyReference {
+func appendIncomingForeignKeyComponent(refs []foreignKeyReference, name, sourceTable, targetTable, onDelete string, component foreignKeyColumnReference) []foreignKeyReference {
 	for index := range refs {
 		if refs[index].name == name && refs[index].sourceTable == sourceTable && refs[index].targetTable == targetTable {
 			refs[index].columns = append(refs[index].columns, component)
 			return refs
 		}
 	}
-	return append(refs, foreignKeyReference{name: name, sourceTable: sourceTable, targetTable: targetTable, columns: []foreignKeyColumnReference{component}})
+	return append(refs, foreignKeyReference{name: name, sourceTable: sourceTable, targetTable: targetTable, columns: []foreignKeyColumnReference{component}, onDelete: strings.ToUpper(onDelete)})
 }
 
 func (a *App) showRelatedDataPicker(tableName, column string, selectedValue foreignKeyRowValue, rowValues map[string]foreignKeyRowValue, relationships []rowRelationship) {
@@ -0,0 +1,471 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	profiler "github.com/shreyam1008/dbterm/internal/changeprofiler"
+)
+
+const (
+	pageResultDiffBaseline = "resultDiffBaseline"
+	pageResultDiffKeys     = "resultDiffKeys"
+
+	resultDiffKindColumn = "diff"
+)
+
+// resultDiffSnapshot is a copy of the rows on screen. It outlives the
+// connection it came from, so a later result from any connection can be
+// compared with it.
+type resultDiffSnapshot struct {
+	label   string
+	columns []string
+	types   []string
+	rows    [][]any // nil is SQL NULL
+}
+
+type resultDiffRow struct {
+	kind    profiler.DiffKind
+	before  map[string]any // baseline values; nil for added rows
+	after   map[string]any // current values; nil for removed rows
+	changed map[string]bool
+}
+
+type resultDiff struct {
+	columns    []string // baseline columns, then columns only the current result has
+	types      []string
+	keyColumns []string
+	rows       []resultDiffRow
+	inserted   int
+	deleted    int
+	updated    int
+	same       int
+}
+
+// pinOrDiffResultBaseline pins the result on screen, or offers to diff it
+// against the baseline already pinned.
+func (a *App) pinOrDiffResultBaseline() {
+	if a.resultDiffBaseline == nil {
+		a.pinResultDiffBaseline()
+		return
+	}
+	baseline := a.resultDiffBaseline
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s Baseline: %s\n\nDiff the current result against it, or pin the current result as the new baseline?", iconInfo, tview.Escape(baseline.label))).
+		AddButtons([]string{" Diff ", " Pin current ", " Cancel "}).
+		SetDoneFunc(func(index int, _ string) {
+			a.pages.RemovePage(pageResultDiffBaseline)
+			a.setFocusWithColor(a.results)
+			switch index {
+			case 0:
+				a.diffAgainstResultBaseline()
+			case 1:
+				a.pinResultDiffBaseline()
+			}
+		})
+	modal.SetBackgroundColor(bg).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(green).
+		SetTextColor(text)
+	a.pages.AddPage(pageResultDiffBaseline, modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+// pinResultDiffBaseline keeps the displayed rows as the diff baseline.
+func (a *App) pinResultDiffBaseline() {
+	snapshot, ok := a.captureResultDiffSnapshot()
+	if !ok {
+		a.flashStatus("[yellow]No result to pin — run a query or open a table first[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	a.resultDiffBaseline = &snapshot
+	a.flashStatus(fmt.Sprintf("[green]%s Pinned %d rows as the diff baseline — press B on a later result to diff[-]", iconSuccess, len(snapshot.rows)), a.currentResultRowCount(), 2400*time.Millisecond)
+}
+
+// diffAgainstResultBaseline asks for the key columns, then diffs.
+func (a *App) diffAgainstResultBaseline() {
+	baseline := a.resultDiffBaseline
+	if baseline == nil {
+		a.flashStatus(fmt.Sprintf("[yellow]%s Pin a baseline first with B on Results[-]", iconInfo), a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	current, ok := a.captureResultDiffSnapshot()
+	if !ok {
+		a.flashStatus("[yellow]No result to diff — run a query or open a table first[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	if a.isQueryRunning() {
+		a.flashStatus("[yellow]Query still running — press Esc or Ctrl+C to cancel it first[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	common := resultDiffCommonColumns(baseline.columns, current.columns)
+	if len(common) == 0 {
+		a.ShowAlert(fmt.Sprintf("%s The current result shares no columns with the baseline (%s).", iconWarn, tview.Escape(baseline.label)), "main")
+		return
+	}
+	a.showResultDiffKeys(*baseline, current, common)
+}
+
+// showResultDiffKeys lets the user pick the columns that identify a row.
+// With none picked, rows are matched by their full contents.
+func (a *App) showResultDiffKeys(baseline, current resultDiffSnapshot, common []string) {
+	picked := make([]bool, len(common))
+	for index, column := range common {
+		picked[index] = strings.EqualFold(column, "id")
+	}
+	summary := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	summary.SetBackgroundColor(mantle)
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" Diff Key Columns ").SetTitleColor(mauve).SetBorderColor(surface1)
+	list.SetBackgroundColor(bg)
+	list.SetMainTextColor(text)
+	list.SetSelectedBackgroundColor(surface0).SetSelectedTextColor(green)
+	refresh := func() {
+		selected := list.GetCurrentItem()
+		list.Clear()
+		keys := 0
+		for index, column := range common {
+			mark := "[#6c7086]○[-]"
+			if picked[index] {
+				mark = "[green]●[-]"
+				keys++
+			}
+			list.AddItem(fmt.Sprintf("  %s  %s", mark, tview.Escape(column)), "", 0, nil)
+		}
+		list.SetCurrentItem(selected)
+		match := fmt.Sprintf("rows match by %d key column(s)", keys)
+		if keys == 0 {
+			match = "no key: rows match by full row, so changes show as removed + added"
+		}
+		summary.SetText(fmt.Sprintf(" [::b]%s[-] (%d rows) → current (%d rows)\n [#a6adc8]%s[-]", tview.Escape(baseline.label), len(baseline.rows), len(current.rows), match))
+	}
+	refresh()
+	modalW, modalH := a.modalSize(64, 100, 14, 30)
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	footer.SetBackgroundColor(crust)
+	footer.SetText(resultDiffKeysFooterText(modalW))
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.pages.RemovePage(pageResultDiffKeys)
+			a.setFocusWithColor(a.results)
+			return nil
+		}
+		if matchesPlainShortcut(event, ' ') {
+			if index := list.GetCurrentItem(); index >= 0 && index < len(picked) {
+				picked[index] = !picked[index]
+				refresh()
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyEnter {
+			var keys []string
+			for index, column := range common {
+				if picked[index] {
+					keys = append(keys, column)
+				}
+			}
+			a.pages.RemovePage(pageResultDiffKeys)
+			a.setFocusWithColor(a.results)
+			a.showResultDiff(baseline, current, keys)
+			return nil
+		}
+		return event
+	})
+	container := tview.NewFlex().SetDirection(tview.FlexRow).AddItem(summary, 3, 0, false).AddItem(list, 0, 1, true).AddItem(footer, 1, 0, false)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageResultDiffKeys, grid, true, true)
+	a.app.SetFocus(list)
+}
+
+func resultDiffKeysFooterText(width int) string {
+	return footerTextThatFits(width,
+		" [yellow]Space[-] Toggle key column  │  [yellow]Enter[-] Diff  │  [yellow]Esc[-] Cancel ",
+		" [yellow]Space[-] Toggle  │  [yellow]Enter[-] Diff  │  [yellow]Esc[-] Cancel ",
+		" [yellow]Enter[-] Diff  │  [yellow]Esc[-] Cancel ",
+	)
+}
+
+// showResultDiff puts the differing rows into the results grid, where the
+// usual export covers them.
+func (a *App) showResultDiff(baseline, current resultDiffSnapshot, keyColumns []string) {
+	diff, err := diffResultSnapshots(baseline, current, keyColumns)
+	if err != nil {
+		a.ShowAlert(fmt.Sprintf("%s Could not diff the results: %v", iconWarn, err), "main")
+		return
+	}
+	a.advanceResultGeneration()
+	a.refreshScriptTabs()
+	a.tableResultsActive = false
+	a.activeTable = ""
+	a.refreshTableSidebarState()
+	a.resultColumnSearch = ""
+	a.clearResultNavigation()
+	a.resetSort()
+	a.clearColumnOverrides()
+	a.renderResultDiff(diff)
+	match := "full row"
+	if len(diff.keyColumns) > 0 {
+		match = strings.Join(diff.keyColumns, ", ")
+	}
+	a.results.SetTitle(a.workspacePanelTitle(iconResults, "Results", actionFocusResults,
+		fmt.Sprintf(" — diff vs %s by %s: +%d −%d ~%d, %d same", baseline.label, match, diff.inserted, diff.deleted, diff.updated, diff.same)))
+	a.results.ScrollToBeginning()
+	a.applyColumnWidths()
+	a.updateStatusBar("", len(diff.rows))
+	a.setFocusWithColor(a.results)
+}
+
+// captureResultDiffSnapshot copies the displayed rows with their raw values.
+func (a *App) captureResultDiffSnapshot() (resultDiffSnapshot, bool) {
+	if a == nil || a.results == nil || a.results.GetColumnCount() == 0 || a.resultColumnName(0) == "" {
+		return resultDiffSnapshot{}, false
+	}
+	columnCount := a.results.GetColumnCount()
+	snapshot := resultDiffSnapshot{
+		columns: make([]string, columnCount),
+		types:   make([]string, columnCount),
+	}
+	for col := range snapshot.columns {
+		snapshot.columns[col] = a.resultColumnName(col)
+	}
+	for row := 1; row <= a.currentResultRowCount(); row++ {
+		values := make([]any, columnCount)
+		for col := range values {
+			cell := a.results.GetCell(row, col)
+			if cell == nil {
+				continue
+			}
+			ref, ok := cell.GetReference().(resultCellReference)
+			switch {
+			case !ok:
+				values[col] = cell.Text
+			case ref.isNull:
+			case ref.rawValue != nil:
+				values[col] = cloneResultRawValue(ref.rawValue)
+			default:
+				values[col] = ref.value
+			}
+			if ok && snapshot.types[col] == "" {
+				snapshot.types[col] = ref.databaseType
+			}
+		}
+		snapshot.rows = append(snapshot.rows, values)
+	}
+
+	source := "query result"
+	if a.isTableResultActive() {
+		source = a.selectedTable
+	}
+	if cfg := a.currentConnectionConfig(); cfg != nil && strings.TrimSpace(cfg.Name) != "" {
+		source = cfg.Name + " · " + source
+	}
+	snapshot.label = fmt.Sprintf("%s @ %s", source, time.Now().Format("15:04:05"))
+	return snapshot, true
+}
+
+// resultDiffCommonColumns lists the baseline columns the current result also
+// has; only these are compared.
+func resultDiffCommonColumns(baseline, current []string) []string {
+	present := make(map[string]bool, len(current))
+	for _, column := range current {
+		present[column] = true
+	}
+	var common []string
+	seen := make(map[string]bool, len(baseline))
+	for _, column := range baseline {
+		if present[column] && !seen[column] {
+			common = append(common, column)
+			seen[column] = true
+		}
+	}
+	return common
+}
+
+// diffResultSnapshots matches rows by keyColumns, or by full row when there
+// are none, and compares the columns both results share. Values are
+// normalized by the Change Profiler codec, so results from different engines
+// compare by value rather than by driver type.
+func diffResultSnapshots(baseline, current resultDiffSnapshot, keyColumns []string) (resultDiff, error) {
+	common := resultDiffCommonColumns(baseline.columns, current.columns)
+	diff := resultDiff{keyColumns: keyColumns}
+	inUnion := make(map[string]bool)
+	for _, side := range []resultDiffSnapshot{baseline, current} {
+		for index, column := range side.columns {
+			if !inUnion[column] {
+				inUnion[column] = true
+				diff.columns = append(diff.columns, column)
+				diff.types = append(diff.types, side.types[index])
+			}
+		}
+	}
+
+	type encodedSide struct {
+		payloads [][]byte
+		keys     []string
+		values   []map[string]any
+	}
+	encode := func(side resultDiffSnapshot) (encodedSide, error) {
+		index := make(map[string]int, len(side.columns))
+		for col := len(side.columns) - 1; col >= 0; col-- {
+			index[side.columns[col]] = col
+		}
+		types := make([]string, len(common))
+		for position, column := range common {
+			types[position] = side.types[index[column]]
+		}
+		encoded := encodedSide{}
+		occurrences := make(map[string]int)
+		for _, row := range side.rows {
+			values := make([]any, len(common))
+			for position, column := range common {
+				values[position] = row[index[column]]
+			}
+			payload, err := profiler.ComparableRow(common, types, values)
+			if err != nil {
+				return encodedSide{}, err
+			}
+			first, err := profiler.ComparableKey(payload, keyColumns, 0)
+			if err != nil {
+				return encodedSide{}, err
+			}
+			key, err := profiler.ComparableKey(payload, keyColumns, occurrences[first])
+			if err != nil {
+				return encodedSide{}, err
+			}
+			occurrences[first]++
+			named := make(map[string]any, len(index))
+			for column, col := range index {
+				named[column] = row[col]
+			}
+			encoded.payloads = append(encoded.payloads, payload)
+			encoded.keys = append(encoded.keys, key)
+			encoded.values = append(encoded.values, named)
+		}
+		return encoded, nil
+	}
+	before, err := encode(baseline)
+	if err != nil {
+		return resultDiff{}, fmt.Errorf("encode baseline: %w", err)
+	}
+	after, err := encode(current)
+	if err != nil {
+		return resultDiff{}, fmt.Errorf("encode current result: %w", err)
+	}
+
+	baselineByKey := make(map[string]int, len(before.keys))
+	for index, key := range before.keys {
+		baselineByKey[key] = index
+	}
+	matched := make([]bool, len(before.keys))
+	for index, key := range after.keys {
+		old, ok := baselineByKey[key]
+		if !ok {
+			diff.rows = append(diff.rows, resultDiffRow{kind: profiler.DiffInserted, after: after.values[index]})
+			diff.inserted++
+			continue
+		}
+		matched[old] = true
+		changed, err := profiler.ChangedColumns(before.payloads[old], after.payloads[index])
+		if err != nil {
+			return resultDiff{}, fmt.Errorf("compare rows: %w", err)
+		}
+		if len(changed) == 0 {
+			diff.same++
+			continue
+		}
+		row := resultDiffRow{kind: profiler.DiffUpdated, before: before.values[old], after: after.values[index], changed: make(map[string]bool, len(changed))}
+		for _, column := range changed {
+			row.changed[column] = true
+		}
+		diff.rows = append(diff.rows, row)
+		diff.updated++
+	}
+	for index := range before.keys {
+		if !matched[index] {
+			diff.rows = append(diff.rows, resultDiffRow{kind: profiler.DiffDeleted, before: before.values[index]})
+			diff.deleted++
+		}
+	}
+	return diff, nil
+}
+
+// renderResultDiff fills the grid with one row per difference: a leading
+// DIFF column, then every column. Changed cells read "old → new".
+func (a *App) renderResultDiff(diff resultDiff) {
+	results := a.results
+	results.Clear()
+	results.SetCell(0, 0, tview.NewTableCell("DIFF").SetReference(resultDiffKindColumn).SetTextColor(peach).SetBackgroundColor(mantle).SetSelectable(true))
+	for col, column := range diff.columns {
+		results.SetCell(0, col+1, tview.NewTableCell(tview.Escape(strings.ToUpper(column))).
+			SetReference(column).
+			SetTextColor(peach).
+			SetSelectable(true).
+			SetBackgroundColor(mantle).
+			SetExpansion(1))
+	}
+	if len(diff.rows) == 0 {
+		results.SetCell(1, 0, &tview.TableCell{Text: iconInfo + " No rows returned — the results match", Color: overlay0})
+		return
+	}
+	for index, row := range diff.rows {
+		color, label := resultDiffStyle(row.kind)
+		kindRef := newResultCellReference(label, label)
+		kindRef.profilerKind = string(row.kind)
+		a.setResultDiffCell(index+1, 0, tview.NewTableCell(label).SetTextColor(color), kindRef)
+
+		for col, column := range diff.columns {
+			databaseType := diff.types[col]
+			values := row.after
+			if row.kind == profiler.DiffDeleted {
+				values = row.before
+			}
+			value, present := values[column]
+			if !present && row.before != nil {
+				value, present = row.before[column]
+			}
+			if !present {
+				ref := newResultCellReference("", "")
+				ref.profilerKind = string(row.kind)
+				a.setResultDiffCell(index+1, col+1, tview.NewTableCell("").SetExpansion(1), ref)
+				continue
+			}
+			display, cellColor := formatCellValueForDatabaseType(value, databaseType)
+			ref := newResultCellReferenceForDatabaseType(value, display, databaseType)
+			if row.changed[column] {
+				old, hadOld := row.before[column]
+				_, hasNew := row.after[column]
+				if hadOld && hasNew {
+					oldDisplay, _ := formatCellValueForDatabaseType(old, databaseType)
+					display = oldDisplay + " → " + display
+					ref = newResultCellReference(fullCellValueForDatabaseType(old, databaseType)+" → "+ref.value, display)
+					cellColor = text
+				}
+				ref.profilerCell = true
+			}
+			ref.profilerKind = string(row.kind)
+			a.setResultDiffCell(index+1, col+1, tview.NewTableCell(tview.Escape(display)).SetTextColor(cellColor).SetExpansion(1), ref)
+		}
+	}
+}
+
+// setResultDiffCell colors a diff cell the way the Change Profiler colors
+// the live rows it changed.
+func (a *App) setResultDiffCell(row, col int, cell *tview.TableCell, ref resultCellReference) {
+	cell.SetReference(ref)
+	a.results.SetCell(row, col, cell)
+	a.restoreProfilerCellStyle(cell)
+}
+
+func resultDiffStyle(kind profiler.DiffKind) (tcell.Color, string) {
+	switch kind {
+	case profiler.DiffInserted:
+		return green, "+ added"
+	case profiler.DiffDeleted:
+		return red, "- removed"
+	default:
+		return yellow, "~ changed"
+	}
+}
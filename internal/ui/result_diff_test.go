@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	profiler "github.com/shreyam1008/dbterm/internal/changeprofiler"
+)
+
+func TestDiffResultSnapshotsMatchesByKeyOrFullRow(t *testing.T) {
+	baseline := resultDiffSnapshot{
+		label:   "staging · orders",
+		columns: []string{"id", "status", "total"},
+		types:   []string{"BIGINT", "VARCHAR", "DECIMAL"},
+		rows: [][]any{
+			{[]byte("1"), []byte("paid"), []byte("10.5")},
+			{[]byte("2"), []byte("open"), []byte("3")},
+			{[]byte("3"), []byte("open"), nil},
+		},
+	}
+	current := resultDiffSnapshot{
+		label:   "prod · orders",
+		columns: []string{"id", "status", "total", "region"},
+		types:   []string{"INT8", "TEXT", "FLOAT8", "TEXT"},
+		rows: [][]any{
+			{int64(1), "paid", 10.5, "eu"},
+			{int64(2), "shipped", float64(3), "us"},
+			{int64(4), "open", nil, "us"},
+		},
+	}
+
+	byID, err := diffResultSnapshots(baseline, current, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byID.same != 1 || byID.updated != 1 || byID.inserted != 1 || byID.deleted != 1 || len(byID.rows) != 3 {
+		t.Fatalf("diff by id = +%d -%d ~%d =%d", byID.inserted, byID.deleted, byID.updated, byID.same)
+	}
+	if changed := byID.rows[0]; changed.kind != profiler.DiffUpdated || !changed.changed["status"] || changed.changed["total"] || changed.changed["region"] {
+		t.Fatalf("changed row = %#v", changed)
+	}
+	if byID.rows[2].kind != profiler.DiffDeleted || string(byID.rows[2].before["id"].([]byte)) != "3" {
+		t.Fatalf("last row = %#v, want the removed id 3", byID.rows[2])
+	}
+	if len(byID.columns) != 4 || byID.columns[3] != "region" {
+		t.Fatalf("columns = %v", byID.columns)
+	}
+
+	fullRow, err := diffResultSnapshots(baseline, current, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fullRow.same != 1 || fullRow.updated != 0 || fullRow.inserted != 2 || fullRow.deleted != 2 {
+		t.Fatalf("full-row diff = +%d -%d ~%d =%d", fullRow.inserted, fullRow.deleted, fullRow.updated, fullRow.same)
+	}
+
+	app := &App{
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		tables:     tview.NewList(),
+		queryInput: tview.NewTextArea(),
+		results:    tview.NewTable(),
+		statusBar:  tview.NewTextView(),
+	}
+	app.pages.AddPage("main", app.results, true, true)
+	app.showResultDiff(baseline, current, []string{"id"})
+	if got := app.results.GetCell(1, 0).Text; got != "~ changed" {
+		t.Fatalf("first diff row is %q", got)
+	}
+	status := app.results.GetCell(1, 2)
+	if _, background, _ := status.Style.Decompose(); status.Text != "open → shipped" || background != updateCellBG {
+		t.Fatalf("changed cell = %q on %v", status.Text, background)
+	}
+	if got := resultExportCellText(status); got != "open → shipped" {
+		t.Fatalf("changed cell exports as %q", got)
+	}
+	removed := app.results.GetCell(3, 1)
+	if _, background, _ := removed.Style.Decompose(); removed.Text != "3" || background != deleteRowBG {
+		t.Fatalf("removed cell = %q on %v", removed.Text, background)
+	}
+	if app.currentResultRowCount() != 3 {
+		t.Fatalf("diff grid shows %d rows", app.currentResultRowCount())
+	}
+}
+
+func TestPinnedResultBaselineSurvivesANewResult(t *testing.T) {
+	app := &App{
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		tables:     tview.NewList(),
+		queryInput: tview.NewTextArea(),
+		results:    tview.NewTable(),
+		statusBar:  tview.NewTextView(),
+	}
+	app.pages.AddPage("main", app.results, true, true)
+	app.results.SetCell(0, 0, tview.NewTableCell("ID").SetReference("id"))
+	app.results.SetCell(1, 0, tview.NewTableCell("1").SetReference(newResultCellReferenceForDatabaseType(int64(1), "1", "INTEGER")))
+	app.pinOrDiffResultBaseline()
+	if app.resultDiffBaseline == nil || len(app.resultDiffBaseline.rows) != 1 || app.resultDiffBaseline.types[0] != "INTEGER" {
+		t.Fatalf("baseline = %#v", app.resultDiffBaseline)
+	}
+
+	app.results.SetCell(1, 0, tview.NewTableCell("2").SetReference(newResultCellReferenceForDatabaseType(int64(2), "2", "INTEGER")))
+	app.pinOrDiffResultBaseline()
+	if page, _ := app.pages.GetFrontPage(); page != pageResultDiffBaseline {
+		t.Fatalf("front page = %q, want the diff-or-pin choice", page)
+	}
+	app.pages.RemovePage(pageResultDiffBaseline)
+	app.diffAgainstResultBaseline()
+	if page, _ := app.pages.GetFrontPage(); page != pageResultDiffKeys {
+		t.Fatalf("front page = %q, want the key chooser", page)
+	}
+	if got := app.resultDiffBaseline.rows[0][0]; got != int64(1) {
+		t.Fatalf("baseline value changed to %v", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"database/sql"
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
@@ -43,7 +42,7 @@ type resultExportScopeOption struct {
 
 type resultExportSnapshot struct {
 	headers []string
-	rows    [][]string
+	rows    [][]resultExportValue
 }
 
 type resultExportPlan struct {
@@ -51,13 +50,15 @@ type resultExportPlan struct {
 	scopeLabel   string
 	outputPath   string
 	expectedRows int
+	format       resultExportFormat
+	target       resultExportTarget
 	snapshot     resultExportSnapshot
 	db           *sql.DB
 	query        string
 	queryArgs    []any
 }
 
-type resultExportCSVProducer func(context.Context, *csv.Writer, func(int)) (int, error)
+type resultExportProducer func(context.Context, resultExportWriter, func(int)) (int, error)
 
 type resultExportCleanupError struct {
 	cause      error
@@ -71,10 +72,10 @@ func (e *resultExportCleanupError) Error() string {
 
 func (e *resultExportCleanupError) Unwrap() error { return e.cause }
 
-// exportCurrentResultsToCSV opens an explicit scope/path picker. The actual
+// exportCurrentResults opens an explicit format/scope/path picker. The actual
 // file work always happens in a worker so large table exports never block the
 // tview event loop.
-func (a *App) exportCurrentResultsToCSV() {
+func (a *App) exportCurrentResults() {
 	if a == nil {
 		return
 	}
@@ -100,10 +101,18 @@ func (a *App) exportCurrentResultsToCSV() {
 	for index, option := range options {
 		optionLabels[index] = option.label
 	}
+	formatLabels := make([]string, len(resultExportFormats))
+	for index, format := range resultExportFormats {
+		formatLabels[index] = format.name
+	}
+	formatIndex := a.resultExportFormatIndex
+	if formatIndex < 0 || formatIndex >= len(resultExportFormats) {
+		formatIndex = 0
+	}
 
 	form := tview.NewForm()
 	form.SetBorder(true).
-		SetTitle(fmt.Sprintf(" %s Export Results to CSV ", iconResults)).
+		SetTitle(fmt.Sprintf(" %s Export Results ", iconResults)).
 		SetTitleColor(mauve).
 		SetBorderColor(surface1)
 	form.SetBackgroundColor(bg)
@@ -113,17 +122,35 @@ func (a *App) exportCurrentResultsToCSV() {
 		SetButtonTextColor(green).
 		SetLabelColor(text)
 
+	pathInput := tview.NewInputField().
+		SetLabel("Path").
+		SetText(a.defaultResultExportPath(resultExportFormats[formatIndex])).
+		SetFieldWidth(72).
+		SetPlaceholder("/path/to/results" + resultExportFormats[formatIndex].extension())
+	formatInput := tview.NewDropDown().
+		SetLabel("Format").
+		SetOptions(formatLabels, nil).
+		SetCurrentOption(formatIndex)
+	formatInput.SetSelectedFunc(func(_ string, index int) {
+		if index < 0 || index >= len(resultExportFormats) || index == formatIndex {
+			return
+		}
+		formatIndex = index
+		format := resultExportFormats[index]
+		pathInput.SetPlaceholder("/path/to/results" + format.extension())
+		// Only swap extensions this picker produces; a custom name is left alone.
+		path := pathInput.GetText()
+		if extension := filepath.Ext(path); isResultExportExtension(extension) {
+			pathInput.SetText(strings.TrimSuffix(path, extension) + format.extension())
+		}
+	})
+	form.AddFormItem(formatInput)
+
 	scopeInput := tview.NewDropDown().
 		SetLabel("Scope").
 		SetOptions(optionLabels, nil).
 		SetCurrentOption(0)
 	form.AddFormItem(scopeInput)
-
-	pathInput := tview.NewInputField().
-		SetLabel("CSV Path").
-		SetText(a.defaultResultExportPath()).
-		SetFieldWidth(72).
-		SetPlaceholder("/path/to/results.csv")
 	form.AddFormItem(pathInput)
 
 	closeModal := func() {
@@ -136,18 +163,20 @@ func (a *App) exportCurrentResultsToCSV() {
 			a.ShowAlert(fmt.Sprintf("%s Choose which rows to export.", iconInfo), pageResultExport)
 			return
 		}
+		format := resultExportFormats[formatIndex]
 
-		outputPath, err := resolveResultExportPath(pathInput.GetText())
+		outputPath, err := resolveResultExportPath(pathInput.GetText(), format)
 		if err != nil {
-			a.ShowAlert(fmt.Sprintf("%s Invalid CSV destination:\n\n%v", iconWarn, err), pageResultExport)
+			a.ShowAlert(fmt.Sprintf("%s Invalid %s destination:\n\n%v", iconWarn, format.name, err), pageResultExport)
 			return
 		}
-		plan, err := a.prepareResultExportPlan(options[optionIndex], outputPath)
+		plan, err := a.prepareResultExportPlan(options[optionIndex], format, outputPath)
 		if err != nil {
-			a.ShowAlert(fmt.Sprintf("%s Could not prepare CSV export:\n\n%v", iconWarn, err), pageResultExport)
+			a.ShowAlert(fmt.Sprintf("%s Could not prepare %s export:\n\n%v", iconWarn, format.name, err), pageResultExport)
 			return
 		}
 
+		a.resultExportFormatIndex = formatIndex
 		a.pages.RemovePage(pageResultExport)
 		a.runResultExport(plan, returnFocus)
 	}
@@ -162,7 +191,7 @@ func (a *App) exportCurrentResultsToCSV() {
 	form.SetCancelFunc(closeModal)
 	form.SetFocus(0)
 
-	modalW, modalH := a.modalSize(72, 108, 11, 15)
+	modalW, modalH := a.modalSize(72, 108, 13, 17)
 	footer := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
@@ -207,7 +236,7 @@ func (a *App) resultExportScopeOptions() []resultExportScopeOption {
 	if a.db != nil && a.isTableResultActive() {
 		label := "All matching table rows"
 		if a.totalRowCount >= 0 {
-			label = fmt.Sprintf("%s (%d)", label, a.totalRowCount)
+			label = fmt.Sprintf("%s (%s)", label, a.formatTotalRowCount())
 		}
 		options = append(options, resultExportScopeOption{
 			scope: resultExportAllMatching,
@@ -217,11 +246,13 @@ func (a *App) resultExportScopeOptions() []resultExportScopeOption {
 	return options
 }
 
-func (a *App) prepareResultExportPlan(option resultExportScopeOption, outputPath string) (resultExportPlan, error) {
+func (a *App) prepareResultExportPlan(option resultExportScopeOption, format resultExportFormat, outputPath string) (resultExportPlan, error) {
 	plan := resultExportPlan{
 		scope:      option.scope,
 		scopeLabel: option.label,
 		outputPath: outputPath,
+		format:     format,
+		target:     a.currentResultExportTarget(),
 	}
 
 	switch option.scope {
@@ -257,6 +288,9 @@ func (a *App) prepareResultExportPlan(option resultExportScopeOption, outputPath
 		plan.query = query
 		plan.queryArgs = args
 		plan.expectedRows = a.totalRowCount
+		if a.totalRowCountApprox {
+			plan.expectedRows = -1
+		}
 	default:
 		return resultExportPlan{}, fmt.Errorf("unknown export scope")
 	}
@@ -274,7 +308,7 @@ func (a *App) captureResultExportSnapshot(rowIndexes []int) (resultExportSnapsho
 	columnCount := a.results.GetColumnCount()
 	snapshot := resultExportSnapshot{
 		headers: make([]string, columnCount),
-		rows:    make([][]string, 0, len(rowIndexes)),
+		rows:    make([][]resultExportValue, 0, len(rowIndexes)),
 	}
 	for column := 0; column < columnCount; column++ {
 		snapshot.headers[column] = resultExportHeaderText(a.results.GetCell(0, column))
@@ -284,9 +318,9 @@ func (a *App) captureResultExportSnapshot(rowIndexes []int) (resultExportSnapsho
 		if row <= 0 || row >= a.results.GetRowCount() {
 			return resultExportSnapshot{}, fmt.Errorf("result row %d is no longer available", row)
 		}
-		record := make([]string, columnCount)
+		record := make([]resultExportValue, columnCount)
 		for column := 0; column < columnCount; column++ {
-			record[column] = resultExportCellText(a.results.GetCell(row, column))
+			record[column] = resultExportCellValue(a.results.GetCell(row, column))
 		}
 		snapshot.rows = append(snapshot.rows, record)
 	}
@@ -306,32 +340,50 @@ func resultExportHeaderText(cell *tview.TableCell) string {
 	return stripSortIndicator(cell.Text)
 }
 
-// resultExportCellText is deliberately reference-first: TableCell.Text is a
+func resultExportCellText(cell *tview.TableCell) string {
+	return resultExportCellValue(cell).text
+}
+
+// resultExportCellValue is deliberately reference-first: TableCell.Text is a
 // preview and can be truncated or numerically rounded. Legacy references that
 // predate rawValue still retain the full string in value.
-func resultExportCellText(cell *tview.TableCell) string {
+func resultExportCellValue(cell *tview.TableCell) resultExportValue {
 	if cell == nil {
-		return ""
+		return resultExportValue{}
 	}
 	switch reference := cell.GetReference().(type) {
 	case resultCellReference:
-		return resultExportReferenceText(reference)
+		return resultExportReferenceValue(reference)
 	case *resultCellReference:
 		if reference != nil {
-			return resultExportReferenceText(*reference)
+			return resultExportReferenceValue(*reference)
 		}
 	}
-	return cell.Text
+	return resultExportValue{text: cell.Text}
 }
 
-func resultExportReferenceText(reference resultCellReference) string {
+func resultExportReferenceValue(reference resultCellReference) resultExportValue {
 	if reference.isNull {
-		return fullCellValue(nil)
+		return newResultExportValue(nil, "")
 	}
 	if reference.rawValue != nil {
-		return fullCellValueForDatabaseType(reference.rawValue, reference.databaseType)
+		return newResultExportValue(reference.rawValue, reference.databaseType)
+	}
+	return resultExportValue{text: reference.value}
+}
+
+// currentResultExportTarget names the INSERT target: the browsed table, or a
+// placeholder the user renames for ad-hoc query results.
+func (a *App) currentResultExportTarget() resultExportTarget {
+	target := resultExportTarget{table: "query_results"}
+	if a == nil {
+		return target
 	}
-	return reference.value
+	target.dbType = a.dbType
+	if a.isTableResultActive() && strings.TrimSpace(a.selectedTable) != "" {
+		target.table = a.selectedTable
+	}
+	return target
 }
 
 func (a *App) allMatchingResultExportQuery() (string, []any, error) {
@@ -376,7 +428,7 @@ func (a *App) runResultExport(plan resultExportPlan, returnFocus tview.Primitive
 	}
 	if !a.beginResultExport(cancelExport) {
 		cancel()
-		a.ShowAlert(fmt.Sprintf("%s Another CSV export is already running.", iconInfo), "main")
+		a.ShowAlert(fmt.Sprintf("%s Another export is already running.", iconInfo), "main")
 		return
 	}
 	modal.SetDoneFunc(func(_ int, _ string) {
@@ -398,7 +450,7 @@ func (a *App) runResultExport(plan resultExportPlan, returnFocus tview.Primitive
 			})
 		}
 
-		rowsWritten, exportErr := writeResultExportCSVAtomic(ctx, plan.outputPath, plan.csvProducer(), progress)
+		rowsWritten, exportErr := writeResultExportAtomic(ctx, plan.outputPath, plan.newWriter, plan.producer(), progress)
 		canceledByUser := !state.CompareAndSwap(resultExportRunning, resultExportFinished)
 		var cancelCleanupErr error
 		var cleanupErr *resultExportCleanupError
@@ -408,7 +460,7 @@ func (a *App) runResultExport(plan resultExportPlan, returnFocus tview.Primitive
 		}
 		if canceledByUser && exportErr == nil {
 			if err := os.Remove(plan.outputPath); err != nil && !os.IsNotExist(err) {
-				cancelCleanupErr = fmt.Errorf("remove completed CSV after cancellation: %w", err)
+				cancelCleanupErr = fmt.Errorf("remove completed %s after cancellation: %w", plan.format.name, err)
 			}
 		}
 		a.queueUpdateDraw(func() {
@@ -416,18 +468,18 @@ func (a *App) runResultExport(plan resultExportPlan, returnFocus tview.Primitive
 			a.restoreResultExportFocus(returnFocus)
 
 			if cancelCleanupErr != nil {
-				a.ShowAlert(fmt.Sprintf("%s CSV export was canceled, but cleanup failed:\n\n%v", iconWarn, cancelCleanupErr), "main")
+				a.ShowAlert(fmt.Sprintf("%s %s export was canceled, but cleanup failed:\n\n%v", iconWarn, plan.format.name, cancelCleanupErr), "main")
 				return
 			}
 			if canceled {
-				a.flashStatus("[yellow]CSV export canceled; no partial file kept[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+				a.flashStatus(fmt.Sprintf("[yellow]%s export canceled; no partial file kept[-]", tview.Escape(plan.format.name)), a.currentResultRowCount(), 1800*time.Millisecond)
 				return
 			}
 			if exportErr != nil {
-				a.ShowAlert(fmt.Sprintf("%s CSV export failed:\n\n%v", iconWarn, exportErr), "main")
+				a.ShowAlert(fmt.Sprintf("%s %s export failed:\n\n%v", iconWarn, plan.format.name, exportErr), "main")
 				return
 			}
-			a.ShowAlert(fmt.Sprintf("%s CSV export complete.\n\nRows: %d\nFile: %s", iconSuccess, rowsWritten, plan.outputPath), "main")
+			a.ShowAlert(fmt.Sprintf("%s %s export complete.\n\nRows: %d\nFile: %s", iconSuccess, plan.format.name, rowsWritten, plan.outputPath), "main")
 		})
 	}()
 }
@@ -473,14 +525,14 @@ func (a *App) cancelActiveResultExport() bool {
 
 func resultExportProgressText(plan resultExportPlan, rows int, canceling bool) string {
 	if canceling {
-		return fmt.Sprintf("\n%s Canceling CSV export...\n\nCleaning up the partial file. Please wait.\n\n%s", iconRefresh, tview.Escape(plan.outputPath))
+		return fmt.Sprintf("\n%s Canceling %s export...\n\nCleaning up the partial file. Please wait.\n\n%s", iconRefresh, tview.Escape(plan.format.name), tview.Escape(plan.outputPath))
 	}
 	expected := ""
 	if plan.expectedRows >= 0 {
 		expected = fmt.Sprintf(" / %d", plan.expectedRows)
 	}
-	return fmt.Sprintf("\n%s Exporting %s\n\nRows written: %d%s\n\n%s\n\nPress Esc or Cancel to stop safely.",
-		iconRefresh, tview.Escape(plan.scopeLabel), rows, expected, tview.Escape(plan.outputPath))
+	return fmt.Sprintf("\n%s Exporting %s as %s\n\nRows written: %d%s\n\n%s\n\nPress Esc or Cancel to stop safely.",
+		iconRefresh, tview.Escape(plan.scopeLabel), tview.Escape(plan.format.name), rows, expected, tview.Escape(plan.outputPath))
 }
 
 func (a *App) restoreResultExportFocus(target tview.Primitive) {
@@ -499,41 +551,45 @@ func (a *App) restoreResultExportFocus(target tview.Primitive) {
 	}
 }
 
-func (plan resultExportPlan) csvProducer() resultExportCSVProducer {
+func (plan resultExportPlan) newWriter(output io.Writer) resultExportWriter {
+	return plan.format.newWriter(output, plan.target)
+}
+
+func (plan resultExportPlan) producer() resultExportProducer {
 	if plan.scope == resultExportAllMatching {
-		return func(ctx context.Context, writer *csv.Writer, progress func(int)) (int, error) {
-			return streamAllMatchingResultCSV(ctx, writer, plan.db, plan.query, plan.queryArgs, progress)
+		return func(ctx context.Context, writer resultExportWriter, progress func(int)) (int, error) {
+			return streamAllMatchingResultRows(ctx, writer, plan.db, plan.query, plan.queryArgs, progress)
 		}
 	}
-	return func(ctx context.Context, writer *csv.Writer, progress func(int)) (int, error) {
-		return writeResultExportSnapshotCSV(ctx, writer, plan.snapshot, progress)
+	return func(ctx context.Context, writer resultExportWriter, progress func(int)) (int, error) {
+		return writeResultExportSnapshot(ctx, writer, plan.snapshot, progress)
 	}
 }
 
-func writeResultExportSnapshotCSV(ctx context.Context, writer *csv.Writer, snapshot resultExportSnapshot, progress func(int)) (int, error) {
+func writeResultExportSnapshot(ctx context.Context, writer resultExportWriter, snapshot resultExportSnapshot, progress func(int)) (int, error) {
 	if len(snapshot.headers) == 0 {
 		return 0, fmt.Errorf("result columns are unavailable")
 	}
 	if err := ctx.Err(); err != nil {
 		return 0, err
 	}
-	if err := writer.Write(snapshot.headers); err != nil {
-		return 0, fmt.Errorf("write CSV header: %w", err)
+	if err := writer.writeHeader(snapshot.headers); err != nil {
+		return 0, fmt.Errorf("write export header: %w", err)
 	}
 
 	for index, record := range snapshot.rows {
 		if err := ctx.Err(); err != nil {
 			return index, err
 		}
-		if err := writer.Write(record); err != nil {
-			return index, fmt.Errorf("write CSV row %d: %w", index+1, err)
+		if err := writer.writeRow(record); err != nil {
+			return index, fmt.Errorf("write export row %d: %w", index+1, err)
 		}
 		reportResultExportProgress(progress, index+1, index+1 == len(snapshot.rows))
 	}
 	return len(snapshot.rows), nil
 }
 
-func streamAllMatchingResultCSV(ctx context.Context, writer *csv.Writer, db *sql.DB, query string, args []any, progress func(int)) (int, error) {
+func streamAllMatchingResultRows(ctx context.Context, writer resultExportWriter, db *sql.DB, query string, args []any, progress func(int)) (int, error) {
 	if db == nil || strings.TrimSpace(query) == "" {
 		return 0, fmt.Errorf("table export query is unavailable")
 	}
@@ -551,8 +607,8 @@ func streamAllMatchingResultCSV(ctx context.Context, writer *csv.Writer, db *sql
 		return 0, fmt.Errorf("result columns are unavailable")
 	}
 	databaseTypes := resultDatabaseTypes(rows, len(headers))
-	if err := writer.Write(headers); err != nil {
-		return 0, fmt.Errorf("write CSV header: %w", err)
+	if err := writer.writeHeader(headers); err != nil {
+		return 0, fmt.Errorf("write export header: %w", err)
 	}
 
 	values := make([]any, len(headers))
@@ -560,6 +616,7 @@ func streamAllMatchingResultCSV(ctx context.Context, writer *csv.Writer, db *sql
 	for index := range values {
 		valuePointers[index] = &values[index]
 	}
+	record := make([]resultExportValue, len(headers))
 
 	rowCount := 0
 	for rows.Next() {
@@ -567,14 +624,13 @@ func streamAllMatchingResultCSV(ctx context.Context, writer *csv.Writer, db *sql
 			return rowCount, err
 		}
 		if err := rows.Scan(valuePointers...); err != nil {
-			return rowCount, fmt.Errorf("scan CSV row %d: %w", rowCount+1, err)
+			return rowCount, fmt.Errorf("scan export row %d: %w", rowCount+1, err)
 		}
-		record := make([]string, len(values))
 		for index, value := range values {
-			record[index] = fullCellValueForDatabaseType(value, databaseTypes[index])
+			record[index] = newResultExportValue(value, databaseTypes[index])
 		}
-		if err := writer.Write(record); err != nil {
-			return rowCount, fmt.Errorf("write CSV row %d: %w", rowCount+1, err)
+		if err := writer.writeRow(record); err != nil {
+			return rowCount, fmt.Errorf("write export row %d: %w", rowCount+1, err)
 		}
 		rowCount++
 		reportResultExportProgress(progress, rowCount, false)
@@ -592,12 +648,12 @@ func reportResultExportProgress(progress func(int), rows int, force bool) {
 	}
 }
 
-// writeResultExportCSVAtomic writes beside the destination, fsyncs, and only
-// then publishes it without replacing an existing path. Every failure path
-// removes the private temporary file.
-func writeResultExportCSVAtomic(ctx context.Context, path string, producer resultExportCSVProducer, progress func(int)) (rowCount int, returnErr error) {
-	if producer == nil {
-		return 0, fmt.Errorf("CSV export source is unavailable")
+// writeResultExportAtomic writes beside the destination in any format, fsyncs,
+// and only then publishes it without replacing an existing path. Every failure
+// path removes the private temporary file.
+func writeResultExportAtomic(ctx context.Context, path string, newWriter func(io.Writer) resultExportWriter, producer resultExportProducer, progress func(int)) (rowCount int, returnErr error) {
+	if producer == nil || newWriter == nil {
+		return 0, fmt.Errorf("export source is unavailable")
 	}
 	if err := ensureResultExportDestinationAvailable(path); err != nil {
 		return 0, err
@@ -609,7 +665,7 @@ func writeResultExportCSVAtomic(ctx context.Context, path string, producer resul
 	directory := filepath.Dir(path)
 	temporary, err := os.CreateTemp(directory, "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
-		return 0, fmt.Errorf("create temporary CSV in %s: %w", directory, err)
+		return 0, fmt.Errorf("create temporary export in %s: %w", directory, err)
 	}
 	temporaryPath := temporary.Name()
 	defer func() {
@@ -617,17 +673,17 @@ func writeResultExportCSVAtomic(ctx context.Context, path string, producer resul
 		if removeErr := os.Remove(temporaryPath); removeErr != nil && !os.IsNotExist(removeErr) {
 			cause := returnErr
 			if cause == nil {
-				cause = fmt.Errorf("CSV was published, but temporary-file cleanup failed")
+				cause = fmt.Errorf("export was published, but temporary-file cleanup failed")
 			}
 			returnErr = &resultExportCleanupError{cause: cause, path: temporaryPath, cleanupErr: removeErr}
 		}
 	}()
 
 	if err := temporary.Chmod(0o600); err != nil {
-		return 0, fmt.Errorf("secure temporary CSV permissions: %w", err)
+		return 0, fmt.Errorf("secure temporary export permissions: %w", err)
 	}
 	buffered := bufio.NewWriterSize(temporary, 64*1024)
-	writer := csv.NewWriter(buffered)
+	writer := newWriter(buffered)
 	rowCount, err = producer(ctx, writer, progress)
 	if err != nil {
 		return rowCount, err
@@ -636,18 +692,17 @@ func writeResultExportCSVAtomic(ctx context.Context, path string, producer resul
 		return rowCount, err
 	}
 
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return rowCount, fmt.Errorf("flush CSV encoder: %w", err)
+	if err := writer.close(); err != nil {
+		return rowCount, fmt.Errorf("finish export encoder: %w", err)
 	}
 	if err := buffered.Flush(); err != nil {
-		return rowCount, fmt.Errorf("flush CSV file: %w", err)
+		return rowCount, fmt.Errorf("flush export file: %w", err)
 	}
 	if err := temporary.Sync(); err != nil {
-		return rowCount, fmt.Errorf("sync CSV file: %w", err)
+		return rowCount, fmt.Errorf("sync export file: %w", err)
 	}
 	if err := temporary.Close(); err != nil {
-		return rowCount, fmt.Errorf("close CSV file: %w", err)
+		return rowCount, fmt.Errorf("close export file: %w", err)
 	}
 	if err := ctx.Err(); err != nil {
 		return rowCount, err
@@ -676,10 +731,10 @@ func publishResultExportNoReplace(ctx context.Context, temporaryPath, destinatio
 	if _, err := os.Lstat(destinationPath); err == nil {
 		return fmt.Errorf("destination already exists: %s (choose a new file name)", destinationPath)
 	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("inspect CSV destination %s after atomic publish failed: %w", destinationPath, err)
+		return fmt.Errorf("inspect export destination %s after atomic publish failed: %w", destinationPath, err)
 	}
 	if err := copyResultExportNoReplace(ctx, temporaryPath, destinationPath); err != nil {
-		return fmt.Errorf("publish CSV to %s (atomic link unavailable: %v): %w", destinationPath, linkErr, err)
+		return fmt.Errorf("publish export to %s (atomic link unavailable: %v): %w", destinationPath, linkErr, err)
 	}
 	return nil
 }
@@ -690,13 +745,13 @@ func copyResultExportNoReplace(ctx context.Context, sourcePath, destinationPath
 	}
 	source, err := os.Open(sourcePath)
 	if err != nil {
-		return fmt.Errorf("open completed CSV for portable publication: %w", err)
+		return fmt.Errorf("open completed export for portable publication: %w", err)
 	}
 	defer source.Close()
 
 	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
 	if err != nil {
-		return fmt.Errorf("create CSV destination without replacing it: %w", err)
+		return fmt.Errorf("create export destination without replacing it: %w", err)
 	}
 	destinationCreated := true
 	defer func() {
@@ -720,7 +775,7 @@ func copyResultExportNoReplace(ctx context.Context, sourcePath, destinationPath
 			for written < readCount {
 				writeCount, writeErr := destination.Write(buffer[written:readCount])
 				if writeErr != nil {
-					return fmt.Errorf("write portable CSV destination: %w", writeErr)
+					return fmt.Errorf("write portable export destination: %w", writeErr)
 				}
 				if writeCount == 0 {
 					return io.ErrShortWrite
@@ -732,39 +787,39 @@ func copyResultExportNoReplace(ctx context.Context, sourcePath, destinationPath
 			break
 		}
 		if readErr != nil {
-			return fmt.Errorf("read completed CSV for portable publication: %w", readErr)
+			return fmt.Errorf("read completed export for portable publication: %w", readErr)
 		}
 	}
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 	if err := destination.Sync(); err != nil {
-		return fmt.Errorf("sync portable CSV destination: %w", err)
+		return fmt.Errorf("sync portable export destination: %w", err)
 	}
 	if err := destination.Close(); err != nil {
-		return fmt.Errorf("close portable CSV destination: %w", err)
+		return fmt.Errorf("close portable export destination: %w", err)
 	}
 	destinationCreated = false
 	return nil
 }
 
-func resolveResultExportPath(rawPath string) (string, error) {
+func resolveResultExportPath(rawPath string, format resultExportFormat) (string, error) {
 	path := strings.TrimSpace(rawPath)
 	if path == "" {
-		return "", fmt.Errorf("CSV path is required")
+		return "", fmt.Errorf("export path is required")
 	}
 	expanded, err := expandHomePath(path)
 	if err != nil {
 		return "", err
 	}
 	if extension := filepath.Ext(expanded); extension == "" {
-		expanded += ".csv"
-	} else if !strings.EqualFold(extension, ".csv") {
-		return "", fmt.Errorf("CSV destination must end in .csv")
+		expanded += format.extension()
+	} else if !format.acceptsExtension(extension) {
+		return "", fmt.Errorf("%s destination must end in %s", format.name, strings.Join(format.extensions, " or "))
 	}
 	absolute, err := filepath.Abs(expanded)
 	if err != nil {
-		return "", fmt.Errorf("resolve CSV path: %w", err)
+		return "", fmt.Errorf("resolve export path: %w", err)
 	}
 	absolute = filepath.Clean(absolute)
 	if err := ensureResultExportDestinationAvailable(absolute); err != nil {
@@ -775,25 +830,25 @@ func resolveResultExportPath(rawPath string) (string, error) {
 
 func ensureResultExportDestinationAvailable(path string) error {
 	if strings.TrimSpace(path) == "" || filepath.Base(path) == "." || filepath.Base(path) == string(filepath.Separator) {
-		return fmt.Errorf("CSV path must include a file name")
+		return fmt.Errorf("export path must include a file name")
 	}
 	directory := filepath.Dir(path)
 	info, err := os.Stat(directory)
 	if err != nil {
-		return fmt.Errorf("access CSV directory %s: %w", directory, err)
+		return fmt.Errorf("access export directory %s: %w", directory, err)
 	}
 	if !info.IsDir() {
-		return fmt.Errorf("CSV parent path is not a directory: %s", directory)
+		return fmt.Errorf("export parent path is not a directory: %s", directory)
 	}
 	if _, err := os.Lstat(path); err == nil {
 		return fmt.Errorf("destination already exists: %s (choose a new file name)", path)
 	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("inspect CSV destination %s: %w", path, err)
+		return fmt.Errorf("inspect export destination %s: %w", path, err)
 	}
 	return nil
 }
 
-func (a *App) defaultResultExportPath() string {
+func (a *App) defaultResultExportPath(format resultExportFormat) string {
 	directory, err := os.Getwd()
 	if err != nil || strings.TrimSpace(directory) == "" {
 		directory = os.TempDir()
@@ -804,7 +859,7 @@ func (a *App) defaultResultExportPath() string {
 	}
 	name = sanitizeResultExportName(name)
 	timestamp := time.Now().Format("20060102_150405")
-	fileName := fmt.Sprintf("dbterm_%s_%s.csv", name, timestamp)
+	fileName := fmt.Sprintf("dbterm_%s_%s%s", name, timestamp, format.extension())
 	path := filepath.Join(directory, fileName)
 	for suffix := 2; ; suffix++ {
 		if _, statErr := os.Lstat(path); os.IsNotExist(statErr) {
@@ -812,7 +867,7 @@ func (a *App) defaultResultExportPath() string {
 		} else if statErr != nil {
 			return path
 		}
-		path = filepath.Join(directory, fmt.Sprintf("dbterm_%s_%s_%d.csv", name, timestamp, suffix))
+		path = filepath.Join(directory, fmt.Sprintf("dbterm_%s_%s_%d%s", name, timestamp, suffix, format.extension()))
 	}
 }
 
@@ -0,0 +1,554 @@
+package ui
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+const (
+	xlsxMaxRows      = 1048576
+	xlsxMaxColumns   = 16384
+	xlsxMaxCellRunes = 32767
+	// xlsxExactDigits is the precision of an Excel number. Longer integers,
+	// typically IDs, are written as text so the spreadsheet cannot round them.
+	xlsxExactDigits = 15
+)
+
+type resultExportKind uint8
+
+const (
+	resultExportText resultExportKind = iota
+	resultExportNull
+	resultExportNumber
+	resultExportBoolean
+	resultExportBinary
+)
+
+// resultExportValue is the complete export text of one cell plus the little
+// type information structured formats need: JSON and SQL must not quote
+// numbers or turn SQL NULL into the string "NULL".
+type resultExportValue struct {
+	text string
+	kind resultExportKind
+}
+
+// resultExportTarget names the table that SQL INSERT exports write into.
+type resultExportTarget struct {
+	dbType config.DBType
+	table  string
+}
+
+// resultExportWriter encodes a header and then rows for one file format.
+// close writes any trailer; the caller owns the underlying file.
+type resultExportWriter interface {
+	writeHeader(headers []string) error
+	writeRow(values []resultExportValue) error
+	close() error
+}
+
+type resultExportFormat struct {
+	name string
+	// extensions lists accepted file extensions; the first is the default.
+	extensions []string
+	newWriter  func(io.Writer, resultExportTarget) resultExportWriter
+}
+
+var resultExportFormats = []resultExportFormat{
+	{name: "CSV", extensions: []string{".csv"}, newWriter: func(output io.Writer, _ resultExportTarget) resultExportWriter {
+		return newResultExportCSVWriter(output)
+	}},
+	{name: "JSON", extensions: []string{".json"}, newWriter: func(output io.Writer, _ resultExportTarget) resultExportWriter {
+		return newResultExportJSONWriter(output, false)
+	}},
+	{name: "NDJSON", extensions: []string{".ndjson", ".jsonl"}, newWriter: func(output io.Writer, _ resultExportTarget) resultExportWriter {
+		return newResultExportJSONWriter(output, true)
+	}},
+	{name: "Markdown", extensions: []string{".md", ".markdown"}, newWriter: func(output io.Writer, _ resultExportTarget) resultExportWriter {
+		return &resultExportMarkdownWriter{output: output}
+	}},
+	{name: "SQL INSERT", extensions: []string{".sql"}, newWriter: func(output io.Writer, target resultExportTarget) resultExportWriter {
+		return &resultExportSQLWriter{output: output, target: target}
+	}},
+	{name: "XLSX", extensions: []string{".xlsx"}, newWriter: func(output io.Writer, _ resultExportTarget) resultExportWriter {
+		return &resultExportXLSXWriter{archive: zip.NewWriter(output)}
+	}},
+}
+
+func (format resultExportFormat) extension() string {
+	return format.extensions[0]
+}
+
+func (format resultExportFormat) acceptsExtension(extension string) bool {
+	for _, candidate := range format.extensions {
+		if strings.EqualFold(candidate, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// isResultExportExtension reports whether any format produces extension, so
+// switching formats can swap a default file name without touching a custom one.
+func isResultExportExtension(extension string) bool {
+	for _, format := range resultExportFormats {
+		if format.acceptsExtension(extension) {
+			return true
+		}
+	}
+	return false
+}
+
+func newResultExportValue(raw any, databaseType string) resultExportValue {
+	text := fullCellValueForDatabaseType(raw, databaseType)
+	switch typed := raw.(type) {
+	case nil:
+		return resultExportValue{text: text, kind: resultExportNull}
+	case bool:
+		return resultExportValue{text: text, kind: resultExportBoolean}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return resultExportValue{text: text, kind: resultExportNumber}
+	case float32:
+		if isResultExportJSONNumber(text) {
+			return resultExportValue{text: text, kind: resultExportNumber}
+		}
+	case float64:
+		if !math.IsNaN(typed) && !math.IsInf(typed, 0) {
+			return resultExportValue{text: text, kind: resultExportNumber}
+		}
+	case []byte:
+		if !databaseByteValueIsText(databaseType) {
+			return resultExportValue{text: text, kind: resultExportBinary}
+		}
+		if resultExportNumericType(databaseType) && isResultExportJSONNumber(text) {
+			return resultExportValue{text: text, kind: resultExportNumber}
+		}
+	case string:
+		if resultExportNumericType(databaseType) && isResultExportJSONNumber(text) {
+			return resultExportValue{text: text, kind: resultExportNumber}
+		}
+	}
+	return resultExportValue{text: text}
+}
+
+// resultExportNumericType recognizes numeric column types whose drivers hand
+// values back as text, such as PostgreSQL NUMERIC or every MySQL column.
+func resultExportNumericType(databaseType string) bool {
+	databaseType = normalizedDatabaseType(databaseType)
+	databaseType = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(databaseType, "UNSIGNED "), " UNSIGNED"))
+	switch databaseType {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8",
+		"HUGEINT", "UBIGINT", "UINTEGER", "USMALLINT", "UTINYINT",
+		"DECIMAL", "NUMERIC", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL":
+		return true
+	default:
+		return false
+	}
+}
+
+// isResultExportJSONNumber accepts exactly JSON's number grammar, which rules
+// out NaN, Infinity, hex, and leading plus signs.
+func isResultExportJSONNumber(text string) bool {
+	if text == "" || (text[0] != '-' && (text[0] < '0' || text[0] > '9')) {
+		return false
+	}
+	return json.Valid([]byte(text))
+}
+
+// resultExportColumnNames returns one non-empty, unique key per column; joins
+// often return two "id" columns, which would collide as JSON object keys.
+func resultExportColumnNames(headers []string) []string {
+	names := make([]string, len(headers))
+	used := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		used[header] = true
+	}
+	seen := make(map[string]bool, len(headers))
+	for index, header := range headers {
+		name := header
+		if name == "" {
+			name = fmt.Sprintf("column_%d", index+1)
+		}
+		if seen[name] || (name != header && used[name]) {
+			base := name
+			for suffix := 2; seen[name] || used[name]; suffix++ {
+				name = fmt.Sprintf("%s_%d", base, suffix)
+			}
+		}
+		seen[name] = true
+		used[name] = true
+		names[index] = name
+	}
+	return names
+}
+
+type resultExportCSVWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newResultExportCSVWriter(output io.Writer) *resultExportCSVWriter {
+	return &resultExportCSVWriter{writer: csv.NewWriter(output)}
+}
+
+func (w *resultExportCSVWriter) writeHeader(headers []string) error {
+	return w.writer.Write(headers)
+}
+
+func (w *resultExportCSVWriter) writeRow(values []resultExportValue) error {
+	w.record = w.record[:0]
+	for _, value := range values {
+		w.record = append(w.record, value.text)
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *resultExportCSVWriter) close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// resultExportJSONWriter writes either one JSON array of objects or, for
+// NDJSON, one object per line. Columns keep their result order.
+type resultExportJSONWriter struct {
+	output  io.Writer
+	lines   bool
+	keys    [][]byte
+	rows    int
+	buffer  bytes.Buffer
+	encoder *json.Encoder
+}
+
+func newResultExportJSONWriter(output io.Writer, lines bool) *resultExportJSONWriter {
+	w := &resultExportJSONWriter{output: output, lines: lines}
+	w.encoder = json.NewEncoder(&w.buffer)
+	w.encoder.SetEscapeHTML(false)
+	return w
+}
+
+func (w *resultExportJSONWriter) writeHeader(headers []string) error {
+	w.keys = make([][]byte, len(headers))
+	for index, name := range resultExportColumnNames(headers) {
+		w.buffer.Reset()
+		w.appendString(name)
+		w.keys[index] = append([]byte(nil), w.buffer.Bytes()...)
+	}
+	if w.lines {
+		return nil
+	}
+	_, err := io.WriteString(w.output, "[")
+	return err
+}
+
+func (w *resultExportJSONWriter) writeRow(values []resultExportValue) error {
+	w.buffer.Reset()
+	if !w.lines {
+		if w.rows > 0 {
+			w.buffer.WriteByte(',')
+		}
+		w.buffer.WriteString("\n  ")
+	}
+	w.buffer.WriteByte('{')
+	for index, value := range values {
+		if index > 0 {
+			w.buffer.WriteByte(',')
+		}
+		if index < len(w.keys) {
+			w.buffer.Write(w.keys[index])
+		} else {
+			w.appendString(fmt.Sprintf("column_%d", index+1))
+		}
+		w.buffer.WriteByte(':')
+		switch value.kind {
+		case resultExportNull:
+			w.buffer.WriteString("null")
+		case resultExportNumber, resultExportBoolean:
+			w.buffer.WriteString(value.text)
+		default:
+			w.appendString(value.text)
+		}
+	}
+	w.buffer.WriteByte('}')
+	if w.lines {
+		w.buffer.WriteByte('\n')
+	}
+	w.rows++
+	_, err := w.output.Write(w.buffer.Bytes())
+	return err
+}
+
+func (w *resultExportJSONWriter) appendString(value string) {
+	_ = w.encoder.Encode(value)
+	w.buffer.Truncate(w.buffer.Len() - 1) // Encode appends a newline.
+}
+
+func (w *resultExportJSONWriter) close() error {
+	if w.lines {
+		return nil
+	}
+	trailer := "]\n"
+	if w.rows > 0 {
+		trailer = "\n]\n"
+	}
+	_, err := io.WriteString(w.output, trailer)
+	return err
+}
+
+// resultExportMarkdownWriter writes a GitHub-flavored Markdown table.
+type resultExportMarkdownWriter struct {
+	output  io.Writer
+	builder strings.Builder
+}
+
+func (w *resultExportMarkdownWriter) writeHeader(headers []string) error {
+	w.builder.Reset()
+	w.writeLine(headers)
+	w.builder.WriteString("|")
+	for range headers {
+		w.builder.WriteString(" --- |")
+	}
+	w.builder.WriteString("\n")
+	_, err := io.WriteString(w.output, w.builder.String())
+	return err
+}
+
+func (w *resultExportMarkdownWriter) writeRow(values []resultExportValue) error {
+	cells := make([]string, len(values))
+	for index, value := range values {
+		cells[index] = value.text
+	}
+	w.builder.Reset()
+	w.writeLine(cells)
+	_, err := io.WriteString(w.output, w.builder.String())
+	return err
+}
+
+func (w *resultExportMarkdownWriter) writeLine(cells []string) {
+	w.builder.WriteString("|")
+	for _, cell := range cells {
+		w.builder.WriteString(" ")
+		w.builder.WriteString(markdownTableCell(cell))
+		w.builder.WriteString(" |")
+	}
+	w.builder.WriteString("\n")
+}
+
+func (w *resultExportMarkdownWriter) close() error { return nil }
+
+// markdownTableCell keeps a value inside its cell: pipes are escaped and line
+// breaks become <br> because a raw newline ends the table row.
+func markdownTableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", "<br>")
+	value = strings.ReplaceAll(value, "\n", "<br>")
+	return strings.ReplaceAll(value, "\r", "<br>")
+}
+
+// resultExportSQLWriter writes one INSERT statement per row so the file can be
+// replayed into another environment of the same engine.
+type resultExportSQLWriter struct {
+	output  io.Writer
+	target  resultExportTarget
+	prefix  string
+	builder strings.Builder
+}
+
+func (w *resultExportSQLWriter) writeHeader(headers []string) error {
+	columns := make([]string, len(headers))
+	for index, header := range headers {
+		columns[index] = quoteIdentifier(w.target.dbType, header)
+	}
+	w.prefix = fmt.Sprintf("INSERT INTO %s (%s) VALUES (", quoteIdentifier(w.target.dbType, w.target.table), strings.Join(columns, ", "))
+	return nil
+}
+
+func (w *resultExportSQLWriter) writeRow(values []resultExportValue) error {
+	w.builder.Reset()
+	w.builder.WriteString(w.prefix)
+	for index, value := range values {
+		if index > 0 {
+			w.builder.WriteString(", ")
+		}
+		w.builder.WriteString(resultExportSQLLiteral(w.target.dbType, value))
+	}
+	w.builder.WriteString(");\n")
+	_, err := io.WriteString(w.output, w.builder.String())
+	return err
+}
+
+func (w *resultExportSQLWriter) close() error { return nil }
+
+func resultExportSQLLiteral(dbType config.DBType, value resultExportValue) string {
+	switch value.kind {
+	case resultExportNull:
+		return "NULL"
+	case resultExportNumber:
+		return value.text
+	case resultExportBoolean:
+		return strings.ToUpper(value.text)
+	case resultExportBinary:
+		encoded := hex.EncodeToString([]byte(value.text))
+		switch dbType {
+		case config.PostgreSQL:
+			return `'\x` + encoded + `'::bytea`
+		case config.DuckDB:
+			return "from_hex('" + encoded + "')"
+		default:
+			return "X'" + encoded + "'"
+		}
+	}
+	text := strings.ReplaceAll(value.text, "'", "''")
+	if dbType == config.MySQL {
+		// MySQL treats backslash as an escape inside string literals by default.
+		text = strings.ReplaceAll(text, `\`, `\\`)
+	}
+	return "'" + text + "'"
+}
+
+// resultExportXLSXWriter streams a single-sheet workbook. The fixed package
+// parts are written up front and the sheet is compressed row by row, so no
+// export is ever held in memory.
+type resultExportXLSXWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	headers []string
+	rows    int
+	buffer  bytes.Buffer
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Results" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func (w *resultExportXLSXWriter) writeHeader(headers []string) error {
+	if len(headers) > xlsxMaxColumns {
+		return fmt.Errorf("XLSX sheets hold at most %d columns; this result has %d", xlsxMaxColumns, len(headers))
+	}
+	now := time.Now()
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRelationships},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRelationships},
+	} {
+		entry, err := w.archive.CreateHeader(&zip.FileHeader{Name: part.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, part.body); err != nil {
+			return err
+		}
+	}
+	sheet, err := w.archive.CreateHeader(&zip.FileHeader{Name: "xl/worksheets/sheet1.xml", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+	w.sheet = sheet
+	w.headers = headers
+	values := make([]resultExportValue, len(headers))
+	for index, header := range headers {
+		values[index] = resultExportValue{text: header}
+	}
+	return w.writeSheetRow(values)
+}
+
+func (w *resultExportXLSXWriter) writeRow(values []resultExportValue) error {
+	return w.writeSheetRow(values)
+}
+
+func (w *resultExportXLSXWriter) writeSheetRow(values []resultExportValue) error {
+	if w.sheet == nil {
+		return fmt.Errorf("XLSX header was not written")
+	}
+	if w.rows >= xlsxMaxRows {
+		return fmt.Errorf("XLSX sheets hold at most %d rows including the header; export fewer rows or use CSV", xlsxMaxRows)
+	}
+	w.rows++
+	w.buffer.Reset()
+	fmt.Fprintf(&w.buffer, `<row r="%d">`, w.rows)
+	for index, value := range values {
+		reference := xlsxColumnName(index) + strconv.Itoa(w.rows)
+		switch {
+		case value.kind == resultExportNull:
+			continue
+		case value.kind == resultExportNumber && xlsxNumberIsExact(value.text):
+			fmt.Fprintf(&w.buffer, `<c r="%s"><v>%s</v></c>`, reference, value.text)
+		case value.kind == resultExportBoolean:
+			flag := "0"
+			if value.text == "true" {
+				flag = "1"
+			}
+			fmt.Fprintf(&w.buffer, `<c r="%s" t="b"><v>%s</v></c>`, reference, flag)
+		default:
+			if utf8.RuneCountInString(value.text) > xlsxMaxCellRunes {
+				column := ""
+				if index < len(w.headers) {
+					column = w.headers[index]
+				}
+				return fmt.Errorf("row %d column %q exceeds the XLSX limit of %d characters per cell", w.rows-1, column, xlsxMaxCellRunes)
+			}
+			fmt.Fprintf(&w.buffer, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, reference)
+			_ = xml.EscapeText(&w.buffer, []byte(value.text))
+			w.buffer.WriteString(`</t></is></c>`)
+		}
+	}
+	w.buffer.WriteString(`</row>`)
+	_, err := w.sheet.Write(w.buffer.Bytes())
+	return err
+}
+
+func (w *resultExportXLSXWriter) close() error {
+	if w.sheet == nil {
+		return fmt.Errorf("XLSX header was not written")
+	}
+	if _, err := io.WriteString(w.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+// xlsxColumnName converts a zero-based index to a spreadsheet column (A, Z, AA).
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func xlsxNumberIsExact(text string) bool {
+	mantissa := strings.TrimPrefix(text, "-")
+	if cut := strings.IndexAny(mantissa, "eE"); cut >= 0 {
+		mantissa = mantissa[:cut]
+	}
+	digits := strings.TrimLeft(strings.Replace(mantissa, ".", "", 1), "0")
+	if strings.Contains(mantissa, ".") {
+		digits = strings.TrimRight(digits, "0")
+	}
+	return len(digits) <= xlsxExactDigits
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func testResultExportSnapshot() resultExportSnapshot {
+	return resultExportSnapshot{
+		headers: []string{"id", "name", "id", "note", "active"},
+		rows: [][]resultExportValue{
+			{
+				newResultExportValue(int64(1), "INTEGER"),
+				newResultExportValue("O'Brien | \"A\" \\ <b>", "TEXT"),
+				newResultExportValue([]byte("12345678901234567890"), "NUMERIC"),
+				newResultExportValue("line\nbreak", "TEXT"),
+				newResultExportValue(true, "BOOLEAN"),
+			},
+			{
+				newResultExportValue(int64(2), "INTEGER"),
+				newResultExportValue([]byte("007"), "VARCHAR"),
+				newResultExportValue([]byte("2.5"), "DECIMAL"),
+				newResultExportValue(nil, "TEXT"),
+				newResultExportValue(false, "BOOLEAN"),
+			},
+		},
+	}
+}
+
+func renderResultExport(t *testing.T, formatName string, target resultExportTarget) string {
+	t.Helper()
+	for _, format := range resultExportFormats {
+		if format.name != formatName {
+			continue
+		}
+		var output strings.Builder
+		writer := format.newWriter(&output, target)
+		if _, err := writeResultExportSnapshot(context.Background(), writer, testResultExportSnapshot(), nil); err != nil {
+			t.Fatalf("%s export: %v", formatName, err)
+		}
+		if err := writer.close(); err != nil {
+			t.Fatalf("%s close: %v", formatName, err)
+		}
+		return output.String()
+	}
+	t.Fatalf("unknown export format %q", formatName)
+	return ""
+}
+
+func TestResultExportFormatsKeepTypesAndEscapeValues(t *testing.T) {
+	var rows []map[string]any
+	decoder := json.NewDecoder(strings.NewReader(renderResultExport(t, "JSON", resultExportTarget{})))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		t.Fatalf("decode JSON export: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["id"] != json.Number("1") || rows[0]["id_2"] != json.Number("12345678901234567890") ||
+		rows[0]["name"] != "O'Brien | \"A\" \\ <b>" || rows[0]["active"] != true {
+		t.Fatalf("JSON rows = %#v", rows)
+	}
+	if rows[1]["name"] != "007" || rows[1]["note"] != nil || rows[1]["id_2"] != json.Number("2.5") {
+		t.Fatalf("JSON second row = %#v", rows[1])
+	}
+
+	lines := strings.Split(strings.TrimSuffix(renderResultExport(t, "NDJSON", resultExportTarget{}), "\n"), "\n")
+	if len(lines) != 2 || lines[1] != `{"id":2,"name":"007","id_2":2.5,"note":null,"active":false}` {
+		t.Fatalf("NDJSON lines = %q", lines)
+	}
+
+	markdown := renderResultExport(t, "Markdown", resultExportTarget{})
+	if !strings.HasPrefix(markdown, "| id | name | id | note | active |\n| --- | --- | --- | --- | --- |\n") ||
+		!strings.Contains(markdown, `| O'Brien \| "A" \ <b> |`) || !strings.Contains(markdown, "| line<br>break |") {
+		t.Fatalf("Markdown = %q", markdown)
+	}
+
+	sqlite := renderResultExport(t, "SQL INSERT", resultExportTarget{dbType: config.SQLite, table: "users"})
+	want := `INSERT INTO "users" ("id", "name", "id", "note", "active") VALUES (2, '007', 2.5, NULL, FALSE);`
+	if !strings.Contains(sqlite, `'O''Brien | "A" \ <b>'`) || !strings.Contains(sqlite, want) {
+		t.Fatalf("SQLite INSERTs = %q", sqlite)
+	}
+	if mysql := renderResultExport(t, "SQL INSERT", resultExportTarget{dbType: config.MySQL, table: "users"}); !strings.Contains(mysql, `'O''Brien | "A" \\ <b>'`) {
+		t.Fatalf("MySQL INSERTs did not escape backslashes: %q", mysql)
+	}
+	blob := newResultExportValue([]byte{0xde, 0xad}, "BYTEA")
+	if got := resultExportSQLLiteral(config.PostgreSQL, blob); got != `'\xdead'::bytea` {
+		t.Fatalf("PostgreSQL blob literal = %q", got)
+	}
+	if got := resultExportSQLLiteral(config.SQLite, blob); got != "X'dead'" {
+		t.Fatalf("SQLite blob literal = %q", got)
+	}
+}
+
+func TestXLSXResultExportIsAStreamedWorkbook(t *testing.T) {
+	directory := t.TempDir()
+	path := filepath.Join(directory, "results.xlsx")
+	plan := resultExportPlan{scope: resultExportCurrentPage, format: resultExportFormats[len(resultExportFormats)-1], snapshot: testResultExportSnapshot()}
+	if _, err := writeResultExportAtomic(context.Background(), path, plan.newWriter, plan.producer(), nil); err != nil {
+		t.Fatalf("write XLSX: %v", err)
+	}
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open XLSX: %v", err)
+	}
+	defer archive.Close()
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[file.Name] = string(body)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if parts[name] == "" {
+			t.Fatalf("workbook part %s is missing", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, fragment := range []string{
+		`<c r="A2"><v>1</v></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">O&#39;Brien | &#34;A&#34; \ &lt;b&gt;</t></is></c>`,
+		`<c r="C2" t="inlineStr"><is><t xml:space="preserve">12345678901234567890</t></is></c>`,
+		`<c r="E2" t="b"><v>1</v></c>`,
+		`<c r="C3"><v>2.5</v></c>`,
+	} {
+		if !strings.Contains(sheet, fragment) {
+			t.Fatalf("sheet is missing %s:\n%s", fragment, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="D3"`) {
+		t.Fatal("SQL NULL should leave the cell empty")
+	}
+	if got := xlsxColumnName(27); got != "AB" {
+		t.Fatalf("xlsxColumnName(27) = %q", got)
+	}
+	assertNoResultExportTempFiles(t, directory)
+}
+
+func TestResolveResultExportPathMatchesFormat(t *testing.T) {
+	directory := t.TempDir()
+	ndjson := resultExportFormats[2]
+	path, err := resolveResultExportPath(filepath.Join(directory, "rows"), ndjson)
+	if err != nil || filepath.Ext(path) != ".ndjson" {
+		t.Fatalf("path = %q, err = %v", path, err)
+	}
+	if _, err := resolveResultExportPath(filepath.Join(directory, "rows.JSONL"), ndjson); err != nil {
+		t.Fatalf("alternate extension rejected: %v", err)
+	}
+	if _, err := resolveResultExportPath(filepath.Join(directory, "rows.csv"), ndjson); err == nil || !strings.Contains(err.Error(), ".ndjson or .jsonl") {
+		t.Fatalf("mismatched extension error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "taken.md"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveResultExportPath(filepath.Join(directory, "taken.md"), resultExportFormats[3]); err == nil {
+		t.Fatal("an existing destination was accepted")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/csv"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,7 +32,7 @@ func TestPrepareSelectedResultExportUsesFullCellReferences(t *testing.T) {
 	plan, err := app.prepareResultExportPlan(resultExportScopeOption{
 		scope: resultExportSelectedRows,
 		label: "Selected rows (1)",
-	}, filepath.Join(t.TempDir(), "selected.csv"))
+	}, resultExportFormats[0], filepath.Join(t.TempDir(), "selected.csv"))
 	if err != nil {
 		t.Fatalf("prepare selected export: %v", err)
 	}
@@ -41,10 +42,10 @@ func TestPrepareSelectedResultExportUsesFullCellReferences(t *testing.T) {
 	if got := plan.snapshot.headers[0]; got != "description" {
 		t.Fatalf("header = %q, want exact reference", got)
 	}
-	if got := plan.snapshot.rows[0][0]; got != longValue {
+	if got := plan.snapshot.rows[0][0].text; got != longValue {
 		t.Fatalf("long value was not preserved: got length %d, want %d", len(got), len(longValue))
 	}
-	if got, want := plan.snapshot.rows[0][1], fullCellValue(preciseFloat); got != want {
+	if got, want := plan.snapshot.rows[0][1].text, fullCellValue(preciseFloat); got != want {
 		t.Fatalf("float = %q, want full precision %q", got, want)
 	}
 }
@@ -93,17 +94,18 @@ func TestWriteResultExportCSVAtomicPublishesPrivateCompleteFile(t *testing.T) {
 	directory := t.TempDir()
 	path := filepath.Join(directory, "results.csv")
 	plan := resultExportPlan{
-		scope: resultExportCurrentPage,
+		scope:  resultExportCurrentPage,
+		format: resultExportFormats[0],
 		snapshot: resultExportSnapshot{
 			headers: []string{"id", "value"},
-			rows: [][]string{
-				{"1", "comma,value"},
-				{"2", "line\nbreak"},
+			rows: [][]resultExportValue{
+				{{text: "1", kind: resultExportNumber}, {text: "comma,value"}},
+				{{text: "2", kind: resultExportNumber}, {text: "line\nbreak"}},
 			},
 		},
 	}
 
-	rows, err := writeResultExportCSVAtomic(context.Background(), path, plan.csvProducer(), nil)
+	rows, err := writeResultExportAtomic(context.Background(), path, plan.newWriter, plan.producer(), nil)
 	if err != nil {
 		t.Fatalf("write atomic CSV: %v", err)
 	}
@@ -137,15 +139,16 @@ func TestWriteResultExportCSVAtomicCleansTempOnCancel(t *testing.T) {
 	directory := t.TempDir()
 	path := filepath.Join(directory, "canceled.csv")
 	ctx, cancel := context.WithCancel(context.Background())
-	producer := func(ctx context.Context, writer *csv.Writer, _ func(int)) (int, error) {
-		if err := writer.Write([]string{"id"}); err != nil {
+	producer := func(ctx context.Context, writer resultExportWriter, _ func(int)) (int, error) {
+		if err := writer.writeHeader([]string{"id"}); err != nil {
 			return 0, err
 		}
 		cancel()
 		return 0, ctx.Err()
 	}
+	newWriter := func(output io.Writer) resultExportWriter { return newResultExportCSVWriter(output) }
 
-	_, err := writeResultExportCSVAtomic(ctx, path, producer, nil)
+	_, err := writeResultExportAtomic(ctx, path, newWriter, producer, nil)
 	if !errors.Is(err, context.Canceled) {
 		t.Fatalf("write error = %v, want context canceled", err)
 	}
@@ -162,14 +165,15 @@ func TestWriteResultExportCSVAtomicNeverReplacesExistingDestination(t *testing.T
 		t.Fatalf("seed destination: %v", err)
 	}
 	plan := resultExportPlan{
-		scope: resultExportCurrentPage,
+		scope:  resultExportCurrentPage,
+		format: resultExportFormats[0],
 		snapshot: resultExportSnapshot{
 			headers: []string{"id"},
-			rows:    [][]string{{"1"}},
+			rows:    [][]resultExportValue{{{text: "1", kind: resultExportNumber}}},
 		},
 	}
-	if _, err := writeResultExportCSVAtomic(context.Background(), path, plan.csvProducer(), nil); err == nil {
-		t.Fatal("writeResultExportCSVAtomic() replaced an existing destination")
+	if _, err := writeResultExportAtomic(context.Background(), path, plan.newWriter, plan.producer(), nil); err == nil {
+		t.Fatal("writeResultExportAtomic() replaced an existing destination")
 	}
 	contents, err := os.ReadFile(path)
 	if err != nil {
@@ -235,7 +239,7 @@ func TestResultExportLifecycleCanBeCanceledByApp(t *testing.T) {
 	}
 }
 
-func TestStreamAllMatchingResultCSVKeepsFullValues(t *testing.T) {
+func TestStreamAllMatchingResultRowsKeepsFullValues(t *testing.T) {
 	db, err := sql.Open("sqlite", ":memory:")
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
@@ -252,8 +256,8 @@ func TestStreamAllMatchingResultCSVKeepsFullValues(t *testing.T) {
 	}
 
 	var output strings.Builder
-	writer := csv.NewWriter(&output)
-	rowCount, err := streamAllMatchingResultCSV(
+	writer := newResultExportCSVWriter(&output)
+	rowCount, err := streamAllMatchingResultRows(
 		context.Background(),
 		writer,
 		db,
@@ -261,11 +265,10 @@ func TestStreamAllMatchingResultCSVKeepsFullValues(t *testing.T) {
 		[]any{"keep"},
 		nil,
 	)
-	writer.Flush()
 	if err != nil {
 		t.Fatalf("stream matching CSV: %v", err)
 	}
-	if err := writer.Error(); err != nil {
+	if err := writer.close(); err != nil {
 		t.Fatalf("flush streamed CSV: %v", err)
 	}
 	if rowCount != 2 {
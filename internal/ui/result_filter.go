@@ -2,7 +2,10 @@ package ui
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -23,6 +26,8 @@ const (
 	resultFilterGreaterEqual resultFilterOperator = ">="
 	resultFilterLess         resultFilterOperator = "<"
 	resultFilterLessEqual    resultFilterOperator = "<="
+	resultFilterIn           resultFilterOperator = "IN"
+	resultFilterBetween      resultFilterOperator = "BETWEEN"
 	resultFilterContains     resultFilterOperator = "contains"
 	resultFilterStartsWith   resultFilterOperator = "starts-with"
 	resultFilterIsNull       resultFilterOperator = "IS NULL"
@@ -36,16 +41,24 @@ var resultFilterOperators = []resultFilterOperator{
 	resultFilterGreaterEqual,
 	resultFilterLess,
 	resultFilterLessEqual,
+	resultFilterIn,
+	resultFilterBetween,
 	resultFilterContains,
 	resultFilterStartsWith,
 	resultFilterIsNull,
 	resultFilterIsNotNull,
 }
 
+// resultFilterPredicate is one condition of a filter expression. IN and
+// BETWEEN keep their values as a []any list.
 type resultFilterPredicate struct {
 	column   string
 	operator resultFilterOperator
 	value    any
+	negate   bool
+	// or starts a new OR group. AND binds tighter, as in SQL, so the ordered
+	// predicates a, AND b, OR c read as (a AND b) OR c.
+	or bool
 }
 
 type resultValueFilter struct {
@@ -64,6 +77,7 @@ type resultFilterViewState struct {
 	pageOffset    int
 	pageSize      int
 	totalRowCount int
+	totalApprox   bool
 	selection     resultSelectionState
 }
 
@@ -161,10 +175,12 @@ func (a *App) showResultFilterModal() {
 	initialPredicate, hasInitialPredicate := latestResultFilterPredicateForColumn(activeFilter, column)
 	initialValue := ""
 	initialOperatorIndex := 0
+	initialNegate := false
 	if hasInitialPredicate {
 		if resultFilterOperatorNeedsValue(initialPredicate.operator) {
 			initialValue = resultFilterValueString(initialPredicate.value)
 		}
+		initialNegate = initialPredicate.negate
 		for index, operator := range resultFilterOperators {
 			if operator == initialPredicate.operator {
 				initialOperatorIndex = index
@@ -202,17 +218,25 @@ func (a *App) showResultFilterModal() {
 		SetCurrentOption(initialOperatorIndex)
 	form.AddFormItem(operatorInput)
 	updateValuePlaceholder := func(index int) {
-		operator := resultFilterOperatorAt(index)
-		if resultFilterOperatorNeedsValue(operator) {
+		switch operator := resultFilterOperatorAt(index); {
+		case operator == resultFilterIn:
+			valueInput.SetPlaceholder(`comma-separated values, e.g. failed, "a, b"`)
+		case operator == resultFilterBetween:
+			valueInput.SetPlaceholder("low, high (inclusive)")
+		case resultFilterOperatorNeedsValue(operator):
 			valueInput.SetPlaceholder("type a comparison value")
-			return
+		default:
+			valueInput.SetPlaceholder("not used for NULL operators")
 		}
-		valueInput.SetPlaceholder("not used for NULL operators")
 	}
 	operatorInput.SetSelectedFunc(func(_ string, index int) {
 		updateValuePlaceholder(index)
 	})
 	updateValuePlaceholder(initialOperatorIndex)
+	negateInput := tview.NewCheckbox().
+		SetLabel("NOT").
+		SetChecked(initialNegate)
+	form.AddFormItem(negateInput)
 
 	closeModal := func() {
 		a.pages.RemovePage(pageResultFilter)
@@ -222,41 +246,44 @@ func (a *App) showResultFilterModal() {
 		index, _ := operatorInput.GetCurrentOption()
 		return resultFilterOperatorAt(index)
 	}
-	applyTypedValue := func(addAND bool) {
-		operator := selectedOperator()
-		value := any(valueInput.GetText())
-		if !resultFilterOperatorNeedsValue(operator) {
-			value = nil
+	applyTypedValue := func(add, or bool) {
+		predicate := resultFilterPredicate{
+			column:   column,
+			operator: selectedOperator(),
+			value:    valueInput.GetText(),
+			negate:   negateInput.IsChecked(),
+			or:       or,
 		}
-		closeModal()
-		if addAND {
-			a.addResultPredicateAND(column, operator, value)
-			return
+		if !resultFilterOperatorNeedsValue(predicate.operator) {
+			predicate.value = nil
 		}
-		a.applyResultPredicate(column, operator, value)
+		closeModal()
+		a.changeResultFilterPredicate(predicate, add)
 	}
 	applyClipboardValue := func() {
 		operator := selectedOperator()
+		negate := negateInput.IsChecked()
+		closeModal()
 		if !resultFilterOperatorNeedsValue(operator) {
-			closeModal()
-			a.applyResultPredicate(column, operator, nil)
+			a.changeResultFilterPredicate(resultFilterPredicate{column: column, operator: operator, negate: negate}, false)
 			return
 		}
-		closeModal()
 		a.withClipboardResultPredicate(column, operator, func(predicate resultFilterPredicate) {
-			a.applyResultPredicate(predicate.column, predicate.operator, predicate.value)
+			predicate.negate = negate
+			a.changeResultFilterPredicate(predicate, false)
 		})
 	}
 	valueInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEnter && event.Modifiers() == tcell.ModNone {
-			applyTypedValue(false)
+			applyTypedValue(false, false)
 			return nil
 		}
 		return event
 	})
 
-	form.AddButton("Apply", func() { applyTypedValue(false) })
-	form.AddButton("Add AND", func() { applyTypedValue(true) })
+	form.AddButton("Apply", func() { applyTypedValue(false, false) })
+	form.AddButton("Add AND", func() { applyTypedValue(true, false) })
+	form.AddButton("Add OR", func() { applyTypedValue(true, true) })
 	form.AddButton("Use Clipboard", applyClipboardValue)
 	form.AddButton("Remove Last", func() {
 		closeModal()
@@ -277,7 +304,7 @@ func (a *App) showResultFilterModal() {
 		SetText(resultFilterModalSummary(activeFilter))
 	activeView.SetBackgroundColor(mantle)
 
-	modalW, modalH := a.modalSize(72, 104, 16, 23)
+	modalW, modalH := a.modalSize(72, 104, 18, 25)
 	footer := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
@@ -369,27 +396,37 @@ func (a *App) addResultPredicateAND(column string, operator resultFilterOperator
 }
 
 func (a *App) changeResultPredicate(column string, operator resultFilterOperator, value any, addAND bool) {
-	if a == nil || a.selectedTable == "" || strings.TrimSpace(column) == "" {
+	a.changeResultFilterPredicate(resultFilterPredicate{column: column, operator: operator, value: value}, addAND)
+}
+
+// changeResultFilterPredicate applies predicate, or adds it to the filter
+// expression when add is set; predicate.or then decides whether it joins the
+// last group with AND or starts a new OR group.
+func (a *App) changeResultFilterPredicate(predicate resultFilterPredicate, add bool) {
+	if a == nil || a.selectedTable == "" || strings.TrimSpace(predicate.column) == "" {
+		return
+	}
+	predicate = normalizedResultFilterPredicate(predicate)
+	if err := resultFilterPredicateError(predicate); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v.", iconWarn, err), "main")
 		return
 	}
 
 	previous := a.captureResultFilterViewState()
-	predicate := normalizedResultFilterPredicate(resultFilterPredicate{
-		column:   column,
-		operator: operator,
-		value:    value,
-	})
 	predicates := []resultFilterPredicate(nil)
 	if active := a.activeResultFilter(a.selectedTable); active != nil {
 		predicates = active.orderedPredicates()
 	}
-	predicates, changedIndex := changedResultFilterPredicates(predicates, predicate, addAND)
+	predicates, changedIndex := changedResultFilterPredicates(predicates, predicate, add)
 	a.setCurrentResultFilter(newResultValueFilter(a.selectedTable, predicates))
 	a.resetPagination()
 	predicateText := resultFilterPredicateText(predicate, 34)
 	action := "Applying"
 	completedAction := "Filter"
-	if addAND {
+	if add && predicate.or && len(predicates) > 1 {
+		action = "Adding OR"
+		completedAction = "Added OR"
+	} else if add {
 		action = "Adding AND"
 		completedAction = "Added AND"
 	} else if changedIndex >= 0 {
@@ -405,20 +442,27 @@ func (a *App) changeResultPredicate(column string, operator resultFilterOperator
 	)
 }
 
-func changedResultFilterPredicates(existing []resultFilterPredicate, predicate resultFilterPredicate, addAND bool) ([]resultFilterPredicate, int) {
+// changedResultFilterPredicates returns existing with predicate applied. A
+// replacement keeps the AND/OR position of the predicate it replaces; an
+// appended predicate joins with AND unless predicate.or is set.
+func changedResultFilterPredicates(existing []resultFilterPredicate, predicate resultFilterPredicate, add bool) ([]resultFilterPredicate, int) {
 	predicates := make([]resultFilterPredicate, len(existing))
 	for index, current := range existing {
 		predicates[index] = cloneResultFilterPredicate(current)
 	}
 	predicate = normalizedResultFilterPredicate(predicate)
 	changedIndex := -1
-	if !addAND {
+	if !add {
 		changedIndex = resultFilterPredicateReplacementIndex(predicates, predicate)
 	}
 	if changedIndex >= 0 {
+		predicate.or = predicates[changedIndex].or
 		predicates[changedIndex] = predicate
 		return predicates, changedIndex
 	}
+	if !add {
+		predicate.or = false
+	}
 	return append(predicates, predicate), -1
 }
 
@@ -428,12 +472,13 @@ func resultFilterPredicateReplacementIndex(predicates []resultFilterPredicate, r
 	// filter behave exactly like the original single-filter modal.
 	for index := len(predicates) - 1; index >= 0; index-- {
 		predicate := normalizedResultFilterPredicate(predicates[index])
-		if predicate.column == replacement.column && predicate.operator == replacement.operator {
+		if predicate.column == replacement.column && predicate.operator == replacement.operator && predicate.negate == replacement.negate {
 			return index
 		}
 	}
 	// Apply is intentionally a replacement action for the selected column.
-	// Users who want a range or another same-column condition choose Add AND.
+	// Users who want a range or another same-column condition choose Add AND
+	// or Add OR.
 	for index := len(predicates) - 1; index >= 0; index-- {
 		if strings.TrimSpace(predicates[index].column) == replacement.column {
 			return index
@@ -495,6 +540,7 @@ func (a *App) captureResultFilterViewState() resultFilterViewState {
 		pageOffset:    a.pageOffset,
 		pageSize:      a.pageSize,
 		totalRowCount: a.totalRowCount,
+		totalApprox:   a.totalRowCountApprox,
 	}
 	if a.results != nil {
 		state.selection = cloneResultSelectionState(a.captureResultSelection())
@@ -510,6 +556,7 @@ func (a *App) restoreResultFilterViewState(state resultFilterViewState) {
 	a.pageOffset = state.pageOffset
 	a.pageSize = state.pageSize
 	a.totalRowCount = state.totalRowCount
+	a.totalRowCountApprox = state.totalApprox
 	if a.results != nil {
 		a.restoreResultSelection(state.selection, a.currentResultRowCount())
 	}
@@ -691,54 +738,99 @@ func resultFilterClause(dbType config.DBType, column string) string {
 	return fmt.Sprintf(" WHERE %s = %s", quoteIdentifier(dbType, column), resultFilterPlaceholder(dbType))
 }
 
-// resultFilterSQL renders an ordered AND expression and its parameter values.
+// resultFilterSQL renders the filter expression and its parameter values.
 // Identifiers are quoted and values remain query parameters on every engine.
+// An expression with OR groups is parenthesized as a whole, so callers can
+// still append their own AND conditions.
 func resultFilterSQL(dbType config.DBType, filter *resultValueFilter) (string, []any) {
 	if filter == nil {
 		return "", nil
 	}
-	predicates := filter.orderedPredicates()
-	if len(predicates) == 0 {
+	groups := resultFilterGroups(filter.orderedPredicates())
+	if len(groups) == 0 {
 		return "", nil
 	}
 
-	conditions := make([]string, 0, len(predicates))
-	args := make([]any, 0, len(predicates))
+	var args []any
+	groupConditions := make([]string, 0, len(groups))
+	for _, group := range groups {
+		conditions := make([]string, 0, len(group))
+		for _, predicate := range group {
+			conditions = append(conditions, resultFilterConditionSQL(dbType, predicate, &args))
+		}
+		condition := strings.Join(conditions, " AND ")
+		if len(groups) > 1 && len(conditions) > 1 {
+			condition = "(" + condition + ")"
+		}
+		groupConditions = append(groupConditions, condition)
+	}
+	if len(groupConditions) == 1 {
+		return " WHERE " + groupConditions[0], args
+	}
+	return " WHERE (" + strings.Join(groupConditions, " OR ") + ")", args
+}
+
+// resultFilterConditionSQL renders one normalized predicate and appends its
+// values to args.
+func resultFilterConditionSQL(dbType config.DBType, predicate resultFilterPredicate, args *[]any) string {
+	column := quoteIdentifier(dbType, predicate.column)
+	placeholder := func(value any) string {
+		*args = append(*args, value)
+		return numberedResultFilterPlaceholder(dbType, len(*args))
+	}
+	var condition string
+	switch predicate.operator {
+	case resultFilterIsNull:
+		condition = column + " IS NULL"
+	case resultFilterIsNotNull:
+		condition = column + " IS NOT NULL"
+	case resultFilterIn:
+		values := resultFilterListValues(predicate.value)
+		placeholders := make([]string, len(values))
+		for index, value := range values {
+			placeholders[index] = placeholder(value)
+		}
+		condition = fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", "))
+	case resultFilterBetween:
+		values := resultFilterListValues(predicate.value)
+		low, high := placeholder(values[0]), placeholder(values[1])
+		condition = fmt.Sprintf("%s BETWEEN %s AND %s", column, low, high)
+	case resultFilterContains, resultFilterStartsWith:
+		value := escapeResultFilterLikeValue(resultFilterValueString(predicate.value))
+		if predicate.operator == resultFilterContains {
+			value = "%" + value + "%"
+		} else {
+			value += "%"
+		}
+		condition = fmt.Sprintf("%s LIKE %s ESCAPE '='", resultFilterTextExpression(dbType, column), placeholder(value))
+	default:
+		operator := string(predicate.operator)
+		if predicate.operator == resultFilterNotEqual {
+			operator = "<>"
+		}
+		condition = fmt.Sprintf("%s %s %s", column, operator, placeholder(predicate.value))
+	}
+	if predicate.negate {
+		return "NOT (" + condition + ")"
+	}
+	return condition
+}
+
+// resultFilterGroups splits ordered predicates into the AND groups that are
+// joined with OR. Predicates that cannot render are skipped.
+func resultFilterGroups(predicates []resultFilterPredicate) [][]resultFilterPredicate {
+	var groups [][]resultFilterPredicate
 	for _, predicate := range predicates {
 		predicate = normalizedResultFilterPredicate(predicate)
-		if predicate.column == "" {
+		if predicate.column == "" || resultFilterPredicateError(predicate) != nil {
 			continue
 		}
-		column := quoteIdentifier(dbType, predicate.column)
-		switch predicate.operator {
-		case resultFilterIsNull:
-			conditions = append(conditions, column+" IS NULL")
-		case resultFilterIsNotNull:
-			conditions = append(conditions, column+" IS NOT NULL")
-		case resultFilterContains, resultFilterStartsWith:
-			placeholder := numberedResultFilterPlaceholder(dbType, len(args)+1)
-			conditions = append(conditions, fmt.Sprintf("%s LIKE %s ESCAPE '='", resultFilterTextExpression(dbType, column), placeholder))
-			value := escapeResultFilterLikeValue(resultFilterValueString(predicate.value))
-			if predicate.operator == resultFilterContains {
-				value = "%" + value + "%"
-			} else {
-				value += "%"
-			}
-			args = append(args, value)
-		default:
-			placeholder := numberedResultFilterPlaceholder(dbType, len(args)+1)
-			operator := string(predicate.operator)
-			if predicate.operator == resultFilterNotEqual {
-				operator = "<>"
-			}
-			conditions = append(conditions, fmt.Sprintf("%s %s %s", column, operator, placeholder))
-			args = append(args, predicate.value)
+		if len(groups) == 0 || predicate.or {
+			groups = append(groups, nil)
 		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], predicate)
 	}
-	if len(conditions) == 0 {
-		return "", nil
-	}
-	return " WHERE " + strings.Join(conditions, " AND "), args
+	return groups
 }
 
 func resultFilterTextExpression(dbType config.DBType, quotedColumn string) string {
@@ -773,6 +865,10 @@ func normalizeResultFilterOperator(operator resultFilterOperator) resultFilterOp
 		return resultFilterLess
 	case "<=":
 		return resultFilterLessEqual
+	case "in":
+		return resultFilterIn
+	case "between":
+		return resultFilterBetween
 	case "contains":
 		return resultFilterContains
 	case "starts-with", "starts with", "startswith":
@@ -789,12 +885,84 @@ func normalizeResultFilterOperator(operator resultFilterOperator) resultFilterOp
 func normalizedResultFilterPredicate(predicate resultFilterPredicate) resultFilterPredicate {
 	predicate.column = strings.TrimSpace(predicate.column)
 	predicate.operator = normalizeResultFilterOperator(predicate.operator)
-	if !resultFilterOperatorNeedsValue(predicate.operator) {
+	switch {
+	case !resultFilterOperatorNeedsValue(predicate.operator):
 		predicate.value = nil
+	case resultFilterOperatorTakesList(predicate.operator):
+		if text, ok := predicate.value.(string); ok {
+			predicate.value = parseResultFilterList(predicate.operator, text)
+		} else if _, ok := predicate.value.([]any); !ok {
+			predicate.value = []any{predicate.value}
+		}
 	}
 	return predicate
 }
 
+// resultFilterPredicateError reports a list operator with the wrong number
+// of values.
+func resultFilterPredicateError(predicate resultFilterPredicate) error {
+	values := resultFilterListValues(predicate.value)
+	switch predicate.operator {
+	case resultFilterIn:
+		if len(values) == 0 {
+			return errors.New("IN needs at least one value, separated by commas")
+		}
+	case resultFilterBetween:
+		if len(values) != 2 {
+			return fmt.Errorf("BETWEEN needs a low and a high value, such as 10, 20; got %d", len(values))
+		}
+	}
+	return nil
+}
+
+func resultFilterOperatorTakesList(operator resultFilterOperator) bool {
+	return operator == resultFilterIn || operator == resultFilterBetween
+}
+
+var resultFilterBetweenAnd = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// parseResultFilterList splits typed IN or BETWEEN values on commas. Values
+// that contain a comma are double-quoted, as in CSV. BETWEEN also accepts
+// "low AND high".
+func parseResultFilterList(operator resultFilterOperator, text string) []any {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	fields, err := reader.Read()
+	if err != nil {
+		fields = strings.Split(text, ",")
+	}
+	if operator == resultFilterBetween && len(fields) == 1 {
+		fields = resultFilterBetweenAnd.Split(fields[0], 2)
+	}
+	values := make([]any, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			values = append(values, field)
+		}
+	}
+	return values
+}
+
+func resultFilterListValues(value any) []any {
+	values, _ := value.([]any)
+	return values
+}
+
+// formatResultFilterList is the inverse of parseResultFilterList, so a
+// reopened filter shows the values it was built from.
+func formatResultFilterList(values []any) string {
+	parts := make([]string, len(values))
+	for index, value := range values {
+		part := resultFilterValueString(value)
+		if strings.ContainsAny(part, ",\"") {
+			part = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		}
+		parts[index] = part
+	}
+	return strings.Join(parts, ", ")
+}
+
 func resultFilterOperatorNeedsValue(operator resultFilterOperator) bool {
 	operator = normalizeResultFilterOperator(operator)
 	return operator != resultFilterIsNull && operator != resultFilterIsNotNull
@@ -865,6 +1033,14 @@ func cloneResultValueFilter(filter *resultValueFilter) *resultValueFilter {
 }
 
 func cloneResultFilterPredicate(predicate resultFilterPredicate) resultFilterPredicate {
+	if values, ok := predicate.value.([]any); ok {
+		cloned := make([]any, len(values))
+		for index, value := range values {
+			cloned[index] = cloneResultRawValue(value)
+		}
+		predicate.value = cloned
+		return predicate
+	}
 	predicate.value = cloneResultRawValue(predicate.value)
 	return predicate
 }
@@ -873,6 +1049,9 @@ func resultFilterValueString(value any) string {
 	if value == nil {
 		return "NULL"
 	}
+	if values, ok := value.([]any); ok {
+		return formatResultFilterList(values)
+	}
 	return fullCellValue(value)
 }
 
@@ -883,7 +1062,7 @@ func latestResultFilterValueForColumn(filter *resultValueFilter, column string)
 	predicates := filter.orderedPredicates()
 	for index := len(predicates) - 1; index >= 0; index-- {
 		predicate := predicates[index]
-		if predicate.column == column && predicate.operator == resultFilterEqual {
+		if predicate.column == column && predicate.operator == resultFilterEqual && !predicate.negate {
 			return resultFilterValueString(predicate.value)
 		}
 	}
@@ -906,24 +1085,74 @@ func latestResultFilterPredicateForColumn(filter *resultValueFilter, column stri
 
 func resultFilterPredicateText(predicate resultFilterPredicate, maxRunes int) string {
 	predicate = normalizedResultFilterPredicate(predicate)
-	if !resultFilterOperatorNeedsValue(predicate.operator) {
-		return fmt.Sprintf("%s %s", predicate.column, predicate.operator)
+	var text string
+	switch {
+	case !resultFilterOperatorNeedsValue(predicate.operator):
+		text = fmt.Sprintf("%s %s", predicate.column, predicate.operator)
+	case predicate.operator == resultFilterIn:
+		text = fmt.Sprintf("%s IN (%s)", predicate.column, resultValuePreview(resultFilterValueString(predicate.value), maxRunes))
+	case predicate.operator == resultFilterBetween:
+		values := resultFilterListValues(predicate.value)
+		bounds := make([]string, len(values))
+		for index, value := range values {
+			bounds[index] = resultValuePreview(resultFilterValueString(value), maxRunes)
+		}
+		text = fmt.Sprintf("%s BETWEEN %s", predicate.column, strings.Join(bounds, " AND "))
+	default:
+		value := resultValuePreview(resultFilterValueString(predicate.value), maxRunes)
+		text = fmt.Sprintf("%s %s %s", predicate.column, predicate.operator, value)
+	}
+	if predicate.negate {
+		return "NOT " + text
 	}
-	value := resultValuePreview(resultFilterValueString(predicate.value), maxRunes)
-	return fmt.Sprintf("%s %s %s", predicate.column, predicate.operator, value)
+	return text
+}
+
+// resultFilterExpressionText renders the filter expression the way it
+// reaches SQL, with AND groups parenthesized once OR joins them.
+func resultFilterExpressionText(predicates []resultFilterPredicate, maxRunes int) string {
+	groups := resultFilterGroups(predicates)
+	parts := make([]string, len(groups))
+	for index, group := range groups {
+		conditions := make([]string, len(group))
+		for conditionIndex, predicate := range group {
+			conditions[conditionIndex] = resultFilterPredicateText(predicate, maxRunes)
+		}
+		parts[index] = strings.Join(conditions, " AND ")
+		if len(groups) > 1 && len(group) > 1 {
+			parts[index] = "(" + parts[index] + ")"
+		}
+	}
+	return strings.Join(parts, " OR ")
 }
 
 func resultFilterModalSummary(filter *resultValueFilter) string {
 	if filter == nil {
-		return " [#6c7086]Active filters: none. Reopen / to add another AND predicate.[-]"
+		return " [#6c7086]Active filters: none. Reopen / to add another AND or OR predicate.[-]"
 	}
 	predicates := filter.orderedPredicates()
 	if len(predicates) == 0 {
 		return " [#6c7086]Active filters: none.[-]"
 	}
-	lines := []string{fmt.Sprintf(" [#a6adc8]Active filters (%d, combined with AND):[-]", len(predicates))}
-	for index, predicate := range predicates {
-		lines = append(lines, fmt.Sprintf(" [yellow]%d.[-] %s", index+1, tview.Escape(resultFilterPredicateText(predicate, 48))))
+	groups := resultFilterGroups(predicates)
+	header := fmt.Sprintf(" [#a6adc8]Active filters (%d, combined with AND):[-]", len(predicates))
+	if len(groups) > 1 {
+		header = fmt.Sprintf(" [#a6adc8]Active filters (%d in %d OR groups; AND binds first):[-]", len(predicates), len(groups))
+	}
+	lines := []string{header}
+	number := 0
+	for groupIndex, group := range groups {
+		for index, predicate := range group {
+			number++
+			join := ""
+			switch {
+			case groupIndex > 0 && index == 0:
+				join = "[#fab387]OR[-] "
+			case index > 0:
+				join = "AND "
+			}
+			lines = append(lines, fmt.Sprintf(" [yellow]%d.[-] %s%s", number, join, tview.Escape(resultFilterPredicateText(predicate, 48))))
+		}
 	}
 	return strings.Join(lines, "\n")
 }
@@ -942,17 +1171,7 @@ func (a *App) resultFilterBadge() string {
 		return ""
 	}
 	predicates := filter.orderedPredicates()
-	previews := make([]string, 0, min(2, len(predicates)))
-	for index, predicate := range predicates {
-		if index == 2 {
-			break
-		}
-		previews = append(previews, resultFilterPredicateText(predicate, 18))
-	}
-	summary := strings.Join(previews, " AND ")
-	if len(predicates) > len(previews) {
-		summary += fmt.Sprintf(" +%d", len(predicates)-len(previews))
-	}
+	summary := truncateForDisplay(resultFilterExpressionText(predicates, 18), 72)
 	return fmt.Sprintf(" [#cba6f7]FILTERED %d: %s • Esc clears[-]", len(predicates), tview.Escape(summary))
 }
 
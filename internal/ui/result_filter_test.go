@@ -97,6 +97,71 @@ func TestResultFilterSQLTextOperatorsWorkOnNonTextColumns(t *testing.T) {
 	}
 }
 
+func TestResultFilterSQLRendersOrGroupsNegationInAndBetween(t *testing.T) {
+	filter := newResultValueFilter("jobs", []resultFilterPredicate{
+		{column: "status", operator: resultFilterIn, value: `failed, "retry, later"`},
+		{column: "attempts", operator: resultFilterBetween, value: "1 and 3", negate: true},
+		{column: "stuck", operator: resultFilterEqual, value: "1", or: true},
+	})
+
+	clause, args := resultFilterSQL(config.PostgreSQL, filter)
+	wantClause := ` WHERE (("status" IN ($1, $2) AND NOT ("attempts" BETWEEN $3 AND $4)) OR "stuck" = $5)`
+	if clause != wantClause {
+		t.Fatalf("resultFilterSQL() clause = %q, want %q", clause, wantClause)
+	}
+	wantArgs := []any{"failed", "retry, later", "1", "3", "1"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("resultFilterSQL() args = %#v, want %#v", args, wantArgs)
+	}
+	mysqlClause, _ := resultFilterSQL(config.MySQL, filter)
+	if want := " WHERE ((`status` IN (?, ?) AND NOT (`attempts` BETWEEN ? AND ?)) OR `stuck` = ?)"; mysqlClause != want {
+		t.Fatalf("MySQL clause = %q, want %q", mysqlClause, want)
+	}
+
+	text := resultFilterExpressionText(filter.orderedPredicates(), 40)
+	if want := `(status IN (failed, "retry, later") AND NOT attempts BETWEEN 1 AND 3) OR stuck = 1`; text != want {
+		t.Fatalf("expression text = %q, want %q", text, want)
+	}
+}
+
+func TestResultFilterListOperatorsRejectWrongValueCounts(t *testing.T) {
+	for _, predicate := range []resultFilterPredicate{
+		{column: "total", operator: resultFilterBetween, value: "10"},
+		{column: "total", operator: resultFilterBetween, value: "1, 2, 3"},
+		{column: "status", operator: resultFilterIn, value: " , "},
+	} {
+		if err := resultFilterPredicateError(normalizedResultFilterPredicate(predicate)); err == nil {
+			t.Errorf("%s %q was accepted", predicate.operator, predicate.value)
+		}
+	}
+	if err := resultFilterPredicateError(normalizedResultFilterPredicate(resultFilterPredicate{
+		column: "total", operator: resultFilterBetween, value: "10, 20",
+	})); err != nil {
+		t.Fatalf("BETWEEN 10, 20: %v", err)
+	}
+}
+
+func TestChangedResultFilterPredicatesKeepsOrGroups(t *testing.T) {
+	existing := []resultFilterPredicate{
+		{column: "status", operator: resultFilterEqual, value: "failed"},
+		{column: "status", operator: resultFilterEqual, value: "retrying", or: true},
+	}
+
+	replaced, changedIndex := changedResultFilterPredicates(existing, resultFilterPredicate{
+		column: "status", operator: resultFilterEqual, value: "stuck",
+	}, false)
+	if changedIndex != 1 || !replaced[1].or || replaced[1].value != "stuck" {
+		t.Fatalf("Apply result = %#v at %d, want the OR predicate updated in place", replaced, changedIndex)
+	}
+
+	added, _ := changedResultFilterPredicates(existing, resultFilterPredicate{
+		column: "region", operator: resultFilterEqual, value: "eu", or: true,
+	}, true)
+	if got := resultFilterExpressionText(added, 20); got != "status = failed OR status = retrying OR region = eu" {
+		t.Fatalf("Add OR expression = %q", got)
+	}
+}
+
 func TestLatestResultFilterPredicateForColumnKeepsOperator(t *testing.T) {
 	filter := newResultValueFilter("items", []resultFilterPredicate{
 		{column: "created_at", operator: resultFilterGreaterEqual, value: "2026-01-01"},
@@ -391,3 +456,29 @@ func TestResultFilterBadgeExplicitlyShowsFilteredState(t *testing.T) {
 		t.Fatalf("filter badge is not explicit enough: %q", badge)
 	}
 }
+
+func TestRememberedResultFiltersKeepRicherExpressions(t *testing.T) {
+	app := &App{
+		selectedTable: "jobs",
+		resultFilter: newResultValueFilter("jobs", []resultFilterPredicate{
+			{column: "status", operator: resultFilterIn, value: "failed, retrying"},
+			{column: "owner", operator: resultFilterIsNull, negate: true},
+			{column: "priority", operator: resultFilterGreater, value: "5", or: true},
+		}),
+	}
+	wantClause, wantArgs := resultFilterSQL(config.SQLite, app.resultFilter)
+
+	app.selectTableWithRememberedFilter("users")
+	app.selectTableWithRememberedFilter("jobs")
+	app.resultFilter.predicates[0].value.([]any)[0] = "changed"
+	app.resultFilter = nil
+	app.restoreRememberedResultFilter("jobs")
+
+	clause, args := resultFilterSQL(config.SQLite, app.activeResultFilter("jobs"))
+	if clause != wantClause || !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("restored filter = (%q, %#v), want (%q, %#v)", clause, args, wantClause, wantArgs)
+	}
+	if badge := app.resultFilterBadge(); !strings.Contains(badge, "(status IN (failed, retrying) AND NOT owner IS NULL) OR priority > 5") {
+		t.Fatalf("badge does not render the expression: %q", badge)
+	}
+}
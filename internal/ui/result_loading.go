@@ -113,12 +113,15 @@ func fallbackText(value, fallback string) string {
 	return value
 }
 
-func (a *App) loadResultPageAsync(targetOffset int, label string) {
+// loadResultPageAsync moves to the page at targetOffset. cursor seeks to it
+// by key; nil reads it with OFFSET, or from the start when targetOffset is 0.
+func (a *App) loadResultPageAsync(targetOffset int, cursor *resultPageCursor, label string) {
 	if a == nil {
 		return
 	}
-	previousOffset := a.pageOffset
+	previousOffset, previousCursor := a.pageOffset, a.pageCursor
 	a.pageOffset = max(0, targetOffset)
+	a.pageCursor = cursor
 	a.loadCurrentTableAsync(tableLoadOptions{
 		loadingText:  fmt.Sprintf("Loading %s...", label),
 		cancelText:   "Press Esc to cancel changing pages.",
@@ -126,6 +129,7 @@ func (a *App) loadResultPageAsync(targetOffset int, label string) {
 		errorText:    fmt.Sprintf("Could not load %s", label),
 		rollback: func() {
 			a.pageOffset = previousOffset
+			a.pageCursor = previousCursor
 		},
 	})
 }
@@ -138,7 +142,7 @@ func (a *App) refreshCurrentTableAsync() {
 		a.flashStatus("[yellow]No active table to refresh[-]", a.currentResultRowCount(), 1400*time.Millisecond)
 		return
 	}
-	previousTotal := a.totalRowCount
+	previousTotal, previousApprox := a.totalRowCount, a.totalRowCountApprox
 	a.totalRowCount = -1
 	a.loadCurrentTableAsync(tableLoadOptions{
 		loadingText:  "Refreshing table...",
@@ -147,7 +151,7 @@ func (a *App) refreshCurrentTableAsync() {
 		errorText:    "Could not refresh table",
 		successText:  fmt.Sprintf("%s Table refreshed", iconRefresh),
 		rollback: func() {
-			a.totalRowCount = previousTotal
+			a.totalRowCount, a.totalRowCountApprox = previousTotal, previousApprox
 		},
 	})
 }
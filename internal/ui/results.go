@@ -41,6 +41,8 @@ type resultCellReference struct {
 	rowSelected  bool
 	profilerKind string
 	profilerCell bool
+	// staged marks a cell showing an uncommitted inline edit.
+	staged bool
 }
 
 type resultSelectionState struct {
@@ -58,13 +60,14 @@ type tableResultRequest struct {
 	selectedTable  string
 	quotedTable    string
 	query          string
-	countQuery     string
 	queryArgs      []any
 	requestedLimit int
 	pageOffset     int
-	generation     uint64
-	selection      resultSelectionState
-	startedAt      time.Time
+	// cursor is set when the page is read in key order; see keyset.go.
+	cursor     *resultPageCursor
+	generation uint64
+	selection  resultSelectionState
+	startedAt  time.Time
 }
 
 type tableResultSnapshot struct {
@@ -84,7 +87,8 @@ type tableResultAsyncCallbacks struct {
 }
 
 // LoadResults loads data from the selected table into the results view
-// using OFFSET/LIMIT pagination to bound memory usage.
+// one bounded page at a time, seeking by key where it can and using OFFSET
+// otherwise.
 func (a *App) LoadResults() error {
 	request, err := a.prepareTableResultRequest()
 	if err != nil {
@@ -128,23 +132,46 @@ func (a *App) prepareTableResultRequest() (*tableResultRequest, error) {
 	}
 
 	query := fmt.Sprintf("SELECT * FROM %s", quotedTable)
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quotedTable)
 	queryArgs := []any(nil)
+	filterClause := ""
 	if filter := a.activeResultFilter(selectedTable); filter != nil {
 		clause, filterArgs := resultFilterSQL(dbType, filter)
-		query += clause
-		countQuery += clause
+		filterClause = clause
 		queryArgs = append(queryArgs, filterArgs...)
 	}
-	if sortColumn := a.serverSortColumnName(); sortColumn != "" {
+
+	var cursor *resultPageCursor
+	if page := a.pageCursorFor(a.pageOffset); len(page.columns) > 0 && queryLimit > 0 {
+		if current := a.pageCursor; current != nil && current.sameScope(page) {
+			page.anchor, page.backward = current.anchor, current.backward
+		}
+		cursor = &page
+	}
+	orderBy := ""
+	if cursor != nil {
+		seek, order, seekArgs := keysetSQL(dbType, *cursor, len(queryArgs)+1)
+		switch {
+		case seek == "":
+		case filterClause == "":
+			filterClause = " WHERE " + seek
+		default:
+			filterClause += " AND " + seek
+		}
+		orderBy = order
+		queryArgs = append(queryArgs, seekArgs...)
+	} else if sortColumn := a.serverSortColumnName(); sortColumn != "" {
 		direction := "ASC"
 		if !a.sortAsc {
 			direction = "DESC"
 		}
-		query = fmt.Sprintf("%s ORDER BY %s %s", query, quoteIdentifier(dbType, sortColumn), direction)
+		orderBy = fmt.Sprintf(" ORDER BY %s %s", quoteIdentifier(dbType, sortColumn), direction)
 	}
+	query += filterClause + orderBy
 	if queryLimit > 0 {
-		query = fmt.Sprintf("%s LIMIT %d OFFSET %d", query, queryLimit, a.pageOffset)
+		query = fmt.Sprintf("%s LIMIT %d", query, queryLimit)
+		if cursor == nil || cursor.usesOffset() {
+			query = fmt.Sprintf("%s OFFSET %d", query, a.pageOffset)
+		}
 	}
 	selection := a.captureResultSelection()
 	// The visible grid still belongs to activeTable while a different table is
@@ -163,10 +190,10 @@ func (a *App) prepareTableResultRequest() (*tableResultRequest, error) {
 		selectedTable:  selectedTable,
 		quotedTable:    quotedTable,
 		query:          query,
-		countQuery:     countQuery,
 		queryArgs:      queryArgs,
 		requestedLimit: requestedLimit,
 		pageOffset:     a.pageOffset,
+		cursor:         cursor,
 		// Every fetch owns a unique generation so a slower page, sort, or
 		// filter request can never overwrite a newer result set.
 		generation: a.advanceResultGeneration(),
@@ -244,6 +271,9 @@ func fetchTableResultSnapshot(ctx context.Context, request *tableResultRequest)
 	if err != nil {
 		return nil, err
 	}
+	if request.cursor != nil && request.cursor.backward {
+		reverseResultRows(results, rowCount)
+	}
 	return &tableResultSnapshot{
 		request:     request,
 		results:     results,
@@ -290,10 +320,27 @@ func (a *App) applyTableResultSnapshot(snapshot *tableResultSnapshot) bool {
 	a.restoreColumnWidths(request.selectedTable)
 	a.applyColumnWidths()
 	a.applyProfilerHighlightsToResults(request.selectedTable)
+	a.applyStagedCellEdits()
 	a.restoreResultSelection(request.selection, snapshot.rowCount)
 
-	countArgs := append([]any(nil), request.queryArgs...)
-	go a.fetchTotalRowCount(request.db, request.selectedTable, request.quotedTable, request.dbType, snapshot.pageLimit, request.pageOffset, request.generation, request.countQuery, countArgs)
+	a.pageCursor = nil
+	if request.cursor != nil {
+		cursor := *request.cursor
+		// Paging back from a last page placed by an estimate can reach the
+		// start early; the short page it returns is then the first page.
+		if cursor.backward && cursor.anchor != nil && snapshot.rowCount < snapshot.pageLimit {
+			a.pageOffset = 0
+			cursor = a.pageCursorFor(0)
+		}
+		a.pageCursor = &cursor
+	}
+	if snapshot.pageLimit > 0 {
+		a.loadTableSeekKey(request.selectedTable)
+		// Estimates and exact counts of huge tables are kept across pages.
+		if a.totalRowCount < 0 || (!a.totalRowCountApprox && a.totalRowCount < exactRowCountAutoLimit) {
+			go a.fetchTotalRowCount(a.currentRowCountRequest())
+		}
+	}
 	a.results.SetTitle(a.paginatedResultTitle(snapshot.rowCount, snapshot.elapsed))
 	a.updateStatusBar("", snapshot.rowCount)
 	return true
@@ -382,58 +429,10 @@ func (a *App) runTableResultRequestAsync(request *tableResultRequest, loadingTex
 }
 
 func (a *App) restartTotalRowCountFetchIfNeeded() {
-	if a == nil || a.totalRowCount >= 0 || a.db == nil || !a.isTableResultActive() {
-		return
-	}
-	pageLimit := a.currentPageLimit()
-	if pageLimit <= 0 {
-		return
-	}
-
-	db := a.db
-	dbType := a.dbType
-	selectedTable := a.selectedTable
-	quotedTable := quoteIdentifier(dbType, selectedTable)
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quotedTable)
-	countArgs := []any(nil)
-	if filter := a.activeResultFilter(selectedTable); filter != nil {
-		clause, filterArgs := resultFilterSQL(dbType, filter)
-		countQuery += clause
-		countArgs = append(countArgs, filterArgs...)
-	}
-	generation := a.currentResultGeneration()
-	pageOffset := a.pageOffset
-	go a.fetchTotalRowCount(db, selectedTable, quotedTable, dbType, pageLimit, pageOffset, generation, countQuery, countArgs)
-}
-
-// fetchTotalRowCount queries COUNT(*) for the captured table and updates the title
-// only if the result still belongs to the active table/connection generation.
-func (a *App) fetchTotalRowCount(db *sql.DB, selectedTable, quotedTable string, dbType config.DBType, pageLimit, pageOffset int, generation uint64, countQuery string, countArgs []any) {
-	if db == nil || selectedTable == "" || pageLimit <= 0 {
+	if a == nil || a.totalRowCount >= 0 || a.db == nil || !a.isTableResultActive() || a.currentPageLimit() <= 0 {
 		return
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	var total int
-	err := db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		return
-	}
-
-	a.queueUpdateDraw(func() {
-		if a.db != db || a.currentResultGeneration() != generation || a.selectedTable != selectedTable || a.dbType != dbType || quoteIdentifier(a.dbType, a.selectedTable) != quotedTable {
-			return
-		}
-
-		a.totalRowCount = total
-		a.results.SetTitle(a.paginatedResultTitle(a.currentResultRowCount(), time.Since(a.queryStart)))
-		if a.statusBar != nil {
-			a.updateStatusBar("", a.currentResultRowCount())
-		}
-		_ = pageOffset // captured for generation identity/debugging if pagination logic changes.
-	})
+	go a.fetchTotalRowCount(a.currentRowCountRequest())
 }
 
 // paginatedResultTitle builds the results panel title with page info.
@@ -448,6 +447,9 @@ func (a *App) paginatedResultTitle(rowCount int, elapsed time.Duration) string {
 		if totalPages < 1 {
 			totalPages = 1
 		}
+		if a.totalRowCountApprox {
+			return fmt.Sprintf("%s [#a6adc8](page %d/~%d, %s total)[-] ", base, page, totalPages, a.formatTotalRowCount())
+		}
 		return fmt.Sprintf("%s [#a6adc8](page %d/%d, %d total)[-] ", base, page, totalPages, a.totalRowCount)
 	}
 	if limit > 0 && a.pageOffset > 0 {
@@ -463,6 +465,7 @@ func (a *App) resetPagination() {
 	a.pageOffset = 0
 	a.pageSize = 0
 	a.totalRowCount = -1
+	a.pageCursor = nil
 }
 
 func (a *App) serverSortColumnName() string {
@@ -479,17 +482,22 @@ func (a *App) serverSortColumnName() string {
 	return stripSortIndicator(cell.Text)
 }
 
-// nextPage advances to the next page of results.
+// nextPage advances to the next page of results, seeking past the last
+// row on screen when the page was read in key order.
 func (a *App) nextPage() {
 	limit := a.currentPageLimit()
 	if limit <= 0 {
 		return
 	}
 	// Don't advance past the last page
-	if a.totalRowCount >= 0 && a.pageOffset+limit >= a.totalRowCount {
+	if a.totalRowCount >= 0 && !a.totalRowCountApprox && a.pageOffset+limit >= a.totalRowCount {
+		return
+	}
+	rows := a.currentResultRowCount()
+	if rows < limit {
 		return
 	}
-	a.loadResultPageAsync(a.pageOffset+limit, "next page")
+	a.loadResultPageAsync(a.pageOffset+limit, a.seekCursorFrom(rows, a.pageOffset+limit, false), "next page")
 }
 
 // prevPage goes back one page of results.
@@ -498,7 +506,12 @@ func (a *App) prevPage() {
 	if limit <= 0 || a.pageOffset <= 0 {
 		return
 	}
-	a.loadResultPageAsync(max(0, a.pageOffset-limit), "previous page")
+	offset := max(0, a.pageOffset-limit)
+	var cursor *resultPageCursor
+	if offset > 0 {
+		cursor = a.seekCursorFrom(1, offset, true)
+	}
+	a.loadResultPageAsync(offset, cursor, "previous page")
 }
 
 // firstPage jumps to the first page.
@@ -506,10 +519,11 @@ func (a *App) firstPage() {
 	if a.pageOffset == 0 {
 		return
 	}
-	a.loadResultPageAsync(0, "first page")
+	a.loadResultPageAsync(0, nil, "first page")
 }
 
-// lastPage jumps to the last page.
+// lastPage jumps to the last page. In key order it reads the last rows
+// directly, so an estimated total is enough; OFFSET needs an exact one.
 func (a *App) lastPage() {
 	limit := a.currentPageLimit()
 	if limit <= 0 || a.totalRowCount < 0 {
@@ -522,7 +536,17 @@ func (a *App) lastPage() {
 	if a.pageOffset == lastOffset {
 		return
 	}
-	a.loadResultPageAsync(lastOffset, "last page")
+	cursor := a.pageCursorFor(lastOffset)
+	cursor.backward = true
+	if len(cursor.columns) == 0 {
+		if a.totalRowCountApprox {
+			a.flashStatus(fmt.Sprintf("[yellow]%s The total is an estimate — press # to count rows exactly, then End[-]", iconInfo), a.currentResultRowCount(), 2200*time.Millisecond)
+			return
+		}
+		a.loadResultPageAsync(lastOffset, nil, "last page")
+		return
+	}
+	a.loadResultPageAsync(lastOffset, &cursor, "last page")
 }
 
 func (a *App) captureResultSelection() resultSelectionState {
@@ -27,7 +27,7 @@ func TestDateDatabaseValuesRemainDateOnly(t *testing.T) {
 	if reference.value != "2021-10-23" {
 		t.Fatalf("DATE complete value = %q, want date only", reference.value)
 	}
-	if exported := resultExportReferenceText(reference); exported != "2021-10-23" {
+	if exported := resultExportReferenceValue(reference).text; exported != "2021-10-23" {
 		t.Fatalf("DATE export = %q, want date only", exported)
 	}
 
@@ -0,0 +1,690 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+const (
+	pageRowInsert       = "rowInsert"
+	pageRowWritePreview = "rowWritePreview"
+
+	paletteActionInsertRow    keymapAction = "palette_insert_row"
+	paletteActionDuplicateRow keymapAction = "palette_duplicate_row"
+	paletteActionDeleteRows   keymapAction = "palette_delete_rows"
+
+	rowWriteTimeout = 30 * time.Second
+)
+
+// rowWriteStatement is one generated INSERT, UPDATE, or DELETE. sql binds
+// values; preview inlines them for display and the activity log only. An
+// UPDATE that reports no affected rows is re-checked with countSQL.
+type rowWriteStatement struct {
+	sql       string
+	args      []any
+	countSQL  string
+	countArgs []any
+	preview   string
+}
+
+// rowWritePreview describes the confirmation shown before a grid write.
+type rowWritePreview struct {
+	title       string
+	body        string
+	commitLabel string
+	tokens      []string
+	commit      func()
+	discard     func()
+}
+
+// rowDeleteImpact counts the rows of one incoming foreign key that point at
+// the rows being deleted.
+type rowDeleteImpact struct {
+	ref  foreignKeyReference
+	rows int64
+}
+
+func rowWriteEngineSupported(dbType config.DBType) bool {
+	switch dbType {
+	case config.PostgreSQL, config.MySQL, config.SQLite, config.Turso:
+		return true
+	default:
+		// D1 and DuckDB connections cannot open database/sql transactions.
+		return false
+	}
+}
+
+// rowWritesAllowed reports whether the grid may change data on the active
+// connection, explaining why not when it may not.
+func (a *App) rowWritesAllowed(feature string) bool {
+	if a.activeConn != nil && a.activeConn.ReadOnly {
+		a.ShowAlert(fmt.Sprintf("%s Read-Only Guard is on for \"%s\".\n\n%s is disabled for read-only profiles.", iconWarn, tview.Escape(a.dbName), feature), "main")
+		return false
+	}
+	if !rowWriteEngineSupported(a.dbType) {
+		a.ShowAlert(fmt.Sprintf("%s %s needs transactions, which this %s connection does not support.", iconWarn, feature, a.dbType), "main")
+		return false
+	}
+	return !a.transactionBlocksWrite(strings.ToLower(feature))
+}
+
+// commitRowWriteStatements runs every statement in one transaction. Each
+// statement must address exactly one existing row; anything else rolls back.
+func commitRowWriteStatements(ctx context.Context, db *sql.DB, statements []rowWriteStatement) ([]int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	affected := make([]int64, 0, len(statements))
+	for _, statement := range statements {
+		result, err := tx.ExecContext(ctx, statement.sql, statement.args...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", statement.preview, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", statement.preview, err)
+		}
+		if rows == 0 && statement.countSQL == "" {
+			return nil, fmt.Errorf("%s: the row no longer exists", statement.preview)
+		}
+		if rows == 0 {
+			// MySQL reports only changed rows, so an unchanged value is
+			// indistinguishable from a missing row without a lookup.
+			var count int64
+			if err := tx.QueryRowContext(ctx, statement.countSQL, statement.countArgs...).Scan(&count); err != nil {
+				return nil, fmt.Errorf("%s: %w", statement.preview, err)
+			}
+			if count != 1 {
+				return nil, fmt.Errorf("%s: the row no longer exists", statement.preview)
+			}
+		}
+		if rows > 1 {
+			return nil, fmt.Errorf("%s: matched %d rows, expected 1", statement.preview, rows)
+		}
+		affected = append(affected, rows)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return affected, nil
+}
+
+func rowWritePreviewText(statements []rowWriteStatement) string {
+	var body strings.Builder
+	body.WriteString(" [#a6adc8]Runs in one transaction:[-]\n\n")
+	for _, statement := range statements {
+		fmt.Fprintf(&body, " %s\n", tview.Escape(statement.preview))
+	}
+	return body.String()
+}
+
+func (a *App) showRowWritePreview(spec rowWritePreview) {
+	preview := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetWordWrap(true).SetScrollable(true)
+	preview.SetBackgroundColor(bg)
+	preview.SetBorder(true).SetTitle(" " + tview.Escape(spec.title) + " ").SetTitleColor(mauve).SetBorderColor(surface1)
+	preview.SetText(spec.body)
+
+	buttons := tview.NewForm()
+	buttons.SetBackgroundColor(bg)
+	buttons.SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	closePreview := func() {
+		a.pages.RemovePage(pageRowWritePreview)
+		a.pages.SwitchToPage("main")
+		a.setFocusWithColor(a.results)
+	}
+	buttons.AddButton(spec.commitLabel, func() {
+		closePreview()
+		if conn := a.activeConn; conn != nil && conn.IsProduction() {
+			a.confirmProductionWrite(spec.tokens, spec.commit)
+			return
+		}
+		spec.commit()
+	})
+	if spec.discard != nil {
+		buttons.AddButton("Discard all", func() {
+			closePreview()
+			spec.discard()
+		})
+	}
+	buttons.AddButton("Back", closePreview)
+
+	capture := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closePreview()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if preview.HasFocus() {
+				a.app.SetFocus(buttons)
+			} else {
+				a.app.SetFocus(preview)
+			}
+			return nil
+		}
+		return event
+	}
+	preview.SetInputCapture(capture)
+	buttons.SetInputCapture(capture)
+
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(preview, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	modalW, modalH := a.modalSize(72, 120, 14, 32)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageRowWritePreview, grid, true, true)
+	a.app.SetFocus(buttons)
+}
+
+// runRowWrite commits statements in the background, logs them to the Change
+// Profiler activity log, and reloads the table once they are durable.
+func (a *App) runRowWrite(table, loadingText string, statements []rowWriteStatement, committed func()) {
+	if a.db == nil || len(statements) == 0 {
+		return
+	}
+	if a.activeConn != nil && a.activeConn.ReadOnly {
+		a.ShowAlert(readOnlyGuardBlockedMessage(a.dbName, firstSQLToken(statements[0].sql)), "main")
+		return
+	}
+	// Staged edits may predate the transaction; keep them until it ends.
+	if a.transactionBlocksWrite("committing grid changes") {
+		return
+	}
+	db := a.db
+	ctx, cancel := context.WithTimeout(context.Background(), rowWriteTimeout)
+	loadingToken := a.showLoadingModal(loadingText, withLoadingCancelOutcome("Press Esc to cancel and roll back.", cancel))
+
+	go func() {
+		defer cancel()
+		affected, err := commitRowWriteStatements(ctx, db, statements)
+		a.queueUpdateDraw(func() {
+			a.finishLoadingModal(loadingToken)
+			if a.db != db {
+				return
+			}
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					a.flashStatus("[yellow]Canceled — the transaction was rolled back[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+					return
+				}
+				a.ShowAlert(fmt.Sprintf("%s Nothing was changed; the transaction was rolled back.\n\n%v", iconWarn, err), "main")
+				return
+			}
+			for index, statement := range statements {
+				a.recordProfilerActivity(statement.preview, affected[index])
+			}
+			if committed != nil {
+				committed()
+			}
+			if a.selectedTable == table {
+				a.refreshCurrentTableAsync()
+			}
+		})
+	}()
+}
+
+// showRowInsert opens the insert form for the active table. When duplicate
+// is set, the form starts from the selected row.
+func (a *App) showRowInsert(duplicate bool) {
+	if !a.isTableResultActive() || a.db == nil {
+		a.flashStatus("[yellow]Open a table to insert rows[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	feature := "Inserting rows"
+	var source map[string]*tview.TableCell
+	if duplicate {
+		feature = "Duplicating rows"
+		row, _ := a.results.GetSelection()
+		if !a.isSelectableResultRow(row) {
+			a.flashStatus("[yellow]Select a data row to duplicate[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+			return
+		}
+		source = make(map[string]*tview.TableCell, a.results.GetColumnCount())
+		for col := 0; col < a.results.GetColumnCount(); col++ {
+			source[strings.ToLower(a.resultColumnName(col))] = a.results.GetCell(row, col)
+		}
+	}
+	if !a.rowWritesAllowed(feature) {
+		return
+	}
+
+	db := a.db
+	dbType := a.dbType
+	table := a.selectedTable
+	namespace := a.defaultObjectNamespace("")
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Loading columns of %s...", table),
+		withLoadingCancel("Press Esc to cancel.", func() {
+			canceled.Store(true)
+			cancel()
+			a.setFocusWithColor(a.results)
+		}),
+	)
+
+	go func() {
+		defer cancel()
+		columns, err := loadSidebarColumnMetadata(ctx, db, dbType, table, namespace)
+		a.queueUpdateDraw(func() {
+			if canceled.Load() || a.db != db || a.selectedTable != table {
+				return
+			}
+			if !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			a.setFocusWithColor(a.results)
+			if err == nil && len(columns) == 0 {
+				err = fmt.Errorf("no columns found")
+			}
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not load the columns of %s:\n\n%v", iconWarn, tview.Escape(table), err), "main")
+				return
+			}
+			a.showRowInsertForm(table, columns, source)
+		})
+	}()
+}
+
+func (a *App) showRowInsertForm(table string, columns []sidebarColumnMeta, source map[string]*tview.TableCell) {
+	title := fmt.Sprintf(" Insert into %s ", tview.Escape(table))
+	if source != nil {
+		title = fmt.Sprintf(" Duplicate row of %s ", tview.Escape(table))
+	}
+	form := tview.NewForm()
+	form.SetItemPadding(0)
+	form.SetBorder(true).SetTitle(title).SetTitleColor(mauve).SetBorderColor(surface1)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	autoColumns := rowInsertAutoColumns(a.dbType, columns)
+	inputs := make([]*tview.InputField, len(columns))
+	for index, column := range columns {
+		value, placeholder := "", rowInsertColumnHint(column, autoColumns[strings.ToLower(column.name)])
+		if cell, ok := source[strings.ToLower(column.name)]; ok {
+			value, placeholder = rowInsertDuplicateValue(cell, column, autoColumns[strings.ToLower(column.name)], placeholder)
+		}
+		inputs[index] = tview.NewInputField().SetLabel(column.name).SetText(value).SetPlaceholder(placeholder).
+			SetPlaceholderTextColor(overlay0)
+		form.AddFormItem(inputs[index])
+	}
+	closeForm := func() {
+		a.pages.RemovePage(pageRowInsert)
+		a.pages.SwitchToPage("main")
+		a.setFocusWithColor(a.results)
+	}
+	form.AddButton("Preview", func() {
+		var values []rowInsertValue
+		for index, column := range columns {
+			value := inputs[index].GetText()
+			if value == "" {
+				if column.notNull && column.defaultValue == "" && !autoColumns[strings.ToLower(column.name)] {
+					a.ShowAlert(fmt.Sprintf("%s %s is required: it is NOT NULL and has no default.", iconWarn, tview.Escape(column.name)), pageRowInsert)
+					return
+				}
+				continue
+			}
+			values = append(values, rowInsertValue{column: column.name, value: value})
+		}
+		statement := rowInsertStatement(a.dbType, table, values)
+		closeForm()
+		a.showRowWritePreview(rowWritePreview{
+			title:       fmt.Sprintf("Insert into %s", table),
+			body:        " [#a6adc8]Blank fields are left to the column default or NULL.[-]\n\n" + rowWritePreviewText([]rowWriteStatement{statement}),
+			commitLabel: "Insert",
+			tokens:      []string{"INSERT"},
+			commit: func() {
+				a.runRowWrite(table, fmt.Sprintf("Inserting into %s...", table), []rowWriteStatement{statement}, func() {
+					a.flashStatus(fmt.Sprintf("[green]%s Inserted 1 row into %s[-]", iconSuccess, tview.Escape(table)), a.currentResultRowCount(), 1800*time.Millisecond)
+				})
+			},
+		})
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+	modalW, modalH := a.modalSize(60, 100, min(len(columns)+5, 12), len(columns)+5)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).AddItem(form, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageRowInsert, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+type rowInsertValue struct {
+	column string
+	value  any
+}
+
+// rowInsertAutoColumns marks columns whose value the database assigns even
+// without a declared default, such as SQLite INTEGER PRIMARY KEY rowid aliases.
+func rowInsertAutoColumns(dbType config.DBType, columns []sidebarColumnMeta) map[string]bool {
+	auto := make(map[string]bool)
+	var primary []sidebarColumnMeta
+	for _, column := range columns {
+		if column.defaultValue == "identity" || column.defaultValue == "auto_increment" {
+			auto[strings.ToLower(column.name)] = true
+		}
+		if column.primaryKey {
+			primary = append(primary, column)
+		}
+	}
+	switch dbType {
+	case config.SQLite, config.Turso, config.CloudflareD1:
+		if len(primary) == 1 && strings.EqualFold(strings.TrimSpace(primary[0].dataType), "INTEGER") {
+			auto[strings.ToLower(primary[0].name)] = true
+		}
+	}
+	return auto
+}
+
+func rowInsertColumnHint(column sidebarColumnMeta, auto bool) string {
+	parts := []string{strings.ToLower(column.dataType)}
+	switch {
+	case auto:
+		parts = append(parts, "generated")
+	case column.defaultValue != "":
+		parts = append(parts, "default "+column.defaultValue)
+	case column.notNull:
+		parts = append(parts, "required")
+	default:
+		parts = append(parts, "NULL if blank")
+	}
+	if column.primaryKey {
+		parts = append(parts, "PK")
+	}
+	if column.foreignKey && column.target != "" {
+		parts = append(parts, "→ "+column.target)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// rowInsertDuplicateValue prefills a duplicated field. Generated keys are
+// left blank so the copy gets a new one, and binary values are not copied.
+func rowInsertDuplicateValue(cell *tview.TableCell, column sidebarColumnMeta, auto bool, hint string) (string, string) {
+	ref, ok := cell.GetReference().(resultCellReference)
+	if !ok || (column.primaryKey && (auto || column.defaultValue != "")) {
+		return "", hint
+	}
+	if ref.isNull {
+		return "", hint + " · was NULL"
+	}
+	if bytes, isBytes := ref.rawValue.([]byte); isBytes && !utf8.Valid(bytes) {
+		return "", hint + " · binary not copied"
+	}
+	return ref.value, hint
+}
+
+func rowInsertStatement(dbType config.DBType, table string, values []rowInsertValue) rowWriteStatement {
+	quotedTable := quoteIdentifier(dbType, table)
+	if len(values) == 0 {
+		sqlText := fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", quotedTable)
+		if dbType == config.MySQL {
+			sqlText = fmt.Sprintf("INSERT INTO %s () VALUES ()", quotedTable)
+		}
+		return rowWriteStatement{sql: sqlText, preview: sqlText + ";"}
+	}
+	columns := make([]string, 0, len(values))
+	placeholders := make([]string, 0, len(values))
+	literals := make([]string, 0, len(values))
+	args := make([]any, 0, len(values))
+	for index, value := range values {
+		columns = append(columns, quoteIdentifier(dbType, value.column))
+		placeholders = append(placeholders, numberedResultFilterPlaceholder(dbType, index+1))
+		literals = append(literals, cellEditSQLLiteral(value.value))
+		args = append(args, value.value)
+	}
+	columnList := strings.Join(columns, ", ")
+	return rowWriteStatement{
+		sql:     fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quotedTable, columnList, strings.Join(placeholders, ", ")),
+		args:    args,
+		preview: fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", quotedTable, columnList, strings.Join(literals, ", ")),
+	}
+}
+
+// deleteSelectedResultRows previews deleting the selected rows, or the
+// current row when none are selected, together with their foreign key impact.
+func (a *App) deleteSelectedResultRows() {
+	if !a.isTableResultActive() || a.db == nil || !a.hasResultDataRows() {
+		a.flashStatus("[yellow]Select table rows to delete[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	rows := a.selectedResultRows()
+	if len(rows) == 0 {
+		row, _ := a.results.GetSelection()
+		if !a.isSelectableResultRow(row) {
+			a.flashStatus("[yellow]Select table rows to delete[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+			return
+		}
+		rows = []int{row}
+	}
+	if !a.rowWritesAllowed("Deleting rows") {
+		return
+	}
+	rowValues := make([]map[string]foreignKeyRowValue, 0, len(rows))
+	for _, row := range rows {
+		rowValues = append(rowValues, a.captureForeignKeyRowValues(row))
+	}
+
+	db := a.db
+	dbType := a.dbType
+	table := a.selectedTable
+	namespace := a.defaultObjectNamespace("")
+	tableNames := append([]string(nil), a.tableOrder...)
+	resultGeneration := a.currentResultGeneration()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Checking references to %d row(s) of %s...", len(rows), table),
+		withLoadingCancel("Press Esc to cancel.", func() {
+			canceled.Store(true)
+			cancel()
+			a.setFocusWithColor(a.results)
+		}),
+	)
+
+	go func() {
+		defer cancel()
+		var statements []rowWriteStatement
+		var keys [][]any
+		var impacts []rowDeleteImpact
+		enforced := true
+		keyColumns, _, err := loadCellEditKey(ctx, db, dbType, table, namespace)
+		if err == nil {
+			keys, err = rowDeleteKeys(keyColumns, rowValues)
+		}
+		if err == nil {
+			statements = rowDeleteStatements(dbType, table, keyColumns, keys)
+			var refs []foreignKeyReference
+			refs, err = loadIncomingForeignKeyReferences(ctx, db, dbType, table, namespace, tableNames)
+			if err == nil {
+				impacts, err = countRowDeleteImpacts(ctx, db, dbType, refs, rowValues)
+			}
+			if err == nil && len(impacts) > 0 {
+				enforced, err = foreignKeysEnforced(ctx, db, dbType)
+			}
+		}
+		a.queueUpdateDraw(func() {
+			if canceled.Load() || a.db != db || a.selectedTable != table || a.currentResultGeneration() != resultGeneration {
+				return
+			}
+			if !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			a.setFocusWithColor(a.results)
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not prepare the delete from %s:\n\n%v", iconWarn, tview.Escape(table), err), "main")
+				return
+			}
+			a.showRowWritePreview(rowWritePreview{
+				title:       fmt.Sprintf("Delete %d row(s) from %s", len(statements), table),
+				body:        rowDeleteImpactText(impacts, enforced) + rowWritePreviewText(statements),
+				commitLabel: "Delete",
+				tokens:      []string{"DELETE"},
+				commit: func() {
+					a.runRowWrite(table, fmt.Sprintf("Deleting %d row(s) from %s...", len(statements), table), statements, func() {
+						a.dropStagedCellEditsForRows(table, keys)
+						a.flashStatus(fmt.Sprintf("[green]%s Deleted %d row(s) from %s[-]", iconSuccess, len(statements), tview.Escape(table)), a.currentResultRowCount(), 1800*time.Millisecond)
+					})
+				},
+			})
+		})
+	}()
+}
+
+func rowDeleteKeys(keyColumns []string, rowValues []map[string]foreignKeyRowValue) ([][]any, error) {
+	keys := make([][]any, 0, len(rowValues))
+	for _, values := range rowValues {
+		key := make([]any, 0, len(keyColumns))
+		for _, column := range keyColumns {
+			value, ok := foreignKeyRowValueForColumn(values, column)
+			if !ok {
+				return nil, fmt.Errorf("key column %s is not part of this result", column)
+			}
+			if value.isNull {
+				return nil, fmt.Errorf("key column %s is NULL", column)
+			}
+			key = append(key, value.value)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func rowDeleteStatements(dbType config.DBType, table string, keyColumns []string, keys [][]any) []rowWriteStatement {
+	quotedTable := quoteIdentifier(dbType, table)
+	statements := make([]rowWriteStatement, 0, len(keys))
+	for _, key := range keys {
+		predicates := make([]string, 0, len(keyColumns))
+		previewPredicates := make([]string, 0, len(keyColumns))
+		for index, column := range keyColumns {
+			quoted := quoteIdentifier(dbType, column)
+			predicates = append(predicates, fmt.Sprintf("%s = %s", quoted, numberedResultFilterPlaceholder(dbType, index+1)))
+			previewPredicates = append(previewPredicates, fmt.Sprintf("%s = %s", quoted, cellEditSQLLiteral(key[index])))
+		}
+		statements = append(statements, rowWriteStatement{
+			sql:     fmt.Sprintf("DELETE FROM %s WHERE %s", quotedTable, strings.Join(predicates, " AND ")),
+			args:    append([]any(nil), key...),
+			preview: fmt.Sprintf("DELETE FROM %s WHERE %s;", quotedTable, strings.Join(previewPredicates, " AND ")),
+		})
+	}
+	return statements
+}
+
+// countRowDeleteImpacts counts, per incoming foreign key, the child rows that
+// reference any of the rows about to be deleted.
+func countRowDeleteImpacts(ctx context.Context, db *sql.DB, dbType config.DBType, refs []foreignKeyReference, rowValues []map[string]foreignKeyRowValue) ([]rowDeleteImpact, error) {
+	var impacts []rowDeleteImpact
+	for _, ref := range refs {
+		var total int64
+		for _, values := range rowValues {
+			predicates, err := incomingForeignKeyPredicates(ref, values)
+			if errors.Is(err, errForeignKeyValueIsNull) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			where, args := resultFilterSQL(dbType, &resultValueFilter{table: ref.sourceTable, predicates: predicates})
+			var count int64
+			if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+quoteIdentifier(dbType, ref.sourceTable)+where, args...).Scan(&count); err != nil {
+				return nil, fmt.Errorf("count rows in %s: %w", ref.sourceTable, err)
+			}
+			total += count
+		}
+		if total > 0 {
+			impacts = append(impacts, rowDeleteImpact{ref: ref, rows: total})
+		}
+	}
+	return impacts, nil
+}
+
+// foreignKeysEnforced reports whether SQLite-family connections enforce
+// foreign keys; other engines always do.
+func foreignKeysEnforced(ctx context.Context, db *sql.DB, dbType config.DBType) (bool, error) {
+	switch dbType {
+	case config.SQLite, config.Turso, config.CloudflareD1:
+		var enabled int
+		if err := db.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enabled); err != nil {
+			return false, fmt.Errorf("read foreign_keys pragma: %w", err)
+		}
+		return enabled != 0, nil
+	default:
+		return true, nil
+	}
+}
+
+func rowDeleteImpactText(impacts []rowDeleteImpact, enforced bool) string {
+	if len(impacts) == 0 {
+		return " [green]✓[-] No other rows reference these rows.\n\n"
+	}
+	var body strings.Builder
+	for _, impact := range impacts {
+		columns := make([]string, 0, len(impact.ref.columns))
+		for _, component := range impact.ref.columns {
+			columns = append(columns, component.localColumn)
+		}
+		source := tview.Escape(fmt.Sprintf("%s(%s)", impact.ref.sourceTable, strings.Join(columns, ", ")))
+		rule := impact.ref.onDelete
+		if rule == "" {
+			rule = "NO ACTION"
+		}
+		switch {
+		case !enforced:
+			fmt.Fprintf(&body, " [yellow]![-] %d row(s) in %s reference these rows and will be left dangling\n", impact.rows, source)
+		case rule == "CASCADE":
+			fmt.Fprintf(&body, " [red]![-] %d row(s) in %s will also be deleted (ON DELETE CASCADE)\n", impact.rows, source)
+		case rule == "SET NULL" || rule == "SET DEFAULT":
+			fmt.Fprintf(&body, " [yellow]![-] %d row(s) in %s will be changed (ON DELETE %s)\n", impact.rows, source, rule)
+		default:
+			fmt.Fprintf(&body, " [red]✗[-] %d row(s) in %s block this delete (ON DELETE %s)\n", impact.rows, source, rule)
+		}
+	}
+	if !enforced {
+		body.WriteString(" [#6c7086]Foreign key enforcement is off for this SQLite connection.[-]\n")
+	}
+	body.WriteString("\n")
+	return body.String()
+}
+
+// dropStagedCellEditsForRows forgets staged edits of rows that were deleted.
+func (a *App) dropStagedCellEditsForRows(table string, keys [][]any) {
+	session := a.cellEdits
+	if session == nil || session.table != table {
+		return
+	}
+	kept := session.edits[:0]
+	for _, edit := range session.edits {
+		deleted := false
+		for _, key := range keys {
+			if reflect.DeepEqual(edit.keys, key) {
+				deleted = true
+				break
+			}
+		}
+		if !deleted {
+			kept = append(kept, edit)
+		}
+	}
+	session.edits = kept
+}
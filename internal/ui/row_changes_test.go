@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestRowInsertAndDeleteStatementsRunTransactionally(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE users(id INTEGER PRIMARY KEY, name TEXT NOT NULL, note TEXT DEFAULT 'none')`,
+		`INSERT INTO users VALUES(1,'ada','x')`,
+	)
+	columns, err := loadSidebarColumnMetadata(ctx, db, config.SQLite, "users", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auto := rowInsertAutoColumns(config.SQLite, columns)
+	if !auto["id"] || auto["name"] || columns[2].defaultValue != "'none'" {
+		t.Fatalf("auto = %v, columns = %+v", auto, columns)
+	}
+	if hint := rowInsertColumnHint(columns[1], false); hint != "text · required" {
+		t.Fatalf("name hint = %q", hint)
+	}
+
+	insert := rowInsertStatement(config.SQLite, "users", []rowInsertValue{{column: "name", value: "bob's"}})
+	if insert.sql != `INSERT INTO "users" ("name") VALUES (?)` || insert.preview != `INSERT INTO "users" ("name") VALUES ('bob''s');` {
+		t.Fatalf("insert = %+v", insert)
+	}
+	if got := rowInsertStatement(config.PostgreSQL, "users", []rowInsertValue{{column: "a", value: "1"}, {column: "b", value: nil}}).sql; got != `INSERT INTO "users" ("a", "b") VALUES ($1, $2)` {
+		t.Fatalf("postgres insert = %q", got)
+	}
+	if got := rowInsertStatement(config.MySQL, "users", nil).sql; got != "INSERT INTO `users` () VALUES ()" {
+		t.Fatalf("mysql default insert = %q", got)
+	}
+	if _, err := commitRowWriteStatements(ctx, db, []rowWriteStatement{insert}); err != nil {
+		t.Fatal(err)
+	}
+
+	deletes := rowDeleteStatements(config.SQLite, "users", []string{"id"}, [][]any{{int64(1)}, {int64(99)}})
+	if deletes[0].preview != `DELETE FROM "users" WHERE "id" = 1;` {
+		t.Fatalf("delete preview = %q", deletes[0].preview)
+	}
+	if _, err := commitRowWriteStatements(ctx, db, deletes); err == nil || !strings.Contains(err.Error(), "no longer exists") {
+		t.Fatalf("deleting a missing row: err = %v", err)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&count); err != nil || count != 2 {
+		t.Fatalf("rows after rolled back delete = %d, %v", count, err)
+	}
+}
+
+func TestRowDeleteImpactReportsReferencingRows(t *testing.T) {
+	ctx := context.Background()
+	db := testCellEditDB(t,
+		`CREATE TABLE people(id INTEGER PRIMARY KEY, name TEXT)`,
+		`CREATE TABLE visits(id INTEGER PRIMARY KEY, person_id INTEGER REFERENCES people(id) ON DELETE CASCADE)`,
+		`CREATE TABLE invoices(id INTEGER PRIMARY KEY, person_id INTEGER REFERENCES people(id))`,
+		`INSERT INTO people VALUES(1,'ada'),(2,'bob')`,
+		`INSERT INTO visits VALUES(1,1),(2,1),(3,2)`,
+		`INSERT INTO invoices VALUES(1,2)`,
+	)
+	refs, err := loadIncomingForeignKeyReferences(ctx, db, config.SQLite, "people", "", []string{"invoices", "people", "visits"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowValues := []map[string]foreignKeyRowValue{{"id": {value: int64(1)}}}
+	impacts, err := countRowDeleteImpacts(ctx, db, config.SQLite, refs, rowValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(impacts) != 1 || impacts[0].ref.sourceTable != "visits" || impacts[0].rows != 2 || impacts[0].ref.onDelete != "CASCADE" {
+		t.Fatalf("impacts = %+v", impacts)
+	}
+	if text := rowDeleteImpactText(impacts, true); !strings.Contains(text, "2 row(s) in visits(person_id) will also be deleted") {
+		t.Fatalf("impact text = %q", text)
+	}
+
+	rowValues = append(rowValues, map[string]foreignKeyRowValue{"id": {value: int64(2)}})
+	impacts, err = countRowDeleteImpacts(ctx, db, config.SQLite, refs, rowValues)
+	if err != nil || len(impacts) != 2 {
+		t.Fatalf("impacts = %+v, err = %v", impacts, err)
+	}
+	if text := rowDeleteImpactText(impacts, true); !strings.Contains(text, "1 row(s) in invoices(person_id) block this delete (ON DELETE NO ACTION)") {
+		t.Fatalf("impact text = %q", text)
+	}
+	if text := rowDeleteImpactText(impacts, false); !strings.Contains(text, "left dangling") {
+		t.Fatalf("unenforced impact text = %q", text)
+	}
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+const (
+	// exactRowCountAutoLimit is the estimate above which the automatic
+	// COUNT(*) is skipped and the estimate is shown instead.
+	exactRowCountAutoLimit = 1000000
+	autoRowCountTimeout    = 5 * time.Second
+	exactRowCountTimeout   = 10 * time.Minute
+)
+
+// rowCountRequest is a count of the browsed table, captured on the UI
+// goroutine.
+type rowCountRequest struct {
+	db          *sql.DB
+	dbType      config.DBType
+	table       string
+	quotedTable string
+	namespace   string
+	query       string
+	args        []any
+	filtered    bool
+	generation  uint64
+}
+
+func (a *App) currentRowCountRequest() rowCountRequest {
+	request := rowCountRequest{
+		db:          a.db,
+		dbType:      a.dbType,
+		table:       a.selectedTable,
+		quotedTable: quoteIdentifier(a.dbType, a.selectedTable),
+		namespace:   a.defaultObjectNamespace(""),
+		generation:  a.currentResultGeneration(),
+	}
+	request.query = fmt.Sprintf("SELECT COUNT(*) FROM %s", request.quotedTable)
+	if filter := a.activeResultFilter(a.selectedTable); filter != nil {
+		clause, args := resultFilterSQL(a.dbType, filter)
+		request.query += clause
+		request.args = args
+		request.filtered = true
+	}
+	return request
+}
+
+// rowCountRequestIsCurrent reports whether a finished count still describes
+// the rows on screen.
+func (a *App) rowCountRequestIsCurrent(request rowCountRequest) bool {
+	return a.db == request.db && a.dbType == request.dbType && a.selectedTable == request.table &&
+		a.currentResultGeneration() == request.generation && quoteIdentifier(a.dbType, a.selectedTable) == request.quotedTable
+}
+
+// approximateRowCount reads the planner's row estimate from the catalog,
+// which is instant at any size. ok is false when the engine keeps no
+// estimate or the table has not been analyzed.
+func approximateRowCount(ctx context.Context, db *sql.DB, dbType config.DBType, tableName, defaultNamespace string) (int64, bool) {
+	namespace, table := splitQualifiedIdentifier(tableName)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	var estimate sql.NullInt64
+	var err error
+	switch dbType {
+	case config.PostgreSQL:
+		err = db.QueryRowContext(ctx, `SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)`, quoteIdentifier(dbType, tableName)).Scan(&estimate)
+	case config.MySQL:
+		err = db.QueryRowContext(ctx, `SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`, namespace, table).Scan(&estimate)
+	case config.SQLite, config.Turso, config.CloudflareD1:
+		// ANALYZE stores the row count as the first number of each stat.
+		var stat sql.NullString
+		err = db.QueryRowContext(ctx, `SELECT stat FROM sqlite_stat1 WHERE tbl = ? LIMIT 1`, table).Scan(&stat)
+		if err == nil && stat.Valid {
+			fields := strings.Fields(stat.String)
+			if len(fields) > 0 {
+				estimate.Int64, err = strconv.ParseInt(fields[0], 10, 64)
+				estimate.Valid = err == nil
+			}
+		}
+	case config.DuckDB:
+		err = db.QueryRowContext(ctx, `SELECT estimated_size FROM duckdb_tables() WHERE table_name = ? AND (schema_name = ? OR ? = '') LIMIT 1`, table, namespace, namespace).Scan(&estimate)
+	default:
+		return 0, false
+	}
+	// PostgreSQL reports -1, and older versions 0, before the first ANALYZE.
+	if err != nil || !estimate.Valid || estimate.Int64 <= 0 {
+		return 0, false
+	}
+	return estimate.Int64, true
+}
+
+// fetchTotalRowCount counts the browsed table in the background. Large
+// unfiltered tables get the catalog estimate instead of a COUNT(*), which
+// the user can still ask for with countRowsExactly. A count that times out
+// also falls back to the estimate.
+func (a *App) fetchTotalRowCount(request rowCountRequest) {
+	if request.db == nil || request.table == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), autoRowCountTimeout)
+	defer cancel()
+
+	var estimate int64
+	estimated := false
+	if !request.filtered {
+		estimate, estimated = approximateRowCount(ctx, request.db, request.dbType, request.table, request.namespace)
+	}
+	total, approximate := estimate, true
+	if !estimated || estimate < exactRowCountAutoLimit {
+		var exact int64
+		if err := request.db.QueryRowContext(ctx, request.query, request.args...).Scan(&exact); err == nil {
+			total, approximate = exact, false
+		} else if !estimated {
+			return
+		}
+	}
+
+	a.queueUpdateDraw(func() {
+		if !a.rowCountRequestIsCurrent(request) {
+			return
+		}
+		a.setTotalRowCount(int(total), approximate)
+	})
+}
+
+func (a *App) setTotalRowCount(total int, approximate bool) {
+	a.totalRowCount = total
+	a.totalRowCountApprox = approximate
+	if a.results != nil {
+		a.results.SetTitle(a.paginatedResultTitle(a.currentResultRowCount(), time.Since(a.queryStart)))
+	}
+	if a.statusBar != nil {
+		a.updateStatusBar("", a.currentResultRowCount())
+	}
+}
+
+// countRowsExactly runs COUNT(*) over the browsed table and its filter with
+// no size limit. It can take minutes on huge tables, so it is cancellable.
+func (a *App) countRowsExactly() {
+	if a.db == nil || !a.isTableResultActive() {
+		a.flashStatus("[yellow]Open a table to count its rows[-]", a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	request := a.currentRowCountRequest()
+	ctx, cancel := context.WithTimeout(context.Background(), exactRowCountTimeout)
+	var canceled atomic.Bool
+	loadingToken := a.showLoadingModal(
+		fmt.Sprintf("Counting rows in %s...", request.table),
+		withLoadingCancel("Press Esc to cancel counting.", func() {
+			canceled.Store(true)
+			cancel()
+			a.setFocusWithColor(a.results)
+			a.flashStatus("[yellow]Row count canceled[-]", a.currentResultRowCount(), 1400*time.Millisecond)
+		}),
+	)
+	started := time.Now()
+	go func() {
+		defer cancel()
+		var total int64
+		err := request.db.QueryRowContext(ctx, request.query, request.args...).Scan(&total)
+		a.queueUpdateDraw(func() {
+			if canceled.Load() || !a.finishLoadingModal(loadingToken) {
+				return
+			}
+			a.setFocusWithColor(a.results)
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s Could not count the rows of %s:\n\n%v", iconWarn, request.table, err), "main")
+				return
+			}
+			if !a.rowCountRequestIsCurrent(request) {
+				return
+			}
+			a.setTotalRowCount(int(total), false)
+			a.flashStatus(fmt.Sprintf("[green]%s %d rows in %s, counted in %s[-]", iconSuccess, total, request.table, formatDuration(time.Since(started))), a.currentResultRowCount(), 2200*time.Millisecond)
+		})
+	}()
+}
+
+// formatTotalRowCount renders the total, marking an estimate with ~.
+func (a *App) formatTotalRowCount() string {
+	if a.totalRowCountApprox {
+		return fmt.Sprintf("~%d", a.totalRowCount)
+	}
+	return strconv.Itoa(a.totalRowCount)
+}
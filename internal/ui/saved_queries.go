@@ -0,0 +1,406 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/savedquery"
+)
+
+const (
+	pageSavedQueries      = "savedQueries"
+	pageSavedQueryForm    = "savedQueryForm"
+	pageSavedQueryDelete  = "savedQueryDelete"
+	savedQueryLibraryMine = "personal"
+	savedQueryLibraryTeam = "project"
+)
+
+// savedQueryLibrary is one directory of saved query files.
+type savedQueryLibrary struct {
+	label string
+	root  string
+}
+
+type savedQueryEntry struct {
+	library savedQueryLibrary
+	query   savedquery.Query
+}
+
+// savedQueryLibraries returns the personal library and, when the project
+// workspace names one, the shared project library.
+func (a *App) savedQueryLibraries() []savedQueryLibrary {
+	var libraries []savedQueryLibrary
+	if a.queryLibraryDir != "" {
+		libraries = append(libraries, savedQueryLibrary{label: savedQueryLibraryMine, root: a.queryLibraryDir})
+	}
+	if a.projectWorkspace != nil && a.projectWorkspace.QueryLibrary != "" {
+		libraries = append(libraries, savedQueryLibrary{label: savedQueryLibraryTeam, root: a.projectWorkspace.QueryLibrary})
+	}
+	return libraries
+}
+
+// savedQueries reads every library and keeps the queries that apply to the
+// active connection. The files are read again each time so edits and git
+// pulls show up without a restart.
+func (a *App) savedQueries() ([]savedQueryEntry, []string) {
+	connection := ""
+	if cfg := a.currentConnectionConfig(); cfg != nil {
+		connection = cfg.Name
+	}
+	var entries []savedQueryEntry
+	var warnings []string
+	for _, library := range a.savedQueryLibraries() {
+		queries, problems, err := savedquery.Load(library.root)
+		warnings = append(warnings, problems...)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+			continue
+		}
+		for _, query := range queries {
+			if query.AppliesTo(a.dbType, connection) {
+				entries = append(entries, savedQueryEntry{library: library, query: query})
+			}
+		}
+	}
+	return entries, warnings
+}
+
+func (a *App) showSavedQueries() {
+	if a.db == nil {
+		a.ShowAlert(fmt.Sprintf("%s No active connection.\n\nConnect to a database first, then press %s.", iconInfo, a.escapedActionShortcut(actionQueryLibrary)), "main")
+		return
+	}
+	if len(a.savedQueryLibraries()) == 0 {
+		a.ShowAlert(fmt.Sprintf("%s The saved query library is unavailable.\n\nCheck permissions for the dbterm config directory and restart dbterm.", iconWarn), "main")
+		return
+	}
+	entries, warnings := a.savedQueries()
+	returnFocus := a.focusedPanel
+
+	filter := tview.NewInputField().SetLabel(" Filter ").SetFieldWidth(0)
+	filter.SetBackgroundColor(bg)
+	filter.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(subtext0)
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBackgroundColor(bg)
+	list.SetMainTextColor(text)
+	list.SetSecondaryTextColor(subtext0)
+	list.SetSelectedBackgroundColor(surface0)
+	list.SetSelectedTextColor(green)
+
+	var shown []savedQueryEntry
+	fill := func() {
+		shown = filterSavedQueries(entries, filter.GetText())
+		list.Clear()
+		for _, entry := range shown {
+			list.AddItem(" "+tview.Escape(entry.query.Title())+"  "+savedQueryScopeTag(entry.query), " "+tview.Escape(savedQuerySummary(entry)), 0, nil)
+		}
+		if len(shown) == 0 {
+			empty := "No saved queries for this connection yet"
+			if len(entries) > 0 {
+				empty = "No saved queries match the filter"
+			}
+			list.AddItem(" "+empty, " S saves the SQL in the Query editor", 0, nil)
+		}
+	}
+	fill()
+	filter.SetChangedFunc(func(string) { fill() })
+
+	closeLibrary := func() {
+		a.pages.RemovePage(pageSavedQueries)
+		if returnFocus != nil {
+			a.setFocusWithColor(returnFocus)
+			return
+		}
+		a.setFocusWithColor(a.queryInput)
+	}
+	selected := func() (savedQueryEntry, bool) {
+		index := list.GetCurrentItem()
+		if index < 0 || index >= len(shown) {
+			return savedQueryEntry{}, false
+		}
+		return shown[index], true
+	}
+	list.SetSelectedFunc(func(int, string, string, rune) {
+		if entry, ok := selected(); ok {
+			a.pages.RemovePage(pageSavedQueries)
+			a.openSavedQuery(entry.query, false)
+		}
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeLibrary()
+			return nil
+		case tcell.KeyRune:
+		default:
+			return event
+		}
+		switch event.Rune() {
+		case 'r', 'R':
+			if entry, ok := selected(); ok {
+				a.pages.RemovePage(pageSavedQueries)
+				a.openSavedQuery(entry.query, true)
+			}
+		case 's', 'S':
+			a.pages.RemovePage(pageSavedQueries)
+			a.saveEditorQuery()
+		case 'e', 'E':
+			if entry, ok := selected(); ok {
+				a.pages.RemovePage(pageSavedQueries)
+				a.showSavedQueryForm(entry, a.showSavedQueries)
+			}
+		case 'd', 'D':
+			if entry, ok := selected(); ok {
+				a.confirmDeleteSavedQuery(entry)
+			}
+		case '/':
+			a.app.SetFocus(filter)
+		default:
+			return event
+		}
+		return nil
+	})
+	filter.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeLibrary()
+			return nil
+		case tcell.KeyDown, tcell.KeyEnter, tcell.KeyTab:
+			a.app.SetFocus(list)
+			return nil
+		}
+		return event
+	})
+
+	footer := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	footer.SetBackgroundColor(crust)
+	footerText := " [yellow]Enter[-] Load  │  [yellow]R[-] Run  │  [yellow]S[-] Save editor SQL  │  [yellow]E[-] Edit  │  [yellow]D[-] Delete  │  [yellow]/[-] Filter  │  [yellow]Esc[-] Close "
+	if len(warnings) > 0 {
+		footerText = fmt.Sprintf(" [yellow]%s %d file(s) skipped: %s[-] ", iconWarn, len(warnings), tview.Escape(truncateForDisplay(warnings[0], 80)))
+	}
+	footer.SetText(footerText)
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(filter, 1, 0, false).
+		AddItem(list, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	container.SetBorder(true).SetTitle(fmt.Sprintf(" %s Saved Queries ", iconQuery)).SetTitleColor(mauve).SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	modalW, modalH := a.modalSize(72, 110, 12, 30)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageSavedQueries, grid, true, true)
+	a.app.SetFocus(list)
+}
+
+// filterSavedQueries keeps the queries whose title, description, tags, or
+// SQL contain every word of the filter.
+func filterSavedQueries(entries []savedQueryEntry, filter string) []savedQueryEntry {
+	words := strings.Fields(strings.ToLower(filter))
+	if len(words) == 0 {
+		return entries
+	}
+	var matches []savedQueryEntry
+	for _, entry := range entries {
+		haystack := strings.ToLower(strings.Join([]string{
+			entry.query.Title(), entry.query.Description, strings.Join(entry.query.Tags, " "), entry.query.SQL,
+		}, "\n"))
+		matched := true
+		for _, word := range words {
+			if !strings.Contains(haystack, word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+func savedQueryScopeTag(query savedquery.Query) string {
+	switch query.Scope() {
+	case savedquery.ScopeConnection:
+		return "[#f9e2af]" + tview.Escape(query.Connection) + "[-]"
+	case savedquery.ScopeEngine:
+		return "[#89b4fa]" + string(query.Engine) + "[-]"
+	default:
+		return "[#6c7086]global[-]"
+	}
+}
+
+func savedQuerySummary(entry savedQueryEntry) string {
+	var parts []string
+	if entry.query.Description != "" {
+		parts = append(parts, truncateForDisplay(entry.query.Description, 70))
+	}
+	for _, tag := range entry.query.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, truncateForDisplay(compactSQL(entry.query.SQL), 70))
+	}
+	return strings.Join(parts, "  ") + "  · " + entry.library.label
+}
+
+// openSavedQuery loads a saved query into the editor and, for run, executes
+// it, prompting for placeholders with the values last used for it.
+func (a *App) openSavedQuery(query savedquery.Query, run bool) {
+	a.pages.SwitchToPage("main")
+	a.loadQueryIntoEditor(query.SQL)
+	a.setFocusWithColor(a.queryInput)
+	if run {
+		a.ExecuteQuery(query.SQL)
+		return
+	}
+	a.flashStatus(fmt.Sprintf("[green]%s Saved query %s loaded[-]", iconSuccess, tview.Escape(query.Name)), a.currentResultRowCount(), 1400*time.Millisecond)
+}
+
+// saveEditorQuery saves the SQL in the Query editor as a new library query.
+func (a *App) saveEditorQuery() {
+	sql := strings.TrimSpace(a.queryInput.GetText())
+	if sql == "" {
+		a.ShowAlert(fmt.Sprintf("%s No query to save.\n\nType SQL in the Query panel first.", iconInfo), "main")
+		return
+	}
+	libraries := a.savedQueryLibraries()
+	if len(libraries) == 0 {
+		a.ShowAlert(fmt.Sprintf("%s The saved query library is unavailable.\n\nCheck permissions for the dbterm config directory and restart dbterm.", iconWarn), "main")
+		return
+	}
+	a.showSavedQueryForm(savedQueryEntry{library: libraries[0], query: savedquery.Query{SQL: sql}}, func() {
+		a.setFocusWithColor(a.queryInput)
+	})
+}
+
+// showSavedQueryForm edits the name, description, tags, folder, scope, and
+// library of a query; the SQL itself is what the editor held when it was
+// saved, or what the file holds.
+func (a *App) showSavedQueryForm(entry savedQueryEntry, done func()) {
+	query := entry.query
+	connection := ""
+	if cfg := a.currentConnectionConfig(); cfg != nil {
+		connection = cfg.Name
+	}
+
+	form := tview.NewForm()
+	form.SetItemPadding(0)
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle).SetFieldTextColor(text).SetLabelColor(text).
+		SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	form.AddInputField("Name", query.Name, 48, nil, nil)
+	form.AddInputField("Description", query.Description, 48, nil, nil)
+	form.AddInputField("Tags", strings.Join(query.Tags, ", "), 48, nil, nil)
+	form.AddInputField("Folder", query.Folder, 48, nil, nil)
+
+	scopes := []string{"Global: every connection", "Engine: every " + string(a.dbType) + " connection"}
+	if connection != "" {
+		scopes = append(scopes, "Connection: "+connection+" only")
+	}
+	scopeIndex := 0
+	switch query.Scope() {
+	case savedquery.ScopeEngine:
+		scopeIndex = 1
+	case savedquery.ScopeConnection:
+		scopeIndex = len(scopes) - 1
+	}
+	form.AddDropDown("Scope", scopes, scopeIndex, nil)
+
+	libraries := a.savedQueryLibraries()
+	libraryLabels := make([]string, len(libraries))
+	libraryIndex := 0
+	for index, library := range libraries {
+		libraryLabels[index] = fmt.Sprintf("%s (%s)", library.label, library.root)
+		if library.root == entry.library.root {
+			libraryIndex = index
+		}
+	}
+	if len(libraries) > 1 {
+		form.AddDropDown("Library", libraryLabels, libraryIndex, nil)
+	}
+
+	closeForm := func() {
+		a.pages.RemovePage(pageSavedQueryForm)
+		if done != nil {
+			done()
+		}
+	}
+	form.AddButton("Save", func() {
+		updated := query
+		updated.Name = strings.TrimSpace(formInputValueByLabel(form, "Name"))
+		updated.Description = strings.TrimSpace(formInputValueByLabel(form, "Description"))
+		updated.Tags = nil
+		for _, tag := range strings.Split(formInputValueByLabel(form, "Tags"), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				updated.Tags = append(updated.Tags, tag)
+			}
+		}
+		updated.Folder = formInputValueByLabel(form, "Folder")
+		updated.Engine, updated.Connection = "", ""
+		if index, _ := form.GetFormItemByLabel("Scope").(*tview.DropDown).GetCurrentOption(); index >= 1 {
+			updated.Engine = a.dbType
+			if index == 2 {
+				updated.Connection = connection
+			}
+		}
+		library := libraries[libraryIndex]
+		if dropDown, ok := form.GetFormItemByLabel("Library").(*tview.DropDown); ok {
+			index, _ := dropDown.GetCurrentOption()
+			library = libraries[max(0, index)]
+		}
+		path, err := savedquery.Save(library.root, updated)
+		if err != nil {
+			a.ShowAlert(fmt.Sprintf("%s Could not save the query:\n\n%v", iconWarn, err), pageSavedQueryForm)
+			return
+		}
+		closeForm()
+		a.flashStatus(fmt.Sprintf("[green]%s Saved %s[-]", iconSuccess, tview.Escape(truncateForDisplay(path, 80))), a.currentResultRowCount(), 2200*time.Millisecond)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	preview := tview.NewTextView().SetDynamicColors(false).SetWrap(true)
+	preview.SetBackgroundColor(crust)
+	preview.SetTextColor(subtext0)
+	preview.SetText(truncateForDisplay(compactSQL(query.SQL), 300))
+
+	title := " Save Query to Library "
+	if query.Path != "" {
+		title = " Edit Saved Query "
+	}
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(preview, 3, 0, false).
+		AddItem(form, 0, 1, true)
+	container.SetBorder(true).SetTitle(title).SetTitleColor(mauve).SetBorderColor(surface1)
+	container.SetBackgroundColor(bg)
+	modalW, modalH := a.modalSize(64, 96, 14, 18)
+	grid := tview.NewGrid().SetColumns(0, modalW, 0).SetRows(0, modalH, 0).
+		AddItem(container, 1, 1, 1, 1, 0, 0, true)
+	a.pages.AddPage(pageSavedQueryForm, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+func (a *App) confirmDeleteSavedQuery(entry savedQueryEntry) {
+	modal := tview.NewModal().SetText(fmt.Sprintf("%s Delete saved query [yellow]%s[-]?\n\n%s will be removed.", iconWarn, tview.Escape(entry.query.Title()), tview.Escape(entry.query.Path))).
+		AddButtons([]string{"Delete", "Cancel"})
+	modal.SetBackgroundColor(bg).SetTextColor(text).SetButtonBackgroundColor(surface1).SetButtonTextColor(green)
+	modal.SetDoneFunc(func(index int, _ string) {
+		a.pages.RemovePage(pageSavedQueryDelete)
+		if index != 0 {
+			return
+		}
+		if err := savedquery.Delete(entry.query); err != nil {
+			a.ShowAlert(fmt.Sprintf("%s Could not delete the query:\n\n%v", iconWarn, err), pageSavedQueries)
+			return
+		}
+		a.pages.RemovePage(pageSavedQueries)
+		a.showSavedQueries()
+	})
+	a.pages.AddPage(pageSavedQueryDelete, modal, true, true)
+	a.app.SetFocus(modal)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/savedquery"
+)
+
+func TestCommandPaletteOffersSavedQueriesInScope(t *testing.T) {
+	personal := filepath.Join(t.TempDir(), "queries")
+	project := filepath.Join(t.TempDir(), "db", "queries")
+	for _, saved := range []struct {
+		root  string
+		query savedquery.Query
+	}{
+		{personal, savedquery.Query{Name: "Row count", Tags: []string{"stats"}, SQL: "SELECT count(*) FROM t"}},
+		{personal, savedquery.Query{Name: "Vacuum", Engine: config.PostgreSQL, SQL: "VACUUM"}},
+		{project, savedquery.Query{Name: "Open orders", Folder: "support", Connection: "Orders", SQL: "SELECT * FROM t WHERE id = :id"}},
+		{project, savedquery.Query{Name: "Billing only", Connection: "billing", SQL: "SELECT 1"}},
+	} {
+		if _, err := savedquery.Save(saved.root, saved.query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app := &App{
+		db:               testCellEditDB(t, `CREATE TABLE t (id INTEGER)`),
+		dbType:           config.SQLite,
+		activeConn:       &config.ConnectionConfig{Name: "orders", Type: config.SQLite},
+		queryLibraryDir:  personal,
+		projectWorkspace: &config.Workspace{QueryLibrary: project},
+	}
+
+	titles := map[string]string{}
+	for _, item := range app.buildCommandPaletteItems() {
+		if item.kind == commandPaletteSaved {
+			titles[item.title] = item.query
+		}
+	}
+	if len(titles) != 2 || titles["Row count"] != "SELECT count(*) FROM t" || titles["support/Open orders"] == "" {
+		t.Fatalf("saved query palette items = %v, want the global and connection queries only", titles)
+	}
+
+	entries, warnings := app.savedQueries()
+	if len(warnings) != 0 {
+		t.Fatalf("warnings = %v", warnings)
+	}
+	if matches := filterSavedQueries(entries, "STATS count"); len(matches) != 1 || matches[0].library.label != savedQueryLibraryMine {
+		t.Fatalf("filter by tag = %+v", matches)
+	}
+	if matches := filterSavedQueries(entries, ":id support"); len(matches) != 1 || matches[0].library.label != savedQueryLibraryTeam {
+		t.Fatalf("filter by SQL and folder = %+v", matches)
+	}
+}
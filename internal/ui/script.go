@@ -0,0 +1,564 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+type scriptStatementStatus int
+
+const (
+	scriptStatementSkipped scriptStatementStatus = iota
+	scriptStatementOK
+	scriptStatementFailed
+	scriptStatementCanceled
+)
+
+// scriptStatementResult is what one statement of a script produced. Rows
+// are kept in their own table so switching tabs does not re-run anything.
+type scriptStatementResult struct {
+	statement    database.ScriptStatement
+	token        string
+	status       scriptStatementStatus
+	rows         *tview.Table
+	rowCount     int
+	truncated    bool
+	rowsAffected int64
+	duration     time.Duration
+	err          error
+}
+
+// scriptRun holds the tabs of the last script. The tabs belong to the result
+// generation that produced them and disappear once anything else owns the grid.
+type scriptRun struct {
+	generation uint64
+	statements []scriptStatementResult
+	current    int
+	elapsed    time.Duration
+}
+
+type scriptJob struct {
+	db              *sql.DB
+	tx              *database.Transaction // open transaction the script runs in, if any
+	dbType          config.DBType
+	generation      uint64
+	requestedLimit  int
+	readOnly        bool
+	timeout         time.Duration
+	continueOnError bool
+	connectionName  string
+	connectionKey   string
+	script          string
+	statements      []database.ScriptStatement
+	bound           []boundStatement // statements with placeholders bound, when the script has any
+	selectedTable   string
+	tableIndex      int
+}
+
+// scriptStatements splits the editor buffer and reports whether it needs
+// script mode: more than one statement, or a single statement the lexer had
+// to rewrite, such as a MySQL routine wrapped in DELIMITER lines.
+func scriptStatements(dbType config.DBType, query string) ([]database.ScriptStatement, bool) {
+	statements := database.SplitScript(dbType, query)
+	switch len(statements) {
+	case 0:
+		return nil, false
+	case 1:
+		return statements, !strings.HasPrefix(query, statements[0].SQL)
+	default:
+		return statements, true
+	}
+}
+
+func (a *App) runScript(query string, statements []database.ScriptStatement, values map[string]any) {
+	if a.transaction != nil {
+		for _, statement := range statements {
+			if endsTransaction(statement.SQL) {
+				a.showTransactionControlBlocked()
+				return
+			}
+		}
+	}
+	var bound []boundStatement
+	if len(values) > 0 {
+		var err error
+		if bound, err = bindScript(a.dbType, statements, values); err != nil {
+			a.ShowAlert(fmt.Sprintf("%s %v", iconWarn, err), "main")
+			return
+		}
+	}
+	ctx, finish, ok := a.startQueryLifecycle()
+	if !ok {
+		a.queueUpdateDraw(func() {
+			a.flashStatus("[yellow]Query already running — press Esc or Ctrl+C to cancel[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		})
+		return
+	}
+
+	// Claim the grid synchronously, exactly like runQuery.
+	job := scriptJob{
+		db:              a.db,
+		tx:              a.transaction,
+		dbType:          a.dbType,
+		generation:      a.advanceResultGeneration(),
+		requestedLimit:  a.effectiveResultLimit(),
+		readOnly:        a.activeConn != nil && a.activeConn.ReadOnly,
+		timeout:         manualQueryTimeout,
+		continueOnError: a.settings != nil && config.NormalizeScriptOnError(a.settings.ScriptOnError) == config.ScriptOnErrorContinue,
+		connectionName:  a.dbName,
+		script:          query,
+		statements:      statements,
+		bound:           bound,
+		selectedTable:   a.selectedTable,
+		tableIndex:      -1,
+	}
+	job.connectionKey, _ = a.activeConnectionKey()
+	if a.activeConn != nil {
+		if configured := a.activeConn.StatementTimeoutDuration(); configured > 0 {
+			job.timeout = configured
+		}
+	}
+	if a.tables != nil {
+		job.tableIndex = a.tables.GetCurrentItem()
+	}
+
+	go a.executeScriptWorker(ctx, finish, job)
+}
+
+func (a *App) executeScriptWorker(ctx context.Context, finish func(), job scriptJob) {
+	finishOnReturn := true
+	defer func() {
+		if finishOnReturn {
+			finish()
+		}
+	}()
+
+	if job.db == nil {
+		a.queueUpdateDraw(func() {
+			a.ShowAlert(fmt.Sprintf("%s Not connected to any database.\n\nPress %s to go to Dashboard and connect.", iconWarn, a.escapedActionShortcut(actionDashboard)), "main")
+		})
+		return
+	}
+	// Check the whole script up front so the guard never stops it halfway.
+	if job.readOnly {
+		for _, statement := range job.statements {
+			if token := firstSQLToken(statement.SQL); !isReadSQLToken(token) {
+				if token == "" {
+					token = "UNKNOWN"
+				}
+				a.queueUpdateDraw(func() {
+					a.ShowAlert(readOnlyGuardBlockedMessage(job.connectionName, token), "main")
+				})
+				return
+			}
+		}
+	}
+
+	var results []scriptStatementResult
+	startedAt := time.Now()
+	if job.tx != nil {
+		results = runScriptStatements(ctx, job.tx, job)
+	} else {
+		// One session for the whole script, so transactions, temporary
+		// tables, and SET carry from one statement to the next.
+		conn, err := job.db.Conn(ctx)
+		if err != nil {
+			if a.handleQueryCancellation(err) {
+				return
+			}
+			a.queueUpdateDraw(func() {
+				a.ShowAlert(fmt.Sprintf("%s Connection lost: %v\n\nPress %s to reconnect from Dashboard.", iconWarn, err, a.escapedActionShortcut(actionDashboard)), "main")
+			})
+			return
+		}
+		results = runScriptStatements(ctx, conn, job)
+		// Discard the session instead of pooling it: a transaction the
+		// script left open must not leak into table browsing.
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = conn.Close()
+	}
+	elapsed := time.Since(startedAt)
+
+	var snapshot *tableListSnapshot
+	if scriptChangedSchema(results) {
+		refreshCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		snapshot, _ = loadTableListSnapshotContext(refreshCtx, job.db, job.dbType, job.selectedTable, job.tableIndex)
+		cancel()
+	}
+	if scriptRanAny(results) {
+		a.recordQueryHistoryForConnection(job.connectionKey, job.script)
+	}
+
+	finishOnReturn = false
+	a.queueManualQueryCompletion(job.db, job.generation, finish, func() {
+		for _, result := range results {
+			if result.status == scriptStatementOK && result.rows == nil {
+				a.recordProfilerActivity(result.statement.SQL, result.rowsAffected)
+			}
+		}
+		if snapshot != nil {
+			a.applyTableListSnapshot(snapshot)
+			a.loadDatabaseObjects()
+		}
+		a.tableResultsActive = false
+		a.activeTable = ""
+		a.refreshTableSidebarState()
+		a.script = &scriptRun{generation: job.generation, statements: results, elapsed: elapsed}
+		a.showScriptTab(scriptInitialTab(results))
+		a.flashStatus(scriptSummary(results, elapsed), a.currentResultRowCount(), 2600*time.Millisecond)
+		if page, _ := a.pages.GetFrontPage(); page == "main" {
+			a.setFocusWithColor(a.results)
+		}
+	})
+}
+
+// runScriptStatements runs each statement on runner in order. Cancellation
+// interrupts the running statement and skips the rest; a failure does the
+// same unless the script is set to continue.
+func runScriptStatements(ctx context.Context, runner queryRunner, job scriptJob) []scriptStatementResult {
+	results := make([]scriptStatementResult, len(job.statements))
+	stopped := false
+	for index, statement := range job.statements {
+		result := &results[index]
+		result.statement = statement
+		result.token = firstSQLToken(statement.SQL)
+		if stopped || ctx.Err() != nil {
+			continue
+		}
+
+		bound := boundStatement{sql: statement.SQL}
+		if index < len(job.bound) {
+			bound = job.bound[index]
+		}
+		started := time.Now()
+		queryCtx, cancel := context.WithTimeout(ctx, job.timeout)
+		if isReadSQLToken(result.token) {
+			result.err = runScriptQuery(queryCtx, runner, result, bound, job.requestedLimit)
+		} else {
+			var res sql.Result
+			if res, result.err = runner.ExecContext(queryCtx, bound.sql, bound.args...); result.err == nil {
+				result.rowsAffected, _ = res.RowsAffected()
+			}
+		}
+		cancel()
+		result.duration = time.Since(started)
+
+		switch {
+		case result.err == nil:
+			result.status = scriptStatementOK
+		case ctx.Err() != nil:
+			result.status = scriptStatementCanceled
+			stopped = true
+		default:
+			if errors.Is(result.err, context.DeadlineExceeded) {
+				result.err = fmt.Errorf("timed out after %s: %w", job.timeout, result.err)
+			}
+			result.status = scriptStatementFailed
+			stopped = !job.continueOnError
+		}
+	}
+	return results
+}
+
+func runScriptQuery(ctx context.Context, runner queryRunner, result *scriptStatementResult, bound boundStatement, requestedLimit int) error {
+	rows, err := runner.QueryContext(ctx, bound.sql, bound.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	table := newResultTable()
+	result.rowCount, result.truncated, err = populateTableWithLimit(table, rows, resolvedResultLimit(requestedLimit, len(columns)))
+	if err != nil {
+		return err
+	}
+	result.rows = table
+	return nil
+}
+
+func scriptChangedSchema(results []scriptStatementResult) bool {
+	for _, result := range results {
+		if result.status != scriptStatementOK {
+			continue
+		}
+		switch result.token {
+		case "CREATE", "DROP", "ALTER", "RENAME":
+			return true
+		}
+	}
+	return false
+}
+
+func scriptRanAny(results []scriptStatementResult) bool {
+	for _, result := range results {
+		if result.status != scriptStatementSkipped {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptInitialTab opens the first failure, otherwise the last statement
+// that returned rows, otherwise the last statement.
+func scriptInitialTab(results []scriptStatementResult) int {
+	lastRows := -1
+	for index, result := range results {
+		if result.status == scriptStatementFailed || result.status == scriptStatementCanceled {
+			return index
+		}
+		if result.rows != nil {
+			lastRows = index
+		}
+	}
+	if lastRows >= 0 {
+		return lastRows
+	}
+	return len(results) - 1
+}
+
+func scriptSummary(results []scriptStatementResult, elapsed time.Duration) string {
+	var ok, failed, skipped int
+	canceled := false
+	for _, result := range results {
+		switch result.status {
+		case scriptStatementOK:
+			ok++
+		case scriptStatementFailed:
+			failed++
+		case scriptStatementCanceled:
+			canceled = true
+		default:
+			skipped++
+		}
+	}
+	summary := fmt.Sprintf("%s Script: %d of %d statements ran in %s", iconSuccess, ok, len(results), formatDuration(elapsed))
+	color := "green"
+	if failed > 0 {
+		summary = fmt.Sprintf("%s Script: %d ok, %d failed", iconFail, ok, failed)
+		color = "red"
+	}
+	if canceled {
+		summary = fmt.Sprintf("%s Script canceled after %d ok", iconWarn, ok)
+		color = "yellow"
+	}
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	return fmt.Sprintf("[%s]%s[-]", color, summary)
+}
+
+// activeScriptRun returns the last script while its tabs still own the grid.
+func (a *App) activeScriptRun() *scriptRun {
+	if a == nil || a.script == nil || a.script.generation != a.currentResultGeneration() {
+		return nil
+	}
+	return a.script
+}
+
+// showScriptTab puts one statement's rows or outcome into the results grid.
+func (a *App) showScriptTab(index int) {
+	run := a.activeScriptRun()
+	if run == nil || index < 0 || index >= len(run.statements) {
+		return
+	}
+	run.current = index
+	result := run.statements[index]
+
+	source := result.rows
+	if source == nil {
+		source = scriptOutcomeTable(result)
+	}
+	a.resultColumnSearch = ""
+	a.clearResultNavigation()
+	a.resetSort()
+	a.clearColumnOverrides()
+	a.results.Clear()
+	for r := 0; r < source.GetRowCount(); r++ {
+		for c := 0; c < source.GetColumnCount(); c++ {
+			a.results.SetCell(r, c, source.GetCell(r, c))
+		}
+	}
+	a.results.SetTitle(a.workspacePanelTitle(iconResults, "Results", actionFocusResults,
+		fmt.Sprintf(" — statement %d/%d, line %d: %s", index+1, len(run.statements), result.statement.Line, scriptStatementOutcome(result))))
+	a.results.ScrollToBeginning()
+	a.applyColumnWidths()
+	a.updateStatusBar("", result.rowCount)
+}
+
+func (a *App) moveScriptTab(delta int) bool {
+	run := a.activeScriptRun()
+	if run == nil {
+		return false
+	}
+	next := run.current + delta
+	if next >= 0 && next < len(run.statements) {
+		a.showScriptTab(next)
+	}
+	return true
+}
+
+// refreshScriptTabs shows the tab strip while a script owns the grid.
+func (a *App) refreshScriptTabs() {
+	if a == nil || a.scriptTabs == nil || a.rightFlex == nil {
+		return
+	}
+	height := 0
+	if run := a.activeScriptRun(); run != nil {
+		width, _ := a.getScreenSize()
+		a.scriptTabs.SetText(scriptTabsText(run, width-2))
+		height = 1
+	}
+	a.rightFlex.ResizeItem(a.scriptTabs, height, 0)
+}
+
+func (a *App) scriptTabsHeight() int {
+	if a.activeScriptRun() != nil {
+		return 1
+	}
+	return 0
+}
+
+func scriptTabsText(run *scriptRun, width int) string {
+	full := make([]string, len(run.statements))
+	compact := make([]string, len(run.statements))
+	for index, result := range run.statements {
+		color := scriptStatusColor(result.status)
+		token := result.token
+		if token == "" {
+			token = "SQL"
+		}
+		full[index] = fmt.Sprintf("%d %s %s", index+1, token, scriptStatementOutcome(result))
+		compact[index] = fmt.Sprintf("%d%s", index+1, scriptStatusMark(result.status))
+		if index == run.current {
+			full[index] = fmt.Sprintf("[#11111b:%s] %s [-:-]", color, tview.Escape(full[index]))
+			compact[index] = fmt.Sprintf("[#11111b:%s] %s [-:-]", color, compact[index])
+		} else {
+			full[index] = fmt.Sprintf("[%s] %s [-]", color, tview.Escape(full[index]))
+			compact[index] = fmt.Sprintf("[%s] %s [-]", color, compact[index])
+		}
+	}
+	hint := "  [#6c7086]{ } switch[-]"
+	return footerTextThatFits(width,
+		strings.Join(full, "│")+hint,
+		strings.Join(compact, "│")+hint,
+		scriptTabsWindow(compact, run.current, width),
+	)
+}
+
+// scriptTabsWindow keeps the current tab visible when even compact tabs do
+// not fit.
+func scriptTabsWindow(tabs []string, current, width int) string {
+	start, end := current, current+1
+	used := tview.TaggedStringWidth(tabs[current]) + 4
+	for grew := true; grew; {
+		grew = false
+		if end < len(tabs) && used+tview.TaggedStringWidth(tabs[end])+1 <= width {
+			used += tview.TaggedStringWidth(tabs[end]) + 1
+			end++
+			grew = true
+		}
+		if start > 0 && used+tview.TaggedStringWidth(tabs[start-1])+1 <= width {
+			start--
+			used += tview.TaggedStringWidth(tabs[start]) + 1
+			grew = true
+		}
+	}
+	text := strings.Join(tabs[start:end], "│")
+	if start > 0 {
+		text = "‹ " + text
+	}
+	if end < len(tabs) {
+		text += " ›"
+	}
+	return text
+}
+
+func scriptStatementOutcome(result scriptStatementResult) string {
+	switch result.status {
+	case scriptStatementOK:
+		if result.rows != nil {
+			rows := fmt.Sprintf("%d rows", result.rowCount)
+			if result.truncated {
+				rows = fmt.Sprintf("first %d rows", result.rowCount)
+			}
+			return fmt.Sprintf("%s %s in %s", iconSuccess, rows, formatDuration(result.duration))
+		}
+		return fmt.Sprintf("%s %d affected in %s", iconSuccess, result.rowsAffected, formatDuration(result.duration))
+	case scriptStatementFailed:
+		return iconFail + " error"
+	case scriptStatementCanceled:
+		return iconWarn + " canceled"
+	default:
+		return "skipped"
+	}
+}
+
+func scriptStatusMark(status scriptStatementStatus) string {
+	switch status {
+	case scriptStatementOK:
+		return iconSuccess
+	case scriptStatementFailed:
+		return iconFail
+	case scriptStatementCanceled:
+		return iconWarn
+	default:
+		return "·"
+	}
+}
+
+func scriptStatusColor(status scriptStatementStatus) string {
+	switch status {
+	case scriptStatementOK:
+		return "#a6e3a1"
+	case scriptStatementFailed:
+		return "#f38ba8"
+	case scriptStatementCanceled:
+		return "#f9e2af"
+	default:
+		return "#6c7086"
+	}
+}
+
+// scriptOutcomeTable describes a statement that returned no rows.
+func scriptOutcomeTable(result scriptStatementResult) *tview.Table {
+	table := newResultTable()
+	fields := [][2]string{{"Status", scriptStatementOutcome(result)}}
+	switch result.status {
+	case scriptStatementOK:
+		fields = append(fields, [2]string{"Rows affected", fmt.Sprint(result.rowsAffected)})
+	case scriptStatementFailed, scriptStatementCanceled:
+		fields = append(fields, [2]string{"Error", result.err.Error()})
+	default:
+		fields = append(fields, [2]string{"Reason", "not run: the script stopped at an earlier statement"})
+	}
+	if result.status != scriptStatementSkipped {
+		fields = append(fields, [2]string{"Time", formatDuration(result.duration)})
+	}
+	fields = append(fields,
+		[2]string{"Line", fmt.Sprint(result.statement.Line)},
+		[2]string{"Statement", result.statement.SQL},
+	)
+
+	for column, name := range []string{"FIELD", "VALUE"} {
+		table.SetCell(0, column, tview.NewTableCell(name).SetTextColor(peach).SetBackgroundColor(mantle).SetReference(strings.ToLower(name)))
+	}
+	for row, field := range fields {
+		table.SetCell(row+1, 0, tview.NewTableCell(field[0]).SetTextColor(subtext0).SetReference(newResultCellReference(field[0], field[0])))
+		value := field[1]
+		display := truncateForDisplay(strings.Join(strings.Fields(value), " "), 400)
+		table.SetCell(row+1, 1, tview.NewTableCell(tview.Escape(display)).SetTextColor(text).SetExpansion(1).SetReference(newResultCellReference(value, display)))
+	}
+	return table
+}
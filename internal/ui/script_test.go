@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+func TestScriptStatementsOnlyUsesScriptModeWhenNeeded(t *testing.T) {
+	if _, script := scriptStatements(config.PostgreSQL, "SELECT 1;"); script {
+		t.Fatal("a single statement should keep the plain query path")
+	}
+	if statements, script := scriptStatements(config.PostgreSQL, "SELECT 1; SELECT ';'"); !script || len(statements) != 2 {
+		t.Fatalf("statements = %+v, script = %v", statements, script)
+	}
+	if statements, script := scriptStatements(config.MySQL, "DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//"); !script || len(statements) != 1 {
+		t.Fatalf("a DELIMITER-wrapped routine must be unwrapped: %+v, %v", statements, script)
+	}
+}
+
+func TestRunScriptStatementsStopsOrContinuesAfterErrors(t *testing.T) {
+	script := "CREATE TABLE t (n INTEGER); INSERT INTO t VALUES (1), (2); INSERT INTO missing VALUES (1); SELECT n FROM t ORDER BY n"
+	for _, continueOnError := range []bool{false, true} {
+		db, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		statements, _ := scriptStatements(config.SQLite, script)
+		results := runScriptStatements(context.Background(), conn, scriptJob{
+			statements: statements, timeout: time.Minute, requestedLimit: 100, continueOnError: continueOnError,
+		})
+		conn.Close()
+		db.Close()
+
+		if len(results) != 4 || results[1].rowsAffected != 2 || results[2].status != scriptStatementFailed || results[2].err == nil {
+			t.Fatalf("continue=%v: results = %+v", continueOnError, results)
+		}
+		last := results[3]
+		if !continueOnError {
+			if last.status != scriptStatementSkipped || scriptInitialTab(results) != 2 {
+				t.Fatalf("stop-on-error ran past the failure: %+v", last)
+			}
+			continue
+		}
+		if last.status != scriptStatementOK || last.rowCount != 2 || last.rows == nil {
+			t.Fatalf("continue-on-error did not run the final SELECT: %+v", last)
+		}
+		if summary := scriptSummary(results, time.Second); !strings.Contains(summary, "3 ok, 1 failed") {
+			t.Fatalf("summary = %q", summary)
+		}
+	}
+}
+
+func TestRunScriptStatementsSkipsTheRestWhenCanceled(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	statements, _ := scriptStatements(config.SQLite, "SELECT 1; SELECT 2")
+	results := runScriptStatements(ctx, conn, scriptJob{statements: statements, timeout: time.Minute, continueOnError: true})
+	for _, result := range results {
+		if result.status != scriptStatementSkipped {
+			t.Fatalf("canceled script still ran a statement: %+v", results)
+		}
+	}
+}
+
+func TestScriptTabsTextKeepsCurrentTabVisible(t *testing.T) {
+	run := &scriptRun{current: 7}
+	for index := 0; index < 12; index++ {
+		run.statements = append(run.statements, scriptStatementResult{token: "UPDATE", status: scriptStatementOK, rowsAffected: int64(index)})
+	}
+	text := scriptTabsText(run, 30)
+	if !strings.Contains(text, " 8✓ ") || !strings.HasPrefix(text, "‹ ") {
+		t.Fatalf("narrow tabs = %q", text)
+	}
+	if wide := scriptTabsText(run, 400); !strings.Contains(wide, "8 UPDATE ✓ 7 affected") {
+		t.Fatalf("wide tabs = %q", wide)
+	}
+}
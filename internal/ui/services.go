@@ -1071,6 +1071,9 @@ func (a *App) showConnectServiceModal() {
 }
 
 func (a *App) connectServiceConfig(cfg *config.ConnectionConfig) {
+	if a.confirmEndTransaction("Switching connections", func() { a.connectServiceConfig(cfg) }) {
+		return
+	}
 	loadingToken := a.showLoadingModal(fmt.Sprintf("Connecting to %s...", cfg.Database))
 	selectedTable := a.selectedTable
 	currentTableIndex := a.tables.GetCurrentItem()
@@ -1100,6 +1103,7 @@ func (a *App) connectServiceConfig(cfg *config.ConnectionConfig) {
 			a.dbType = cfg.Type
 			a.dbName = cfg.Name
 			a.activeConn = cloneConnectionConfig(cfg)
+			a.refreshEnvironmentChrome()
 			if tableLoadErr != nil {
 				a.applyTableListSnapshot(&tableListSnapshot{
 					items: []tableListSnapshotItem{{label: fmt.Sprintf("[gray]%s Tables could not be loaded[-]", iconWarn)}},
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -17,6 +18,9 @@ const pageSettings = "settings"
 const (
 	settingsLabelAgentScope         = "Agent Connection Scope"
 	settingsLabelAgentProfileWrites = "Allow Agent Profile Writes"
+	settingsLabelScriptOnError      = "Script on Error"
+	settingsLabelSQLKeywordCase     = "SQL Keyword Case"
+	settingsLabelTransactionIdle    = "Transaction Idle Timeout"
 	pageAgentSetup                  = "agentSetup"
 )
 
@@ -30,6 +34,46 @@ type keymapFieldSpec struct {
 	Label  string
 }
 
+var scriptOnErrorOptions = []string{
+	"Stop at the first failing statement",
+	"Continue with the next statement",
+}
+
+// sqlKeywordCaseOptions are listed in the order of sqlKeywordCaseModes.
+var sqlKeywordCaseOptions = []string{
+	"UPPER CASE keywords",
+	"lower case keywords",
+	"Keep keywords as typed",
+}
+
+var sqlKeywordCaseModes = []string{
+	config.SQLKeywordCaseUpper,
+	config.SQLKeywordCaseLower,
+	config.SQLKeywordCasePreserve,
+}
+
+// transactionIdleModes are the idle timeouts Settings offers.
+var transactionIdleModes = []string{"5m", config.DefaultTransactionIdleTimeout, "30m", "1h", config.TransactionIdleTimeoutOff}
+
+// transactionIdleChoices returns the dropdown options and their modes,
+// keeping a custom timeout from settings.json selectable.
+func transactionIdleChoices(current string) ([]string, []string) {
+	current = config.NormalizeTransactionIdleTimeout(current)
+	modes := append([]string(nil), transactionIdleModes...)
+	if !slices.Contains(modes, current) {
+		modes = append(modes, current)
+	}
+	options := make([]string, len(modes))
+	for index, mode := range modes {
+		if mode == config.TransactionIdleTimeoutOff {
+			options[index] = "Never roll back idle transactions"
+		} else {
+			options[index] = "Roll back after " + mode + " idle"
+		}
+	}
+	return options, modes
+}
+
 var keymapFieldSpecs = []keymapFieldSpec{
 	{Action: config.ActionFocusTables, Label: "Focus Tables"},
 	{Action: config.ActionFocusQuery, Label: "Focus Query"},
@@ -41,14 +85,23 @@ var keymapFieldSpecs = []keymapFieldSpec{
 	{Action: config.ActionBackup, Label: "Open Backup"},
 	{Action: config.ActionBackupCenter, Label: "Backup Center"},
 	{Action: config.ActionChangeProfiler, Label: "Change Profiler"},
-	{Action: config.ActionExportCSV, Label: "Export CSV"},
+	{Action: config.ActionExportCSV, Label: "Export Results"},
 	{Action: config.ActionHistory, Label: "Query History"},
+	{Action: config.ActionQueryLibrary, Label: "Saved Queries"},
 	{Action: config.ActionSettings, Label: "Open Settings"},
 	{Action: config.ActionImportDump, Label: "Import Dump"},
+	{Action: config.ActionImportData, Label: "Import Data File"},
 	{Action: config.ActionInspectSchema, Label: "Inspect Schema"},
+	{Action: config.ActionExplainQuery, Label: "Explain Query Plan"},
+	{Action: config.ActionFormatSQL, Label: "Format SQL"},
+	{Action: config.ActionTransaction, Label: "Transaction"},
 	{Action: config.ActionSelectAll, Label: "Select All Rows"},
 	{Action: config.ActionClearSelection, Label: "Clear Selection"},
 	{Action: config.ActionCommandPalette, Label: "Command Palette"},
+	{Action: config.ActionNewEditorTab, Label: "New Editor Tab"},
+	{Action: config.ActionCloseEditorTab, Label: "Close Editor Tab"},
+	{Action: config.ActionNextEditorTab, Label: "Next Editor Tab"},
+	{Action: config.ActionPrevEditorTab, Label: "Previous Editor Tab"},
 }
 
 func settingsFooterText(width int) string {
@@ -96,11 +149,14 @@ func cloneSettings(settings *config.Settings) *config.Settings {
 	}
 
 	cloned := &config.Settings{
-		Keymap:                make(map[string][]string, len(settings.Keymap)),
-		DashboardHealthChecks: settings.DashboardHealthChecks,
-		AgentAccess:           settings.AgentAccess,
-		TableColumnWidths:     make(map[string]map[string]map[string]int, len(settings.TableColumnWidths)),
-		PinnedTables:          make(map[string][]string, len(settings.PinnedTables)),
+		Keymap:                 make(map[string][]string, len(settings.Keymap)),
+		DashboardHealthChecks:  settings.DashboardHealthChecks,
+		ScriptOnError:          settings.ScriptOnError,
+		SQLKeywordCase:         settings.SQLKeywordCase,
+		TransactionIdleTimeout: settings.TransactionIdleTimeout,
+		AgentAccess:            settings.AgentAccess,
+		TableColumnWidths:      make(map[string]map[string]map[string]int, len(settings.TableColumnWidths)),
+		PinnedTables:           make(map[string][]string, len(settings.PinnedTables)),
 	}
 
 	for action, bindings := range settings.Keymap {
@@ -144,6 +200,46 @@ func selectedAgentConnectionScope(form *tview.Form) string {
 	return config.AgentConnectionScopeActive
 }
 
+func scriptOnErrorIndex(mode string) int {
+	if config.NormalizeScriptOnError(mode) == config.ScriptOnErrorContinue {
+		return 1
+	}
+	return 0
+}
+
+func selectedScriptOnError(form *tview.Form) string {
+	dropdown, ok := form.GetFormItemByLabel(settingsLabelScriptOnError).(*tview.DropDown)
+	if !ok {
+		return config.ScriptOnErrorStop
+	}
+	if index, _ := dropdown.GetCurrentOption(); index == 1 {
+		return config.ScriptOnErrorContinue
+	}
+	return config.ScriptOnErrorStop
+}
+
+func sqlKeywordCaseIndex(mode string) int {
+	mode = config.NormalizeSQLKeywordCase(mode)
+	for index, option := range sqlKeywordCaseModes {
+		if option == mode {
+			return index
+		}
+	}
+	return 0
+}
+
+func selectedSQLKeywordCase(form *tview.Form) string {
+	dropdown, ok := form.GetFormItemByLabel(settingsLabelSQLKeywordCase).(*tview.DropDown)
+	if !ok {
+		return config.SQLKeywordCaseUpper
+	}
+	index, _ := dropdown.GetCurrentOption()
+	if index < 0 || index >= len(sqlKeywordCaseModes) {
+		return config.SQLKeywordCaseUpper
+	}
+	return sqlKeywordCaseModes[index]
+}
+
 func settingsFormCheckboxChecked(form *tview.Form, label string) bool {
 	item := form.GetFormItemByLabel(label)
 	checkbox, ok := item.(*tview.Checkbox)
@@ -243,6 +339,10 @@ func (a *App) showSettings() {
 	})
 
 	form.AddInputField("Dashboard Health Checks", settings.DashboardHealthChecks, 48, nil, nil)
+	form.AddDropDown(settingsLabelScriptOnError, scriptOnErrorOptions, scriptOnErrorIndex(settings.ScriptOnError), nil)
+	form.AddDropDown(settingsLabelSQLKeywordCase, sqlKeywordCaseOptions, sqlKeywordCaseIndex(settings.SQLKeywordCase), nil)
+	idleOptions, idleModes := transactionIdleChoices(settings.TransactionIdleTimeout)
+	form.AddDropDown(settingsLabelTransactionIdle, idleOptions, slices.Index(idleModes, config.NormalizeTransactionIdleTimeout(settings.TransactionIdleTimeout)), nil)
 
 	for _, field := range fields {
 		form.AddInputField(field.Label, keymapFieldValue(settings, field.Action), 48, nil, nil)
@@ -278,6 +378,13 @@ func (a *App) showSettings() {
 			return
 		}
 
+		updated.ScriptOnError = selectedScriptOnError(form)
+		updated.SQLKeywordCase = selectedSQLKeywordCase(form)
+		if dropdown, ok := form.GetFormItemByLabel(settingsLabelTransactionIdle).(*tview.DropDown); ok {
+			if index, _ := dropdown.GetCurrentOption(); index >= 0 && index < len(idleModes) {
+				updated.TransactionIdleTimeout = idleModes[index]
+			}
+		}
 		updated.AgentAccess.ConnectionScope = selectedAgentConnectionScope(form)
 		updated.AgentAccess.AllowProfileWrites = settingsFormCheckboxChecked(form, settingsLabelAgentProfileWrites)
 
@@ -325,6 +432,15 @@ func (a *App) showSettings() {
 			}
 		}
 		setFormInputValue(form, "Dashboard Health Checks", defaults.DashboardHealthChecks)
+		if dropdown, ok := form.GetFormItemByLabel(settingsLabelScriptOnError).(*tview.DropDown); ok {
+			dropdown.SetCurrentOption(scriptOnErrorIndex(defaults.ScriptOnError))
+		}
+		if dropdown, ok := form.GetFormItemByLabel(settingsLabelSQLKeywordCase).(*tview.DropDown); ok {
+			dropdown.SetCurrentOption(sqlKeywordCaseIndex(defaults.SQLKeywordCase))
+		}
+		if dropdown, ok := form.GetFormItemByLabel(settingsLabelTransactionIdle).(*tview.DropDown); ok {
+			dropdown.SetCurrentOption(slices.Index(idleModes, defaults.TransactionIdleTimeout))
+		}
 		for _, field := range fields {
 			setFormInputValueByLabel(form, field.Label, keymapFieldValue(defaults, field.Action))
 		}
@@ -69,7 +69,7 @@ func (a *App) refreshWorkspaceShortcutLabels() {
 		a.updateTableListTitle()
 	}
 	if a.queryInput != nil {
-		a.queryInput.SetTitle(a.workspacePanelTitle(iconQuery, "Query", actionFocusQuery, ""))
+		a.queryInput.SetTitle(a.queryPanelTitle())
 	}
 	if a.results != nil {
 		a.results.SetTitle(replacePanelShortcut(a.results.GetTitle(), "Results", a.escapedActionShortcut(actionFocusResults)))
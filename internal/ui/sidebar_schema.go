@@ -27,6 +27,9 @@ type sidebarColumnMeta struct {
 	primaryKey bool
 	foreignKey bool
 	target     string
+	// defaultValue is the declared default; identity and auto-increment
+	// columns report "identity" and "auto_increment".
+	defaultValue string
 }
 
 type sidebarSearchEntry struct {
@@ -611,8 +614,12 @@ func loadSidebarColumnMetadata(ctx context.Context, db *sql.DB, dbType config.DB
 	}
 	columns := make([]sidebarColumnMeta, 0)
 	switch dbType {
-	case config.PostgreSQL:
-		rows, err := db.QueryContext(ctx, `SELECT column_name, data_type, is_nullable
+	case config.PostgreSQL, config.DuckDB:
+		defaultExpression := "COALESCE(column_default, '')"
+		if dbType == config.PostgreSQL {
+			defaultExpression = "COALESCE(column_default, CASE WHEN is_identity = 'YES' THEN 'identity' ELSE '' END)"
+		}
+		rows, err := db.QueryContext(ctx, `SELECT column_name, data_type, is_nullable, `+defaultExpression+`
 FROM information_schema.columns
 WHERE table_schema = $1 AND table_name = $2
 ORDER BY ordinal_position`, namespace, tableOnly)
@@ -620,12 +627,12 @@ ORDER BY ordinal_position`, namespace, tableOnly)
 			return nil, err
 		}
 		for rows.Next() {
-			var name, dataType, nullable string
-			if err := rows.Scan(&name, &dataType, &nullable); err != nil {
+			var name, dataType, nullable, defaultValue string
+			if err := rows.Scan(&name, &dataType, &nullable, &defaultValue); err != nil {
 				rows.Close()
 				return nil, err
 			}
-			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: strings.EqualFold(nullable, "NO")})
+			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: strings.EqualFold(nullable, "NO"), defaultValue: defaultValue})
 		}
 		err = rows.Err()
 		rows.Close()
@@ -633,7 +640,7 @@ ORDER BY ordinal_position`, namespace, tableOnly)
 			return nil, err
 		}
 	case config.MySQL:
-		rows, err := db.QueryContext(ctx, `SELECT column_name, column_type, is_nullable
+		rows, err := db.QueryContext(ctx, `SELECT column_name, column_type, is_nullable, COALESCE(column_default, ''), extra
 FROM information_schema.columns
 WHERE table_schema = ? AND table_name = ?
 ORDER BY ordinal_position`, namespace, tableOnly)
@@ -641,12 +648,15 @@ ORDER BY ordinal_position`, namespace, tableOnly)
 			return nil, err
 		}
 		for rows.Next() {
-			var name, dataType, nullable string
-			if err := rows.Scan(&name, &dataType, &nullable); err != nil {
+			var name, dataType, nullable, defaultValue, extra string
+			if err := rows.Scan(&name, &dataType, &nullable, &defaultValue, &extra); err != nil {
 				rows.Close()
 				return nil, err
 			}
-			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: strings.EqualFold(nullable, "NO")})
+			if defaultValue == "" && strings.Contains(strings.ToLower(extra), "auto_increment") {
+				defaultValue = "auto_increment"
+			}
+			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: strings.EqualFold(nullable, "NO"), defaultValue: defaultValue})
 		}
 		err = rows.Err()
 		rows.Close()
@@ -666,7 +676,7 @@ ORDER BY ordinal_position`, namespace, tableOnly)
 				rows.Close()
 				return nil, err
 			}
-			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: notNull == 1, primaryKey: primary > 0})
+			columns = append(columns, sidebarColumnMeta{name: name, dataType: dataType, notNull: notNull == 1, primaryKey: primary > 0, defaultValue: defaultValue.String})
 		}
 		err = rows.Err()
 		rows.Close()
@@ -677,7 +687,7 @@ ORDER BY ordinal_position`, namespace, tableOnly)
 		return nil, fmt.Errorf("column metadata is not supported for %s", dbType)
 	}
 
-	if dbType == config.PostgreSQL || dbType == config.MySQL {
+	if dbType == config.PostgreSQL || dbType == config.MySQL || dbType == config.DuckDB {
 		primary, err := loadSidebarPrimaryKeyColumns(ctx, db, dbType, namespace, tableOnly)
 		if err == nil {
 			for index := range columns {
@@ -718,6 +728,10 @@ WHERE constraint_row.contype = 'p' AND namespace_row.nspname = $1 AND table_row.
 FROM information_schema.key_column_usage
 WHERE table_schema = ? AND table_name = ? AND constraint_name = 'PRIMARY'
 ORDER BY ordinal_position`, namespace, table)
+	case config.DuckDB:
+		rows, err = db.QueryContext(ctx, `SELECT UNNEST(constraint_column_names)
+FROM duckdb_constraints()
+WHERE schema_name = $1 AND table_name = $2 AND constraint_type = 'PRIMARY KEY'`, namespace, table)
 	default:
 		return result, nil
 	}
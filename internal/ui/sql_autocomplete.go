@@ -153,6 +153,10 @@ var sqlDialectKeywords = map[config.DBType][]string{
 		"PRAGMA", "INSERT OR REPLACE", "INSERT OR IGNORE", "ON CONFLICT", "WITHOUT ROWID",
 		"VACUUM", "ATTACH DATABASE", "DETACH DATABASE",
 	},
+	config.DuckDB: {
+		"DESCRIBE", "SUMMARIZE", "PIVOT", "UNPIVOT", "QUALIFY", "EXCLUDE", "REPLACE", "ASOF JOIN",
+		"ATTACH", "DETACH", "COPY", "EXPORT DATABASE", "INSTALL", "LOAD", "PRAGMA",
+	},
 }
 
 var sqlDialectFunctions = map[config.DBType][]string{
@@ -161,6 +165,7 @@ var sqlDialectFunctions = map[config.DBType][]string{
 	config.SQLite:       {"DATE", "TIME", "DATETIME", "JULIANDAY", "STRFTIME", "GROUP_CONCAT", "JSON_EXTRACT"},
 	config.Turso:        {"DATE", "TIME", "DATETIME", "JULIANDAY", "STRFTIME", "GROUP_CONCAT", "JSON_EXTRACT"},
 	config.CloudflareD1: {"DATE", "TIME", "DATETIME", "JULIANDAY", "STRFTIME", "GROUP_CONCAT", "JSON_EXTRACT"},
+	config.DuckDB:       {"READ_PARQUET", "READ_CSV", "READ_JSON", "STRFTIME", "DATE_TRUNC", "STRING_AGG", "LIST", "UNNEST"},
 }
 
 var sqlReservedWords = func() map[string]struct{} {
@@ -1555,6 +1560,32 @@ ORDER BY c.table_name, c.ordinal_position`)
 			rows.Close()
 		}
 		builder.addSchema(databaseName)
+	case config.DuckDB:
+		rows, err := db.QueryContext(ctx, `SELECT c.table_schema, c.table_name, t.table_type, c.column_name
+FROM information_schema.columns c
+JOIN information_schema.tables t
+  ON t.table_catalog = c.table_catalog AND t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema NOT IN ('information_schema', 'pg_catalog')
+ORDER BY c.table_schema, c.table_name, c.ordinal_position`)
+		if err == nil {
+			for rows.Next() {
+				var schema, table, tableType, column string
+				if rows.Scan(&schema, &table, &tableType, &column) != nil {
+					break
+				}
+				kind := sqlCompletionTable
+				if strings.EqualFold(tableType, "VIEW") {
+					kind = sqlCompletionView
+				}
+				// Tables in main are listed unqualified, as in the sidebar.
+				if schema == "main" {
+					schema = ""
+				}
+				builder.addSchema(schema)
+				builder.addRelation(qualifiedIdentifier(schema, table), kind, column)
+			}
+			rows.Close()
+		}
 	case config.SQLite, config.Turso, config.CloudflareD1:
 		rows, err := db.QueryContext(ctx, `SELECT m.name, m.type, p.name
 FROM sqlite_master AS m
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
@@ -248,15 +249,26 @@ func (a *App) rebuildTableSidebarForPins(selectedTable string) {
 	a.rebuildTableSidebar(sidebarSelection{table: selectedTable})
 }
 
+// pinnedTablesForConnection returns the project workspace pins followed by
+// the user's own pins for the active connection.
 func (a *App) pinnedTablesForConnection() []string {
-	if a == nil || a.settings == nil || a.settings.PinnedTables == nil {
+	if a == nil {
 		return nil
 	}
+	pins := append([]string(nil), a.projectPinnedTables()...)
+	if a.settings == nil || a.settings.PinnedTables == nil {
+		return pins
+	}
 	connection, ok := a.activeConnectionKey()
 	if !ok {
-		return nil
+		return pins
+	}
+	for _, table := range a.settings.PinnedTables[connection] {
+		if !slices.Contains(pins, table) {
+			pins = append(pins, table)
+		}
 	}
-	return a.settings.PinnedTables[connection]
+	return pins
 }
 
 func (a *App) tableIsPinned(table string) bool {
@@ -291,6 +303,10 @@ func (a *App) toggleSelectedTablePin() {
 		a.flashStatus("[yellow]Table pins require an active database connection[-]", a.currentResultRowCount(), 1800*time.Millisecond)
 		return
 	}
+	if slices.Contains(a.projectPinnedTables(), table) && !slices.Contains(a.settings.PinnedTables[connection], table) {
+		a.flashStatus(fmt.Sprintf("[yellow]%s is pinned by %s[-]", tview.Escape(table), tview.Escape(a.projectWorkspacePath())), a.currentResultRowCount(), 2400*time.Millisecond)
+		return
+	}
 	if a.settings.PinnedTables == nil {
 		a.settings.PinnedTables = make(map[string][]string)
 	}
@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+const (
+	pageTransactionActions = "transactionActions"
+	pageTransactionConfirm = "transactionConfirm"
+
+	transactionBeginTimeout = 10 * time.Second
+)
+
+// queryRunner is what editor statements run on: the pool, or the pinned
+// session of an explicit transaction.
+type queryRunner interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// toggleTransaction begins a transaction, or offers to end the open one.
+func (a *App) toggleTransaction() {
+	if a.transaction != nil {
+		a.showTransactionActions()
+		return
+	}
+	a.beginTransaction()
+}
+
+// beginTransaction pins a session for the editor. Until it is committed or
+// rolled back, every statement run from Query goes through it.
+func (a *App) beginTransaction() {
+	if a.db == nil {
+		a.ShowAlert(fmt.Sprintf("%s Not connected to any database.\n\nPress %s to go to Dashboard and connect.", iconWarn, a.escapedActionShortcut(actionDashboard)), "main")
+		return
+	}
+	if a.transaction != nil {
+		a.flashStatus(fmt.Sprintf("[yellow]%s A transaction is already open[-]", iconInfo), a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	if err := database.TransactionSupport(a.dbType); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s %v.", iconWarn, err), "main")
+		return
+	}
+	if a.isQueryRunning() {
+		a.flashStatus("[yellow]Query already running — press Esc or Ctrl+C to cancel[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	db, dbType := a.db, a.dbType
+	idleTimeout := a.transactionIdleTimeout()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), transactionBeginTimeout)
+		tx, err := database.BeginTransaction(ctx, db, dbType)
+		cancel()
+		a.queueUpdateDraw(func() {
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s %v", iconWarn, err), "main")
+				return
+			}
+			if a.db != db || a.transaction != nil {
+				tx.Abandon()
+				return
+			}
+			a.transaction = tx
+			go a.watchTransaction(tx, idleTimeout)
+			a.refreshTransactionChrome()
+			a.flashStatus(fmt.Sprintf("[#fab387]%s Transaction started — Query runs in it until %s commits or rolls back[-]", iconInfo, a.escapedActionShortcut(actionTransaction)), a.currentResultRowCount(), 2600*time.Millisecond)
+		})
+	}()
+}
+
+// endTransaction commits or rolls back the open transaction, then runs
+// then. A failed commit still ends the transaction, so then is skipped and
+// the error is shown instead.
+func (a *App) endTransaction(commit bool, then func()) {
+	tx := a.transaction
+	if tx == nil {
+		a.flashStatus(fmt.Sprintf("[#a6adc8]%s No transaction is open[-]", iconInfo), a.currentResultRowCount(), 1600*time.Millisecond)
+		return
+	}
+	if a.isQueryRunning() {
+		a.flashStatus("[yellow]Query still running — press Esc or Ctrl+C to cancel it first[-]", a.currentResultRowCount(), 1800*time.Millisecond)
+		return
+	}
+	a.transaction = nil
+	a.refreshTransactionChrome()
+	statements, age := tx.Statements(), time.Since(tx.StartedAt())
+	go func() {
+		var err error
+		if commit {
+			err = tx.Commit()
+		} else {
+			err = tx.Rollback()
+		}
+		a.queueUpdateDraw(func() {
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s %v\n\nThe transaction has ended; its changes were not saved.", iconFail, err), "main")
+				return
+			}
+			verb := "rolled back"
+			if commit {
+				verb = "committed"
+				a.refreshDataAsync()
+			}
+			a.flashStatus(fmt.Sprintf("[green]%s Transaction %s — %s after %s[-]", iconSuccess, verb, statementCount(statements), formatTransactionAge(age)), a.currentResultRowCount(), 2200*time.Millisecond)
+			if then != nil {
+				then()
+			}
+		})
+	}()
+}
+
+// abandonTransaction rolls back without waiting, for cleanup.
+func (a *App) abandonTransaction() {
+	if a.transaction == nil {
+		return
+	}
+	a.transaction.Abandon()
+	a.transaction = nil
+	a.refreshTransactionChrome()
+}
+
+// watchTransaction refreshes the badge every second and rolls the
+// transaction back once it has been idle for idleTimeout.
+func (a *App) watchTransaction(tx *database.Transaction, idleTimeout time.Duration) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tx.Done():
+			return
+		case now := <-ticker.C:
+			a.queueUpdateDraw(func() {
+				if a.transaction != tx {
+					return
+				}
+				if idleTimeout > 0 && !a.isQueryRunning() && now.Sub(tx.LastUsed()) >= idleTimeout {
+					a.endTransaction(false, func() {
+						a.ShowAlert(fmt.Sprintf("%s The transaction was idle for %s and has been rolled back.\n\nChange the limit with Transaction Idle Timeout in Settings.", iconWarn, formatTransactionAge(idleTimeout)), "main")
+					})
+					return
+				}
+				// Only the title ticks, so status messages are not cut short.
+				a.queryInput.SetTitle(a.queryPanelTitle())
+			})
+		}
+	}
+}
+
+func (a *App) transactionIdleTimeout() time.Duration {
+	if a.settings == nil {
+		return config.TransactionIdleTimeoutDuration(config.DefaultTransactionIdleTimeout)
+	}
+	return config.TransactionIdleTimeoutDuration(a.settings.TransactionIdleTimeout)
+}
+
+// showTransactionActions offers Commit and Rollback for the open transaction.
+func (a *App) showTransactionActions() {
+	tx := a.transaction
+	if tx == nil {
+		return
+	}
+	returnFocus := a.focusedPanel
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s Transaction open for %s with %s.\n\nCommit saves every change made in it; Roll back discards them.", iconWarn, formatTransactionAge(time.Since(tx.StartedAt())), statementCount(tx.Statements()))).
+		AddButtons([]string{" Commit ", " Roll back ", " Keep open "}).
+		SetDoneFunc(func(index int, _ string) {
+			a.pages.RemovePage(pageTransactionActions)
+			a.pages.SwitchToPage("main")
+			if returnFocus != nil {
+				a.setFocusWithColor(returnFocus)
+			}
+			switch index {
+			case 0:
+				a.endTransaction(true, nil)
+			case 1:
+				a.endTransaction(false, nil)
+			}
+		})
+	modal.SetBackgroundColor(bg).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(green).
+		SetTextColor(text)
+	a.pages.AddPage(pageTransactionActions, modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+// confirmEndTransaction asks to commit or roll back the open transaction
+// before proceed, which would otherwise lose it. It reports whether it
+// asked; proceed then runs only once the transaction has ended.
+func (a *App) confirmEndTransaction(action string, proceed func()) bool {
+	tx := a.transaction
+	if tx == nil {
+		return false
+	}
+	returnPage, _ := a.pages.GetFrontPage()
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s A transaction on %s is still open (%s, %s).\n\n%s would roll it back. Commit or roll back first?",
+			iconWarn, tview.Escape(a.dbName), formatTransactionAge(time.Since(tx.StartedAt())), statementCount(tx.Statements()), action)).
+		AddButtons([]string{" Commit ", " Roll back ", " Cancel "}).
+		SetDoneFunc(func(index int, _ string) {
+			a.pages.RemovePage(pageTransactionConfirm)
+			if returnPage != "" {
+				a.pages.ShowPage(returnPage)
+			}
+			switch index {
+			case 0:
+				a.endTransaction(true, proceed)
+			case 1:
+				a.endTransaction(false, proceed)
+			}
+		})
+	modal.SetBackgroundColor(bg).
+		SetButtonBackgroundColor(surface1).
+		SetButtonTextColor(red).
+		SetTextColor(text)
+	a.pages.AddPage(pageTransactionConfirm, modal, true, true)
+	a.app.SetFocus(modal)
+	return true
+}
+
+// transactionBadge marks the Query panel while a transaction is open.
+func (a *App) transactionBadge() string {
+	if a.transaction == nil {
+		return ""
+	}
+	return fmt.Sprintf("[#1e1e2e:#fab387:b] TX %s · %d uncommitted [-:-:-]", formatTransactionAge(time.Since(a.transaction.StartedAt())), a.transaction.Statements())
+}
+
+func (a *App) refreshTransactionChrome() {
+	if a.queryInput != nil {
+		a.queryInput.SetTitle(a.queryPanelTitle())
+	}
+	if a.statusBar != nil && !a.isQueryRunning() {
+		a.updateStatusBar("", a.currentResultRowCount())
+	}
+}
+
+// endsTransaction reports statements that would end or restart the
+// transaction behind the driver's back. Savepoints are fine.
+func endsTransaction(statement string) bool {
+	switch firstSQLToken(statement) {
+	case "BEGIN", "START", "COMMIT", "END":
+		return true
+	case "ROLLBACK", "ABORT":
+		return !rollbackToSavepoint.MatchString(statement)
+	}
+	return false
+}
+
+var rollbackToSavepoint = regexp.MustCompile(`(?i)\bROLLBACK\s+(?:WORK\s+|TRANSACTION\s+)?TO\b`)
+
+func (a *App) showTransactionControlBlocked() {
+	a.ShowAlert(fmt.Sprintf("%s A transaction is open, so BEGIN, COMMIT, and ROLLBACK cannot run from Query.\n\nPress %s to commit or roll it back. Savepoints work as usual.", iconWarn, a.escapedActionShortcut(actionTransaction)), "main")
+}
+
+// transactionBlocksWrite reports, with an alert, that feature cannot run
+// while a transaction is open. Grid writes and imports use their own pooled
+// transaction, so they would wait on the open one's locks (SQLITE_BUSY on
+// SQLite) and save outside it.
+func (a *App) transactionBlocksWrite(feature string) bool {
+	if a.transaction == nil {
+		return false
+	}
+	a.ShowAlert(fmt.Sprintf("%s A transaction is open, so %s is unavailable: it would run outside the transaction and wait on its locks.\n\nPress %s to commit or roll it back first, or write the change from Query.", iconWarn, feature, a.escapedActionShortcut(actionTransaction)), "main")
+	return true
+}
+
+func statementCount(n int) string {
+	if n == 1 {
+		return "1 statement"
+	}
+	return fmt.Sprintf("%d statements", n)
+}
+
+// formatTransactionAge renders a coarse elapsed time such as 45s or 3m05s.
+func formatTransactionAge(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+	"github.com/shreyam1008/dbterm/internal/database"
+)
+
+func TestEditorStatementsRunInsideTheOpenTransaction(t *testing.T) {
+	db, err := database.Connect(&config.ConnectionConfig{Type: config.SQLite, FilePath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE t (n INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := database.BeginTransaction(ctx, db, config.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements, _ := scriptStatements(config.SQLite, "INSERT INTO t VALUES (1); INSERT INTO t VALUES (2); SAVEPOINT s; SELECT COUNT(*) FROM t")
+	results := runScriptStatements(ctx, tx, scriptJob{statements: statements, timeout: time.Minute, requestedLimit: 100})
+	if last := results[len(results)-1]; last.status != scriptStatementOK || last.rows.GetCell(1, 0).Text != "2" {
+		t.Fatalf("the transaction does not see its own rows: %+v", last)
+	}
+	var committed int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM t").Scan(&committed); err != nil || committed != 0 {
+		t.Fatalf("the pool sees %d uncommitted rows, err %v", committed, err)
+	}
+
+	app := &App{
+		app:         tview.NewApplication(),
+		pages:       tview.NewPages(),
+		tables:      tview.NewList(),
+		queryInput:  tview.NewTextArea(),
+		results:     tview.NewTable(),
+		statusBar:   tview.NewTextView(),
+		db:          db,
+		dbType:      config.SQLite,
+		transaction: tx,
+	}
+	app.pages.AddPage("main", app.results, true, true)
+	app.runQuery("COMMIT", nil)
+	if page, _ := app.pages.GetFrontPage(); page != "alert" || app.isQueryRunning() {
+		t.Fatalf("COMMIT from Query was not blocked: front page %q", page)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEndsTransactionLeavesSavepointsAlone(t *testing.T) {
+	for statement, want := range map[string]bool{
+		"BEGIN":                          true,
+		"start transaction":              true,
+		"-- done\nCOMMIT":                true,
+		"ROLLBACK":                       true,
+		"rollback work":                  true,
+		"ROLLBACK TO SAVEPOINT before":   false,
+		"rollback transaction to before": false,
+		"SAVEPOINT before":               false,
+		"RELEASE SAVEPOINT before":       false,
+		"SELECT 'COMMIT'":                false,
+	} {
+		if got := endsTransaction(statement); got != want {
+			t.Errorf("endsTransaction(%q) = %v, want %v", statement, got, want)
+		}
+	}
+}
+
+// testTransactionApp opens a transaction on a SQLite table users(id, name)
+// holding one row, and returns an app whose grid writes must respect it.
+func testTransactionApp(t *testing.T) (*App, *sql.DB) {
+	t.Helper()
+	db := testCellEditDB(t, `CREATE TABLE users(id INTEGER PRIMARY KEY, name TEXT)`, `INSERT INTO users VALUES(1,'ada')`)
+	tx, err := database.BeginTransaction(context.Background(), db, config.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+	app := &App{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		results:       tview.NewTable(),
+		statusBar:     tview.NewTextView(),
+		db:            db,
+		dbType:        config.SQLite,
+		selectedTable: "users",
+		transaction:   tx,
+	}
+	app.pages.AddPage("main", app.results, true, true)
+	return app, db
+}
+
+func assertTransactionWriteBlocked(t *testing.T, app *App, db *sql.DB) {
+	t.Helper()
+	if page, _ := app.pages.GetFrontPage(); page != "alert" {
+		t.Fatalf("write during a transaction was not blocked: front page %q", page)
+	}
+	var names []string
+	rows, err := db.Query(`SELECT name FROM users ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	if strings.Join(names, ",") != "ada" {
+		t.Fatalf("users = %v, want the table untouched", names)
+	}
+}
+
+func TestRowWritesAreBlockedDuringATransaction(t *testing.T) {
+	app, db := testTransactionApp(t)
+	insert := rowInsertStatement(config.SQLite, "users", []rowInsertValue{{column: "name", value: "bob"}})
+	app.runRowWrite("users", "Inserting...", []rowWriteStatement{insert}, func() { t.Error("the insert reported success") })
+	assertTransactionWriteBlocked(t, app, db)
+
+	app.pages.RemovePage("alert")
+	if app.rowWritesAllowed("Deleting rows") {
+		t.Fatal("row deletes may start while a transaction is open")
+	}
+}
+
+func TestCellEditCommitIsBlockedDuringATransaction(t *testing.T) {
+	app, db := testTransactionApp(t)
+	session := &cellEditSession{table: "users", keyColumns: []string{"id"}, edits: []stagedCellEdit{
+		{rowKey: "value:1", keys: []any{int64(1)}, column: "name", original: "ada", value: "changed"},
+	}}
+	app.cellEdits = session
+	app.commitCellEdits()
+	assertTransactionWriteBlocked(t, app, db)
+	if len(session.edits) != 1 {
+		t.Fatalf("blocked commit dropped the staged edits: %+v", session.edits)
+	}
+}
+
+func TestDataImportIsBlockedDuringATransaction(t *testing.T) {
+	app, db := testTransactionApp(t)
+	app.showDataImport()
+	assertTransactionWriteBlocked(t, app, db)
+
+	// A wizard opened before the transaction began must not import either.
+	app.pages.RemovePage("alert")
+	preview := testDataImportPreview(t, "name\nbob\n")
+	plan := dataImportPlan{preview: preview, dbType: config.SQLite, table: "users",
+		columns: []dataImportColumn{{source: 0, name: "name", kind: preview.Columns[0].Kind}}}
+	app.runDataImport(plan, false, nil, app.results)
+	assertTransactionWriteBlocked(t, app, db)
+	if app.isImportRunning() {
+		t.Fatal("the blocked import left an import run open")
+	}
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/secrets"
+)
+
+const (
+	pageVaultUnlock = "vaultUnlock"
+
+	paletteActionUnlockVault keymapAction = "palette_unlock_vault"
+
+	vaultLabelPassphrase = "Passphrase"
+	vaultLabelKeyFile    = "Key File"
+)
+
+// showVaultUnlockIfLocked prompts once at startup when saved credentials are
+// sealed and no non-interactive unlock source applied.
+func (a *App) showVaultUnlockIfLocked() {
+	if !secrets.Locked() {
+		return
+	}
+	if unlocked, err := secrets.UnlockNonInteractive(); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s Could not unlock the secrets vault automatically:\n\n%v", iconWarn, err), "dashboard")
+		return
+	} else if unlocked {
+		a.finishVaultUnlock("dashboard", false)
+		return
+	}
+	a.showVaultUnlock("dashboard")
+}
+
+// showVaultUnlock asks for the vault passphrase or key file. Cancelling keeps
+// dbterm usable; profiles simply connect without their saved secrets.
+func (a *App) showVaultUnlock(returnPage string) {
+	if !secrets.Enabled() {
+		a.ShowAlert(fmt.Sprintf("%s No secrets vault is configured.\n\nRun `dbterm connections migrate-secrets` to move saved passwords into an encrypted vault.", iconInfo), returnPage)
+		return
+	}
+	if !secrets.Locked() {
+		a.ShowAlert(fmt.Sprintf("%s The secrets vault is already unlocked.", iconSuccess), returnPage)
+		return
+	}
+
+	mode := secrets.ModePassphrase
+	keyFile := ""
+	if path, err := secrets.DefaultPath(); err == nil {
+		if metadata, metaErr := secrets.LoadMetadata(path); metaErr == nil {
+			mode, keyFile = metadata.Mode, metadata.KeyFile
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetTitle(fmt.Sprintf(" %s Unlock Secrets Vault ", iconWarn))
+	form.SetTitleColor(yellow)
+	form.SetBorder(true)
+	form.SetBorderColor(yellow)
+	if mode == secrets.ModeKeyFile {
+		form.AddInputField(vaultLabelKeyFile, keyFile, 48, nil, nil)
+	} else {
+		form.AddPasswordField(vaultLabelPassphrase, "", 32, '*', nil)
+	}
+
+	closeForm := func() {
+		a.pages.RemovePage(pageVaultUnlock)
+		a.pages.ShowPage(returnPage)
+	}
+	form.AddButton("Unlock", func() {
+		key := secrets.Key{}
+		if mode == secrets.ModeKeyFile {
+			key.IdentityFile = formInputValueByLabel(form, vaultLabelKeyFile)
+		} else if input, ok := form.GetFormItemByLabel(vaultLabelPassphrase).(*tview.InputField); ok {
+			// Passphrases are exact values, like database passwords.
+			key.Passphrase = input.GetText()
+		}
+		if key.Passphrase == "" && strings.TrimSpace(key.IdentityFile) == "" {
+			a.ShowAlert(fmt.Sprintf("%s Enter the vault %s to continue.", iconInfo, map[bool]string{true: "key file", false: "passphrase"}[mode == secrets.ModeKeyFile]), pageVaultUnlock)
+			return
+		}
+		a.pages.RemovePage(pageVaultUnlock)
+		a.unlockVaultAsync(key, returnPage)
+	})
+	form.AddButton("Later", closeForm)
+
+	form.SetBackgroundColor(bg)
+	form.SetFieldBackgroundColor(mantle)
+	form.SetButtonBackgroundColor(surface1)
+	form.SetButtonTextColor(green)
+	form.SetLabelColor(text)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+
+	modalW, modalH := a.modalSize(52, 76, 9, 11)
+	grid := tview.NewGrid().
+		SetColumns(0, modalW, 0).
+		SetRows(0, modalH, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+
+	a.pages.AddPage(pageVaultUnlock, grid, true, true)
+	a.app.SetFocus(form)
+}
+
+// unlockVaultAsync keeps the passphrase key derivation off the UI goroutine.
+func (a *App) unlockVaultAsync(key secrets.Key, returnPage string) {
+	token := a.showLoadingModal(fmt.Sprintf("%s Unlocking secrets vault...", iconInfo))
+	go func() {
+		_, err := secrets.Unlock(key)
+		a.app.QueueUpdateDraw(func() {
+			if !a.finishLoadingModal(token) {
+				return
+			}
+			if err != nil {
+				a.ShowAlert(fmt.Sprintf("%s %v", iconFail, err), returnPage)
+				return
+			}
+			a.finishVaultUnlock(returnPage, true)
+		})
+	}()
+}
+
+func (a *App) finishVaultUnlock(returnPage string, announce bool) {
+	if err := a.store.RevealSecrets(); err != nil {
+		a.ShowAlert(fmt.Sprintf("%s Vault unlocked, but saved connections could not read it:\n\n%v", iconWarn, err), returnPage)
+		return
+	}
+	if returnPage == "dashboard" {
+		a.showDashboard()
+	}
+	if announce {
+		a.ShowAlert(fmt.Sprintf("%s Secrets vault unlocked for this session.", iconSuccess), returnPage)
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/shreyam1008/dbterm/internal/config"
+)
+
+// SetWorkspace attaches a project workspace file. Its connections appear on
+// the dashboard after the saved ones and are never written to the store.
+func (a *App) SetWorkspace(workspace *config.Workspace) {
+	a.projectWorkspace = workspace
+}
+
+// projectWorkspaceTrusted reports whether the workspace was opened with
+// --workspace. Without one there is nothing to distrust.
+func (a *App) projectWorkspaceTrusted() bool {
+	return a == nil || a.projectWorkspace == nil || a.projectWorkspace.Trusted
+}
+
+// projectConnections returns the workspace connections in file order.
+func (a *App) projectConnections() []config.ConnectionConfig {
+	if a == nil || a.projectWorkspace == nil {
+		return nil
+	}
+	connections := make([]config.ConnectionConfig, 0, len(a.projectWorkspace.Connections))
+	for _, connection := range a.projectWorkspace.Connections {
+		connections = append(connections, connection.ConnectionConfig)
+	}
+	return connections
+}
+
+// activeProjectConnection returns the workspace entry the current connection
+// was opened from, matched on the same signature history and pins use.
+func (a *App) activeProjectConnection() (*config.WorkspaceConnection, bool) {
+	if a == nil || a.projectWorkspace == nil {
+		return nil, false
+	}
+	signature := stableConnectionSignature(a.currentConnectionConfig())
+	if signature == "" {
+		return nil, false
+	}
+	for index := range a.projectWorkspace.Connections {
+		connection := &a.projectWorkspace.Connections[index]
+		if stableConnectionSignature(&connection.ConnectionConfig) == signature {
+			return connection, true
+		}
+	}
+	return nil, false
+}
+
+func (a *App) projectPinnedTables() []string {
+	if connection, ok := a.activeProjectConnection(); ok {
+		return connection.PinnedTables
+	}
+	return nil
+}
+
+// projectQueries returns the shared queries that apply to the active
+// connection: unscoped ones, plus those naming its workspace entry.
+func (a *App) projectQueries() []config.WorkspaceQuery {
+	if a == nil || a.projectWorkspace == nil {
+		return nil
+	}
+	activeName := ""
+	if connection, ok := a.activeProjectConnection(); ok {
+		activeName = connection.Name
+	}
+	var queries []config.WorkspaceQuery
+	for _, query := range a.projectWorkspace.Queries {
+		if query.Connection == "" || query.Connection == activeName {
+			queries = append(queries, query)
+		}
+	}
+	return queries
+}
+
+func (a *App) showProjectConnectionReadOnly(connection config.ConnectionConfig, action string) {
+	a.ShowAlert(fmt.Sprintf("%s [yellow]%s[-] comes from the project workspace and cannot be %s here.\n\nEdit %s instead.",
+		iconInfo, tview.Escape(connection.Name), action, tview.Escape(a.projectWorkspacePath())), "dashboard")
+}
+
+func (a *App) projectWorkspacePath() string {
+	if a == nil || a.projectWorkspace == nil {
+		return config.WorkspaceFileName
+	}
+	return a.projectWorkspace.Path
+}
+
+func projectQueryDescription(query config.WorkspaceQuery, workspacePath string) string {
+	var description strings.Builder
+	if text := strings.TrimSpace(query.Description); text != "" {
+		description.WriteString(text + "\n\n")
+	}
+	description.WriteString("Shared in " + workspacePath + ". Load this SQL into the Query editor:\n\n" + query.SQL)
+	return description.String()
+}